@@ -0,0 +1,67 @@
+package pluginapi
+
+import (
+	"sync"
+	"time"
+)
+
+// TimeLocation returns the user's time zone, or time.Local if the host did not
+// send one or sent a name this system does not know.
+func (c AgentContext) TimeLocation() *time.Location {
+	if c.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// agentContextState holds the agent context last delivered to a plugin.
+// The zero value is ready to use.
+type agentContextState struct {
+	mu      sync.Mutex
+	current AgentContext
+}
+
+// swap stores agentCtx and returns the context it replaces.
+func (s *agentContextState) swap(agentCtx AgentContext) AgentContext {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.current
+	s.current = agentCtx
+	return previous
+}
+
+func agentContextToProto(agentCtx AgentContext) *AgentContextRequest {
+	return &AgentContextRequest{
+		Name:            agentCtx.Name,
+		ConfigPath:      agentCtx.ConfigPath,
+		SettingsPath:    agentCtx.SettingsPath,
+		AgentDir:        agentCtx.AgentDir,
+		CurrentLocation: agentCtx.CurrentLocation,
+		ConversationId:  agentCtx.ConversationID,
+		UserDisplayName: agentCtx.UserDisplayName,
+		Locale:          agentCtx.Locale,
+		Timezone:        agentCtx.Timezone,
+		LlmModel:        agentCtx.LLMModel,
+		WorkspaceRoot:   agentCtx.WorkspaceRoot,
+	}
+}
+
+func agentContextFromProto(req *AgentContextRequest) AgentContext {
+	return AgentContext{
+		Name:            req.Name,
+		ConfigPath:      req.ConfigPath,
+		SettingsPath:    req.SettingsPath,
+		AgentDir:        req.AgentDir,
+		CurrentLocation: req.CurrentLocation,
+		ConversationID:  req.ConversationId,
+		UserDisplayName: req.UserDisplayName,
+		Locale:          req.Locale,
+		Timezone:        req.Timezone,
+		LLMModel:        req.LlmModel,
+		WorkspaceRoot:   req.WorkspaceRoot,
+	}
+}
@@ -0,0 +1,132 @@
+package pluginapi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type contextListenerTestTool struct {
+	BasePlugin
+	updates [][2]AgentContext
+}
+
+func (t *contextListenerTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *contextListenerTestTool) OnAgentContextUpdate(previous, current AgentContext) {
+	t.updates = append(t.updates, [2]AgentContext{previous, current})
+}
+
+func TestGRPCClient_UpdateAgentContext(t *testing.T) {
+	tool := &contextListenerTestTool{}
+	client := newTestClient(t, tool)
+	ctx := context.Background()
+
+	home := AgentContext{Name: "default", AgentDir: t.TempDir(), CurrentLocation: "Home"}
+	if err := client.SetAgentContextCtx(ctx, home); err != nil {
+		t.Fatalf("SetAgentContext failed: %v", err)
+	}
+	if len(tool.updates) != 0 {
+		t.Errorf("SetAgentContext should not notify listeners, got %v", tool.updates)
+	}
+
+	office := home
+	office.CurrentLocation = "Office"
+	if err := client.UpdateAgentContext(ctx, office); err != nil {
+		t.Fatalf("UpdateAgentContext failed: %v", err)
+	}
+	if err := client.UpdateAgentContext(ctx, office); err != nil {
+		t.Fatalf("UpdateAgentContext failed: %v", err)
+	}
+
+	if len(tool.updates) != 1 {
+		t.Fatalf("expected 1 update for 1 change, got %v", tool.updates)
+	}
+	if tool.updates[0][0] != home || tool.updates[0][1] != office {
+		t.Errorf("update = %+v", tool.updates[0])
+	}
+	if got := tool.GetAgentContext().CurrentLocation; got != "Office" {
+		t.Errorf("stored CurrentLocation = %q, want Office", got)
+	}
+}
+
+type contextReadingTestTool struct {
+	BasePlugin
+}
+
+func (t *contextReadingTestTool) Call(ctx context.Context, args string) (string, error) {
+	return t.GetAgentContext().CurrentLocation, nil
+}
+
+func TestGRPCClient_UpdateAgentContextDuringCalls(t *testing.T) {
+	client := newTestClient(t, &contextReadingTestTool{})
+	ctx := context.Background()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			if _, err := client.Call(ctx, ""); err != nil {
+				t.Errorf("Call failed: %v", err)
+				return
+			}
+		}
+	}()
+	for _, location := range []string{"Home", "Office", "Home", "Office"} {
+		if err := client.UpdateAgentContext(ctx, AgentContext{CurrentLocation: location}); err != nil {
+			t.Fatalf("UpdateAgentContext failed: %v", err)
+		}
+	}
+	<-done
+
+	if got, _ := client.Call(ctx, ""); got != "Office" {
+		t.Errorf("CurrentLocation = %q, want Office", got)
+	}
+}
+
+func TestBasePlugin_SetAgentContextSwitchesSettings(t *testing.T) {
+	var base BasePlugin
+	base.SetMetadata(&PluginMetadata{Name: "weather"})
+
+	base.SetAgentContext(AgentContext{AgentDir: t.TempDir()})
+	if err := base.Settings().Set("units", "metric"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	base.SetAgentContext(AgentContext{AgentDir: t.TempDir()})
+	if units, _ := base.Settings().GetString("units"); units != "" {
+		t.Errorf("expected the new agent's settings to be empty, got units=%q", units)
+	}
+}
+
+func TestAgentContext_ProtoRoundTrip(t *testing.T) {
+	agentCtx := AgentContext{
+		Name:            "work",
+		AgentDir:        "/agents/work",
+		ConfigPath:      "/agents/work/config.yaml",
+		CurrentLocation: "Office",
+		ConversationID:  "conv-42",
+		UserDisplayName: "Sam",
+		Locale:          "de-DE",
+		Timezone:        "Europe/Berlin",
+		LLMModel:        "llama3",
+		WorkspaceRoot:   "/home/sam/workspace",
+	}
+	if got := agentContextFromProto(agentContextToProto(agentCtx)); got != agentCtx {
+		t.Errorf("round trip = %+v, want %+v", got, agentCtx)
+	}
+}
+
+func TestAgentContext_TimeLocation(t *testing.T) {
+	if got := (AgentContext{Timezone: "Europe/Berlin"}).TimeLocation().String(); got != "Europe/Berlin" {
+		t.Errorf("TimeLocation = %q, want Europe/Berlin", got)
+	}
+	if got := (AgentContext{}).TimeLocation(); got != time.Local {
+		t.Errorf("empty Timezone should fall back to time.Local, got %v", got)
+	}
+	if got := (AgentContext{Timezone: "Not/AZone"}).TimeLocation(); got != time.Local {
+		t.Errorf("unknown Timezone should fall back to time.Local, got %v", got)
+	}
+}
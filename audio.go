@@ -0,0 +1,148 @@
+package pluginapi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// MaxInlineAudioSize is the largest clip, in decoded bytes, that can be embedded in
+// a result. Longer recordings should be returned with AudioFile or AudioURL.
+const MaxInlineAudioSize = 10 << 20
+
+// MaxWaveformPoints is the largest number of peaks an AudioData waveform may hold.
+const MaxWaveformPoints = 2048
+
+// AudioData is the payload of a DisplayTypeAudio result: a playable clip with
+// optional metadata for drawing a scrubber. Exactly one of Data, URL and Path is set.
+type AudioData struct {
+	// MimeType is the audio type (e.g., MIMETypeWAV)
+	MimeType string `json:"mime_type" yaml:"mime_type"`
+	// Data is the base64-encoded (standard encoding) clip
+	Data string `json:"data,omitempty" yaml:"data,omitempty"`
+	// URL is an http(s) URL the host streams the clip from
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Path is an absolute path to the clip on a filesystem shared with the host
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Duration is the clip length in seconds, if known
+	Duration float64 `json:"duration,omitempty" yaml:"duration,omitempty"`
+	// Waveform holds peak amplitudes in [0, 1] spread evenly over the clip (see WaveformPeaks)
+	Waveform []float64 `json:"waveform,omitempty" yaml:"waveform,omitempty"`
+}
+
+// InlineAudio creates an AudioData that embeds data, base64-encoded for transport.
+func InlineAudio(mimeType string, data []byte) AudioData {
+	return AudioData{MimeType: mimeType, Data: base64.StdEncoding.EncodeToString(data)}
+}
+
+// AudioURL creates an AudioData the host streams from an http(s) URL.
+func AudioURL(mimeType, audioURL string) AudioData {
+	return AudioData{MimeType: mimeType, URL: audioURL}
+}
+
+// AudioFile creates an AudioData that refers to a local file, for hosts that share
+// the plugin's filesystem. path must be absolute.
+func AudioFile(mimeType, path string) AudioData {
+	return AudioData{MimeType: mimeType, Path: path}
+}
+
+// NewAudioResult creates a result that shows a playable audio clip.
+//
+// Example:
+//
+//	clip := pluginapi.InlineAudio(pluginapi.MIMETypeWAV, wav)
+//	clip.Duration = 4.2
+//	clip.Waveform = pluginapi.WaveformPeaks(samples, 200)
+//	return pluginapi.NewAudioResult("Drum loop preview", clip).ToJSON()
+func NewAudioResult(title string, audio AudioData) *StructuredResult {
+	return &StructuredResult{
+		DisplayType: DisplayTypeAudio,
+		Title:       title,
+		Data:        audio,
+	}
+}
+
+// WaveformPeaks downsamples samples (in [-1, 1]) to at most points peak amplitudes
+// for AudioData.Waveform. Each peak is the largest absolute sample in its slice of
+// the clip, clamped to 1.
+func WaveformPeaks(samples []float64, points int) []float64 {
+	if points <= 0 || len(samples) == 0 {
+		return nil
+	}
+	if points > len(samples) {
+		points = len(samples)
+	}
+	peaks := make([]float64, points)
+	for i := range peaks {
+		start, end := i*len(samples)/points, (i+1)*len(samples)/points
+		for _, s := range samples[start:end] {
+			peaks[i] = math.Max(peaks[i], math.Abs(s))
+		}
+		peaks[i] = math.Min(peaks[i], 1)
+	}
+	return peaks
+}
+
+// Bytes decodes the inline clip. Returns nil for clips sent by URL or path.
+func (a *AudioData) Bytes() ([]byte, error) {
+	if a.Data == "" {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(a.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audio data: %w", err)
+	}
+	return data, nil
+}
+
+// Validate checks that the clip has exactly one well-formed source and sane metadata.
+func (a *AudioData) Validate() error {
+	if !strings.HasPrefix(a.MimeType, "audio/") {
+		return fmt.Errorf("mime type must be an audio type (got %q)", a.MimeType)
+	}
+	if a.Duration < 0 {
+		return fmt.Errorf("duration cannot be negative")
+	}
+	if len(a.Waveform) > MaxWaveformPoints {
+		return fmt.Errorf("waveform has %d points, the maximum is %d", len(a.Waveform), MaxWaveformPoints)
+	}
+	for i, peak := range a.Waveform {
+		if peak < 0 || peak > 1 {
+			return fmt.Errorf("waveform[%d] = %g is outside [0, 1]", i, peak)
+		}
+	}
+
+	sources := 0
+	for _, s := range []string{a.Data, a.URL, a.Path} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("audio must have exactly one of data, url and path")
+	}
+
+	switch {
+	case a.Data != "":
+		data, err := a.Bytes()
+		if err != nil {
+			return err
+		}
+		if len(data) > MaxInlineAudioSize {
+			return fmt.Errorf("inline audio exceeds %d bytes; use a URL or file path instead", MaxInlineAudioSize)
+		}
+	case a.URL != "":
+		u, err := url.Parse(a.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("audio URL must be an absolute http(s) URL (got %q)", a.URL)
+		}
+	case a.Path != "":
+		if !filepath.IsAbs(a.Path) || strings.Contains(a.Path, "://") {
+			return fmt.Errorf("audio path must be an absolute file path (got %q)", a.Path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package pluginapi
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAudioResult_Validate(t *testing.T) {
+	absPath, err := filepath.Abs("loop.wav")
+	if err != nil {
+		t.Fatalf("filepath.Abs failed: %v", err)
+	}
+	withWaveform := InlineAudio(MIMETypeWAV, []byte("RIFF"))
+	withWaveform.Duration = 4.2
+	withWaveform.Waveform = []float64{0, 0.5, 1}
+	badWaveform := AudioURL(MIMETypeMP3, "https://example.com/a.mp3")
+	badWaveform.Waveform = []float64{1.5}
+
+	tests := []struct {
+		name    string
+		audio   AudioData
+		wantErr bool
+	}{
+		{name: "inline", audio: withWaveform},
+		{name: "url", audio: AudioURL(MIMETypeMP3, "https://example.com/a.mp3")},
+		{name: "file", audio: AudioFile(MIMETypeFLAC, absPath)},
+		{name: "not audio", audio: InlineAudio(MIMETypePNG, []byte{1}), wantErr: true},
+		{name: "relative file", audio: AudioFile(MIMETypeWAV, "loop.wav"), wantErr: true},
+		{name: "ftp url", audio: AudioURL(MIMETypeWAV, "ftp://example.com/a.wav"), wantErr: true},
+		{name: "no source", audio: AudioData{MimeType: MIMETypeWAV}, wantErr: true},
+		{name: "two sources", audio: AudioData{MimeType: MIMETypeWAV, URL: "https://example.com/a.wav", Path: absPath}, wantErr: true},
+		{name: "waveform out of range", audio: badWaveform, wantErr: true},
+		{name: "too large", audio: InlineAudio(MIMETypeWAV, make([]byte, MaxInlineAudioSize+1)), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewAudioResult("Preview", tt.audio).Validate()
+			if tt.wantErr && err == nil {
+				t.Error("expected validation error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAudioResult_RoundTrip(t *testing.T) {
+	clip := InlineAudio(MIMETypeWAV, []byte("RIFF"))
+	clip.Duration = 1.5
+	clip.Waveform = []float64{0.25, 0.75}
+	jsonStr, err := NewAudioResult("Preview", clip).ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	parsed, err := ParseStructuredResult(jsonStr)
+	if err != nil {
+		t.Fatalf("ParseStructuredResult failed: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Fatalf("parsed result failed validation: %v", err)
+	}
+	audio, err := decodeResultData[AudioData](parsed.Data)
+	if err != nil {
+		t.Fatalf("decodeResultData failed: %v", err)
+	}
+	data, _ := audio.Bytes()
+	if string(data) != "RIFF" || audio.Duration != 1.5 || !reflect.DeepEqual(audio.Waveform, clip.Waveform) {
+		t.Errorf("got %+v after round trip", audio)
+	}
+}
+
+func TestWaveformPeaks(t *testing.T) {
+	samples := []float64{0.1, -0.4, 0.2, 0.3, -2, 0}
+	if got := WaveformPeaks(samples, 3); !reflect.DeepEqual(got, []float64{0.4, 0.3, 1}) {
+		t.Errorf("WaveformPeaks = %v", got)
+	}
+	if got := WaveformPeaks(samples[:2], 10); len(got) != 2 {
+		t.Errorf("expected at most one peak per sample, got %v", got)
+	}
+	if WaveformPeaks(nil, 10) != nil {
+		t.Error("expected nil for no samples")
+	}
+}
@@ -2,6 +2,8 @@ package pluginapi
 
 import (
 	"fmt"
+	"log/slog"
+	"net/http"
 	"sync"
 )
 
@@ -27,10 +29,47 @@ type BasePlugin struct {
 	apiVersion      string
 	metadata        *PluginMetadata
 	agentContext    AgentContext
+	host            HostServices // Set by SetHostServices while calls may be running
+	hostMu          sync.RWMutex
 	defaultSettings string
 	pluginConfig    *PluginConfig   // Stores parsed plugin.yaml config
 	settingsManager SettingsManager // Lazy-initialized settings manager
-	settingsMu      sync.Mutex      // Mutex for settings initialization
+	settingsMu      sync.Mutex      // Guards agentContext and settings initialization
+	logs            *logBroker      // Lazy-initialized destination of Logger
+	logsMu          sync.Mutex
+	oauth           *OAuthHelper // Lazy-initialized from pluginConfig.OAuth
+	oauthMu         sync.Mutex
+	httpClient      *http.Client // Lazy-initialized from the declared permissions
+	httpClientMu    sync.Mutex
+}
+
+// BaseSetter is implemented by every type that embeds BasePlugin.
+// ServeGRPCPlugin uses it to inject the initialized BasePlugin without reflection;
+// asserting it at compile time catches tools that forgot to embed BasePlugin:
+//
+//	var _ pluginapi.BaseSetter = (*myTool)(nil)
+type BaseSetter interface {
+	setBase(base *BasePlugin)
+}
+
+// setBase copies the initialized plugin state into b. Implements BaseSetter.
+// The settings mutex is not copied; b keeps its own.
+func (b *BasePlugin) setBase(base *BasePlugin) {
+	b.version = base.version
+	b.minAgentVer = base.minAgentVer
+	b.maxAgentVer = base.maxAgentVer
+	b.apiVersion = base.apiVersion
+	b.metadata = base.metadata
+	base.settingsMu.Lock()
+	b.agentContext = base.agentContext
+	base.settingsMu.Unlock()
+	base.hostMu.RLock()
+	b.host = base.host
+	base.hostMu.RUnlock()
+	b.defaultSettings = base.defaultSettings
+	b.pluginConfig = base.pluginConfig
+	b.settingsManager = base.settingsManager
+	b.logs = base.logs
 }
 
 // newBasePlugin creates a new base plugin with version and compatibility info.
@@ -76,15 +115,65 @@ func (b *BasePlugin) APIVersion() string {
 }
 
 // SetAgentContext stores the agent context for later use.
+// If the agent directory changes (e.g., the user switched agents), Settings
+// opens the new agent's settings on next use.
 // Implements AgentAwareTool interface.
 func (b *BasePlugin) SetAgentContext(ctx AgentContext) {
+	b.settingsMu.Lock()
+	defer b.settingsMu.Unlock()
+	if ctx.AgentDir != b.agentContext.AgentDir {
+		b.settingsManager = nil
+	}
 	b.agentContext = ctx
 }
 
-// GetAgentContext returns a pointer to the stored agent context.
-// This is a convenience method for plugins to access their context.
+// GetAgentContext returns a copy of the stored agent context, so it can be read
+// safely while the agent updates the context. Changes to the copy are not stored.
 func (b *BasePlugin) GetAgentContext() *AgentContext {
-	return &b.agentContext
+	b.settingsMu.Lock()
+	defer b.settingsMu.Unlock()
+	agentCtx := b.agentContext
+	return &agentCtx
+}
+
+// SetHostServices stores the agent's host services.
+// Implements HostAwareTool interface.
+func (b *BasePlugin) SetHostServices(host HostServices) {
+	b.hostMu.Lock()
+	defer b.hostMu.Unlock()
+	b.host = host
+}
+
+// Host returns the agent's host services, for calling back into the agent.
+// Until the host connects, every method returns ErrHostServiceUnavailable.
+func (b *BasePlugin) Host() HostServices {
+	b.hostMu.RLock()
+	defer b.hostMu.RUnlock()
+	if b.host == nil {
+		return UnimplementedHostServices{}
+	}
+	return b.host
+}
+
+// Logger returns a structured logger whose entries are streamed to the host
+// (see SubscribeLogs), with their level, attributes and timestamp. Entries logged
+// while no host is subscribed also go to stderr.
+//
+// Example:
+//
+//	t.Logger().Info("rendered track", "path", out, "duration_ms", elapsed.Milliseconds())
+func (b *BasePlugin) Logger() *slog.Logger {
+	return slog.New(&logHandler{broker: b.logBroker()})
+}
+
+// logBroker returns the plugin's log broker, creating it on first use. Implements logSource.
+func (b *BasePlugin) logBroker() *logBroker {
+	b.logsMu.Lock()
+	defer b.logsMu.Unlock()
+	if b.logs == nil {
+		b.logs = newLogBroker()
+	}
+	return b.logs
 }
 
 // SetMetadata sets the plugin metadata.
@@ -163,8 +252,11 @@ func (b *BasePlugin) GetConfigFromYAML() []ConfigVariable {
 // Settings returns the settings manager for this plugin.
 // The settings manager is lazily initialized when first accessed.
 // This method is thread-safe and can be called multiple times.
+// When the agent sets EnvSettingsKey, the settings file is encrypted at rest.
 //
-// Returns nil if the agent context has not been set yet.
+// Returns nil if the agent context has not been set yet. If the settings can't be
+// opened (e.g., EnvSettingsKey is malformed), the error is logged and every method
+// of the returned manager fails with it.
 // Call this method only after SetAgentContext has been called.
 //
 // Example usage:
@@ -201,18 +293,73 @@ func (b *BasePlugin) Settings() SettingsManager {
 		pluginName = b.metadata.Name
 	}
 
-	// Lazy initialize the settings manager
-	sm, err := NewSettingsManager(b.agentContext.AgentDir, pluginName)
+	// Lazy initialize the settings manager, encrypted if the agent provided a key
+	opts, err := settingsOptionsFromEnv()
+	var sm SettingsManager
+	if err == nil {
+		sm, err = NewSettingsManager(b.agentContext.AgentDir, pluginName, opts...)
+	}
 	if err != nil {
-		// Log error but return nil - caller should handle this
-		// TODO: Consider adding logging here
-		return nil
+		// A manager whose every method fails keeps callers from silently losing data
+		b.Logger().Error("settings unavailable", "error", err)
+		sm = failedSettings{err: fmt.Errorf("settings unavailable: %w", err)}
 	}
 
 	b.settingsManager = sm
 	return b.settingsManager
 }
 
+// reloadSettings reloads the settings manager from disk if it has been created.
+// Implements settingsReloader.
+func (b *BasePlugin) reloadSettings() error {
+	b.settingsMu.Lock()
+	sm := b.settingsManager
+	b.settingsMu.Unlock()
+	if sm == nil {
+		return nil
+	}
+	return sm.Load()
+}
+
+// OAuth returns the helper for the OAuth provider declared in plugin.yaml, or nil
+// if there is none. Tokens are kept in the host's keychain, or in Settings when
+// the host has no keychain.
+//
+// Example:
+//
+//	func (t *myTool) Call(ctx context.Context, args string) (string, error) {
+//	    token, err := t.OAuth().AccessToken(ctx)
+//	    if err != nil {
+//	        return "", err
+//	    }
+//	    // Call the API with token...
+//	}
+func (b *BasePlugin) OAuth() *OAuthHelper {
+	b.oauthMu.Lock()
+	defer b.oauthMu.Unlock()
+	if b.oauth == nil && b.pluginConfig != nil && b.pluginConfig.OAuth != nil {
+		b.oauth = &OAuthHelper{config: *b.pluginConfig.OAuth, host: b.Host, settings: b.Settings}
+	}
+	return b.oauth
+}
+
+// HTTPClient returns a client that only reaches the hosts plugin.yaml allows
+// (see NewHTTPClient). Use it instead of http.DefaultClient.
+//
+// Example plugin.yaml:
+//
+//	permissions:
+//	  network_access: true
+//	  allowed_hosts: [api.openweathermap.org]
+func (b *BasePlugin) HTTPClient() *http.Client {
+	b.httpClientMu.Lock()
+	defer b.httpClientMu.Unlock()
+	if b.httpClient == nil {
+		b.httpClient = NewHTTPClient(b.GetRequiredPermissions())
+	}
+	return b.httpClient
+}
+
 // GetToolDefinition returns the tool definition from plugin.yaml if available.
 // This method allows plugins to define their tool interface in YAML instead of code.
 // Returns an error if no tool definition is found in the plugin config.
@@ -299,5 +446,48 @@ func (b *BasePlugin) Definition() Tool {
 	}
 }
 
-// Compile-time interface check: BasePlugin implements OperationsProvider
-var _ OperationsProvider = (*BasePlugin)(nil)
+// GetRequiredPermissions returns the permissions declared in plugin.yaml's permissions section.
+// Implements PermissionProvider interface.
+func (b *BasePlugin) GetRequiredPermissions() PluginPermissions {
+	if b.pluginConfig == nil {
+		return PluginPermissions{}
+	}
+	return b.pluginConfig.ToPermissions()
+}
+
+// GetRateLimits returns the rate_limit entries of plugin.yaml's tool definition.
+// Implements RateLimitProvider interface.
+func (b *BasePlugin) GetRateLimits() []RateLimit {
+	if b.pluginConfig == nil {
+		return nil
+	}
+	return RateLimitsFromYAML(b.pluginConfig.Tool)
+}
+
+// GetPluginDependencies returns the requirements.plugins entries of plugin.yaml.
+// Implements PluginDependencyProvider interface.
+func (b *BasePlugin) GetPluginDependencies() []PluginDependency {
+	if b.pluginConfig == nil {
+		return nil
+	}
+	return b.pluginConfig.PluginDependencies()
+}
+
+// GetCategory returns the category declared in plugin.yaml.
+// Implements CategoryProvider interface.
+// Returns an empty string if no category is set.
+func (b *BasePlugin) GetCategory() string {
+	if b.pluginConfig == nil {
+		return ""
+	}
+	return b.pluginConfig.Category
+}
+
+// Compile-time interface checks
+var (
+	_ OperationsProvider       = (*BasePlugin)(nil)
+	_ PermissionProvider       = (*BasePlugin)(nil)
+	_ RateLimitProvider        = (*BasePlugin)(nil)
+	_ PluginDependencyProvider = (*BasePlugin)(nil)
+	_ CategoryProvider         = (*BasePlugin)(nil)
+)
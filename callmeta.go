@@ -0,0 +1,126 @@
+package pluginapi
+
+import (
+	"context"
+	"maps"
+)
+
+// Well-known call metadata keys. Hosts may send any other string keys as well.
+const (
+	// CallMetadataSource identifies what triggered the call (see the CallSource constants)
+	CallMetadataSource = "source"
+	// CallMetadataUserHint carries a free-form hint from the user that is not part of the args
+	CallMetadataUserHint = "user_hint"
+)
+
+// Common values for CallMetadataSource.
+const (
+	CallSourceChat      = "chat"
+	CallSourceScheduler = "scheduler"
+	CallSourceWebUI     = "web_ui"
+)
+
+type callMetadataKey struct{}
+
+// WithCallMetadata returns a context carrying call metadata. Hosts use it before
+// calling a plugin; the metadata is sent alongside the args and is available to the
+// plugin through CallMetadata. Keys already in ctx are kept unless md overrides them.
+//
+// Metadata is for host-controlled information (invocation source, feature flags)
+// and is never seen or produced by the LLM, unlike the JSON args.
+//
+// Example:
+//
+//	ctx = pluginapi.WithCallMetadata(ctx, map[string]string{
+//	    pluginapi.CallMetadataSource: pluginapi.CallSourceScheduler,
+//	})
+//	result, err := tool.Call(ctx, args)
+func WithCallMetadata(ctx context.Context, md map[string]string) context.Context {
+	if len(md) == 0 {
+		return ctx
+	}
+	merged := maps.Clone(CallMetadata(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(md))
+	}
+	maps.Copy(merged, md)
+	return context.WithValue(ctx, callMetadataKey{}, merged)
+}
+
+// CallMetadata returns the metadata the host attached to the current call,
+// or nil if there is none. The returned map must not be modified.
+func CallMetadata(ctx context.Context) map[string]string {
+	md, _ := ctx.Value(callMetadataKey{}).(map[string]string)
+	return md
+}
+
+// CallMetadataValue returns a single metadata value, or an empty string if it is absent.
+func CallMetadataValue(ctx context.Context, key string) string {
+	return CallMetadata(ctx)[key]
+}
+
+// CallSource returns the invocation source the host reported (e.g., CallSourceChat),
+// or an empty string if the host did not say.
+func CallSource(ctx context.Context) string {
+	return CallMetadataValue(ctx, CallMetadataSource)
+}
+
+// CallInfo describes the invocation a call belongs to. Hosts fill in what they know;
+// empty fields are unknown.
+type CallInfo struct {
+	Agent          string // Calling agent
+	ConversationID string // Conversation the call was made in
+	MessageID      string // User message that started the invocation
+	Model          string // LLM model that requested the call
+}
+
+type callInfoKey struct{}
+
+// WithCallMeta returns a context carrying info about the invocation. Like call
+// metadata, it is sent with Call, CallStream, CallWithFiles, and StartTask and is
+// available to the plugin through CallMeta. A zero info returns ctx unchanged.
+func WithCallMeta(ctx context.Context, info CallInfo) context.Context {
+	if info == (CallInfo{}) {
+		return ctx
+	}
+	return context.WithValue(ctx, callInfoKey{}, info)
+}
+
+// CallMeta returns the invocation info the host attached to the current call, or a
+// zero CallInfo if there is none.
+//
+// Example:
+//
+//	func (t *MyTool) Call(ctx context.Context, args string) (string, error) {
+//	    meta := pluginapi.CallMeta(ctx)
+//	    t.Logger().Info("called", "conversation", meta.ConversationID, "model", meta.Model)
+//	    ...
+//	}
+func CallMeta(ctx context.Context) CallInfo {
+	info, _ := ctx.Value(callInfoKey{}).(CallInfo)
+	return info
+}
+
+// callMetaToProto returns ctx's invocation info for a request, or nil if there is none.
+func callMetaToProto(ctx context.Context) *ProtoCallMeta {
+	info := CallMeta(ctx)
+	if info == (CallInfo{}) {
+		return nil
+	}
+	return &ProtoCallMeta{
+		Agent:          info.Agent,
+		ConversationId: info.ConversationID,
+		MessageId:      info.MessageID,
+		Model:          info.Model,
+	}
+}
+
+// withProtoCallMeta sets the invocation info from a request on ctx.
+func withProtoCallMeta(ctx context.Context, meta *ProtoCallMeta) context.Context {
+	return WithCallMeta(ctx, CallInfo{
+		Agent:          meta.GetAgent(),
+		ConversationID: meta.GetConversationId(),
+		MessageID:      meta.GetMessageId(),
+		Model:          meta.GetModel(),
+	})
+}
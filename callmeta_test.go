@@ -0,0 +1,107 @@
+package pluginapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestWithCallMetadata(t *testing.T) {
+	ctx := context.Background()
+	if md := CallMetadata(ctx); md != nil {
+		t.Errorf("expected nil metadata, got %v", md)
+	}
+	if WithCallMetadata(ctx, nil) != ctx {
+		t.Error("empty metadata should return ctx unchanged")
+	}
+
+	ctx = WithCallMetadata(ctx, map[string]string{CallMetadataSource: CallSourceChat, "flag": "a"})
+	inner := WithCallMetadata(ctx, map[string]string{"flag": "b"})
+
+	if CallSource(inner) != CallSourceChat {
+		t.Errorf("CallSource = %q, want %q", CallSource(inner), CallSourceChat)
+	}
+	if CallMetadataValue(inner, "flag") != "b" {
+		t.Errorf("inner flag = %q, want b", CallMetadataValue(inner, "flag"))
+	}
+	if CallMetadataValue(ctx, "flag") != "a" {
+		t.Errorf("outer metadata was modified: %v", CallMetadata(ctx))
+	}
+}
+
+type metadataTestTool struct {
+	BasePlugin
+}
+
+func (t *metadataTestTool) Call(ctx context.Context, args string) (string, error) {
+	return CallSource(ctx) + "/" + CallMetadataValue(ctx, "flag"), nil
+}
+
+func (t *metadataTestTool) CallWithFiles(ctx context.Context, args string, files []FileAttachment) (string, error) {
+	return "files:" + CallSource(ctx) + "/" + CallMetadataValue(ctx, "flag"), nil
+}
+
+func (t *metadataTestTool) AcceptsFiles() []string {
+	return []string{MIMETypeWAV}
+}
+
+func TestGRPCClient_CallMetadata(t *testing.T) {
+	client := newTestClient(t, &metadataTestTool{})
+	ctx := WithCallMetadata(context.Background(), map[string]string{
+		CallMetadataSource: CallSourceScheduler,
+		"flag":             "beta",
+	})
+
+	got, err := client.Call(ctx, `{}`)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if got != "scheduler/beta" {
+		t.Errorf("Call = %q, want scheduler/beta", got)
+	}
+
+	got, err = client.CallWithFiles(ctx, `{}`, nil)
+	if err != nil {
+		t.Fatalf("CallWithFiles failed: %v", err)
+	}
+	if got != "files:scheduler/beta" {
+		t.Errorf("CallWithFiles = %q, want files:scheduler/beta", got)
+	}
+
+	got, err = client.Call(context.Background(), `{}`)
+	if err != nil || got != "/" {
+		t.Errorf("Call without metadata = %q, %v", got, err)
+	}
+}
+
+type callMetaTestTool struct {
+	BasePlugin
+}
+
+func (t *callMetaTestTool) Call(ctx context.Context, args string) (string, error) {
+	meta := CallMeta(ctx)
+	return strings.Join([]string{meta.Agent, meta.ConversationID, meta.MessageID, meta.Model}, "/"), nil
+}
+
+func TestGRPCClient_CallMeta(t *testing.T) {
+	client := newTestClient(t, &callMetaTestTool{})
+	ctx := WithCallMeta(context.Background(), CallInfo{
+		Agent:          "assistant",
+		ConversationID: "conv-1",
+		MessageID:      "msg-7",
+		Model:          "small",
+	})
+
+	got, err := client.Call(ctx, `{}`)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if got != "assistant/conv-1/msg-7/small" {
+		t.Errorf("Call = %q, want assistant/conv-1/msg-7/small", got)
+	}
+
+	got, err = client.Call(context.Background(), `{}`)
+	if err != nil || got != "///" {
+		t.Errorf("Call without meta = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,89 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CallMetadataToolChain lists the tools on the current call stack, outermost first,
+// as a comma-separated string. It is maintained by the host and HostServices.CallTool
+// so that tools calling each other cannot loop forever.
+const CallMetadataToolChain = "tool_chain"
+
+// MaxToolCallDepth is the longest chain of tools calling tools that the host will run.
+const MaxToolCallDepth = 8
+
+// ErrToolCallLoop is returned by HostServices.CallTool when the call would re-enter a
+// tool already on the call stack or exceed MaxToolCallDepth.
+var ErrToolCallLoop = errors.New("tool call loop")
+
+// WithToolCallChain returns a context whose call metadata records chain as the
+// tools on the call stack. Hosts should record the tool they are about to call:
+//
+//	ctx = pluginapi.WithToolCallChain(ctx, append(pluginapi.ToolCallChain(ctx), name))
+//	result, err := tools[name].Call(ctx, args)
+func WithToolCallChain(ctx context.Context, chain []string) context.Context {
+	return WithCallMetadata(ctx, map[string]string{CallMetadataToolChain: strings.Join(chain, ",")})
+}
+
+// ToolCallChain returns the tools on the current call stack, outermost first.
+func ToolCallChain(ctx context.Context) []string {
+	chain := CallMetadataValue(ctx, CallMetadataToolChain)
+	if chain == "" {
+		return nil
+	}
+	return strings.Split(chain, ",")
+}
+
+// checkToolCallChain reports whether name may be called with chain on the call stack.
+func checkToolCallChain(chain []string, name string) error {
+	if slices.Contains(chain, name) {
+		return fmt.Errorf("%w: %s -> %s", ErrToolCallLoop, strings.Join(chain, " -> "), name)
+	}
+	if len(chain) >= MaxToolCallDepth {
+		return fmt.Errorf("%w: %s is more than %d calls deep", ErrToolCallLoop, name, MaxToolCallDepth)
+	}
+	return nil
+}
+
+func (UnimplementedHostServices) CallTool(ctx context.Context, name, argsJSON string) (string, error) {
+	return "", ErrHostServiceUnavailable
+}
+
+// CallTool refuses loops before the host sees the call, and records name on the
+// chain so the host forwards it when it calls the tool.
+func (s *hostServer) CallTool(ctx context.Context, req *HostCallToolRequest) (*CallResponse, error) {
+	if err := checkToolCallChain(req.CallChain, req.Name); err != nil {
+		return nil, hostStatus(err)
+	}
+	ctx = WithCallMetadata(ctx, req.Metadata)
+	ctx = WithToolCallChain(ctx, append(slices.Clone(req.CallChain), req.Name))
+
+	result, err := s.Impl.CallTool(ctx, req.Name, req.ArgsJson)
+	if st := hostStatus(err); st != nil {
+		return nil, st
+	}
+	if err != nil {
+		return callErrorResponse(err), nil
+	}
+	return &CallResponse{ResultJson: result}, nil
+}
+
+func (h *hostClient) CallTool(ctx context.Context, name, argsJSON string) (string, error) {
+	resp, err := h.client.CallTool(h.outgoing(ctx), &HostCallToolRequest{
+		Name:      name,
+		ArgsJson:  argsJSON,
+		CallChain: ToolCallChain(ctx),
+		Metadata:  CallMetadata(ctx),
+	})
+	if err != nil {
+		return "", hostSentinel(err)
+	}
+	if resp.Error != "" {
+		return "", callError(resp.Error, resp.StructuredError)
+	}
+	return resp.ResultJson, nil
+}
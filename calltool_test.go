@@ -0,0 +1,96 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// routingHostServices dispatches CallTool to plugins the way the agent does.
+type routingHostServices struct {
+	UnimplementedHostServices
+	tools map[string]*grpcClient
+}
+
+func (h *routingHostServices) CallTool(ctx context.Context, name, argsJSON string) (string, error) {
+	tool, ok := h.tools[name]
+	if !ok {
+		return "", NewPluginError(ErrorCodeInvalidArgs, "unknown tool "+name)
+	}
+	return tool.Call(ctx, argsJSON)
+}
+
+// relayTestTool calls another tool for args of the form "call:<name>:<args>",
+// and otherwise echoes its args and call chain.
+type relayTestTool struct {
+	BasePlugin
+}
+
+func (t *relayTestTool) Call(ctx context.Context, args string) (string, error) {
+	if parts := strings.SplitN(args, ":", 3); len(parts) == 3 && parts[0] == "call" {
+		result, err := t.Host().CallTool(ctx, parts[1], parts[2])
+		if errors.Is(err, ErrToolCallLoop) {
+			return "", WrapPluginError(ErrorCodeInvalidArgs, err)
+		}
+		return result, err
+	}
+	return "pong:" + args + " chain=" + strings.Join(ToolCallChain(ctx), ">") + " source=" + CallSource(ctx), nil
+}
+
+func newRelayTools(t *testing.T, names ...string) map[string]*grpcClient {
+	t.Helper()
+	host := &routingHostServices{tools: make(map[string]*grpcClient)}
+	address := newTestHost(t, host)
+	for _, name := range names {
+		client := newTestClient(t, &relayTestTool{})
+		if err := client.ConnectHostServices(context.Background(), address, name); err != nil {
+			t.Fatalf("ConnectHostServices failed: %v", err)
+		}
+		host.tools[name] = client
+	}
+	return host.tools
+}
+
+func TestHostServices_CallTool(t *testing.T) {
+	tools := newRelayTools(t, "a", "b")
+
+	ctx := WithCallMetadata(context.Background(), map[string]string{CallMetadataSource: CallSourceChat})
+	ctx = WithToolCallChain(ctx, []string{"a"})
+	result, err := tools["a"].Call(ctx, "call:b:hello")
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if result != "pong:hello chain=a>b source=chat" {
+		t.Errorf("result = %q", result)
+	}
+
+	_, err = tools["a"].Call(ctx, "call:missing:x")
+	var pluginErr *PluginError
+	if !errors.As(err, &pluginErr) || pluginErr.Code != ErrorCodeInvalidArgs || pluginErr.Message != "unknown tool missing" {
+		t.Errorf("expected the called tool's error to be preserved, got %#v", err)
+	}
+}
+
+func TestHostServices_CallToolLoop(t *testing.T) {
+	tools := newRelayTools(t, "a", "b")
+	ctx := WithToolCallChain(context.Background(), []string{"a"})
+
+	_, err := tools["a"].Call(ctx, "call:b:call:a:x")
+	if err == nil || !strings.Contains(err.Error(), "tool call loop: a -> b -> a") {
+		t.Errorf("expected loop error, got %v", err)
+	}
+}
+
+func TestCheckToolCallChain(t *testing.T) {
+	if err := checkToolCallChain([]string{"a", "b"}, "c"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	deep := make([]string, MaxToolCallDepth)
+	for i := range deep {
+		deep[i] = string(rune('a' + i))
+	}
+	if err := checkToolCallChain(deep, "z"); !errors.Is(err, ErrToolCallLoop) {
+		t.Errorf("expected depth limit error, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package pluginapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// cancelCallTimeout bounds the CancelCall RPC the client sends after its context ends.
+const cancelCallTimeout = 5 * time.Second
+
+type callIDContextKey struct{}
+
+// WithCallID returns a context that sends id with the next call.
+// Hosts only need this to cancel a call from somewhere other than its own context
+// (see the client's CancelCall); otherwise an ID is generated automatically.
+func WithCallID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, callIDContextKey{}, id)
+}
+
+// CallID returns the ID of the current call, or an empty string if the host sent none.
+// Plugins can use it to correlate log lines with host-side records.
+func CallID(ctx context.Context) string {
+	id, _ := ctx.Value(callIDContextKey{}).(string)
+	return id
+}
+
+// newCallID returns a random call ID.
+func newCallID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// callRegistry tracks the running calls of a plugin so they can be cancelled by ID.
+// The zero value is ready to use.
+type callRegistry struct {
+	mu      sync.Mutex
+	running map[string]*runningCall
+}
+
+type runningCall struct {
+	cancel context.CancelFunc
+}
+
+// start derives a cancellable context for the call with the given ID.
+// The returned function must be called when the call finishes.
+func (r *callRegistry) start(ctx context.Context, id string) (context.Context, func()) {
+	if id == "" {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(WithCallID(ctx, id))
+	call := &runningCall{cancel: cancel}
+
+	r.mu.Lock()
+	if r.running == nil {
+		r.running = make(map[string]*runningCall)
+	}
+	r.running[id] = call
+	r.mu.Unlock()
+
+	return ctx, func() {
+		r.mu.Lock()
+		if r.running[id] == call {
+			delete(r.running, id)
+		}
+		r.mu.Unlock()
+		cancel()
+	}
+}
+
+// cancel cancels the running call with the given ID and reports whether one was found.
+func (r *callRegistry) cancel(id string) bool {
+	r.mu.Lock()
+	call, ok := r.running[id]
+	r.mu.Unlock()
+	if ok {
+		call.cancel()
+	}
+	return ok
+}
@@ -0,0 +1,109 @@
+package pluginapi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type blockingTestTool struct {
+	BasePlugin
+	started   chan string
+	cancelled chan struct{}
+}
+
+func newBlockingTestTool() *blockingTestTool {
+	return &blockingTestTool{
+		started:   make(chan string, 1),
+		cancelled: make(chan struct{}),
+	}
+}
+
+func (t *blockingTestTool) Call(ctx context.Context, args string) (string, error) {
+	t.started <- CallID(ctx)
+	<-ctx.Done()
+	close(t.cancelled)
+	return "", ctx.Err()
+}
+
+func waitClosed(t *testing.T, ch <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+	}
+}
+
+func TestGRPCClient_Call_CancellationReachesPlugin(t *testing.T) {
+	tool := newBlockingTestTool()
+	client := newTestClient(t, tool)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := client.Call(ctx, `{}`)
+		errCh <- err
+	}()
+
+	if id := <-tool.started; id == "" {
+		t.Error("plugin should see a generated call ID")
+	}
+	cancel()
+
+	waitClosed(t, tool.cancelled, "plugin context cancellation")
+	if err := <-errCh; err == nil {
+		t.Error("expected error from cancelled call")
+	}
+}
+
+func TestGRPCClient_CancelCall(t *testing.T) {
+	tool := newBlockingTestTool()
+	client := newTestClient(t, tool)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := client.Call(WithCallID(context.Background(), "render-42"), `{}`)
+		errCh <- err
+	}()
+
+	if id := <-tool.started; id != "render-42" {
+		t.Errorf("CallID = %q, want render-42", id)
+	}
+	if err := client.CancelCall(context.Background(), "render-42"); err != nil {
+		t.Fatalf("CancelCall failed: %v", err)
+	}
+
+	waitClosed(t, tool.cancelled, "plugin context cancellation")
+	if err := <-errCh; err == nil {
+		t.Error("expected error from cancelled call")
+	}
+
+	if err := client.CancelCall(context.Background(), "render-42"); err == nil {
+		t.Error("expected error cancelling a call that is no longer running")
+	}
+}
+
+func TestCallRegistry(t *testing.T) {
+	var r callRegistry
+
+	ctx, done := r.start(context.Background(), "")
+	done()
+	if ctx.Err() != nil {
+		t.Error("calls without an ID should not be tracked or cancelled")
+	}
+
+	first, doneFirst := r.start(context.Background(), "dup")
+	second, doneSecond := r.start(context.Background(), "dup")
+	doneFirst()
+	if first.Err() == nil {
+		t.Error("finished call context should be cancelled")
+	}
+	if !r.cancel("dup") || second.Err() == nil {
+		t.Error("the later call with the same ID should still be cancellable")
+	}
+	doneSecond()
+	if r.cancel("dup") {
+		t.Error("finished calls should be unregistered")
+	}
+}
@@ -0,0 +1,95 @@
+package pluginapi
+
+import "slices"
+
+// Capabilities describes everything a host discovers about a plugin at startup,
+// as returned by GetCapabilities in a single round trip.
+type Capabilities struct {
+	// Interfaces names the optional interfaces the plugin implements (e.g., "WebPageProvider")
+	Interfaces []string
+
+	// Version is the plugin version, "unknown" without VersionedTool
+	Version         string
+	MinAgentVersion string
+	MaxAgentVersion string
+	APIVersion      string
+
+	// Metadata is nil if the plugin doesn't provide any
+	Metadata *PluginMetadata
+	// WebPages falls back to bare paths without WebPageInfoProvider
+	WebPages             []WebPageInfo
+	AcceptedFileTypes    []string
+	Operations           []OperationInfo
+	SystemPromptFragment string
+	FileWatches          []FileWatch
+	Permissions          PluginPermissions
+	Category             string
+	ScheduledTasks       []ScheduledTask
+	RateLimits           []RateLimit
+}
+
+// Implements reports whether the plugin implements the named optional interface.
+func (c Capabilities) Implements(name string) bool {
+	return slices.Contains(c.Interfaces, name)
+}
+
+// optionalInterfaces lists the interfaces reported in Capabilities.Interfaces.
+var optionalInterfaces = []struct {
+	name       string
+	implements func(PluginTool) bool
+}{
+	{"VersionedTool", implements[VersionedTool]},
+	{"PluginCompatibility", implements[PluginCompatibility]},
+	{"PluginDependencyProvider", implements[PluginDependencyProvider]},
+	{"AgentAwareTool", implements[AgentAwareTool]},
+	{"AgentContextUpdateListener", implements[AgentContextUpdateListener]},
+	{"ConfigChangeListener", implements[ConfigChangeListener]},
+	{"HostAwareTool", implements[HostAwareTool]},
+	{"EventListener", implements[EventListener]},
+	{"MessageListener", implements[MessageListener]},
+	{"WebPageProvider", implements[WebPageProvider]},
+	{"WebPageInfoProvider", implements[WebPageInfoProvider]},
+	{"DefaultSettingsProvider", implements[DefaultSettingsProvider]},
+	{"InitializationProvider", implements[InitializationProvider]},
+	{"ConnectivityTester", implements[ConnectivityTester]},
+	{"MetadataProvider", implements[MetadataProvider]},
+	{"FileAttachmentHandler", implements[FileAttachmentHandler]},
+	{"FileOutputProvider", implements[FileOutputProvider]},
+	{"OperationsProvider", implements[OperationsProvider]},
+	{"HealthCheckProvider", implements[HealthCheckProvider]},
+	{"InterceptorProvider", implements[InterceptorProvider]},
+	{"Initializer", implements[Initializer]},
+	{"ShutdownHandler", implements[ShutdownHandler]},
+	{"CategoryProvider", implements[CategoryProvider]},
+	{"PermissionProvider", implements[PermissionProvider]},
+	{"RateLimitProvider", implements[RateLimitProvider]},
+	{"StatefulPlugin", implements[StatefulPlugin]},
+	{"StatePorter", implements[StatePorter]},
+	{"HandoffProvider", implements[HandoffProvider]},
+	{"ToolSetProvider", implements[ToolSetProvider]},
+	{"DynamicDefinitionProvider", implements[DynamicDefinitionProvider]},
+	{"SystemPromptProvider", implements[SystemPromptProvider]},
+	{"PromptContributor", implements[PromptContributor]},
+	{"ContextEnricher", implements[ContextEnricher]},
+	{"EmbeddingProvider", implements[EmbeddingProvider]},
+	{"FileWatchProvider", implements[FileWatchProvider]},
+	{"ScheduledTaskProvider", implements[ScheduledTaskProvider]},
+	{"TaskProvider", implements[TaskProvider]},
+	{"StreamingTool", implements[StreamingTool]},
+}
+
+func implements[T any](tool PluginTool) bool {
+	_, ok := tool.(T)
+	return ok
+}
+
+// implementedInterfaces returns the names of the optional interfaces tool implements.
+func implementedInterfaces(tool PluginTool) []string {
+	var names []string
+	for _, iface := range optionalInterfaces {
+		if iface.implements(tool) {
+			names = append(names, iface.name)
+		}
+	}
+	return names
+}
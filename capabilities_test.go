@@ -0,0 +1,76 @@
+package pluginapi
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+type capableTestTool struct {
+	webPageInfoTestTool
+}
+
+func (t *capableTestTool) GetCategory() string { return "music" }
+
+func (t *capableTestTool) GetSystemPromptFragment() string { return "Prefer WAV exports." }
+
+func (t *capableTestTool) AcceptsFiles() []string { return []string{".wav"} }
+
+func (t *capableTestTool) CallWithFiles(ctx context.Context, args string, files []FileAttachment) (string, error) {
+	return "", nil
+}
+
+func TestGRPCClient_GetCapabilities(t *testing.T) {
+	tool := &capableTestTool{}
+	caps, err := newTestClient(t, tool).GetCapabilities(context.Background())
+	if err != nil {
+		t.Fatalf("GetCapabilities failed: %v", err)
+	}
+	for _, name := range []string{"WebPageProvider", "WebPageInfoProvider", "CategoryProvider", "SystemPromptProvider", "FileAttachmentHandler"} {
+		if !caps.Implements(name) {
+			t.Errorf("expected %s in %v", name, caps.Interfaces)
+		}
+	}
+	if caps.Implements("EmbeddingProvider") || caps.Implements("StreamingTool") {
+		t.Errorf("unexpected interfaces in %v", caps.Interfaces)
+	}
+	if caps.Category != "music" || caps.SystemPromptFragment != "Prefer WAV exports." {
+		t.Errorf("unexpected category or prompt: %+v", caps)
+	}
+	if len(caps.AcceptedFileTypes) != 1 || caps.AcceptedFileTypes[0] != ".wav" {
+		t.Errorf("unexpected file types: %v", caps.AcceptedFileTypes)
+	}
+	if len(caps.WebPages) != 2 || caps.WebPages[0].Title != "Script Marketplace" {
+		t.Errorf("unexpected web pages: %+v", caps.WebPages)
+	}
+	if caps.Operations != nil || caps.FileWatches != nil {
+		t.Errorf("expected no operations or watches, got %+v", caps)
+	}
+}
+
+// legacyToolServer serves a plugin as it was before GetCapabilities existed.
+type legacyToolServer struct {
+	*grpcServer
+}
+
+func (legacyToolServer) GetCapabilities(context.Context, *Empty) (*CapabilitiesResponse, error) {
+	return UnimplementedToolServiceServer{}.GetCapabilities(context.Background(), &Empty{})
+}
+
+func TestGRPCClient_GetCapabilities_ProbesOlderPlugins(t *testing.T) {
+	server := grpc.NewServer()
+	RegisterToolServiceServer(server, legacyToolServer{&grpcServer{Impl: &capableTestTool{}}})
+	client := &grpcClient{client: NewToolServiceClient(dialTestServer(t, server))}
+
+	caps, err := client.GetCapabilities(context.Background())
+	if err != nil {
+		t.Fatalf("GetCapabilities failed: %v", err)
+	}
+	if len(caps.Interfaces) != 0 {
+		t.Errorf("older plugins can't report interfaces, got %v", caps.Interfaces)
+	}
+	if caps.Category != "music" || len(caps.WebPages) != 2 || len(caps.AcceptedFileTypes) != 1 {
+		t.Errorf("expected probed capabilities, got %+v", caps)
+	}
+}
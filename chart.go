@@ -0,0 +1,167 @@
+package pluginapi
+
+import (
+	"fmt"
+	"math"
+)
+
+// ChartType selects how chart series are drawn.
+type ChartType string
+
+const (
+	ChartTypeLine    ChartType = "line"
+	ChartTypeBar     ChartType = "bar"
+	ChartTypeArea    ChartType = "area"
+	ChartTypeScatter ChartType = "scatter"
+	ChartTypePie     ChartType = "pie"
+)
+
+// ChartAxisType describes how axis values are interpreted.
+type ChartAxisType string
+
+const (
+	ChartAxisLinear   ChartAxisType = "linear"   // Numeric values
+	ChartAxisLog      ChartAxisType = "log"      // Numeric values on a logarithmic scale
+	ChartAxisCategory ChartAxisType = "category" // DataPoint.Label values
+	ChartAxisTime     ChartAxisType = "time"     // DataPoint.X as Unix milliseconds
+)
+
+// DataPoint is a single value in a chart series.
+// Category-based charts (bar, pie) use Label as the category and ignore X.
+// Numeric charts (line, area, scatter) use X; Label is then an optional point annotation.
+type DataPoint struct {
+	X     float64 `json:"x" yaml:"x"`
+	Y     float64 `json:"y" yaml:"y"`
+	Label string  `json:"label,omitempty" yaml:"label,omitempty"`
+}
+
+// Series is a named sequence of data points drawn together.
+type Series struct {
+	Name   string      `json:"name" yaml:"name"`
+	Points []DataPoint `json:"points" yaml:"points"`
+	// Color is an optional CSS color (e.g., "#4f46e5"); hosts pick one if empty
+	Color string `json:"color,omitempty" yaml:"color,omitempty"`
+}
+
+// ChartAxis describes the labeling and range of a chart axis.
+type ChartAxis struct {
+	Label string        `json:"label,omitempty" yaml:"label,omitempty"`
+	Unit  string        `json:"unit,omitempty" yaml:"unit,omitempty"` // e.g., "ms", "%", "MB"
+	Type  ChartAxisType `json:"type,omitempty" yaml:"type,omitempty"`
+	Min   *float64      `json:"min,omitempty" yaml:"min,omitempty"`
+	Max   *float64      `json:"max,omitempty" yaml:"max,omitempty"`
+}
+
+// ChartData is the payload of a DisplayTypeChart result.
+type ChartData struct {
+	Type   ChartType  `json:"type" yaml:"type"`
+	Series []Series   `json:"series" yaml:"series"`
+	XAxis  *ChartAxis `json:"xAxis,omitempty" yaml:"xAxis,omitempty"`
+	YAxis  *ChartAxis `json:"yAxis,omitempty" yaml:"yAxis,omitempty"`
+}
+
+// NewSeries creates a named chart series.
+func NewSeries(name string, points ...DataPoint) Series {
+	return Series{Name: name, Points: points}
+}
+
+// Point creates a numeric data point for line, area, and scatter charts.
+func Point(x, y float64) DataPoint {
+	return DataPoint{X: x, Y: y}
+}
+
+// CategoryPoint creates a labeled data point for bar and pie charts.
+func CategoryPoint(label string, y float64) DataPoint {
+	return DataPoint{Label: label, Y: y}
+}
+
+// NewChartResult creates a StructuredResult for a chart.
+func NewChartResult(title string, chart ChartData) *StructuredResult {
+	return &StructuredResult{
+		DisplayType: DisplayTypeChart,
+		Title:       title,
+		Data:        chart,
+	}
+}
+
+// NewLineChartResult creates a line chart with one or more series.
+//
+// Example:
+//
+//	result := pluginapi.NewLineChartResult("Latency",
+//	    pluginapi.NewSeries("p50", pluginapi.Point(1, 12), pluginapi.Point(2, 15)),
+//	    pluginapi.NewSeries("p99", pluginapi.Point(1, 40), pluginapi.Point(2, 52)),
+//	)
+func NewLineChartResult(title string, series ...Series) *StructuredResult {
+	return NewChartResult(title, ChartData{Type: ChartTypeLine, Series: series})
+}
+
+// NewBarChartResult creates a bar chart. Points should be built with CategoryPoint.
+//
+// Example:
+//
+//	result := pluginapi.NewBarChartResult("Downloads",
+//	    pluginapi.NewSeries("2024", pluginapi.CategoryPoint("Jan", 120), pluginapi.CategoryPoint("Feb", 98)),
+//	)
+func NewBarChartResult(title string, series ...Series) *StructuredResult {
+	return NewChartResult(title, ChartData{
+		Type:   ChartTypeBar,
+		Series: series,
+		XAxis:  &ChartAxis{Type: ChartAxisCategory},
+	})
+}
+
+// NewPieChartResult creates a pie chart from labeled slices.
+func NewPieChartResult(title string, slices ...DataPoint) *StructuredResult {
+	return NewChartResult(title, ChartData{
+		Type:   ChartTypePie,
+		Series: []Series{{Name: title, Points: slices}},
+	})
+}
+
+// Validate checks that the chart payload is well-formed.
+func (c *ChartData) Validate() error {
+	switch c.Type {
+	case ChartTypeLine, ChartTypeBar, ChartTypeArea, ChartTypeScatter, ChartTypePie:
+	case "":
+		return fmt.Errorf("chart type is required")
+	default:
+		return fmt.Errorf("unsupported chart type: %s", c.Type)
+	}
+
+	if len(c.Series) == 0 {
+		return fmt.Errorf("chart must have at least one series")
+	}
+	if c.Type == ChartTypePie && len(c.Series) != 1 {
+		return fmt.Errorf("pie chart must have exactly one series (got %d)", len(c.Series))
+	}
+
+	categorical := c.Type == ChartTypeBar || c.Type == ChartTypePie
+	for i, s := range c.Series {
+		for j, p := range s.Points {
+			if math.IsNaN(p.X) || math.IsInf(p.X, 0) || math.IsNaN(p.Y) || math.IsInf(p.Y, 0) {
+				return fmt.Errorf("series[%d] point[%d]: values must be finite", i, j)
+			}
+			if categorical && p.Label == "" {
+				return fmt.Errorf("series[%d] point[%d]: label is required for %s charts", i, j, c.Type)
+			}
+		}
+	}
+
+	if err := c.XAxis.validate("xAxis"); err != nil {
+		return err
+	}
+	return c.YAxis.validate("yAxis")
+}
+
+// validate checks the axis range. A nil axis is valid.
+func (a *ChartAxis) validate(name string) error {
+	if a == nil {
+		return nil
+	}
+	if a.Min != nil && a.Max != nil && *a.Min > *a.Max {
+		return fmt.Errorf("%s: min (%v) cannot be greater than max (%v)", name, *a.Min, *a.Max)
+	}
+
+	return nil
+}
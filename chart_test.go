@@ -0,0 +1,96 @@
+package pluginapi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChartResult_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  *StructuredResult
+		wantErr bool
+	}{
+		{
+			name: "valid line chart",
+			result: NewLineChartResult("Latency",
+				NewSeries("p50", Point(1, 12), Point(2, 15)),
+			),
+		},
+		{
+			name: "valid bar chart",
+			result: NewBarChartResult("Downloads",
+				NewSeries("2024", CategoryPoint("Jan", 120), CategoryPoint("Feb", 98)),
+			),
+		},
+		{
+			name:   "valid pie chart",
+			result: NewPieChartResult("Share", CategoryPoint("a", 1), CategoryPoint("b", 2)),
+		},
+		{
+			name:    "no series",
+			result:  NewLineChartResult("Empty"),
+			wantErr: true,
+		},
+		{
+			name:    "bar chart without labels",
+			result:  NewBarChartResult("Bad", NewSeries("s", Point(1, 2))),
+			wantErr: true,
+		},
+		{
+			name:    "non-finite value",
+			result:  NewLineChartResult("NaN", NewSeries("s", Point(1, math.NaN()))),
+			wantErr: true,
+		},
+		{
+			name:    "unknown chart type",
+			result:  NewChartResult("Bad", ChartData{Type: "radar", Series: []Series{NewSeries("s")}}),
+			wantErr: true,
+		},
+		{
+			name:    "missing chart data",
+			result:  &StructuredResult{DisplayType: DisplayTypeChart},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.result.Validate()
+			if tt.wantErr && err == nil {
+				t.Error("expected validation error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestChartResult_ValidateAfterParse(t *testing.T) {
+	result := NewBarChartResult("Downloads", NewSeries("2024", CategoryPoint("Jan", 120)))
+	jsonStr, err := result.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+
+	parsed, err := ParseStructuredResult(jsonStr)
+	if err != nil {
+		t.Fatalf("ParseStructuredResult failed: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Errorf("unexpected validation error after parse: %v", err)
+	}
+
+	yamlStr, err := result.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+	parsed, err = FromYAML(yamlStr)
+	if err != nil {
+		t.Fatalf("FromYAML failed: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Errorf("unexpected validation error after YAML parse: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package pluginapi
+
+import "sync"
+
+// responseCache holds the plugin's discovery responses, which rarely change while
+// it runs, so repeated Definition and compatibility lookups cost one RPC in total.
+// Only successful responses are cached. The zero value is ready to use.
+type responseCache struct {
+	mu            sync.Mutex
+	definition    *ToolDefinition
+	metadata      *MetadataResponse
+	compatibility *CompatibilityInfoResponse
+}
+
+// cachedResponse returns *slot if it is set, and otherwise fetches and stores it.
+// Concurrent misses may fetch more than once; the last response wins.
+func cachedResponse[T any](cache *responseCache, slot **T, fetch func() (*T, error)) (*T, error) {
+	cache.mu.Lock()
+	resp := *slot
+	cache.mu.Unlock()
+	if resp != nil {
+		return resp, nil
+	}
+
+	resp, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	cache.mu.Lock()
+	*slot = resp
+	cache.mu.Unlock()
+	return resp, nil
+}
+
+func (c *responseCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.definition, c.metadata, c.compatibility = nil, nil, nil
+}
@@ -0,0 +1,66 @@
+package pluginapi
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// countingInterceptor counts the unary RPCs a client makes by method name.
+type countingInterceptor struct {
+	counts map[string]*atomic.Int32
+}
+
+func (c countingInterceptor) intercept(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	c.counts[method].Add(1)
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func TestGRPCClient_CachesDiscoveryResponses(t *testing.T) {
+	methods := []string{
+		"/pluginapi.ToolService/GetDefinition",
+		"/pluginapi.ToolService/GetMetadata",
+		"/pluginapi.ToolService/GetCompatibilityInfo",
+	}
+	counter := countingInterceptor{counts: map[string]*atomic.Int32{}}
+	for _, m := range methods {
+		counter.counts[m] = &atomic.Int32{}
+	}
+	conn := ClientInterceptors{Unary: []grpc.UnaryClientInterceptor{counter.intercept}}.Wrap(newTestConn(t, &plainTestTool{}))
+	client := &grpcClient{client: NewToolServiceClient(conn)}
+
+	lookups := func() {
+		client.Definition()
+		client.MinAgentVersion()
+		client.MaxAgentVersion()
+		client.APIVersion()
+		client.GetTags()
+		if metadata, err := client.GetMetadata(); err == nil && metadata != nil {
+			metadata.Tags = append(metadata.Tags, "mutated")
+		}
+	}
+	lookups()
+	lookups()
+	for _, m := range methods {
+		if got := counter.counts[m].Load(); got != 1 {
+			t.Errorf("%s called %d times, want 1", m, got)
+		}
+	}
+	if metadata, err := client.GetMetadata(); err == nil && metadata != nil {
+		for _, tag := range metadata.Tags {
+			if tag == "mutated" {
+				t.Error("callers must not be able to modify the cached metadata")
+			}
+		}
+	}
+
+	client.Invalidate()
+	lookups()
+	for _, m := range methods {
+		if got := counter.counts[m].Load(); got != 2 {
+			t.Errorf("%s called %d times after Invalidate, want 2", m, got)
+		}
+	}
+}
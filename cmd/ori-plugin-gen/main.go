@@ -23,6 +23,7 @@ import (
 	"strings"
 	"text/template"
 
+	pluginapi "github.com/oriagent/ori-pluginapi"
 	"gopkg.in/yaml.v3"
 )
 
@@ -38,6 +39,8 @@ type YAMLToolParameter struct {
 // YAMLOperationDefinition represents per-operation parameters in plugin.yaml
 type YAMLOperationDefinition struct {
 	Parameters []YAMLToolParameter `yaml:"parameters"`
+	Pagination bool                `yaml:"pagination,omitempty"`
+	DryRun     bool                `yaml:"dry_run,omitempty"`
 }
 
 // YAMLToolDefinition represents tool definition in plugin.yaml
@@ -109,8 +112,11 @@ type TemplateData struct {
 	Fields             []FieldInfo
 	OptionalInterfaces []string
 
-	Operations    []OperationInfo
-	HasOperations bool
+	Operations       []OperationInfo
+	HasOperations    bool
+	HasPagination    bool
+	DryRunOperations []OperationInfo
+	HasDryRun        bool
 
 	ConfigVars    []ConfigVariable
 	HasConfig     bool
@@ -121,9 +127,11 @@ type TemplateData struct {
 	FileOperations    []OperationInfo
 	HasFileOperations bool
 
-	WebPages        []string
-	WebPageHandlers []OperationInfo
-	HasWebPages     bool
+	WebPages         []string
+	WebPageHandlers  []OperationInfo
+	WebRouteHandlers []OperationInfo
+	HasWebPages      bool
+	HasWebRoutes     bool
 
 	Assets    []string
 	HasAssets bool
@@ -257,6 +265,16 @@ func generateCode(pkgName string, config *PluginConfig) (string, error) {
 		})
 	}
 
+	var dryRunOperations []OperationInfo
+	for _, name := range opNames {
+		if config.Tool.Operations[name].DryRun {
+			dryRunOperations = append(dryRunOperations, OperationInfo{
+				Name:        name,
+				HandlerName: "dryRun" + toPascalCase(name),
+			})
+		}
+	}
+
 	var configVars []ConfigVariable
 	var hasValidation bool
 	if config.Config != nil {
@@ -281,6 +299,8 @@ func generateCode(pkgName string, config *PluginConfig) (string, error) {
 		}
 	}
 
+	staticPages, routePages := splitWebPages(config.WebPages)
+
 	tmplData := TemplateData{
 		PackageName:        pkgName,
 		ToolName:           toolName,
@@ -290,6 +310,9 @@ func generateCode(pkgName string, config *PluginConfig) (string, error) {
 		OptionalInterfaces: optionalInterfaces,
 		Operations:         operations,
 		HasOperations:      len(operations) > 0,
+		HasPagination:      hasPagination(config.Tool),
+		DryRunOperations:   dryRunOperations,
+		HasDryRun:          len(dryRunOperations) > 0,
 		ConfigVars:         configVars,
 		HasConfig:          len(configVars) > 0,
 		HasValidation:      hasValidation,
@@ -297,9 +320,11 @@ func generateCode(pkgName string, config *PluginConfig) (string, error) {
 		HasAcceptsFiles:    len(acceptsFiles) > 0,
 		FileOperations:     fileOperations,
 		HasFileOperations:  len(fileOperations) > 0,
-		WebPages:           config.WebPages,
-		WebPageHandlers:    buildWebPageHandlers(config.WebPages),
+		WebPages:           staticPages,
+		WebPageHandlers:    buildWebPageHandlers(staticPages),
+		WebRouteHandlers:   buildWebPageHandlers(routePages),
 		HasWebPages:        len(config.WebPages) > 0,
+		HasWebRoutes:       len(routePages) > 0,
 		Assets:             config.Assets,
 		HasAssets:          len(config.Assets) > 0,
 	}
@@ -317,12 +342,41 @@ func buildWebPageHandlers(pages []string) []OperationInfo {
 	for _, page := range pages {
 		handlers = append(handlers, OperationInfo{
 			Name:        page,
-			HandlerName: "serve" + toPascalCase(page) + "Page",
+			HandlerName: "serve" + pageHandlerName(page) + "Page",
 		})
 	}
 	return handlers
 }
 
+// splitWebPages separates plain page paths from route patterns like "projects/{id}"
+func splitWebPages(pages []string) (static, routes []string) {
+	for _, page := range pages {
+		if strings.ContainsAny(page, "{}*") {
+			routes = append(routes, page)
+		} else {
+			static = append(static, page)
+		}
+	}
+	return static, routes
+}
+
+// pageHandlerName builds a handler name from a page path or route pattern,
+// e.g. "projects/{id}" becomes "ProjectsById" and "files/{path...}" becomes "FilesByPath".
+func pageHandlerName(page string) string {
+	var name strings.Builder
+	for _, seg := range strings.Split(strings.Trim(page, "/"), "/") {
+		switch {
+		case seg == "*":
+			name.WriteString("Any")
+		case strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}"):
+			name.WriteString("By" + toPascalCase(strings.TrimSuffix(seg[1:len(seg)-1], "...")))
+		default:
+			name.WriteString(toPascalCase(seg))
+		}
+	}
+	return name.String()
+}
+
 func getOperationNames(tool *YAMLToolDefinition) []string {
 	if tool == nil || len(tool.Operations) == 0 {
 		return nil
@@ -378,12 +432,49 @@ func collectParameters(tool *YAMLToolDefinition) ([]YAMLToolParameter, error) {
 					return nil, err
 				}
 			}
+			if op.Pagination {
+				for _, param := range paginationParameters() {
+					if err := addParam(param); err != nil {
+						return nil, err
+					}
+				}
+			}
 		}
 	}
 
 	return ordered, nil
 }
 
+// paginationParameters returns the parameters injected into operations marked with
+// pagination: true, converted from pluginapi.PaginationParameters so the generated
+// struct documents the same defaults and limits the runtime applies.
+func paginationParameters() []YAMLToolParameter {
+	standard := pluginapi.PaginationParameters()
+	params := make([]YAMLToolParameter, len(standard))
+	for i, p := range standard {
+		params[i] = YAMLToolParameter{
+			Name:        p.Name,
+			Type:        p.Type,
+			Description: p.Description,
+			Required:    p.Required,
+			Enum:        p.Enum,
+		}
+	}
+	return params
+}
+
+func hasPagination(tool *YAMLToolDefinition) bool {
+	if tool == nil {
+		return false
+	}
+	for _, op := range tool.Operations {
+		if op.Pagination {
+			return true
+		}
+	}
+	return false
+}
+
 func toPascalCase(s string) string {
 	s = strings.ReplaceAll(s, "-", "_")
 	parts := strings.Split(s, "_")
@@ -434,6 +525,7 @@ import (
 
 // Compile-time interface checks
 var _ pluginapi.PluginTool = (*{{.ToolNamePascal}}Tool)(nil)
+var _ pluginapi.BaseSetter = (*{{.ToolNamePascal}}Tool)(nil)
 {{- if .OptionalInterfaces}}
 
 // Optional interface checks (auto-detected from plugin.yaml)
@@ -462,6 +554,13 @@ type {{.ParamsStruct}} struct {
 	{{.Name}} {{.Type}} ` + "`json:\"{{.JSONTag}}\"`" + ` // {{.Comment}}
 {{- end}}
 }
+{{- if .HasPagination}}
+
+// Pagination returns the paging arguments with the default limit applied and the limit clamped
+func (p *{{.ParamsStruct}}) Pagination() pluginapi.PaginationParams {
+	return pluginapi.PaginationParams{Cursor: p.Cursor, Limit: p.Limit}.Normalized()
+}
+{{- end}}
 
 {{- if .HasOperations}}
 
@@ -482,13 +581,33 @@ var (
 	_ OperationHandler = {{.HandlerName}}
 {{- end}}
 )
+{{- if .HasDryRun}}
 
-// Execute dispatches to the appropriate operation handler
+// dryRunRegistry maps operations declared with dry_run: true to their preview handlers.
+// Preview handlers must be defined with the naming convention dryRun{PascalCase}
+// and should return a pluginapi.NewPlanResult without making changes.
+var dryRunRegistry = map[string]OperationHandler{
+{{- range .DryRunOperations}}
+	"{{.Name}}": {{.HandlerName}},
+{{- end}}
+}
+{{- end}}
+
+// Execute dispatches to the appropriate operation handler.
+// In dry-run mode, operations without a preview handler are refused rather than executed.
 func (t *{{.ToolNamePascal}}Tool) Execute(ctx context.Context, params *{{.ParamsStruct}}) (string, error) {
 	handler, ok := operationRegistry[params.Operation]
 	if !ok {
 		return "", fmt.Errorf("unknown operation: %s. Valid operations: {{range $i, $op := .Operations}}{{if $i}}, {{end}}{{$op.Name}}{{end}}", params.Operation)
 	}
+	if pluginapi.IsDryRun(ctx) {
+{{- if .HasDryRun}}
+		if dryRun, ok := dryRunRegistry[params.Operation]; ok {
+			return dryRun(ctx, t, params)
+		}
+{{- end}}
+		return "", fmt.Errorf("%w: %s", pluginapi.ErrDryRunUnsupported, params.Operation)
+	}
 	return handler(ctx, t, params)
 }
 {{- end}}
@@ -519,23 +638,24 @@ func (t *{{.ToolNamePascal}}Tool) GetRequiredConfig() []pluginapi.ConfigVariable
 	return t.GetConfigFromYAML()
 }
 
-// ValidateConfig validates the provided configuration
+// ValidateConfig validates the provided configuration, reporting every problem at once
 func (t *{{.ToolNamePascal}}Tool) ValidateConfig(config map[string]interface{}) error {
+	var errs pluginapi.ValidationErrors
 {{- range .ConfigVars}}
 {{- if .Required}}
 	if val, ok := config["{{.Key}}"]; !ok || val == nil || val == "" {
-		return fmt.Errorf("{{.Key}} is required")
+		errs.Add("{{.Key}}", "{{.Key}} is required")
 	}
 {{- end}}
 {{- if .Validation}}
 	if val, ok := config["{{.Key}}"].(string); ok && val != "" {
 		if matched, _ := regexp.MatchString(` + "`{{.Validation}}`" + `, val); !matched {
-			return fmt.Errorf("{{.Key}} does not match required pattern")
+			errs.Add("{{.Key}}", "{{.Key}} does not match required pattern")
 		}
 	}
 {{- end}}
 {{- end}}
-	return nil
+	return errs.Err()
 }
 
 // InitializeWithConfig initializes the plugin with the provided configuration
@@ -588,7 +708,8 @@ func (t *{{.ToolNamePascal}}Tool) CallWithFiles(ctx context.Context, args string
 		return "", fmt.Errorf("invalid arguments: %w", err)
 	}
 
-	if handler, ok := fileOperationRegistry[params.Operation]; ok {
+	// Dry runs go through Execute, which never calls the real handlers
+	if handler, ok := fileOperationRegistry[params.Operation]; ok && !pluginapi.IsDryRun(ctx) {
 		return handler(ctx, t, &params, files)
 	}
 
@@ -608,7 +729,8 @@ func (t *{{.ToolNamePascal}}Tool) CallWithFiles(ctx context.Context, args string
 {{- end}}
 {{- if .HasWebPages}}
 
-// GetWebPages returns the available web pages for this plugin
+// GetWebPages returns the available web pages for this plugin.
+// Route patterns are omitted since they can't be opened without parameters.
 func (t *{{.ToolNamePascal}}Tool) GetWebPages() []string {
 	return []string{
 {{- range .WebPages}}
@@ -633,14 +755,42 @@ var (
 	_ WebPageHandler = {{.HandlerName}}
 {{- end}}
 )
+{{- if .HasWebRoutes}}
+
+// WebPageRouteHandler serves a page whose path has parameters (e.g., "projects/{id}")
+type WebPageRouteHandler func(t *{{.ToolNamePascal}}Tool, req pluginapi.WebRequest) (string, string, error)
+
+// webPageRoutes maps route patterns to their handler functions
+var webPageRoutes = map[string]WebPageRouteHandler{
+{{- range .WebRouteHandlers}}
+	"{{.Name}}": {{.HandlerName}},
+{{- end}}
+}
+{{- end}}
 
 // ServeWebPage dispatches to the appropriate page handler
 func (t *{{.ToolNamePascal}}Tool) ServeWebPage(path string, query map[string]string) (string, string, error) {
-	handler, ok := webPageRegistry[path]
-	if !ok {
-		return "", "", fmt.Errorf("page not found: %s", path)
+	router := pluginapi.NewWebRouter()
+	for page, handler := range webPageRegistry {
+		handler := handler
+		router.Handle(page, func(req pluginapi.WebRequest) (string, string, error) {
+			return handler(t, req.Query)
+		})
+	}
+{{- if .HasWebRoutes}}
+	for pattern, handler := range webPageRoutes {
+		handler := handler
+		router.Handle(pattern, func(req pluginapi.WebRequest) (string, string, error) {
+			return handler(t, req)
+		})
 	}
-	return handler(t, query)
+{{- end}}
+{{- if .HasAssets}}
+	// Embedded assets are served at their embedded paths (e.g., "assets/app.css");
+	// pages take precedence since "*" is the least specific pattern
+	router.Handle("*", pluginapi.ServeAssetsFrom(assetsFS, ""))
+{{- end}}
+	return router.ServeWebPage(path, query)
 }
 {{- end}}
 `))
@@ -195,6 +195,7 @@ func generateFile(path, templateStr string, data TemplateData) error {
 var pluginYAMLTemplate = `name: {{.PluginName}}
 version: 0.1.0
 description: {{.Description}}
+category: Utilities
 tags: ["utility"]
 
 license: MIT
@@ -220,7 +221,7 @@ requirements:
 #     - key: api_key
 #       name: API Key
 #       description: Your API key for the service
-#       type: password
+#       type: secret
 #       required: true
 #     - key: timeout
 #       name: Timeout
@@ -229,6 +230,11 @@ requirements:
 #       required: false
 #       default_value: 30
 
+# Uncomment and customize if your plugin needs system permissions
+# permissions:
+#   network_access: true
+#   description: Fetches data from the service API
+
 tool_definition:
   description: "{{.Description}}"
   parameters:
@@ -297,17 +303,22 @@ func handleStatus(ctx context.Context, t *{{.PluginNamePascal}}Tool, params *Par
 	return "Plugin is running!", nil
 }
 
+type item struct {
+	Name   string ` + "`" + `table:"Name"` + "`" + `
+	Status string ` + "`" + `table:"Status"` + "`" + `
+}
+
 func handleList(ctx context.Context, t *{{.PluginNamePascal}}Tool, params *Params) (string, error) {
-	// Example: Return a table result
-	result := pluginapi.NewTableResult(
-		"Items",
-		[]string{"Name", "Status"},
-		[]map[string]string{
-			{"Name": "item-1", "Status": "active"},
-			{"Name": "item-2", "Status": "pending"},
-		},
-	)
-	result.Description = "Found 2 items"
+	// Example: Return a table result (columns are derived from the struct fields)
+	items := []item{
+		{Name: "item-1", Status: "active"},
+		{Name: "item-2", Status: "pending"},
+	}
+	result, err := pluginapi.NewTableResultFromStructs("Items", items)
+	if err != nil {
+		return "", err
+	}
+	result.Description = fmt.Sprintf("Found %d items", len(items))
 	return result.ToJSON()
 }
 
@@ -540,8 +551,11 @@ Key interfaces used:
 Return rich UI data:
 
 ` + "```" + `go
-// Table result
-result := pluginapi.NewTableResult("Title", []string{"Col1", "Col2"}, rows)
+// Table result from a slice of structs (columns from field names or ` + "`" + `table` + "`" + ` tags)
+result, err := pluginapi.NewTableResultFromStructs("Title", rows)
+if err != nil {
+	return "", err
+}
 return result.ToJSON()
 
 // Text result
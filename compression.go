@@ -0,0 +1,108 @@
+package pluginapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	// Registers the gzip codec, so plugin servers accept compressed requests
+	// and compress their responses to them
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+// AttachmentEncodingGzip marks a FileAttachment whose Content is gzip-compressed.
+const AttachmentEncodingGzip = "gzip"
+
+// MaxMessageSize is the largest message plugin servers send or accept, raised from
+// gRPC's 4 MiB default so that file attachments fit. gRPC applies the limit after
+// decompression, so hosts should raise their client limits to match:
+//
+//	grpc.WithDefaultCallOptions(
+//	    grpc.MaxCallSendMsgSize(pluginapi.MaxMessageSize),
+//	    grpc.MaxCallRecvMsgSize(pluginapi.MaxMessageSize),
+//	)
+//
+// Compressing attachments with FileAttachment.Compress is the way to send files
+// larger than that.
+const MaxMessageSize = 64 << 20
+
+// compressCallThreshold is the attachment size above which CallWithFiles
+// compresses its request.
+const compressCallThreshold = 1 << 20
+
+const (
+	// uploadStreamThreshold is the attachment size above which CallWithFiles
+	// uploads in chunks with CallWithFilesStream
+	uploadStreamThreshold = 16 << 20
+	// uploadChunkSize is how much file content each CallWithFilesStream message carries
+	uploadChunkSize = 1 << 20
+	// maxUploadSize bounds the attachments a plugin buffers for one CallWithFilesStream call
+	maxUploadSize = 1 << 30
+)
+
+// maxDecompressedAttachmentSize bounds Decompress when the attachment's Size is
+// unknown, so a small malicious payload can't expand without limit.
+const maxDecompressedAttachmentSize = 1 << 30
+
+// Compress returns a copy of f with gzip-compressed Content. Size keeps the
+// uncompressed size. Compressing an already compressed attachment, or one sent by
+// reference, is a no-op.
+//
+// Example:
+//
+//	file, err := pluginapi.FileAttachment{Name: "mix.wav", Type: "audio/wav", Size: int64(len(data)), Content: data}.Compress()
+func (f FileAttachment) Compress() (FileAttachment, error) {
+	if f.Encoding == AttachmentEncodingGzip || f.IsReference() {
+		return f, nil
+	}
+	if f.Encoding != "" {
+		return f, fmt.Errorf("attachment %s: unsupported encoding %q", f.Name, f.Encoding)
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(f.Content); err != nil {
+		return f, fmt.Errorf("attachment %s: %w", f.Name, err)
+	}
+	if err := w.Close(); err != nil {
+		return f, fmt.Errorf("attachment %s: %w", f.Name, err)
+	}
+	if f.Size == 0 {
+		f.Size = int64(len(f.Content))
+	}
+	f.Content = buf.Bytes()
+	f.Encoding = AttachmentEncodingGzip
+	return f, nil
+}
+
+// Decompress returns a copy of f with raw Content. Attachments that aren't
+// compressed are returned unchanged.
+func (f FileAttachment) Decompress() (FileAttachment, error) {
+	switch f.Encoding {
+	case "":
+		return f, nil
+	case AttachmentEncodingGzip:
+	default:
+		return f, fmt.Errorf("attachment %s: unsupported encoding %q", f.Name, f.Encoding)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(f.Content))
+	if err != nil {
+		return f, fmt.Errorf("attachment %s: %w", f.Name, err)
+	}
+	limit := int64(maxDecompressedAttachmentSize)
+	if f.Size > 0 {
+		limit = f.Size
+	}
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return f, fmt.Errorf("attachment %s: %w", f.Name, err)
+	}
+	if int64(len(content)) > limit {
+		return f, fmt.Errorf("attachment %s: decompressed content exceeds %d bytes", f.Name, limit)
+	}
+	f.Content = content
+	f.Encoding = ""
+	return f, nil
+}
@@ -0,0 +1,153 @@
+package pluginapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fileSizeTestTool struct {
+	BasePlugin
+}
+
+func (t *fileSizeTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *fileSizeTestTool) AcceptsFiles() []string {
+	return []string{"audio/wav"}
+}
+
+func (t *fileSizeTestTool) CallWithFiles(ctx context.Context, args string, files []FileAttachment) (string, error) {
+	var summary string
+	for _, f := range files {
+		summary += fmt.Sprintf("%s:%d:%d:%q ", f.Name, f.Size, len(f.Content), f.Encoding)
+	}
+	return summary, nil
+}
+
+func TestFileAttachment_CompressRoundTrip(t *testing.T) {
+	content := bytes.Repeat([]byte("la "), 10000)
+	file := FileAttachment{Name: "song.wav", Type: "audio/wav", Content: content}
+
+	compressed, err := file.Compress()
+	if err != nil {
+		t.Fatalf("Compress failed: %v", err)
+	}
+	if compressed.Encoding != AttachmentEncodingGzip || compressed.Size != int64(len(content)) || len(compressed.Content) >= len(content) {
+		t.Errorf("unexpected compressed attachment: encoding %q, size %d, %d bytes", compressed.Encoding, compressed.Size, len(compressed.Content))
+	}
+	if again, _ := compressed.Compress(); !bytes.Equal(again.Content, compressed.Content) {
+		t.Error("compressing twice should be a no-op")
+	}
+
+	raw, err := compressed.Decompress()
+	if err != nil {
+		t.Fatalf("Decompress failed: %v", err)
+	}
+	if raw.Encoding != "" || !bytes.Equal(raw.Content, content) {
+		t.Error("round trip changed the content")
+	}
+	if file.Encoding != "" || !bytes.Equal(file.Content, content) {
+		t.Error("Compress should not modify the original")
+	}
+}
+
+func TestFileAttachment_DecompressRejectsBadInput(t *testing.T) {
+	compressed, _ := FileAttachment{Name: "a.txt", Content: bytes.Repeat([]byte("a"), 1000)}.Compress()
+
+	understated := compressed
+	understated.Size = 10
+	if _, err := understated.Decompress(); err == nil {
+		t.Error("expected error when content is larger than Size")
+	}
+
+	if _, err := (FileAttachment{Name: "a.txt", Encoding: AttachmentEncodingGzip, Content: []byte("not gzip")}).Decompress(); err == nil {
+		t.Error("expected error for corrupt content")
+	}
+	if _, err := (FileAttachment{Name: "a.txt", Encoding: "brotli"}).Decompress(); err == nil {
+		t.Error("expected error for unsupported encoding")
+	}
+}
+
+func TestGRPCClient_CallWithLargeFiles(t *testing.T) {
+	tool := &fileSizeTestTool{}
+	client := &grpcClient{client: NewToolServiceClient(newTestConn(t, tool, serverOptions(tool)...))}
+
+	// Larger than gRPC's default 4 MiB limit
+	big := bytes.Repeat([]byte{0x42}, 6<<20)
+	preCompressed, err := FileAttachment{Name: "b.wav", Content: bytes.Repeat([]byte{0x17}, 1000)}.Compress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := client.CallWithFiles(context.Background(), "{}", []FileAttachment{
+		{Name: "a.wav", Size: int64(len(big)), Content: big},
+		preCompressed,
+	})
+	if err != nil {
+		t.Fatalf("CallWithFiles failed: %v", err)
+	}
+	if want := fmt.Sprintf(`a.wav:%d:%d:"" b.wav:1000:1000:"" `, len(big), len(big)); result != want {
+		t.Errorf("result = %q, want %q", result, want)
+	}
+}
+
+func TestGRPCClient_CallWithFilesStream(t *testing.T) {
+	client := newTestClient(t, &fileSizeTestTool{})
+
+	multiChunk := bytes.Repeat([]byte{1, 2, 3}, uploadChunkSize) // 3 chunks
+	compressed, err := FileAttachment{Name: "c.wav", Content: bytes.Repeat([]byte{7}, 5000)}.Compress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := client.CallWithFilesStream(context.Background(), "{}", []FileAttachment{
+		{Name: "a.wav", Size: int64(len(multiChunk)), Content: multiChunk},
+		{Name: "empty.wav"},
+		compressed,
+	})
+	if err != nil {
+		t.Fatalf("CallWithFilesStream failed: %v", err)
+	}
+	want := fmt.Sprintf(`a.wav:%d:%d:"" empty.wav:0:0:"" c.wav:5000:5000:"" `, len(multiChunk), len(multiChunk))
+	if result != want {
+		t.Errorf("result = %q, want %q", result, want)
+	}
+}
+
+func TestGRPCClient_CallWithFiles_StreamsLargeUploads(t *testing.T) {
+	// The test server keeps gRPC's default 4 MiB limit, so only a chunked upload fits
+	client := newTestClient(t, &fileSizeTestTool{})
+	big := make([]byte, uploadStreamThreshold+1)
+
+	result, err := client.CallWithFiles(context.Background(), "{}", []FileAttachment{{Name: "big.zip", Content: big}})
+	if err != nil {
+		t.Fatalf("CallWithFiles failed: %v", err)
+	}
+	if want := fmt.Sprintf(`big.zip:0:%d:"" `, len(big)); result != want {
+		t.Errorf("result = %q, want %q", result, want)
+	}
+}
+
+func TestReceiveFileUpload_RejectsMalformedUploads(t *testing.T) {
+	conn := newTestConn(t, &fileSizeTestTool{})
+	for name, chunks := range map[string][]*FileUploadChunk{
+		"no call":          {{Part: &FileUploadChunk_File{File: &ProtoFileAttachment{Name: "a"}}}},
+		"data before file": {{Part: &FileUploadChunk_Call{Call: &CallWithFilesRequest{}}}, {Part: &FileUploadChunk_Data{Data: []byte("x")}}},
+	} {
+		stream, err := NewToolServiceClient(conn).CallWithFilesStream(context.Background())
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, chunk := range chunks {
+			_ = stream.Send(chunk)
+		}
+		if _, err := stream.CloseAndRecv(); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+}
@@ -31,6 +31,8 @@ type YAMLRequirements struct {
 	MaxOriVersion string   `yaml:"max_ori_version,omitempty"`
 	ApiVersion    string   `yaml:"api_version,omitempty"`
 	Dependencies  []string `yaml:"dependencies,omitempty"`
+	// Plugins lists required plugins with optional version constraints (e.g., "music-library >= 1.2.0")
+	Plugins []string `yaml:"plugins,omitempty"`
 }
 
 // YAMLConfigVariable represents a configuration variable in YAML format
@@ -52,6 +54,19 @@ type YAMLConfig struct {
 	Variables []YAMLConfigVariable `yaml:"variables,omitempty"`
 }
 
+// YAMLPermissions represents the permissions section in plugin.yaml
+type YAMLPermissions struct {
+	FileAccess     bool   `yaml:"file_access,omitempty"`
+	NetworkAccess  bool   `yaml:"network_access,omitempty"`
+	SystemCommands bool   `yaml:"system_commands,omitempty"`
+	Description    string `yaml:"description,omitempty"`
+	// ReadPaths and WritePaths are the file access allowlists (see PluginPermissions)
+	ReadPaths  []string `yaml:"read_paths,omitempty"`
+	WritePaths []string `yaml:"write_paths,omitempty"`
+	// AllowedHosts is the network allowlist (see PluginPermissions)
+	AllowedHosts []string `yaml:"allowed_hosts,omitempty"`
+}
+
 // YAMLToolParameter represents a parameter for a tool in YAML format
 type YAMLToolParameter struct {
 	Name        string      `yaml:"name"`
@@ -69,19 +84,42 @@ type YAMLToolParameter struct {
 	MinLength  *int                         `yaml:"min_length,omitempty"` // For string validation
 	MaxLength  *int                         `yaml:"max_length,omitempty"` // For string validation
 	Pattern    string                       `yaml:"pattern,omitempty"`    // For string regex validation
+
+	// Deprecation marks the parameter as legacy (deprecated, deprecation_message, replaced_by)
+	Deprecation `yaml:",inline"`
 }
 
 // YAMLOperationDefinition represents an operation-specific tool definition in YAML format.
 type YAMLOperationDefinition struct {
 	Parameters []YAMLToolParameter `yaml:"parameters,omitempty"` // Array format: - name: foo ...
+	// Pagination injects the standard cursor and limit parameters (see PaginationParameters)
+	Pagination bool `yaml:"pagination,omitempty"`
+	// Permissions lists the permissions this operation needs (e.g., [file_access])
+	Permissions []PermissionType `yaml:"permissions,omitempty"`
+	// DryRun declares that the operation can preview its effects (see IsDryRun)
+	DryRun bool `yaml:"dry_run,omitempty"`
+	// Timeout is the default deadline for calls to this operation, as a Go duration (e.g., "30s", "5m")
+	Timeout string `yaml:"timeout,omitempty"`
+	// Examples are few-shot calls of this operation; the operation argument is filled in
+	Examples []ToolExample `yaml:"examples,omitempty"`
+	// Deprecation marks the operation as legacy (deprecated, deprecation_message, replaced_by)
+	Deprecation `yaml:",inline"`
+	// RateLimit caps calls to this operation, e.g. "10/minute" (see ParseRateLimit)
+	RateLimit string `yaml:"rate_limit,omitempty"`
+	// CostHint is the operation's expected latency and cost (latency, cost)
+	CostHint `yaml:",inline"`
 }
 
 // YAMLToolDefinition represents a tool definition in YAML format
 type YAMLToolDefinition struct {
 	Name        string                             `yaml:"name"`
 	Description string                             `yaml:"description"`
-	Parameters  []YAMLToolParameter                `yaml:"parameters,omitempty"` // Array format: - name: foo ...
-	Operations  map[string]YAMLOperationDefinition `yaml:"operations,omitempty"` // Per-operation parameters
+	Parameters  []YAMLToolParameter                `yaml:"parameters,omitempty"`  // Array format: - name: foo ...
+	Operations  map[string]YAMLOperationDefinition `yaml:"operations,omitempty"`  // Per-operation parameters
+	Annotations ToolAnnotations                    `yaml:"annotations,omitempty"` // read_only, destructive, idempotent, open_world
+	Examples    []ToolExample                      `yaml:"examples,omitempty"`    // Few-shot calls: args and summary
+	RateLimit   string                             `yaml:"rate_limit,omitempty"`  // Caps all calls, e.g. "60/minute"
+	Deprecation `yaml:",inline"`
 }
 
 // PluginConfig represents the complete plugin configuration from plugin.yaml
@@ -89,6 +127,7 @@ type PluginConfig struct {
 	Name         string              `yaml:"name"`
 	Version      string              `yaml:"version"`
 	Description  string              `yaml:"description"`
+	Category     string              `yaml:"category,omitempty"` // e.g., "Developer Tools"; see CategoryProvider
 	Tags         []string            `yaml:"tags,omitempty"`
 	License      string              `yaml:"license"`
 	Repository   string              `yaml:"repository"`
@@ -99,6 +138,8 @@ type PluginConfig struct {
 	Tool         *YAMLToolDefinition `yaml:"tool_definition,omitempty"` // Optional tool definition
 	Assets       []string            `yaml:"assets,omitempty"`
 	WebPages     []string            `yaml:"web_pages,omitempty"`
+	Permissions  *YAMLPermissions    `yaml:"permissions,omitempty"`
+	OAuth        *OAuthConfig        `yaml:"oauth,omitempty"` // Optional OAuth provider; see BasePlugin.OAuth
 }
 
 // readPluginConfig parses and validates plugin configuration from embedded YAML.
@@ -172,9 +213,52 @@ func readPluginConfig(embeddedYAML string) (PluginConfig, error) {
 		}
 	}
 
+	for _, spec := range config.Requirements.Plugins {
+		if _, err := ParsePluginDependency(spec); err != nil {
+			return PluginConfig{}, fmt.Errorf("invalid plugin config: %w", err)
+		}
+	}
+
+	if config.OAuth != nil {
+		if err := config.OAuth.Validate(); err != nil {
+			return PluginConfig{}, fmt.Errorf("invalid plugin config: %w", err)
+		}
+	}
+
+	if err := config.ToPermissions().validatePaths(); err != nil {
+		return PluginConfig{}, fmt.Errorf("invalid plugin config: %w", err)
+	}
+	if err := config.ToPermissions().validateHosts(); err != nil {
+		return PluginConfig{}, fmt.Errorf("invalid plugin config: %w", err)
+	}
+
+	// Operations may only require permissions the plugin declares
+	if config.Permissions != nil && config.Tool != nil {
+		if err := ValidateOperationPermissions(GetOperationsFromYAML(config.Tool), config.ToPermissions()); err != nil {
+			return PluginConfig{}, fmt.Errorf("invalid plugin config: %w", err)
+		}
+	}
+
 	return config, nil
 }
 
+// ToPermissions converts the permissions section to PluginPermissions.
+// Returns no permissions if plugin.yaml has no permissions section.
+func (c *PluginConfig) ToPermissions() PluginPermissions {
+	if c.Permissions == nil {
+		return PluginPermissions{}
+	}
+	return PluginPermissions{
+		FileAccess:     c.Permissions.FileAccess,
+		NetworkAccess:  c.Permissions.NetworkAccess,
+		SystemCommands: c.Permissions.SystemCommands,
+		Description:    c.Permissions.Description,
+		ReadPaths:      c.Permissions.ReadPaths,
+		WritePaths:     c.Permissions.WritePaths,
+		AllowedHosts:   c.Permissions.AllowedHosts,
+	}
+}
+
 // ToMetadata converts PluginConfig to PluginMetadata format for RPC
 func (c *PluginConfig) ToMetadata() (*PluginMetadata, error) {
 	// Convert maintainers to protobuf Maintainer format
@@ -199,6 +283,7 @@ func (c *PluginConfig) ToMetadata() (*PluginMetadata, error) {
 	requirements := &Requirements{
 		MinOriVersion: c.Requirements.MinOriVersion,
 		Dependencies:  c.Requirements.Dependencies,
+		Plugins:       pluginDependenciesToProto(c.PluginDependencies()),
 	}
 
 	return &PluginMetadata{
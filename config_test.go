@@ -190,3 +190,34 @@ platforms:
 		t.Fatalf("expected 3 tags, got %d (%v)", len(meta.Tags), meta.Tags)
 	}
 }
+
+func TestBasePlugin_GetCategoryOverRPC(t *testing.T) {
+	yaml := `
+name: test-plugin
+version: 1.0.0
+description: Test plugin
+category: Developer Tools
+license: MIT
+repository: https://github.com/test/test
+maintainers:
+  - name: Test
+    email: test@test.com
+platforms:
+  - os: darwin
+    architectures: [amd64, arm64]
+`
+
+	config, err := readPluginConfig(yaml)
+	if err != nil {
+		t.Fatalf("readPluginConfig error: %v", err)
+	}
+	tool := &plainTestTool{}
+	tool.SetPluginConfig(&config)
+
+	if got := newTestClient(t, tool).GetCategory(); got != "Developer Tools" {
+		t.Errorf("GetCategory() = %q, want %q", got, "Developer Tools")
+	}
+	if got := newTestClient(t, &plainTestTool{}).GetCategory(); got != "" {
+		t.Errorf("expected empty category without plugin.yaml, got %q", got)
+	}
+}
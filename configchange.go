@@ -0,0 +1,23 @@
+package pluginapi
+
+import "context"
+
+// ConfigChangeListener allows plugins to react when the user edits their
+// configuration in the agent UI while they run, e.g. to reconnect an API client
+// with new credentials instead of waiting for a restart.
+//
+// Before the listener is called, secret values in the new configuration are moved
+// to the host keychain (as with InitializeWithConfig) and the BasePlugin settings
+// cache is reloaded, so Settings() already returns the new values.
+type ConfigChangeListener interface {
+	PluginTool
+	// OnConfigChanged is called after a configuration edit. changed lists the keys
+	// whose values were added, modified or removed; config is the full new configuration.
+	OnConfigChanged(ctx context.Context, changed []string, config map[string]interface{}) error
+}
+
+// settingsReloader is implemented by BasePlugin to pick up settings the host
+// rewrote on disk.
+type settingsReloader interface {
+	reloadSettings() error
+}
@@ -0,0 +1,49 @@
+package pluginapi
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type configChangeTestTool struct {
+	plainTestTool
+	changed []string
+	apiKey  string
+}
+
+func (t *configChangeTestTool) OnConfigChanged(ctx context.Context, changed []string, config map[string]interface{}) error {
+	t.changed = changed
+	t.apiKey, _ = t.Settings().GetString("api_key")
+	return nil
+}
+
+func TestNotifyConfigChanged(t *testing.T) {
+	agentDir := t.TempDir()
+	tool := &configChangeTestTool{}
+	tool.SetAgentContext(AgentContext{Name: "test", AgentDir: agentDir})
+	_ = tool.Settings().Set("api_key", "old")
+
+	// The agent UI writes the settings file behind the plugin's back
+	ui, err := NewSettingsManager(agentDir, "unknown")
+	if err != nil {
+		t.Fatalf("NewSettingsManager failed: %v", err)
+	}
+	_ = ui.Set("api_key", "new")
+
+	client := newTestClient(t, tool)
+	config := map[string]interface{}{"api_key": "new"}
+	if err := client.NotifyConfigChanged(context.Background(), []string{"api_key"}, config); err != nil {
+		t.Fatalf("NotifyConfigChanged failed: %v", err)
+	}
+	if !reflect.DeepEqual(tool.changed, []string{"api_key"}) {
+		t.Errorf("unexpected changed keys: %v", tool.changed)
+	}
+	if tool.apiKey != "new" {
+		t.Errorf("expected settings to be reloaded before the listener runs, got %q", tool.apiKey)
+	}
+
+	if err := newTestClient(t, &plainTestTool{}).NotifyConfigChanged(context.Background(), nil, nil); err == nil {
+		t.Error("expected an error for a plugin without ConfigChangeListener")
+	}
+}
@@ -0,0 +1,20 @@
+package pluginapi
+
+import "time"
+
+// DefaultConnectivityTestTimeout bounds TestConfig calls made without a deadline,
+// so a Test button never hangs on an unreachable server.
+const DefaultConnectivityTestTimeout = 15 * time.Second
+
+// ConnectivityTester allows plugins to check a configuration against the real
+// service before it is saved, so config screens can offer a "Test connection" button.
+// Unlike InitializationProvider.ValidateConfig, which only checks that values are
+// well-formed, TestConfig should exercise the API key, URL, etc. with a cheap request.
+//
+// Secret variables the user left blank are filled in from the host keychain, so a
+// connection can be retested without re-entering stored credentials. Nothing is saved.
+type ConnectivityTester interface {
+	// TestConfig returns nil if config works, or an error describing why not
+	// (e.g., "authentication failed: invalid API key").
+	TestConfig(config map[string]interface{}) error
+}
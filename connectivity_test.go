@@ -0,0 +1,47 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+type connectivityTestTool struct {
+	secretConfigTestTool
+}
+
+func (t *connectivityTestTool) TestConfig(config map[string]interface{}) error {
+	if config["api_key"] != "s3cret" {
+		return fmt.Errorf("authentication failed: invalid API key")
+	}
+	return nil
+}
+
+func TestTestConfig(t *testing.T) {
+	impl := &keychainHost{secrets: map[string]string{"weather/api_key": "s3cret"}}
+	tool := &connectivityTestTool{}
+	client := newTestClient(t, tool)
+	if err := client.ConnectHostServices(context.Background(), newTestHost(t, impl), "weather"); err != nil {
+		t.Fatalf("ConnectHostServices failed: %v", err)
+	}
+
+	// A blank secret is retested with the stored value
+	if err := client.TestConfig(map[string]interface{}{"units": "metric"}); err != nil {
+		t.Errorf("expected the stored API key to pass, got %v", err)
+	}
+
+	err := client.TestConfig(map[string]interface{}{"api_key": "wrong"})
+	if err == nil || err.Error() != "authentication failed: invalid API key" {
+		t.Errorf("expected the test failure to be reported, got %v", err)
+	}
+	if impl.secrets["weather/api_key"] != "s3cret" {
+		t.Error("TestConfig must not store secrets")
+	}
+	if tool.config != nil {
+		t.Error("TestConfig must not initialize the plugin")
+	}
+
+	if err := newTestClient(t, &plainTestTool{}).TestConfig(nil); err == nil {
+		t.Error("expected an error for a plugin without ConnectivityTester")
+	}
+}
@@ -0,0 +1,94 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CallMetadataConversationID identifies the conversation a call was made from.
+// Hosts set it so GetConversationHistory knows which conversation to read.
+const CallMetadataConversationID = "conversation_id"
+
+const (
+	// DefaultConversationHistoryLimit is used when GetConversationHistory is called with a limit of 0
+	DefaultConversationHistoryLimit = 20
+	// MaxConversationHistoryLimit caps how many messages a plugin can read in one request
+	MaxConversationHistoryLimit = 100
+)
+
+// ConversationRoleTool marks a message containing a tool result; user and
+// assistant messages use CompletionRoleUser and CompletionRoleAssistant.
+const ConversationRoleTool = "tool"
+
+// ConversationMessage is a read-only view of one message in a conversation.
+type ConversationMessage struct {
+	Role      string    `json:"role"`
+	Content   string    `json:"content"`
+	Timestamp time.Time `json:"timestamp"`
+	// Redacted is set when the host removed content it does not share with plugins
+	// (e.g., secrets or other tools' results); Content then holds a placeholder.
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// clampConversationLimit applies the default and maximum history limits.
+func clampConversationLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultConversationHistoryLimit
+	}
+	return min(limit, MaxConversationHistoryLimit)
+}
+
+func (UnimplementedHostServices) GetConversationHistory(ctx context.Context, limit int) ([]ConversationMessage, error) {
+	return nil, ErrHostServiceUnavailable
+}
+
+// GetConversationHistory enforces the history limits even if the host implementation does not.
+func (s *hostServer) GetConversationHistory(ctx context.Context, req *HostConversationRequest) (*HostConversationResponse, error) {
+	limit := clampConversationLimit(int(req.Limit))
+	messages, err := s.Impl.GetConversationHistory(WithCallMetadata(ctx, req.Metadata), limit)
+	if st := hostStatus(err); st != nil {
+		return nil, st
+	}
+	if err != nil {
+		return &HostConversationResponse{Error: err.Error()}, nil
+	}
+	if len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+
+	protoMessages := make([]*ProtoConversationMessage, len(messages))
+	for i, m := range messages {
+		protoMessages[i] = &ProtoConversationMessage{
+			Role:        m.Role,
+			Content:     m.Content,
+			TimestampMs: m.Timestamp.UnixMilli(),
+			Redacted:    m.Redacted,
+		}
+	}
+	return &HostConversationResponse{Messages: protoMessages}, nil
+}
+
+func (h *hostClient) GetConversationHistory(ctx context.Context, limit int) ([]ConversationMessage, error) {
+	resp, err := h.client.GetConversationHistory(h.outgoing(ctx), &HostConversationRequest{
+		Limit:    int32(limit),
+		Metadata: CallMetadata(ctx),
+	})
+	if err != nil {
+		return nil, hostSentinel(err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+
+	messages := make([]ConversationMessage, len(resp.Messages))
+	for i, m := range resp.Messages {
+		messages[i] = ConversationMessage{
+			Role:      m.Role,
+			Content:   m.Content,
+			Timestamp: time.UnixMilli(m.TimestampMs),
+			Redacted:  m.Redacted,
+		}
+	}
+	return messages, nil
+}
@@ -0,0 +1,96 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// historyHostServices serves a fixed history per conversation and ignores the requested limit.
+type historyHostServices struct {
+	UnimplementedHostServices
+	conversations map[string][]ConversationMessage
+	limits        []int
+}
+
+func (h *historyHostServices) GetConversationHistory(ctx context.Context, limit int) ([]ConversationMessage, error) {
+	h.limits = append(h.limits, limit)
+	id := CallMetadataValue(ctx, CallMetadataConversationID)
+	messages, ok := h.conversations[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown conversation %q", id)
+	}
+	return messages, nil
+}
+
+type historyTestTool struct {
+	BasePlugin
+}
+
+func (t *historyTestTool) Call(ctx context.Context, args string) (string, error) {
+	var limit int
+	fmt.Sscan(args, &limit)
+	messages, err := t.Host().GetConversationHistory(ctx, limit)
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, len(messages))
+	for i, m := range messages {
+		parts[i] = m.Role + ":" + m.Content
+		if m.Redacted {
+			parts[i] += "*"
+		}
+	}
+	return strings.Join(parts, ","), nil
+}
+
+func TestHostServices_GetConversationHistory(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	var long []ConversationMessage
+	for i := 0; i < MaxConversationHistoryLimit+10; i++ {
+		long = append(long, ConversationMessage{Role: CompletionRoleUser, Content: fmt.Sprint(i), Timestamp: start})
+	}
+	host := &historyHostServices{conversations: map[string][]ConversationMessage{
+		"c1": {
+			{Role: CompletionRoleUser, Content: "hi", Timestamp: start},
+			{Role: ConversationRoleTool, Content: "[redacted]", Timestamp: start.Add(time.Second), Redacted: true},
+			{Role: CompletionRoleAssistant, Content: "hello", Timestamp: start.Add(2 * time.Second)},
+		},
+		"long": long,
+	}}
+	client := newTestClient(t, &historyTestTool{})
+	if err := client.ConnectHostServices(context.Background(), newTestHost(t, host), "notes"); err != nil {
+		t.Fatalf("ConnectHostServices failed: %v", err)
+	}
+
+	inConversation := func(id string) context.Context {
+		return WithCallMetadata(context.Background(), map[string]string{CallMetadataConversationID: id})
+	}
+
+	result, err := client.Call(inConversation("c1"), "0")
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if result != "user:hi,tool:[redacted]*,assistant:hello" {
+		t.Errorf("result = %q", result)
+	}
+
+	result, err = client.Call(inConversation("long"), "500")
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	parts := strings.Split(result, ",")
+	if len(parts) != MaxConversationHistoryLimit || parts[len(parts)-1] != fmt.Sprintf("user:%d", len(long)-1) {
+		t.Errorf("expected the %d most recent messages, got %d ending in %q", MaxConversationHistoryLimit, len(parts), parts[len(parts)-1])
+	}
+
+	if want := []int{DefaultConversationHistoryLimit, MaxConversationHistoryLimit}; fmt.Sprint(host.limits) != fmt.Sprint(want) {
+		t.Errorf("host saw limits %v, want %v", host.limits, want)
+	}
+
+	if _, err := client.Call(inConversation("missing"), "5"); err == nil || !strings.Contains(err.Error(), "unknown conversation") {
+		t.Errorf("expected host error, got %v", err)
+	}
+}
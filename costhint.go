@@ -0,0 +1,62 @@
+package pluginapi
+
+// LatencyClass is the expected duration of an operation, coarse enough for
+// planners to compare operations without benchmarks.
+type LatencyClass string
+
+const (
+	// LatencyFast operations answer in about a second or less (local lookups, cached data)
+	LatencyFast LatencyClass = "fast"
+	// LatencyModerate operations take a few seconds (typical remote API calls)
+	LatencyModerate LatencyClass = "moderate"
+	// LatencySlow operations take tens of seconds or more (renders, large exports)
+	LatencySlow LatencyClass = "slow"
+)
+
+// IsValid reports whether c is empty (unknown) or a known latency class.
+func (c LatencyClass) IsValid() bool {
+	switch c {
+	case "", LatencyFast, LatencyModerate, LatencySlow:
+		return true
+	}
+	return false
+}
+
+// CostHint tells agent planners how expensive an operation is to call, so they
+// can prefer cheap operations and confirm expensive ones with the user.
+//
+// Example plugin.yaml:
+//
+//	operations:
+//	  lookup:
+//	    latency: fast
+//	  generate_video:
+//	    latency: slow
+//	    cost: 50
+type CostHint struct {
+	// Latency is the operation's expected latency class (empty = unknown)
+	Latency LatencyClass `yaml:"latency,omitempty"`
+	// Cost is the relative monetary cost of one call (0 = free or unknown). Weights
+	// are only comparable within a plugin; by convention 1 is one ordinary paid API request.
+	Cost float64 `yaml:"cost,omitempty"`
+}
+
+// OperationCostHint returns the cost hint declared for operation, or a zero
+// CostHint if none.
+func OperationCostHint(operations []OperationInfo, operation string) CostHint {
+	for _, op := range operations {
+		if op.Name == operation {
+			return op.CostHint
+		}
+	}
+	return CostHint{}
+}
+
+func (h CostHint) validate(path string, errs *ValidationErrors) {
+	if !h.Latency.IsValid() {
+		errs.Add(path+".latency", "unknown latency %q (use fast, moderate or slow)", h.Latency)
+	}
+	if h.Cost < 0 {
+		errs.Add(path+".cost", "cost cannot be negative")
+	}
+}
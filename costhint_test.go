@@ -0,0 +1,60 @@
+package pluginapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const costHintTestYAML = `
+name: media
+description: Generates media
+parameters:
+  - name: operation
+    type: string
+    description: Operation to perform
+    required: true
+operations:
+  lookup:
+    latency: fast
+  generate_video:
+    latency: slow
+    cost: 50
+`
+
+func TestCostHint_OverRPC(t *testing.T) {
+	var toolDef YAMLToolDefinition
+	if err := yaml.Unmarshal([]byte(costHintTestYAML), &toolDef); err != nil {
+		t.Fatalf("failed to parse YAML: %v", err)
+	}
+	if err := ValidateYAMLToolDefinition(&toolDef); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	client := newTestClient(t, &examplesTestTool{toolDef: &toolDef})
+	ops := client.GetOperationsCtx(context.Background())
+	if hint := OperationCostHint(ops, "generate_video"); hint != (CostHint{Latency: LatencySlow, Cost: 50}) {
+		t.Errorf("unexpected generate_video hint: %+v", hint)
+	}
+	if hint := OperationCostHint(ops, "lookup"); hint != (CostHint{Latency: LatencyFast}) {
+		t.Errorf("unexpected lookup hint: %+v", hint)
+	}
+	if hint := OperationCostHint(ops, "missing"); hint != (CostHint{}) {
+		t.Errorf("expected no hint for an unknown operation, got %+v", hint)
+	}
+}
+
+func TestCostHint_Validation(t *testing.T) {
+	var toolDef YAMLToolDefinition
+	if err := yaml.Unmarshal([]byte(costHintTestYAML), &toolDef); err != nil {
+		t.Fatalf("failed to parse YAML: %v", err)
+	}
+	toolDef.Operations["lookup"] = YAMLOperationDefinition{CostHint: CostHint{Latency: "instant", Cost: -1}}
+
+	err := ValidateYAMLToolDefinition(&toolDef)
+	if err == nil || !strings.Contains(err.Error(), `unknown latency "instant"`) || !strings.Contains(err.Error(), "cost cannot be negative") {
+		t.Errorf("expected latency and cost errors, got %v", err)
+	}
+}
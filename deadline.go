@@ -0,0 +1,47 @@
+package pluginapi
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// OperationFromArgs returns the "operation" argument of a call, or an empty string
+// if the args are not a JSON object or have no operation.
+func OperationFromArgs(args string) string {
+	var parsed struct {
+		Operation string `json:"operation"`
+	}
+	if err := json.Unmarshal([]byte(args), &parsed); err != nil {
+		return ""
+	}
+	return parsed.Operation
+}
+
+// OperationTimeout returns the default timeout declared for operation, or 0 if none.
+func OperationTimeout(operations []OperationInfo, operation string) time.Duration {
+	for _, op := range operations {
+		if op.Name == operation {
+			return op.Timeout
+		}
+	}
+	return 0
+}
+
+// withOperationTimeout bounds ctx by the default timeout of the operation in args,
+// if the plugin declares one. A deadline the host already set is kept when it is sooner.
+func withOperationTimeout(ctx context.Context, impl PluginTool, args string) (context.Context, context.CancelFunc) {
+	opsProvider, ok := impl.(OperationsProvider)
+	if !ok {
+		return ctx, func() {}
+	}
+	operation := OperationFromArgs(args)
+	if operation == "" {
+		return ctx, func() {}
+	}
+	timeout := OperationTimeout(opsProvider.GetOperations(), operation)
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
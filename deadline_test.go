@@ -0,0 +1,114 @@
+package pluginapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestOperationFromArgs(t *testing.T) {
+	tests := map[string]string{
+		`{"operation":"render","track":1}`: "render",
+		`{"track":1}`:                      "",
+		`not json`:                         "",
+		`["render"]`:                       "",
+	}
+	for args, want := range tests {
+		if got := OperationFromArgs(args); got != want {
+			t.Errorf("OperationFromArgs(%s) = %q, want %q", args, got, want)
+		}
+	}
+}
+
+func TestGetOperationsFromYAML_Timeout(t *testing.T) {
+	var toolDef YAMLToolDefinition
+	err := yaml.Unmarshal([]byte(`
+name: renderer
+description: Render audio
+parameters:
+  - name: operation
+    type: string
+    description: Operation to perform
+    required: true
+operations:
+  render:
+    timeout: 5m
+  status: {}
+`), &toolDef)
+	if err != nil {
+		t.Fatalf("failed to parse YAML: %v", err)
+	}
+	if err := ValidateYAMLToolDefinition(&toolDef); err != nil {
+		t.Fatalf("ValidateYAMLToolDefinition() error = %v", err)
+	}
+
+	ops := GetOperationsFromYAML(&toolDef)
+	if got := OperationTimeout(ops, "render"); got != 5*time.Minute {
+		t.Errorf("render timeout = %v, want 5m", got)
+	}
+	if got := OperationTimeout(ops, "status"); got != 0 {
+		t.Errorf("status timeout = %v, want 0", got)
+	}
+
+	toolDef.Operations["status"] = YAMLOperationDefinition{Timeout: "soon"}
+	if err := ValidateYAMLToolDefinition(&toolDef); err == nil {
+		t.Error("expected error for invalid timeout")
+	}
+}
+
+type deadlineTestTool struct {
+	BasePlugin
+}
+
+func (t *deadlineTestTool) GetOperations() []OperationInfo {
+	return []OperationInfo{
+		{Name: "render", Timeout: 50 * time.Millisecond},
+		{Name: "status"},
+	}
+}
+
+func (t *deadlineTestTool) Call(ctx context.Context, args string) (string, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return "none", nil
+	}
+	return time.Until(deadline).Round(time.Second).String(), nil
+}
+
+func TestGRPCServer_Deadlines(t *testing.T) {
+	client := newTestClient(t, &deadlineTestTool{})
+
+	// Operation default applies when the host sets no deadline
+	got, err := client.Call(context.Background(), `{"operation":"render"}`)
+	if err != nil || got != "0s" {
+		t.Errorf("render without host deadline = %q, %v", got, err)
+	}
+
+	got, err = client.Call(context.Background(), `{"operation":"status"}`)
+	if err != nil || got != "none" {
+		t.Errorf("status without host deadline = %q, %v", got, err)
+	}
+
+	// Host deadlines are forwarded to the plugin
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	got, err = client.Call(ctx, `{"operation":"status"}`)
+	if err != nil || got != "1m0s" {
+		t.Errorf("status with host deadline = %q, %v", got, err)
+	}
+
+	// The sooner of the host deadline and the operation default wins
+	got, err = client.Call(ctx, `{"operation":"render"}`)
+	if err != nil || got != "0s" {
+		t.Errorf("render with host deadline = %q, %v", got, err)
+	}
+}
+
+func TestGRPCClient_GetOperations_Timeout(t *testing.T) {
+	ops := newTestClient(t, &deadlineTestTool{}).GetOperations()
+	if got := OperationTimeout(ops, "render"); got != 50*time.Millisecond {
+		t.Errorf("render timeout = %v, want 50ms", got)
+	}
+}
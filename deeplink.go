@@ -0,0 +1,124 @@
+package pluginapi
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// DeepLinkAction is the kind of navigation a deep-link result asks the host to perform.
+// The vocabulary is deliberately small so hosts never navigate somewhere a plugin
+// could not otherwise reach.
+type DeepLinkAction string
+
+const (
+	// DeepLinkOpenWebPage opens one of the plugin's own web pages (see WebPageProvider)
+	DeepLinkOpenWebPage DeepLinkAction = "open_web_page"
+	// DeepLinkOpenSettings opens the settings screen for the calling plugin
+	DeepLinkOpenSettings DeepLinkAction = "open_settings"
+	// DeepLinkOpenFile opens a local file with the system's default application
+	DeepLinkOpenFile DeepLinkAction = "open_file"
+)
+
+// DeepLinkData is the payload of a DisplayTypeDeepLink result.
+type DeepLinkData struct {
+	Action DeepLinkAction `json:"action" yaml:"action"`
+	// Label is the link or button text (e.g., "Configure now")
+	Label string `json:"label" yaml:"label"`
+	// Message is optional text shown alongside the link
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Target is the web page path for DeepLinkOpenWebPage or the absolute file path
+	// for DeepLinkOpenFile. It is empty for DeepLinkOpenSettings.
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
+	// Query holds URL query parameters for DeepLinkOpenWebPage
+	Query map[string]string `json:"query,omitempty" yaml:"query,omitempty"`
+}
+
+// NewOpenWebPageResult creates a result that links to one of the plugin's web pages.
+// page is a path returned by GetWebPages (e.g., "marketplace"); query may be nil.
+func NewOpenWebPageResult(message, label, page string, query map[string]string) *StructuredResult {
+	return newDeepLinkResult(DeepLinkData{
+		Action:  DeepLinkOpenWebPage,
+		Label:   label,
+		Message: message,
+		Target:  page,
+		Query:   query,
+	})
+}
+
+// NewOpenSettingsResult creates a result that links to the plugin's settings screen.
+//
+// Example:
+//
+//	if apiKey == "" {
+//	    return pluginapi.NewOpenSettingsResult("An API key is required.", "Configure now").ToJSON()
+//	}
+func NewOpenSettingsResult(message, label string) *StructuredResult {
+	return newDeepLinkResult(DeepLinkData{
+		Action:  DeepLinkOpenSettings,
+		Label:   label,
+		Message: message,
+	})
+}
+
+// NewOpenFileResult creates a result that opens a local file. path must be absolute.
+func NewOpenFileResult(message, label, path string) *StructuredResult {
+	return newDeepLinkResult(DeepLinkData{
+		Action:  DeepLinkOpenFile,
+		Label:   label,
+		Message: message,
+		Target:  path,
+	})
+}
+
+func newDeepLinkResult(link DeepLinkData) *StructuredResult {
+	return &StructuredResult{
+		DisplayType: DisplayTypeDeepLink,
+		Data:        link,
+	}
+}
+
+// Validate checks that the deep link uses a known action and a safe target.
+func (d *DeepLinkData) Validate() error {
+	if d.Label == "" {
+		return fmt.Errorf("deep link label is required")
+	}
+
+	switch d.Action {
+	case DeepLinkOpenWebPage:
+		if d.Target == "" {
+			return fmt.Errorf("open_web_page requires a page path")
+		}
+		if strings.Contains(d.Target, "://") || strings.HasPrefix(d.Target, "/") || strings.HasPrefix(d.Target, "\\") {
+			return fmt.Errorf("web page path must be relative to the plugin (got %q)", d.Target)
+		}
+		for _, segment := range strings.FieldsFunc(d.Target, func(r rune) bool { return r == '/' || r == '\\' }) {
+			if segment == ".." {
+				return fmt.Errorf("web page path cannot contain '..' (got %q)", d.Target)
+			}
+		}
+	case DeepLinkOpenSettings:
+		if d.Target != "" {
+			return fmt.Errorf("open_settings does not take a target")
+		}
+	case DeepLinkOpenFile:
+		if d.Target == "" {
+			return fmt.Errorf("open_file requires a file path")
+		}
+		if !filepath.IsAbs(d.Target) {
+			return fmt.Errorf("file path must be absolute (got %q)", d.Target)
+		}
+		if strings.Contains(d.Target, "://") {
+			return fmt.Errorf("file path cannot be a URL (got %q)", d.Target)
+		}
+	case "":
+		return fmt.Errorf("deep link action is required")
+	default:
+		return fmt.Errorf("unsupported deep link action: %s", d.Action)
+	}
+
+	if d.Action != DeepLinkOpenWebPage && len(d.Query) > 0 {
+		return fmt.Errorf("%s does not take query parameters", d.Action)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package pluginapi
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDeepLinkResult_Validate(t *testing.T) {
+	absPath, err := filepath.Abs("report.pdf")
+	if err != nil {
+		t.Fatalf("filepath.Abs failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		result  *StructuredResult
+		wantErr bool
+	}{
+		{name: "open settings", result: NewOpenSettingsResult("An API key is required.", "Configure now")},
+		{name: "open web page", result: NewOpenWebPageResult("", "Browse", "marketplace", map[string]string{"q": "drums"})},
+		{name: "nested web page", result: NewOpenWebPageResult("", "Stats", "stats/daily", nil)},
+		{name: "open file", result: NewOpenFileResult("Report ready", "Open report", absPath)},
+		{name: "missing label", result: NewOpenSettingsResult("", ""), wantErr: true},
+		{name: "web page url", result: NewOpenWebPageResult("", "Go", "https://example.com", nil), wantErr: true},
+		{name: "web page absolute", result: NewOpenWebPageResult("", "Go", "/etc", nil), wantErr: true},
+		{name: "web page traversal", result: NewOpenWebPageResult("", "Go", "a/../../admin", nil), wantErr: true},
+		{name: "relative file", result: NewOpenFileResult("", "Open", "report.pdf"), wantErr: true},
+		{name: "empty file", result: NewOpenFileResult("", "Open", ""), wantErr: true},
+		{
+			name: "unknown action",
+			result: &StructuredResult{
+				DisplayType: DisplayTypeDeepLink,
+				Data:        DeepLinkData{Action: "run_command", Label: "Run", Target: "rm -rf /"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "settings with target",
+			result: &StructuredResult{
+				DisplayType: DisplayTypeDeepLink,
+				Data:        DeepLinkData{Action: DeepLinkOpenSettings, Label: "Configure", Target: "other-plugin"},
+			},
+			wantErr: true,
+		},
+		{name: "missing data", result: &StructuredResult{DisplayType: DisplayTypeDeepLink}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.result.Validate()
+			if tt.wantErr && err == nil {
+				t.Error("expected validation error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeepLinkResult_RoundTrip(t *testing.T) {
+	jsonStr, err := NewOpenWebPageResult("", "Browse", "marketplace", map[string]string{"q": "drums"}).ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	parsed, err := ParseStructuredResult(jsonStr)
+	if err != nil {
+		t.Fatalf("ParseStructuredResult failed: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Fatalf("parsed result failed validation: %v", err)
+	}
+
+	link, err := decodeResultData[DeepLinkData](parsed.Data)
+	if err != nil {
+		t.Fatalf("decodeResultData failed: %v", err)
+	}
+	if link.Action != DeepLinkOpenWebPage || link.Target != "marketplace" || link.Query["q"] != "drums" {
+		t.Errorf("got %+v after round trip", link)
+	}
+}
@@ -0,0 +1,157 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// PluginDependency declares another plugin this plugin needs, such as a companion
+// library plugin whose tools it calls with HostServices.CallTool.
+type PluginDependency struct {
+	// Name is the required plugin's name
+	Name string `json:"name"`
+	// Constraint is a semver constraint on its version (e.g., ">= 1.2.0"); empty accepts any version
+	Constraint string `json:"constraint,omitempty"`
+}
+
+func (d PluginDependency) String() string {
+	if d.Constraint == "" {
+		return d.Name
+	}
+	return d.Name + " " + d.Constraint
+}
+
+// PluginDependencyProvider allows plugins to declare the plugins they depend on, so
+// the host can verify they are installed before enabling the plugin.
+// BasePlugin implements it with the requirements.plugins list of plugin.yaml:
+//
+//	requirements:
+//	  plugins:
+//	    - music-library >= 1.2.0
+//	    - audio-tools
+type PluginDependencyProvider interface {
+	// GetPluginDependencies returns the plugins this plugin requires
+	GetPluginDependencies() []PluginDependency
+}
+
+// ParsePluginDependency parses a dependency of the form "<name> [constraint]",
+// e.g. "music-library >= 1.2.0" or "audio-tools ^2".
+func ParsePluginDependency(spec string) (PluginDependency, error) {
+	spec = strings.TrimSpace(spec)
+	end := strings.IndexFunc(spec, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.'
+	})
+	if end < 0 {
+		end = len(spec)
+	}
+	dep := PluginDependency{Name: spec[:end], Constraint: strings.TrimSpace(spec[end:])}
+	if dep.Name == "" {
+		return PluginDependency{}, fmt.Errorf("invalid plugin dependency %q: missing plugin name", spec)
+	}
+	if dep.Constraint != "" {
+		if _, err := semver.NewConstraint(dep.Constraint); err != nil {
+			return PluginDependency{}, fmt.Errorf("invalid plugin dependency %q: %w", spec, err)
+		}
+	}
+	return dep, nil
+}
+
+// PluginDependencies returns the dependencies in requirements.plugins.
+// Invalid entries are skipped; plugin.yaml is validated when the plugin starts.
+func (c *PluginConfig) PluginDependencies() []PluginDependency {
+	var deps []PluginDependency
+	for _, spec := range c.Requirements.Plugins {
+		if dep, err := ParsePluginDependency(spec); err == nil {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// UnmetDependency is a PluginDependency the installed plugins don't satisfy.
+type UnmetDependency struct {
+	PluginDependency
+	// Installed is the installed version of the plugin, or empty if it is not installed
+	Installed string
+}
+
+// DependencyError reports the dependencies of a plugin that are not met.
+type DependencyError struct {
+	Unmet []UnmetDependency
+}
+
+func (e *DependencyError) Error() string {
+	problems := make([]string, len(e.Unmet))
+	for i, u := range e.Unmet {
+		if u.Installed == "" {
+			problems[i] = fmt.Sprintf("%s is not installed", u.PluginDependency)
+		} else {
+			problems[i] = fmt.Sprintf("%s is required, %s is installed", u.PluginDependency, u.Installed)
+		}
+	}
+	return "unmet plugin dependencies: " + strings.Join(problems, "; ")
+}
+
+// CheckPluginDependencies verifies deps against installed, which maps plugin names
+// to their versions. Hosts call it before enabling a plugin; it returns a
+// *DependencyError listing every dependency that is missing or has the wrong version.
+func CheckPluginDependencies(deps []PluginDependency, installed map[string]string) error {
+	var unmet []UnmetDependency
+	for _, dep := range deps {
+		version, ok := installed[dep.Name]
+		if !ok {
+			unmet = append(unmet, UnmetDependency{PluginDependency: dep})
+			continue
+		}
+		if dep.Constraint == "" {
+			continue
+		}
+		constraint, err := semver.NewConstraint(dep.Constraint)
+		if err != nil {
+			return fmt.Errorf("invalid constraint for %s: %w", dep.Name, err)
+		}
+		if v, err := semver.NewVersion(version); err != nil || !constraint.Check(v) {
+			unmet = append(unmet, UnmetDependency{PluginDependency: dep, Installed: version})
+		}
+	}
+	if len(unmet) > 0 {
+		return &DependencyError{Unmet: unmet}
+	}
+	return nil
+}
+
+func pluginDependenciesToProto(deps []PluginDependency) []*ProtoPluginDependency {
+	if len(deps) == 0 {
+		return nil
+	}
+	out := make([]*ProtoPluginDependency, len(deps))
+	for i, dep := range deps {
+		out[i] = &ProtoPluginDependency{Name: dep.Name, Constraint: dep.Constraint}
+	}
+	return out
+}
+
+func pluginDependenciesFromProto(deps []*ProtoPluginDependency) []PluginDependency {
+	if len(deps) == 0 {
+		return nil
+	}
+	out := make([]PluginDependency, len(deps))
+	for i, dep := range deps {
+		out[i] = PluginDependency{Name: dep.Name, Constraint: dep.Constraint}
+	}
+	return out
+}
+
+// GetPluginDependencies returns the plugins the plugin requires, from its cached
+// compatibility info. Returns nil if the plugin declares none.
+func (c *grpcClient) GetPluginDependencies(ctx context.Context) ([]PluginDependency, error) {
+	resp, err := c.compatibilityInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pluginDependenciesFromProto(resp.PluginDependencies), nil
+}
@@ -0,0 +1,92 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePluginDependency(t *testing.T) {
+	tests := []struct {
+		spec string
+		want PluginDependency
+	}{
+		{"audio-tools", PluginDependency{Name: "audio-tools"}},
+		{"music-library >= 1.2.0", PluginDependency{Name: "music-library", Constraint: ">= 1.2.0"}},
+		{" music_library>=1.2, <2 ", PluginDependency{Name: "music_library", Constraint: ">=1.2, <2"}},
+		{"midi ^2", PluginDependency{Name: "midi", Constraint: "^2"}},
+	}
+	for _, tt := range tests {
+		if got, err := ParsePluginDependency(tt.spec); err != nil || got != tt.want {
+			t.Errorf("ParsePluginDependency(%q) = %+v, %v, want %+v", tt.spec, got, err, tt.want)
+		}
+	}
+	for _, spec := range []string{"", ">= 1.0", "midi >= banana"} {
+		if _, err := ParsePluginDependency(spec); err == nil {
+			t.Errorf("ParsePluginDependency(%q) should fail", spec)
+		}
+	}
+}
+
+func TestCheckPluginDependencies(t *testing.T) {
+	deps := []PluginDependency{
+		{Name: "music-library", Constraint: ">= 1.2.0"},
+		{Name: "audio-tools"},
+		{Name: "midi", Constraint: "^2"},
+	}
+	installed := map[string]string{"music-library": "1.4.1", "audio-tools": "0.1.0", "midi": "2.3.0"}
+	if err := CheckPluginDependencies(deps, installed); err != nil {
+		t.Errorf("expected dependencies to be met, got %v", err)
+	}
+
+	installed = map[string]string{"music-library": "1.1.0", "midi": "2.0.0"}
+	err := CheckPluginDependencies(deps, installed)
+	var depErr *DependencyError
+	if !errors.As(err, &depErr) {
+		t.Fatalf("expected *DependencyError, got %v", err)
+	}
+	want := []UnmetDependency{
+		{PluginDependency: deps[0], Installed: "1.1.0"},
+		{PluginDependency: deps[1]},
+	}
+	if !reflect.DeepEqual(depErr.Unmet, want) {
+		t.Errorf("Unmet = %+v, want %+v", depErr.Unmet, want)
+	}
+	if !strings.Contains(err.Error(), "audio-tools is not installed") {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestPluginDependencies_FromYAML(t *testing.T) {
+	config, err := readPluginConfig(permissionsPluginYAML + `
+requirements:
+  plugins:
+    - music-library >= 1.2.0
+    - audio-tools
+`)
+	if err != nil {
+		t.Fatalf("readPluginConfig error: %v", err)
+	}
+	metadata, err := config.ToMetadata()
+	if err != nil {
+		t.Fatalf("ToMetadata error: %v", err)
+	}
+	if plugins := metadata.Requirements.Plugins; len(plugins) != 2 || plugins[0].Constraint != ">= 1.2.0" {
+		t.Errorf("unexpected metadata requirements: %v", plugins)
+	}
+
+	tool := &plainTestTool{}
+	tool.SetPluginConfig(&config)
+	client := newTestClient(t, tool)
+	deps, err := client.GetPluginDependencies(context.Background())
+	want := []PluginDependency{{Name: "music-library", Constraint: ">= 1.2.0"}, {Name: "audio-tools"}}
+	if err != nil || !reflect.DeepEqual(deps, want) {
+		t.Errorf("GetPluginDependencies = %+v, %v, want %+v", deps, err, want)
+	}
+
+	if _, err := readPluginConfig(permissionsPluginYAML + "requirements:\n  plugins: [\"midi >= banana\"]\n"); err == nil {
+		t.Error("expected an invalid constraint to be rejected")
+	}
+}
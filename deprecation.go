@@ -0,0 +1,89 @@
+package pluginapi
+
+import "strings"
+
+// Deprecation marks a tool, operation, or parameter as legacy. Hosts warn users who
+// rely on it, and the notice is added to the descriptions the model sees so it
+// prefers the replacement.
+//
+// Example plugin.yaml:
+//
+//	operations:
+//	  export_mp3:
+//	    deprecated: true
+//	    deprecation_message: MP3 export will be removed in 3.0
+//	    replaced_by: export_audio
+type Deprecation struct {
+	Deprecated bool `yaml:"deprecated,omitempty"`
+	// Message explains why, or when it will be removed (optional)
+	Message string `yaml:"deprecation_message,omitempty"`
+	// ReplacedBy names the tool, operation, or parameter to use instead (optional)
+	ReplacedBy string `yaml:"replaced_by,omitempty"`
+}
+
+// Notice describes the deprecation in a sentence, e.g.
+// "Deprecated: MP3 export will be removed in 3.0. Use export_audio instead."
+// It returns an empty string if d is not deprecated.
+func (d Deprecation) Notice() string {
+	if !d.Deprecated {
+		return ""
+	}
+	notice := "Deprecated."
+	if d.Message != "" {
+		notice = "Deprecated: " + strings.TrimSuffix(d.Message, ".") + "."
+	}
+	if d.ReplacedBy != "" {
+		notice += " Use " + d.ReplacedBy + " instead."
+	}
+	return notice
+}
+
+// withDeprecationNotice appends d's notice to description.
+func withDeprecationNotice(description string, d Deprecation) string {
+	notice := d.Notice()
+	if notice == "" {
+		return description
+	}
+	if description == "" {
+		return notice
+	}
+	return description + " " + notice
+}
+
+// deprecatedOperationsNotice lists the deprecated operations among names for the
+// operation parameter's description, or returns an empty string if there are none.
+func deprecatedOperationsNotice(operations map[string]YAMLOperationDefinition, names []string) string {
+	var deprecated []string
+	for _, name := range names {
+		d := operations[name].Deprecation
+		switch {
+		case !d.Deprecated:
+		case d.ReplacedBy != "":
+			deprecated = append(deprecated, name+" (use "+d.ReplacedBy+")")
+		default:
+			deprecated = append(deprecated, name)
+		}
+	}
+	if len(deprecated) == 0 {
+		return ""
+	}
+	return "Deprecated operations: " + strings.Join(deprecated, ", ") + "."
+}
+
+// validate records deprecation details given without deprecated: true in errs under path.
+func (d Deprecation) validate(path string, errs *ValidationErrors) {
+	if !d.Deprecated && (d.Message != "" || d.ReplacedBy != "") {
+		errs.Add(path, "deprecation_message and replaced_by require deprecated: true")
+	}
+}
+
+func deprecationToProto(d Deprecation) *ProtoDeprecation {
+	if !d.Deprecated {
+		return nil
+	}
+	return &ProtoDeprecation{Deprecated: true, Message: d.Message, ReplacedBy: d.ReplacedBy}
+}
+
+func deprecationFromProto(d *ProtoDeprecation) Deprecation {
+	return Deprecation{Deprecated: d.GetDeprecated(), Message: d.GetMessage(), ReplacedBy: d.GetReplacedBy()}
+}
@@ -0,0 +1,129 @@
+package pluginapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const deprecationTestYAML = `
+name: exporter
+description: Exports the session
+parameters:
+  - name: operation
+    type: string
+    description: Operation to perform
+    required: true
+  - name: bitrate
+    type: integer
+    description: Bitrate in kbps
+    deprecated: true
+    replaced_by: quality
+operations:
+  export_mp3:
+    deprecated: true
+    deprecation_message: MP3 export will be removed in 3.0
+    replaced_by: export_audio
+  export_audio:
+    parameters:
+      - name: quality
+        type: string
+        description: Quality preset
+`
+
+func TestDeprecation_Notice(t *testing.T) {
+	tests := []struct {
+		d    Deprecation
+		want string
+	}{
+		{Deprecation{}, ""},
+		{Deprecation{Deprecated: true}, "Deprecated."},
+		{Deprecation{Deprecated: true, ReplacedBy: "quality"}, "Deprecated. Use quality instead."},
+		{Deprecation{Deprecated: true, Message: "Going away in 3.0.", ReplacedBy: "export_audio"}, "Deprecated: Going away in 3.0. Use export_audio instead."},
+	}
+	for _, tt := range tests {
+		if got := tt.d.Notice(); got != tt.want {
+			t.Errorf("Notice(%+v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestDeprecation_YAML(t *testing.T) {
+	var toolDef YAMLToolDefinition
+	if err := yaml.Unmarshal([]byte(deprecationTestYAML), &toolDef); err != nil {
+		t.Fatalf("failed to parse YAML: %v", err)
+	}
+	if err := ValidateYAMLToolDefinition(&toolDef); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	tool, err := toolDef.ToToolDefinition()
+	if err != nil {
+		t.Fatalf("ToToolDefinition failed: %v", err)
+	}
+	props := tool.Parameters["properties"].(map[string]interface{})
+	bitrate := props["bitrate"].(map[string]interface{})
+	if bitrate["deprecated"] != true || bitrate["description"] != "Bitrate in kbps Deprecated. Use quality instead." {
+		t.Errorf("unexpected bitrate schema: %v", bitrate)
+	}
+	operation := props["operation"].(map[string]interface{})
+	if operation["description"] != "Operation to perform Deprecated operations: export_mp3 (use export_audio)." {
+		t.Errorf("unexpected operation description: %v", operation["description"])
+	}
+	if _, ok := props["quality"].(map[string]interface{})["deprecated"]; ok {
+		t.Error("quality should not be deprecated")
+	}
+
+	client := newTestClient(t, &examplesTestTool{toolDef: &toolDef})
+	ops := client.GetOperationsCtx(context.Background())
+	if len(ops) != 2 || ops[1].Name != "export_mp3" {
+		t.Fatalf("unexpected operations: %+v", ops)
+	}
+	want := Deprecation{Deprecated: true, Message: "MP3 export will be removed in 3.0", ReplacedBy: "export_audio"}
+	if ops[1].Deprecation != want {
+		t.Errorf("Deprecation = %+v, want %+v", ops[1].Deprecation, want)
+	}
+	if ops[0].Deprecation.Deprecated {
+		t.Error("export_audio should not be deprecated")
+	}
+}
+
+func TestDeprecation_Tool(t *testing.T) {
+	toolDef := &YAMLToolDefinition{
+		Name:        "old_weather",
+		Description: "Gets the weather",
+		Parameters:  []YAMLToolParameter{{Name: "city", Type: "string", Description: "City"}},
+		Deprecation: Deprecation{Deprecated: true, ReplacedBy: "weather"},
+	}
+	client := newTestClient(t, &examplesTestTool{toolDef: toolDef})
+	tool := client.DefinitionCtx(context.Background())
+	if tool.Description != "Gets the weather Deprecated. Use weather instead." {
+		t.Errorf("Description = %q", tool.Description)
+	}
+	if !tool.Deprecation.Deprecated || tool.Deprecation.ReplacedBy != "weather" {
+		t.Errorf("Deprecation = %+v", tool.Deprecation)
+	}
+}
+
+func TestDeprecation_Validation(t *testing.T) {
+	var toolDef YAMLToolDefinition
+	if err := yaml.Unmarshal([]byte(deprecationTestYAML), &toolDef); err != nil {
+		t.Fatalf("failed to parse YAML: %v", err)
+	}
+	op := toolDef.Operations["export_mp3"]
+	op.ReplacedBy = "export_wav"
+	toolDef.Operations["export_mp3"] = op
+	toolDef.Message = "Use the new plugin"
+
+	err := ValidateYAMLToolDefinition(&toolDef)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{`replaced by unknown operation "export_wav"`, "require deprecated: true"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+}
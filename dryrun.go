@@ -0,0 +1,84 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// CallMetadataDryRun is the call metadata key that requests a dry run ("true" or "false").
+const CallMetadataDryRun = "dry_run"
+
+// WithDryRun returns a context that asks the plugin to preview the call instead of executing it.
+// Plugins that support dry runs (see OperationInfo.SupportsDryRun) answer with a plan result;
+// the host can show it to the user and then repeat the call without the flag.
+func WithDryRun(ctx context.Context) context.Context {
+	return WithCallMetadata(ctx, map[string]string{CallMetadataDryRun: "true"})
+}
+
+// IsDryRun reports whether the host asked for a dry run of the current call.
+// A plugin that sees true must not make any changes. Operations that cannot
+// preview themselves should return ErrDryRunUnsupported rather than executing.
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := strconv.ParseBool(CallMetadataValue(ctx, CallMetadataDryRun))
+	return dryRun
+}
+
+// ErrDryRunUnsupported is returned (possibly wrapped) by operations that were
+// called in dry-run mode but cannot preview their effects.
+var ErrDryRunUnsupported = errors.New("operation does not support dry run")
+
+// PlanStep is a single change a dry run would make.
+type PlanStep struct {
+	// Action is a short verb describing the change (e.g., "delete", "create", "send")
+	Action string `json:"action" yaml:"action"`
+	// Target is what the change applies to (e.g., a file path or record ID)
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
+	// Description explains the change in plain language
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Destructive marks changes that cannot be undone, so hosts can highlight them
+	Destructive bool `json:"destructive,omitempty" yaml:"destructive,omitempty"`
+}
+
+// PlanData is the payload of a DisplayTypePlan result.
+type PlanData struct {
+	// Summary describes the overall effect (e.g., "Delete 3 files")
+	Summary string `json:"summary" yaml:"summary"`
+	// Steps lists the individual changes in the order they would be made
+	Steps []PlanStep `json:"steps,omitempty" yaml:"steps,omitempty"`
+}
+
+// NewPlanResult creates the result a plugin returns from a dry run.
+//
+// Example:
+//
+//	if pluginapi.IsDryRun(ctx) {
+//	    steps := make([]pluginapi.PlanStep, len(paths))
+//	    for i, p := range paths {
+//	        steps[i] = pluginapi.PlanStep{Action: "delete", Target: p, Destructive: true}
+//	    }
+//	    return pluginapi.NewPlanResult(fmt.Sprintf("Delete %d files", len(paths)), steps...).ToJSON()
+//	}
+func NewPlanResult(summary string, steps ...PlanStep) *StructuredResult {
+	return &StructuredResult{
+		DisplayType: DisplayTypePlan,
+		Data: PlanData{
+			Summary: summary,
+			Steps:   steps,
+		},
+	}
+}
+
+// Validate checks that the plan has a summary and every step has an action.
+func (p *PlanData) Validate() error {
+	if p.Summary == "" {
+		return fmt.Errorf("plan summary is required")
+	}
+	for i, step := range p.Steps {
+		if step.Action == "" {
+			return fmt.Errorf("step[%d]: action is required", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package pluginapi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestIsDryRun(t *testing.T) {
+	ctx := context.Background()
+	if IsDryRun(ctx) {
+		t.Error("plain context should not be a dry run")
+	}
+	if !IsDryRun(WithDryRun(ctx)) {
+		t.Error("WithDryRun context should be a dry run")
+	}
+	if IsDryRun(WithCallMetadata(ctx, map[string]string{CallMetadataDryRun: "false"})) {
+		t.Error("dry_run=false should not be a dry run")
+	}
+	if IsDryRun(WithCallMetadata(ctx, map[string]string{CallMetadataDryRun: "maybe"})) {
+		t.Error("unparseable dry_run should not be a dry run")
+	}
+}
+
+func TestPlanResult_Validate(t *testing.T) {
+	result := NewPlanResult("Delete 2 files",
+		PlanStep{Action: "delete", Target: "/tmp/a.txt", Destructive: true},
+		PlanStep{Action: "delete", Target: "/tmp/b.txt", Destructive: true},
+	)
+	if err := result.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	jsonStr, err := result.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	parsed, err := FromJSON(jsonStr)
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Errorf("parsed plan should validate: %v", err)
+	}
+	plan, err := decodeResultData[PlanData](parsed.Data)
+	if err != nil {
+		t.Fatalf("decodeResultData failed: %v", err)
+	}
+	if len(plan.Steps) != 2 || !plan.Steps[0].Destructive {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+
+	if err := NewPlanResult("").Validate(); err == nil {
+		t.Error("expected error for missing summary")
+	}
+	if err := NewPlanResult("Do things", PlanStep{Target: "x"}).Validate(); err == nil {
+		t.Error("expected error for step without action")
+	}
+}
+
+type dryRunTestTool struct {
+	BasePlugin
+}
+
+func (t *dryRunTestTool) Call(ctx context.Context, args string) (string, error) {
+	if IsDryRun(ctx) {
+		return NewPlanResult("Would run").ToJSON()
+	}
+	return "ran", nil
+}
+
+func TestGRPCClient_DryRun(t *testing.T) {
+	client := newTestClient(t, &dryRunTestTool{})
+
+	got, err := client.Call(WithDryRun(context.Background()), `{}`)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	result, err := FromJSON(got)
+	if err != nil || result.DisplayType != DisplayTypePlan {
+		t.Errorf("expected plan result, got %q (%v)", got, err)
+	}
+
+	got, err = client.Call(context.Background(), `{}`)
+	if err != nil || got != "ran" {
+		t.Errorf("Call = %q, %v", got, err)
+	}
+}
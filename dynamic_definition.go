@@ -0,0 +1,59 @@
+package pluginapi
+
+import "context"
+
+// DynamicDefinitionProvider is implemented by plugins whose definition depends on
+// runtime state, such as installed DAW scripts or connected devices, so Definition
+// may return a different schema while the plugin runs.
+// Plugins can optionally implement this interface.
+//
+// When the definition changes, the plugin reports the new version with
+// HostServices.DefinitionChanged, and the host fetches it again without a restart.
+//
+// Example:
+//
+//	func (p *dawPlugin) DefinitionVersion() string {
+//	    return p.scripts.Checksum()
+//	}
+//
+//	func (p *dawPlugin) onScriptsInstalled(ctx context.Context) {
+//	    _ = p.Host().DefinitionChanged(ctx, p.DefinitionVersion())
+//	}
+type DynamicDefinitionProvider interface {
+	// DefinitionVersion returns an opaque version (e.g., a hash of the schema) that
+	// changes whenever Definition does
+	DefinitionVersion() string
+}
+
+// definitionVersion returns the version of tool's definition, or "" if it is static.
+func definitionVersion(tool PluginTool) string {
+	if dynamic, ok := tool.(DynamicDefinitionProvider); ok {
+		return dynamic.DefinitionVersion()
+	}
+	return ""
+}
+
+// DefinitionVersion returns the version of the plugin's current definition, or ""
+// if the plugin's definition is static. It comes with the cached definition, so
+// hosts call Invalidate when the plugin reports DefinitionChanged.
+func (c *grpcClient) DefinitionVersion(ctx context.Context) (string, error) {
+	resp, err := cachedResponse(&c.cache, &c.cache.definition, func() (*ToolDefinition, error) {
+		return c.client.GetDefinition(ctx, &Empty{})
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Version, nil
+}
+
+func (UnimplementedHostServices) DefinitionChanged(ctx context.Context, version string) error {
+	return ErrHostServiceUnavailable
+}
+
+func (s *hostServer) DefinitionChanged(ctx context.Context, req *DefinitionChangedRequest) (*ConfigResponse, error) {
+	return hostResponse(s.Impl.DefinitionChanged(ctx, req.Version))
+}
+
+func (h *hostClient) DefinitionChanged(ctx context.Context, version string) error {
+	return hostError(h.client.DefinitionChanged(h.outgoing(ctx), &DefinitionChangedRequest{Version: version}))
+}
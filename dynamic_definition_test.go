@@ -0,0 +1,93 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+type deviceTestTool struct {
+	mu      sync.Mutex
+	devices []string
+}
+
+func (t *deviceTestTool) Definition() Tool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	devices := make([]interface{}, len(t.devices))
+	for i, d := range t.devices {
+		devices[i] = d
+	}
+	return Tool{
+		Name:        "devices",
+		Description: "Controls connected devices",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"device": map[string]interface{}{"type": "string", "enum": devices},
+			},
+		},
+	}
+}
+
+func (t *deviceTestTool) DefinitionVersion() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return fmt.Sprintf("v%d", len(t.devices))
+}
+
+func (t *deviceTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+type definitionWatchingHost struct {
+	UnimplementedHostServices
+	client   *grpcClient
+	versions []string
+}
+
+func (h *definitionWatchingHost) DefinitionChanged(ctx context.Context, version string) error {
+	h.versions = append(h.versions, version+"|"+HostCallerToken(ctx))
+	h.client.Invalidate()
+	return nil
+}
+
+func TestDynamicDefinition_Changed(t *testing.T) {
+	tool := &deviceTestTool{devices: []string{"mixer"}}
+	client := newTestClient(t, tool)
+	ctx := context.Background()
+
+	if version, err := client.DefinitionVersion(ctx); err != nil || version != "v1" {
+		t.Fatalf("DefinitionVersion = %q, %v, want v1", version, err)
+	}
+	if enum := client.Definition().Parameters["properties"].(map[string]interface{})["device"].(map[string]interface{})["enum"]; len(enum.([]interface{})) != 1 {
+		t.Fatalf("unexpected device enum %v", enum)
+	}
+
+	impl := &definitionWatchingHost{client: client}
+	host := connectTestHost(t, impl, "daw")
+	tool.mu.Lock()
+	tool.devices = append(tool.devices, "sampler")
+	tool.mu.Unlock()
+	if err := host.DefinitionChanged(ctx, tool.DefinitionVersion()); err != nil {
+		t.Fatalf("DefinitionChanged failed: %v", err)
+	}
+	if len(impl.versions) != 1 || impl.versions[0] != "v2|daw" {
+		t.Errorf("host received %q", impl.versions)
+	}
+
+	if version, err := client.DefinitionVersion(ctx); err != nil || version != "v2" {
+		t.Errorf("DefinitionVersion after change = %q, %v, want v2", version, err)
+	}
+	if enum := client.Definition().Parameters["properties"].(map[string]interface{})["device"].(map[string]interface{})["enum"]; len(enum.([]interface{})) != 2 {
+		t.Errorf("expected the new device in the definition, got %v", enum)
+	}
+}
+
+func TestDynamicDefinition_StaticPlugin(t *testing.T) {
+	client := newTestClient(t, &plainTestTool{})
+	if version, err := client.DefinitionVersion(context.Background()); err != nil || version != "" {
+		t.Errorf("DefinitionVersion = %q, %v, want empty for a static definition", version, err)
+	}
+}
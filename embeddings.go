@@ -0,0 +1,45 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+)
+
+func (UnimplementedHostServices) Embeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	return nil, ErrHostServiceUnavailable
+}
+
+func (s *hostServer) Embeddings(ctx context.Context, req *EmbedRequest) (*EmbedResponse, error) {
+	vectors, err := s.Impl.Embeddings(ctx, req.Texts)
+	if st := hostStatus(err); st != nil {
+		return nil, st
+	}
+	if err == nil && len(vectors) != len(req.Texts) {
+		err = fmt.Errorf("host returned %d embeddings for %d texts", len(vectors), len(req.Texts))
+	}
+	if err != nil {
+		return &EmbedResponse{SupportsEmbeddings: true, Error: err.Error()}, nil
+	}
+
+	embeddings := make([]*Embedding, len(vectors))
+	for i, v := range vectors {
+		embeddings[i] = &Embedding{Values: v}
+	}
+	return &EmbedResponse{Embeddings: embeddings, SupportsEmbeddings: true}, nil
+}
+
+func (h *hostClient) Embeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	resp, err := h.client.Embeddings(h.outgoing(ctx), &EmbedRequest{Texts: texts})
+	if err != nil {
+		return nil, hostSentinel(err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+
+	vectors := make([][]float32, len(resp.Embeddings))
+	for i, e := range resp.Embeddings {
+		vectors[i] = e.Values
+	}
+	return vectors, nil
+}
@@ -0,0 +1,44 @@
+package pluginapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// pluginEmbeddingHost serves the Embeddings host service from an embedding plugin,
+// as an agent configured with a plugin embedding backend would.
+type pluginEmbeddingHost struct {
+	UnimplementedHostServices
+	backend EmbeddingProvider
+}
+
+func (h *pluginEmbeddingHost) Embeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	return h.backend.Embed(ctx, texts)
+}
+
+type shortEmbeddingHost struct {
+	UnimplementedHostServices
+}
+
+func (shortEmbeddingHost) Embeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	return [][]float32{{1}}, nil
+}
+
+func TestHostServices_Embeddings(t *testing.T) {
+	backend := newTestClient(t, &embeddingTestTool{})
+	host := connectTestHost(t, &pluginEmbeddingHost{backend: backend}, "samples")
+
+	vectors, err := host.Embeddings(context.Background(), []string{"kick", "snare"})
+	if err != nil {
+		t.Fatalf("Embeddings failed: %v", err)
+	}
+	if len(vectors) != 2 || vectors[0][0] != 4 || vectors[1][0] != 5 {
+		t.Errorf("unexpected vectors: %v", vectors)
+	}
+
+	short := connectTestHost(t, shortEmbeddingHost{}, "samples")
+	if _, err := short.Embeddings(context.Background(), []string{"a", "b"}); err == nil || !strings.Contains(err.Error(), "1 embeddings for 2 texts") {
+		t.Errorf("expected count mismatch error, got %v", err)
+	}
+}
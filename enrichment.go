@@ -0,0 +1,83 @@
+package pluginapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MaxContextEnrichmentSize caps the JSON size of the context a plugin attaches to
+// one turn, so enrichment stays small next to the conversation itself.
+const MaxContextEnrichmentSize = 4 * 1024
+
+// DefaultEnrichmentTimeout bounds EnrichContext when the host's context has no
+// deadline. Enrichment runs before every turn, so a slow plugin is skipped rather
+// than waited for.
+const DefaultEnrichmentTimeout = 500 * time.Millisecond
+
+// EnrichRequest describes the LLM turn a ContextEnricher is asked about.
+type EnrichRequest struct {
+	ConversationID string
+	AgentName      string
+	// UserMessage is the message that starts the turn
+	UserMessage string
+}
+
+// ContextItem is a small piece of structured context attached to an LLM turn.
+type ContextItem struct {
+	// Label tells the model what the data is (e.g., "Current DAW session")
+	Label string `json:"label"`
+	// Data is the context itself; it must be JSON-serializable
+	Data interface{} `json:"data"`
+}
+
+// ContextEnricher allows plugins to attach context that changes faster than their
+// config, such as the open DAW session or files, to each LLM turn without the model
+// having to call a tool first. The host calls it before every turn.
+// Plugins can optionally implement this interface.
+//
+// Example:
+//
+//	func (p *dawPlugin) EnrichContext(ctx context.Context, req pluginapi.EnrichRequest) ([]pluginapi.ContextItem, error) {
+//	    session, ok := p.currentSession()
+//	    if !ok {
+//	        return nil, nil
+//	    }
+//	    return []pluginapi.ContextItem{{Label: "Current DAW session", Data: session}}, nil
+//	}
+type ContextEnricher interface {
+	// EnrichContext returns the context for the turn, or nothing. It must answer
+	// quickly (see DefaultEnrichmentTimeout), and the items may total at most
+	// MaxContextEnrichmentSize bytes of JSON.
+	EnrichContext(ctx context.Context, req EnrichRequest) ([]ContextItem, error)
+}
+
+// contextItemsToProto encodes items, enforcing MaxContextEnrichmentSize.
+func contextItemsToProto(items []ContextItem) ([]*ProtoContextItem, error) {
+	out := make([]*ProtoContextItem, len(items))
+	size := 0
+	for i, item := range items {
+		data, err := json.Marshal(item.Data)
+		if err != nil {
+			return nil, fmt.Errorf("context %q is not valid JSON: %w", item.Label, err)
+		}
+		size += len(item.Label) + len(data)
+		out[i] = &ProtoContextItem{Label: item.Label, DataJson: string(data)}
+	}
+	if size > MaxContextEnrichmentSize {
+		return nil, fmt.Errorf("context enrichment is %d bytes, over the %d byte limit", size, MaxContextEnrichmentSize)
+	}
+	return out, nil
+}
+
+func contextItemsFromProto(items []*ProtoContextItem) ([]ContextItem, error) {
+	out := make([]ContextItem, len(items))
+	for i, item := range items {
+		out[i].Label = item.Label
+		if err := json.Unmarshal([]byte(item.DataJson), &out[i].Data); err != nil {
+			return nil, fmt.Errorf("invalid context %q: %w", item.Label, err)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,56 @@
+package pluginapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type enrichTestTool struct {
+	plainTestTool
+}
+
+func (t *enrichTestTool) EnrichContext(ctx context.Context, req EnrichRequest) ([]ContextItem, error) {
+	switch req.UserMessage {
+	case "huge":
+		return []ContextItem{{Label: "dump", Data: strings.Repeat("x", MaxContextEnrichmentSize)}}, nil
+	case "none":
+		return nil, nil
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, NewPluginError(ErrorCodeInternal, "no deadline")
+	}
+	return []ContextItem{{
+		Label: "Current DAW session",
+		Data:  map[string]interface{}{"project": "demo", "conversation": req.ConversationID, "tracks": 3},
+	}}, nil
+}
+
+func TestEnrichContext(t *testing.T) {
+	client := newTestClient(t, &enrichTestTool{})
+	ctx := context.Background()
+
+	items, err := client.EnrichContext(ctx, EnrichRequest{ConversationID: "conv-1", UserMessage: "mix it"})
+	if err != nil {
+		t.Fatalf("EnrichContext failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Label != "Current DAW session" {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+	data, ok := items[0].Data.(map[string]interface{})
+	if !ok || data["conversation"] != "conv-1" || data["tracks"] != float64(3) {
+		t.Errorf("unexpected data: %#v", items[0].Data)
+	}
+
+	if items, err := client.EnrichContext(ctx, EnrichRequest{UserMessage: "none"}); err != nil || len(items) != 0 {
+		t.Errorf("expected no context, got %+v, %v", items, err)
+	}
+	if _, err := client.EnrichContext(ctx, EnrichRequest{UserMessage: "huge"}); err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Errorf("expected size limit error, got %v", err)
+	}
+
+	plain := newTestClient(t, &plainTestTool{})
+	if items, err := plain.EnrichContext(ctx, EnrichRequest{}); err != nil || items != nil {
+		t.Errorf("expected nothing without ContextEnricher, got %+v, %v", items, err)
+	}
+}
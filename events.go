@@ -0,0 +1,195 @@
+package pluginapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// eventResubscribeDelay is how long a listener waits before resubscribing after its event stream breaks.
+const eventResubscribeDelay = time.Second
+
+// AgentEventType identifies the kind of an agent event.
+type AgentEventType string
+
+const (
+	EventLocationChanged     AgentEventType = "location_changed"
+	EventSettingsChanged     AgentEventType = "settings_changed"
+	EventAgentSwitched       AgentEventType = "agent_switched"
+	EventConversationStarted AgentEventType = "conversation_started"
+)
+
+// AgentEvent is an event in the agent. Use a type switch to get at the details:
+//
+//	switch e := event.(type) {
+//	case pluginapi.LocationChangedEvent:
+//	    t.refreshForLocation(e.Current)
+//	case pluginapi.SettingsChangedEvent:
+//	    t.reloadSettings()
+//	}
+type AgentEvent interface {
+	EventType() AgentEventType
+}
+
+// LocationChangedEvent is sent when the agent detects a new location zone.
+type LocationChangedEvent struct {
+	Previous string `json:"previous,omitempty"`
+	Current  string `json:"current"`
+}
+
+// SettingsChangedEvent is sent when the agent's settings change.
+type SettingsChangedEvent struct {
+	// Keys lists the changed settings; empty if the host does not know which changed
+	Keys []string `json:"keys,omitempty"`
+}
+
+// AgentSwitchedEvent is sent when the user switches to a different agent.
+type AgentSwitchedEvent struct {
+	Previous string `json:"previous,omitempty"`
+	Current  string `json:"current"`
+}
+
+// ConversationStartedEvent is sent when the user starts a new conversation.
+type ConversationStartedEvent struct {
+	ConversationID string `json:"conversation_id"`
+}
+
+func (LocationChangedEvent) EventType() AgentEventType     { return EventLocationChanged }
+func (SettingsChangedEvent) EventType() AgentEventType     { return EventSettingsChanged }
+func (AgentSwitchedEvent) EventType() AgentEventType       { return EventAgentSwitched }
+func (ConversationStartedEvent) EventType() AgentEventType { return EventConversationStarted }
+
+// EventListener allows plugins to react to agent events.
+// Plugins can optionally implement this interface; once the host connects its
+// services, the plugin is subscribed automatically and stays subscribed across
+// host reconnects.
+type EventListener interface {
+	PluginTool
+	// AgentEvents lists the event types to receive; nil receives all of them
+	AgentEvents() []AgentEventType
+	// OnAgentEvent is called for each event, one at a time
+	OnAgentEvent(event AgentEvent)
+}
+
+// encodeAgentEvent converts an event for the wire.
+func encodeAgentEvent(event AgentEvent) (*ProtoAgentEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.EventType(), err)
+	}
+	return &ProtoAgentEvent{Type: string(event.EventType()), PayloadJson: string(payload)}, nil
+}
+
+// decodeAgentEvent converts an event received over the wire. It returns nil for
+// event types this version of the package does not know.
+func decodeAgentEvent(pe *ProtoAgentEvent) (AgentEvent, error) {
+	var event AgentEvent
+	var err error
+	switch AgentEventType(pe.Type) {
+	case EventLocationChanged:
+		event, err = decodeEventPayload[LocationChangedEvent](pe.PayloadJson)
+	case EventSettingsChanged:
+		event, err = decodeEventPayload[SettingsChangedEvent](pe.PayloadJson)
+	case EventAgentSwitched:
+		event, err = decodeEventPayload[AgentSwitchedEvent](pe.PayloadJson)
+	case EventConversationStarted:
+		event, err = decodeEventPayload[ConversationStartedEvent](pe.PayloadJson)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s event: %w", pe.Type, err)
+	}
+	return event, nil
+}
+
+func decodeEventPayload[E AgentEvent](payload string) (AgentEvent, error) {
+	var event E
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// listenForEvents keeps listener subscribed to host events until ctx ends or the
+// host turns out not to provide them.
+func listenForEvents(ctx context.Context, host HostServices, listener EventListener) {
+	for {
+		err := host.SubscribeEvents(ctx, listener.AgentEvents(), func(event AgentEvent) error {
+			listener.OnAgentEvent(event)
+			return nil
+		})
+		if errors.Is(err, ErrHostServiceUnavailable) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventResubscribeDelay):
+		}
+	}
+}
+
+func (UnimplementedHostServices) SubscribeEvents(ctx context.Context, types []AgentEventType, handle func(AgentEvent) error) error {
+	return ErrHostServiceUnavailable
+}
+
+// SubscribeEvents only forwards the requested event types, even if the host sends others.
+func (s *hostServer) SubscribeEvents(req *HostSubscribeEventsRequest, stream HostService_SubscribeEventsServer) error {
+	types := make([]AgentEventType, len(req.Types))
+	for i, t := range req.Types {
+		types[i] = AgentEventType(t)
+	}
+	err := s.Impl.SubscribeEvents(stream.Context(), types, func(event AgentEvent) error {
+		if len(types) > 0 && !slices.Contains(types, event.EventType()) {
+			return nil
+		}
+		pe, err := encodeAgentEvent(event)
+		if err != nil {
+			return err
+		}
+		return stream.Send(pe)
+	})
+	if st := hostStatus(err); st != nil {
+		return st
+	}
+	return err
+}
+
+func (h *hostClient) SubscribeEvents(ctx context.Context, types []AgentEventType, handle func(AgentEvent) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req := &HostSubscribeEventsRequest{Types: make([]string, len(types))}
+	for i, t := range types {
+		req.Types[i] = string(t)
+	}
+	stream, err := h.client.SubscribeEvents(h.outgoing(ctx), req)
+	if err != nil {
+		return hostSentinel(err)
+	}
+	for {
+		pe, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return hostSentinel(err)
+		}
+		event, err := decodeAgentEvent(pe)
+		if err != nil {
+			return err
+		}
+		if event == nil {
+			continue
+		}
+		if err := handle(event); err != nil {
+			return err
+		}
+	}
+}
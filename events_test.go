@@ -0,0 +1,102 @@
+package pluginapi
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// broadcastHostServices sends every published event to its subscribers.
+type broadcastHostServices struct {
+	UnimplementedHostServices
+	subscribed chan struct{}
+	events     chan AgentEvent
+}
+
+func (h *broadcastHostServices) SubscribeEvents(ctx context.Context, types []AgentEventType, handle func(AgentEvent) error) error {
+	h.subscribed <- struct{}{}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-h.events:
+			if err := handle(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+type listenerTestTool struct {
+	BasePlugin
+	received chan AgentEvent
+}
+
+func (t *listenerTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *listenerTestTool) AgentEvents() []AgentEventType {
+	return []AgentEventType{EventLocationChanged, EventSettingsChanged}
+}
+
+func (t *listenerTestTool) OnAgentEvent(event AgentEvent) {
+	t.received <- event
+}
+
+func TestEventListener(t *testing.T) {
+	host := &broadcastHostServices{subscribed: make(chan struct{}, 1), events: make(chan AgentEvent)}
+	tool := &listenerTestTool{received: make(chan AgentEvent, 4)}
+	client := newTestClient(t, tool)
+	if err := client.ConnectHostServices(context.Background(), newTestHost(t, host), "weather"); err != nil {
+		t.Fatalf("ConnectHostServices failed: %v", err)
+	}
+
+	select {
+	case <-host.subscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("plugin did not subscribe to events")
+	}
+
+	host.events <- ConversationStartedEvent{ConversationID: "c1"}
+	host.events <- LocationChangedEvent{Previous: "Home", Current: "Office"}
+	host.events <- SettingsChangedEvent{Keys: []string{"units"}}
+
+	want := []AgentEvent{
+		LocationChangedEvent{Previous: "Home", Current: "Office"},
+		SettingsChangedEvent{Keys: []string{"units"}},
+	}
+	for _, w := range want {
+		select {
+		case got := <-tool.received:
+			if !reflect.DeepEqual(got, w) {
+				t.Errorf("received %#v, want %#v", got, w)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s event", w.EventType())
+		}
+	}
+}
+
+func TestDecodeAgentEvent(t *testing.T) {
+	for _, event := range []AgentEvent{
+		LocationChangedEvent{Current: "Home"},
+		SettingsChangedEvent{},
+		AgentSwitchedEvent{Previous: "default", Current: "music"},
+		ConversationStartedEvent{ConversationID: "c1"},
+	} {
+		pe, err := encodeAgentEvent(event)
+		if err != nil {
+			t.Fatalf("encode failed: %v", err)
+		}
+		decoded, err := decodeAgentEvent(pe)
+		if err != nil || !reflect.DeepEqual(decoded, event) {
+			t.Errorf("round trip of %#v gave %#v, %v", event, decoded, err)
+		}
+	}
+
+	if event, err := decodeAgentEvent(&ProtoAgentEvent{Type: "future_event", PayloadJson: "{}"}); event != nil || err != nil {
+		t.Errorf("unknown events should be skipped, got %#v, %v", event, err)
+	}
+}
@@ -0,0 +1,90 @@
+package pluginapi
+
+import (
+	"encoding/json"
+	"maps"
+)
+
+// ToolExample is a few-shot example of a call, which hosts can include in prompts
+// to show the model how the tool is meant to be called.
+//
+// Example plugin.yaml:
+//
+//	tool_definition:
+//	  examples:
+//	    - args: {location: "Berlin"}
+//	      summary: Current weather in Berlin in celsius
+//	  operations:
+//	    create_track:
+//	      examples:
+//	        - args: {name: "Bass", type: "midi"}
+//	          summary: Adds an empty MIDI track named Bass
+type ToolExample struct {
+	// Args are the call's arguments
+	Args map[string]interface{} `yaml:"args"`
+	// Summary describes what the call does or returns
+	Summary string `yaml:"summary"`
+}
+
+// operationExamples returns the examples of operation with the operation argument
+// filled in, so they are complete calls of the tool.
+func operationExamples(operation string, examples []ToolExample) []ToolExample {
+	if len(examples) == 0 {
+		return nil
+	}
+	out := make([]ToolExample, len(examples))
+	for i, example := range examples {
+		args := maps.Clone(example.Args)
+		if args == nil {
+			args = map[string]interface{}{}
+		}
+		if _, ok := args["operation"]; !ok {
+			args["operation"] = operation
+		}
+		out[i] = ToolExample{Args: args, Summary: example.Summary}
+	}
+	return out
+}
+
+// validateExamples records examples without a summary, or with arguments that are
+// not among params, in errs under path.
+func validateExamples(path string, examples []ToolExample, params []YAMLToolParameter, errs *ValidationErrors) {
+	for i, example := range examples {
+		if example.Summary == "" {
+			errs.Add(path, "example %d: summary is required", i+1)
+		}
+		for name := range example.Args {
+			if _, ok := findParameter(params, name); !ok {
+				errs.Add(path, "example %d: unknown parameter %q", i+1, name)
+			}
+		}
+	}
+}
+
+func toolExamplesToProto(examples []ToolExample) ([]*ProtoToolExample, error) {
+	if len(examples) == 0 {
+		return nil, nil
+	}
+	out := make([]*ProtoToolExample, len(examples))
+	for i, example := range examples {
+		args, err := json.Marshal(example.Args)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = &ProtoToolExample{ArgsJson: string(args), Summary: example.Summary}
+	}
+	return out, nil
+}
+
+// toolExamplesFromProto converts examples received from a plugin, skipping ones with malformed arguments.
+func toolExamplesFromProto(examples []*ProtoToolExample) []ToolExample {
+	var out []ToolExample
+	for _, example := range examples {
+		var args map[string]interface{}
+		if err := json.Unmarshal([]byte(example.ArgsJson), &args); err != nil {
+			continue
+		}
+		out = append(out, ToolExample{Args: args, Summary: example.Summary})
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package pluginapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const examplesTestYAML = `
+name: daw
+description: Controls the DAW
+parameters:
+  - name: operation
+    type: string
+    description: Operation to perform
+    required: true
+examples:
+  - args: {operation: list_tracks}
+    summary: Lists all tracks
+operations:
+  create_track:
+    parameters:
+      - name: name
+        type: string
+        description: Track name
+        required: true
+    examples:
+      - args: {name: Bass}
+        summary: Adds an empty track named Bass
+  list_tracks: {}
+`
+
+type examplesTestTool struct {
+	plainTestTool
+	toolDef *YAMLToolDefinition
+}
+
+func (t *examplesTestTool) Definition() Tool {
+	tool, _ := t.toolDef.ToToolDefinition()
+	return tool
+}
+
+func (t *examplesTestTool) GetOperations() []OperationInfo {
+	return GetOperationsFromYAML(t.toolDef)
+}
+
+func TestToolExamples(t *testing.T) {
+	var toolDef YAMLToolDefinition
+	if err := yaml.Unmarshal([]byte(examplesTestYAML), &toolDef); err != nil {
+		t.Fatalf("failed to parse YAML: %v", err)
+	}
+	if err := ValidateYAMLToolDefinition(&toolDef); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	client := newTestClient(t, &examplesTestTool{toolDef: &toolDef})
+	tool := client.DefinitionCtx(context.Background())
+	if len(tool.Examples) != 1 || tool.Examples[0].Args["operation"] != "list_tracks" || tool.Examples[0].Summary != "Lists all tracks" {
+		t.Errorf("unexpected tool examples: %+v", tool.Examples)
+	}
+
+	ops := client.GetOperationsCtx(context.Background())
+	if len(ops) != 2 || ops[0].Name != "create_track" {
+		t.Fatalf("unexpected operations: %+v", ops)
+	}
+	examples := ops[0].Examples
+	if len(examples) != 1 || examples[0].Args["name"] != "Bass" || examples[0].Summary != "Adds an empty track named Bass" {
+		t.Fatalf("unexpected operation examples: %+v", examples)
+	}
+	if examples[0].Args["operation"] != "create_track" {
+		t.Errorf("expected the operation argument to be filled in, got %v", examples[0].Args)
+	}
+	if _, ok := toolDef.Operations["create_track"].Examples[0].Args["operation"]; ok {
+		t.Error("filling in the operation should not modify the YAML definition")
+	}
+	if len(ops[1].Examples) != 0 {
+		t.Errorf("expected no examples for list_tracks, got %+v", ops[1].Examples)
+	}
+}
+
+func TestToolExamples_Validation(t *testing.T) {
+	var toolDef YAMLToolDefinition
+	if err := yaml.Unmarshal([]byte(examplesTestYAML), &toolDef); err != nil {
+		t.Fatalf("failed to parse YAML: %v", err)
+	}
+	op := toolDef.Operations["create_track"]
+	op.Examples = append(op.Examples, ToolExample{Args: map[string]interface{}{"volume": 3}})
+	toolDef.Operations["create_track"] = op
+
+	err := ValidateYAMLToolDefinition(&toolDef)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"example 2: summary is required", `example 2: unknown parameter "volume"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+}
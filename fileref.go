@@ -0,0 +1,87 @@
+package pluginapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// NewFileReference describes the file at path as a by-reference attachment, for
+// hosts and plugins that share a filesystem. Only the path and size travel over
+// the RPC; the receiver reads the content with Open.
+//
+// Example:
+//
+//	pack, err := pluginapi.NewFileReference("/samples/drums.zip", "application/zip")
+//	result, err := tool.CallWithFiles(ctx, args, []pluginapi.FileAttachment{pack})
+func NewFileReference(path, mimeType string) (FileAttachment, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return FileAttachment{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return FileAttachment{}, err
+	}
+	if !info.Mode().IsRegular() {
+		return FileAttachment{}, fmt.Errorf("attachment %s: not a regular file", path)
+	}
+	return FileAttachment{
+		Name: filepath.Base(path),
+		Type: mimeType,
+		Size: info.Size(),
+		URI:  path,
+	}, nil
+}
+
+// IsReference reports whether f carries a URI instead of its content.
+func (f FileAttachment) IsReference() bool {
+	return f.URI != "" && len(f.Content) == 0
+}
+
+// Open returns a reader for the attachment's raw content, whether it was sent
+// inline (decompressing it if needed) or by reference. References are read
+// lazily from disk, so large files never have to be held in memory.
+// The caller must close the reader.
+func (f FileAttachment) Open() (io.ReadCloser, error) {
+	if !f.IsReference() {
+		switch f.Encoding {
+		case "":
+			return io.NopCloser(bytes.NewReader(f.Content)), nil
+		case AttachmentEncodingGzip:
+			r, err := gzip.NewReader(bytes.NewReader(f.Content))
+			if err != nil {
+				return nil, fmt.Errorf("attachment %s: %w", f.Name, err)
+			}
+			return r, nil
+		default:
+			return nil, fmt.Errorf("attachment %s: unsupported encoding %q", f.Name, f.Encoding)
+		}
+	}
+
+	path, err := referencePath(f.URI)
+	if err != nil {
+		return nil, fmt.Errorf("attachment %s: %w", f.Name, err)
+	}
+	return os.Open(path)
+}
+
+// referencePath resolves an attachment URI, either an absolute path or a
+// file:// URI, to a local path.
+func referencePath(uri string) (string, error) {
+	if filepath.IsAbs(uri) {
+		return uri, nil
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" || (u.Host != "" && u.Host != "localhost") {
+		return "", fmt.Errorf("unsupported attachment URI %q: want an absolute path or file:// URI", uri)
+	}
+	return filepath.FromSlash(u.Path), nil
+}
@@ -0,0 +1,87 @@
+package pluginapi
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileAttachment_Open(t *testing.T) {
+	inline := FileAttachment{Name: "a.txt", Content: []byte("inline")}
+	compressed, err := inline.Compress()
+	if err != nil {
+		t.Fatalf("Compress failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "pack.bin")
+	if err := os.WriteFile(path, []byte("on disk"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ref, err := NewFileReference(path, "application/octet-stream")
+	if err != nil {
+		t.Fatalf("NewFileReference failed: %v", err)
+	}
+	if !ref.IsReference() || ref.Size != 7 || ref.Name != "pack.bin" {
+		t.Errorf("unexpected reference: %+v", ref)
+	}
+	if again, _ := ref.Compress(); again.Encoding != "" {
+		t.Error("compressing a reference should be a no-op")
+	}
+
+	fileURI := FileAttachment{Name: "pack.bin", URI: "file://" + filepath.ToSlash(path)}
+	for _, tc := range []struct {
+		file FileAttachment
+		want string
+	}{
+		{inline, "inline"},
+		{compressed, "inline"},
+		{ref, "on disk"},
+		{fileURI, "on disk"},
+	} {
+		r, err := tc.file.Open()
+		if err != nil {
+			t.Errorf("Open(%+v) failed: %v", tc.file, err)
+			continue
+		}
+		content, err := io.ReadAll(r)
+		_ = r.Close()
+		if err != nil || string(content) != tc.want {
+			t.Errorf("Open(%s) read %q, %v; want %q", tc.file.URI, content, err, tc.want)
+		}
+	}
+
+	for _, uri := range []string{"relative/path", "https://example.com/pack.bin"} {
+		if _, err := (FileAttachment{URI: uri}).Open(); err == nil {
+			t.Errorf("expected error opening %q", uri)
+		}
+	}
+	if _, err := NewFileReference(t.TempDir(), ""); err == nil {
+		t.Error("expected error referencing a directory")
+	}
+}
+
+func TestGRPCClient_CallWithFileReference(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "big.wav")
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", 5000)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ref, err := NewFileReference(path, "audio/wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := newTestClient(t, &fileSizeTestTool{})
+	for _, call := range []func(context.Context, string, []FileAttachment) (string, error){client.CallWithFiles, client.CallWithFilesStream} {
+		result, err := call(context.Background(), "{}", []FileAttachment{ref})
+		if err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+		// The plugin sees the size but no inline content
+		if result != `big.wav:5000:0:"" ` {
+			t.Errorf("unexpected result %q", result)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+)
+
+// FileWatch declares a directory the host should watch on the plugin's behalf.
+type FileWatch struct {
+	// Path is the absolute directory to watch
+	Path string `json:"path"`
+	// Glob optionally filters events by file name (e.g., "*.rpp"); empty matches everything
+	Glob string `json:"glob,omitempty"`
+	// Recursive includes subdirectories
+	Recursive bool `json:"recursive,omitempty"`
+}
+
+// FileChangeOp describes what happened to a watched file.
+type FileChangeOp string
+
+const (
+	FileCreated  FileChangeOp = "created"
+	FileModified FileChangeOp = "modified"
+	FileDeleted  FileChangeOp = "deleted"
+	FileRenamed  FileChangeOp = "renamed"
+)
+
+// FileChangeEvent is a single change to a watched file.
+type FileChangeEvent struct {
+	// Path is the absolute path of the changed file
+	Path string `json:"path"`
+	// Op is the kind of change
+	Op FileChangeOp `json:"op"`
+	// OldPath is the previous path for FileRenamed events
+	OldPath string `json:"old_path,omitempty"`
+}
+
+// FileWatchProvider allows plugins to react to file changes without polling.
+// Plugins can optionally implement this interface to have the host watch directories
+// and deliver change events, e.g. to keep a project index current.
+//
+// Watching requires the file access permission (see PermissionProvider);
+// the host rejects watches from plugins that don't declare it.
+type FileWatchProvider interface {
+	// GetFileWatches returns the directories to watch
+	GetFileWatches() []FileWatch
+
+	// OnFileChanges is called with batches of change events.
+	// Hosts may coalesce rapid changes, so events are hints to re-scan rather than an exact log.
+	OnFileChanges(ctx context.Context, events []FileChangeEvent) error
+}
+
+// FileChangeStream delivers batches of file change events to a plugin over a single stream.
+// Hosts forwarding a continuous watcher feed should prefer this over repeated OnFileChanges calls.
+type FileChangeStream interface {
+	// Send delivers a batch and waits for the plugin to handle it
+	Send(events []FileChangeEvent) error
+	// Close ends the stream
+	Close() error
+}
+
+// Validate checks that the watch is well-formed.
+func (w FileWatch) Validate() error {
+	if w.Path == "" {
+		return fmt.Errorf("watch path is required")
+	}
+	if !filepath.IsAbs(w.Path) {
+		return fmt.Errorf("watch path must be absolute (got %q)", w.Path)
+	}
+	if w.Glob != "" {
+		if _, err := filepath.Match(w.Glob, ""); err != nil {
+			return fmt.Errorf("invalid watch glob %q: %w", w.Glob, err)
+		}
+	}
+	return nil
+}
+
+// ValidateFileWatches checks each watch and that the plugin's permissions allow file access.
+func ValidateFileWatches(watches []FileWatch, permissions PluginPermissions) error {
+	if len(watches) == 0 {
+		return nil
+	}
+	if !permissions.FileAccess {
+		return fmt.Errorf("file watches require the %s permission", PermissionFileAccess)
+	}
+	for i, w := range watches {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("watch[%d]: %w", i, err)
+		}
+		if !permissions.AllowsPath(w.Path, false) {
+			return fmt.Errorf("watch[%d]: %w: %s", i, ErrOutsideSandbox, w.Path)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the watch covers path.
+// Hosts can use this to route events from a shared watcher to the right plugins.
+func (w FileWatch) Matches(path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(w.Path), filepath.Clean(path))
+	if err != nil || rel == "." || rel == ".." || len(rel) > 2 && rel[:3] == ".."+string(filepath.Separator) {
+		return false
+	}
+	if !w.Recursive && filepath.Dir(rel) != "." {
+		return false
+	}
+	if w.Glob == "" {
+		return true
+	}
+	matched, err := filepath.Match(w.Glob, filepath.Base(path))
+	return err == nil && matched
+}
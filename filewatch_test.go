@@ -0,0 +1,151 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestValidateFileWatches(t *testing.T) {
+	dir := t.TempDir()
+	allowed := PluginPermissions{FileAccess: true}
+
+	tests := []struct {
+		name        string
+		watches     []FileWatch
+		permissions PluginPermissions
+		wantErr     bool
+	}{
+		{name: "valid", watches: []FileWatch{{Path: dir, Glob: "*.rpp", Recursive: true}}, permissions: allowed},
+		{name: "no watches", permissions: PluginPermissions{}},
+		{name: "missing permission", watches: []FileWatch{{Path: dir}}, wantErr: true},
+		{name: "relative path", watches: []FileWatch{{Path: "projects"}}, permissions: allowed, wantErr: true},
+		{name: "bad glob", watches: []FileWatch{{Path: dir, Glob: "[a-"}}, permissions: allowed, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFileWatches(tt.watches, tt.permissions)
+			if tt.wantErr && err == nil {
+				t.Error("expected validation error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFileWatch_Matches(t *testing.T) {
+	dir := t.TempDir()
+	flat := FileWatch{Path: dir, Glob: "*.rpp"}
+	deep := FileWatch{Path: dir, Recursive: true}
+
+	if !flat.Matches(filepath.Join(dir, "song.rpp")) {
+		t.Error("expected direct child matching glob to match")
+	}
+	if flat.Matches(filepath.Join(dir, "song.wav")) {
+		t.Error("expected glob to filter non-matching file")
+	}
+	if flat.Matches(filepath.Join(dir, "sub", "song.rpp")) {
+		t.Error("expected non-recursive watch to ignore subdirectories")
+	}
+	if !deep.Matches(filepath.Join(dir, "sub", "song.wav")) {
+		t.Error("expected recursive watch to match nested file")
+	}
+	if deep.Matches(filepath.Join(filepath.Dir(dir), "other.rpp")) {
+		t.Error("expected path outside the watch to not match")
+	}
+}
+
+type fileWatchTestTool struct {
+	BasePlugin
+	dir    string
+	mu     sync.Mutex
+	events []FileChangeEvent
+}
+
+func (t *fileWatchTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *fileWatchTestTool) GetRequiredPermissions() PluginPermissions {
+	return PluginPermissions{FileAccess: true}
+}
+
+func (t *fileWatchTestTool) GetFileWatches() []FileWatch {
+	return []FileWatch{{Path: t.dir, Glob: "*.rpp", Recursive: true}}
+}
+
+func (t *fileWatchTestTool) OnFileChanges(ctx context.Context, events []FileChangeEvent) error {
+	for _, e := range events {
+		if e.Op == "" {
+			return fmt.Errorf("missing op for %s", e.Path)
+		}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, events...)
+	return nil
+}
+
+func TestGRPCClient_FileWatch(t *testing.T) {
+	tool := &fileWatchTestTool{dir: t.TempDir()}
+	client := newTestClient(t, tool)
+
+	watches := client.GetFileWatches()
+	if len(watches) != 1 || watches[0].Path != tool.dir || !watches[0].Recursive {
+		t.Fatalf("unexpected watches: %+v", watches)
+	}
+
+	stream, err := client.OpenFileChangeStream(context.Background())
+	if err != nil {
+		t.Fatalf("OpenFileChangeStream failed: %v", err)
+	}
+	if err := stream.Send([]FileChangeEvent{{Path: filepath.Join(tool.dir, "a.rpp"), Op: FileCreated}}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if err := stream.Send([]FileChangeEvent{{Path: filepath.Join(tool.dir, "b.rpp")}}); err == nil {
+		t.Error("expected handler error to be reported for the batch")
+	}
+	if err := stream.Send([]FileChangeEvent{
+		{Path: filepath.Join(tool.dir, "c.rpp"), Op: FileRenamed, OldPath: filepath.Join(tool.dir, "a.rpp")},
+	}); err != nil {
+		t.Fatalf("Send after handler error failed: %v", err)
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if err := client.OnFileChanges(context.Background(), []FileChangeEvent{{Path: filepath.Join(tool.dir, "c.rpp"), Op: FileDeleted}}); err != nil {
+		t.Fatalf("OnFileChanges failed: %v", err)
+	}
+
+	tool.mu.Lock()
+	defer tool.mu.Unlock()
+	if len(tool.events) != 3 || tool.events[1].OldPath == "" || tool.events[2].Op != FileDeleted {
+		t.Errorf("unexpected delivered events: %+v", tool.events)
+	}
+}
+
+func TestGRPCClient_FileWatchRequiresPermission(t *testing.T) {
+	// Watches from a plugin that does not declare file access are rejected
+	client := newTestClient(t, &unpermittedWatchTool{})
+	if watches := client.GetFileWatches(); watches != nil {
+		t.Errorf("expected watches to be rejected without file access, got %+v", watches)
+	}
+}
+
+type unpermittedWatchTool struct {
+	plainTestTool
+}
+
+func (t *unpermittedWatchTool) GetFileWatches() []FileWatch {
+	return []FileWatch{{Path: "/tmp"}}
+}
+
+func (t *unpermittedWatchTool) OnFileChanges(ctx context.Context, events []FileChangeEvent) error {
+	return nil
+}
@@ -0,0 +1,298 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// hostTokenMetadataKey carries the plugin's host token on every HostService request.
+const hostTokenMetadataKey = "ori-host-token"
+
+// ErrHostServiceUnavailable is returned by HostServices methods when the plugin is not
+// connected to the host, or the host does not provide the service.
+// Plugins should treat host services as best-effort and degrade gracefully.
+var ErrHostServiceUnavailable = errors.New("host service unavailable")
+
+// LogLevel is the severity of a message logged through HostServices.
+type LogLevel string
+
+const (
+	LogDebug LogLevel = "debug"
+	LogInfo  LogLevel = "info"
+	LogWarn  LogLevel = "warn"
+	LogError LogLevel = "error"
+)
+
+// HostServices lets plugins call back into the agent.
+// Plugins get it from BasePlugin.Host() (or by implementing HostAwareTool); every
+// method returns ErrHostServiceUnavailable until the host has connected.
+//
+// Hosts implement it to serve the callbacks (see RegisterHostServices) and should
+// embed UnimplementedHostServices so they keep compiling as services are added.
+type HostServices interface {
+	// Log writes a message to the agent's log, attributed to the calling plugin
+	Log(ctx context.Context, level LogLevel, message string, fields map[string]string) error
+	// Complete asks the agent's active model for a completion (see Summarize).
+	// Returns ErrTokenBudgetExceeded once the plugin has used up its token budget.
+	Complete(ctx context.Context, req CompletionRequest) (Completion, error)
+	// CallTool invokes another tool registered with the agent and returns its result.
+	// Call metadata in ctx (e.g., dry run) is forwarded; ErrToolCallLoop is returned
+	// if the call would re-enter a tool already on the call stack.
+	CallTool(ctx context.Context, name, argsJSON string) (string, error)
+	// GetConversationHistory returns up to limit recent messages of the conversation the
+	// current call was made from, oldest first. Pass the call's ctx so the host can tell
+	// which conversation that is. The host caps limit at MaxConversationHistoryLimit
+	// and may redact messages.
+	GetConversationHistory(ctx context.Context, limit int) ([]ConversationMessage, error)
+	// Remember stores text in the agent's memory under key, replacing any earlier
+	// memory with the same key. Hosts keep each plugin's memories separate.
+	Remember(ctx context.Context, key, text string, tags []string) error
+	// Recall returns up to k memories most relevant to query, best first
+	// (k of 0 uses DefaultRecallLimit).
+	Recall(ctx context.Context, query string, k int) ([]MemoryRecord, error)
+	// Embeddings returns one vector per text from the agent's embedding model,
+	// so plugins can offer semantic search without their own model credentials.
+	// Vectors from the same host are comparable across calls.
+	Embeddings(ctx context.Context, texts []string) ([][]float32, error)
+	// Notify shows a notification in the agent UI, e.g. when a background export finishes.
+	// Unlike a notification result, it can be sent at any time, not just as a call's result.
+	Notify(ctx context.Context, level NotificationLevel, title, body string) error
+	// SubscribeEvents calls handle for each agent event of the given types (all types
+	// if none are given) until ctx ends or handle returns an error. Most plugins
+	// implement EventListener instead of calling it directly.
+	SubscribeEvents(ctx context.Context, types []AgentEventType, handle func(AgentEvent) error) error
+	// SendToPlugin sends a message on topic to the plugin named target, or with an
+	// empty target, to every plugin subscribed to topic (see MessageBus).
+	SendToPlugin(ctx context.Context, target, topic string, payload []byte) error
+	// SubscribeMessages calls handle for each message from other plugins on the given
+	// topics (all topics if none are given) until ctx ends or handle returns an error.
+	// Most plugins implement MessageListener instead of calling it directly.
+	SubscribeMessages(ctx context.Context, topics []string, handle func(PluginMessage) error) error
+	// DefinitionChanged tells the agent that the plugin's tool definitions changed at
+	// runtime (see DynamicDefinitionProvider). Hosts drop their cached definition with
+	// Invalidate and fetch it again before offering the tool to the model.
+	DefinitionChanged(ctx context.Context, version string) error
+	// GetSecret reads the plugin's secret stored under key from the agent's keychain.
+	// Returns ErrSecretNotFound if there is none. Hosts keep each plugin's secrets separate.
+	GetSecret(ctx context.Context, key string) (string, error)
+	// SetSecret stores value under key in the agent's keychain, replacing any earlier
+	// value. An empty value deletes the secret.
+	SetSecret(ctx context.Context, key, value string) error
+	// RequestAuthorization has the user authorize the plugin with an OAuth provider
+	// (see OAuthHelper). For the device flow (UserCode set), the host shows the URL
+	// and code and returns right away; otherwise it opens URL in a browser and
+	// returns the URL the browser was redirected to once it reaches RedirectURL.
+	RequestAuthorization(ctx context.Context, req AuthorizationRequest) (string, error)
+	// ReportUsage records upstream API consumption for the current call (see Usage),
+	// for per-plugin dashboards. Returns ErrUsageBudgetExceeded once the plugin is
+	// over a budget the host set.
+	ReportUsage(ctx context.Context, usage Usage) error
+}
+
+// HostAwareTool allows plugins to receive the agent's HostServices.
+// BasePlugin implements it; override SetHostServices only to react to the connection.
+type HostAwareTool interface {
+	PluginTool
+	// SetHostServices is called when the host connects (or reconnects) its services
+	SetHostServices(host HostServices)
+}
+
+// UnimplementedHostServices returns ErrHostServiceUnavailable from every method.
+type UnimplementedHostServices struct{}
+
+func (UnimplementedHostServices) Log(ctx context.Context, level LogLevel, message string, fields map[string]string) error {
+	return ErrHostServiceUnavailable
+}
+
+// HostCallerToken returns the token of the plugin making a HostService call,
+// as given to ConnectHostServices. Hosts use it to tell plugins apart.
+func HostCallerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(hostTokenMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// RegisterHostServices serves impl to plugins on server.
+// Pass the server's address to each plugin with the client's ConnectHostServices.
+//
+// Example:
+//
+//	server := grpc.NewServer()
+//	pluginapi.RegisterHostServices(server, &agentHostServices{})
+//	go server.Serve(lis)
+//	err := client.ConnectHostServices(ctx, lis.Addr().String(), pluginID)
+func RegisterHostServices(server grpc.ServiceRegistrar, impl HostServices) {
+	RegisterHostServiceServer(server, &hostServer{Impl: impl})
+}
+
+// =============================================================================
+// Host Services - Server Side (runs in the agent)
+// =============================================================================
+
+// hostServer adapts HostServices to the generated HostServiceServer.
+type hostServer struct {
+	UnimplementedHostServiceServer
+	Impl HostServices
+}
+
+// hostStatusCodes maps the sentinel errors of host services to the gRPC status codes
+// that carry them to the plugin. ErrHostServiceUnavailable uses Unimplemented, the
+// same status a host built before the service existed returns.
+var hostStatusCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{ErrHostServiceUnavailable, codes.Unimplemented},
+	{ErrTokenBudgetExceeded, codes.ResourceExhausted},
+	{ErrToolCallLoop, codes.Aborted},
+	{ErrSecretNotFound, codes.NotFound},
+	{ErrUsageBudgetExceeded, codes.FailedPrecondition},
+}
+
+// hostStatus returns the status error for a sentinel host service error, or nil for any other error.
+func hostStatus(err error) error {
+	for _, sc := range hostStatusCodes {
+		if errors.Is(err, sc.err) {
+			return status.Error(sc.code, err.Error())
+		}
+	}
+	return nil
+}
+
+// hostSentinel returns the sentinel error carried by a status error from the host, or err itself.
+// The host's message is kept, so details like the looping call chain are not lost.
+func hostSentinel(err error) error {
+	st := status.Convert(err)
+	for _, sc := range hostStatusCodes {
+		if st.Code() == sc.code {
+			return &hostStatusError{sentinel: sc.err, message: st.Message()}
+		}
+	}
+	return err
+}
+
+// hostStatusError is a sentinel error received from the host, with the host's message.
+type hostStatusError struct {
+	sentinel error
+	message  string
+}
+
+func (e *hostStatusError) Error() string {
+	return e.message
+}
+
+func (e *hostStatusError) Unwrap() error {
+	return e.sentinel
+}
+
+// hostResponse reports the outcome of a host service call.
+func hostResponse(err error) (*ConfigResponse, error) {
+	if st := hostStatus(err); st != nil {
+		return nil, st
+	}
+	if err != nil {
+		return &ConfigResponse{Success: false, Error: err.Error()}, nil
+	}
+	return &ConfigResponse{Success: true}, nil
+}
+
+func (s *hostServer) Log(ctx context.Context, req *HostLogRequest) (*ConfigResponse, error) {
+	return hostResponse(s.Impl.Log(ctx, LogLevel(req.Level), req.Message, req.Fields))
+}
+
+// =============================================================================
+// Host Services - Client Side (runs in the plugin)
+// =============================================================================
+
+// hostClient is the plugin's handle on the agent's HostService.
+type hostClient struct {
+	client HostServiceClient
+	token  string
+}
+
+// outgoing attaches the plugin's host token to ctx.
+func (h *hostClient) outgoing(ctx context.Context) context.Context {
+	ctx = withTraceMetadata(ctx)
+	if id := InvocationID(ctx); id != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, invocationIDMetadataKey, id)
+	}
+	return metadata.AppendToOutgoingContext(ctx, hostTokenMetadataKey, h.token)
+}
+
+// hostError converts the result of a host service call to an error.
+func hostError(resp *ConfigResponse, err error) error {
+	if err != nil {
+		return hostSentinel(err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
+func (h *hostClient) Log(ctx context.Context, level LogLevel, message string, fields map[string]string) error {
+	return hostError(h.client.Log(h.outgoing(ctx), &HostLogRequest{
+		Level:   string(level),
+		Message: message,
+		Fields:  fields,
+	}))
+}
+
+// hostConnection owns the plugin's connection to the agent's HostService.
+// The zero value is ready to use.
+type hostConnection struct {
+	mu     sync.Mutex
+	conn   *grpc.ClientConn
+	client HostServices       // Services on conn, handed to the plugin
+	stop   context.CancelFunc // Ends background work on conn, such as event subscriptions
+}
+
+// connect dials address, replacing any previous connection. The connection uses
+// TLS when the plugin is served with TLS (see hostCredentialsFromEnv).
+// The returned context ends when the connection is replaced.
+func (c *hostConnection) connect(address, token string) (HostServices, context.Context, error) {
+	creds, err := hostCredentialsFromEnv()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid TLS configuration for host services: %w", err)
+	}
+	conn, err := grpc.NewClient(dialTarget(address), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to host services at %s: %w", address, err)
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	client := &hostClient{client: NewHostServiceClient(conn), token: token}
+
+	c.mu.Lock()
+	previous, stopPrevious := c.conn, c.stop
+	c.conn, c.client, c.stop = conn, client, stop
+	c.mu.Unlock()
+	if previous != nil {
+		stopPrevious()
+		_ = previous.Close()
+	}
+
+	return client, ctx, nil
+}
+
+// current returns the connected host's services, or UnimplementedHostServices
+// before the host has connected.
+func (c *hostConnection) current() HostServices {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client == nil {
+		return UnimplementedHostServices{}
+	}
+	return c.client
+}
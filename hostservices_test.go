@@ -0,0 +1,108 @@
+package pluginapi
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+type recordingHostServices struct {
+	UnimplementedHostServices
+	mu      sync.Mutex
+	entries []string
+	tokens  []string
+}
+
+func (h *recordingHostServices) Log(ctx context.Context, level LogLevel, message string, fields map[string]string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, string(level)+": "+message+" path="+fields["path"])
+	h.tokens = append(h.tokens, HostCallerToken(ctx))
+	return nil
+}
+
+// newTestHost serves impl as the agent's HostService on a loopback port and returns its address.
+func newTestHost(t *testing.T, impl HostServices, opts ...grpc.ServerOption) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer(opts...)
+	RegisterHostServices(server, impl)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+type hostLoggingTestTool struct {
+	BasePlugin
+}
+
+func (t *hostLoggingTestTool) Call(ctx context.Context, args string) (string, error) {
+	if err := t.Host().Log(ctx, LogInfo, "scanning", map[string]string{"path": args}); err != nil {
+		return "", err
+	}
+	return "ok", nil
+}
+
+func TestHostServices_Log(t *testing.T) {
+	host := &recordingHostServices{}
+	client := newTestClient(t, &hostLoggingTestTool{})
+
+	if _, err := client.Call(context.Background(), "/tmp"); err == nil || err.Error() != ErrHostServiceUnavailable.Error() {
+		t.Errorf("expected host service unavailable before connecting, got %v", err)
+	}
+
+	if err := client.ConnectHostServices(context.Background(), newTestHost(t, host), "plugin-7"); err != nil {
+		t.Fatalf("ConnectHostServices failed: %v", err)
+	}
+	if _, err := client.Call(context.Background(), "/tmp"); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+
+	if len(host.entries) != 1 || host.entries[0] != "info: scanning path=/tmp" {
+		t.Errorf("entries = %v", host.entries)
+	}
+	if host.tokens[0] != "plugin-7" {
+		t.Errorf("HostCallerToken = %q, want plugin-7", host.tokens[0])
+	}
+}
+
+func TestHostServices_Unimplemented(t *testing.T) {
+	client := newTestClient(t, &hostLoggingTestTool{})
+	if err := client.ConnectHostServices(context.Background(), newTestHost(t, UnimplementedHostServices{}), "plugin-7"); err != nil {
+		t.Fatalf("ConnectHostServices failed: %v", err)
+	}
+
+	_, err := client.Call(context.Background(), "/tmp")
+	if err == nil || err.Error() != ErrHostServiceUnavailable.Error() {
+		t.Errorf("expected host service unavailable, got %v", err)
+	}
+}
+
+func TestBasePlugin_SetHostServicesConcurrently(t *testing.T) {
+	var b BasePlugin
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				b.SetHostServices(UnimplementedHostServices{})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if b.Host() == nil {
+					t.Error("Host returned nil")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
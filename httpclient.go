@@ -0,0 +1,86 @@
+package pluginapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrHostNotAllowed is returned by clients from NewHTTPClient for requests to hosts
+// the plugin's permissions don't allow.
+var ErrHostNotAllowed = errors.New("host not allowed by plugin permissions")
+
+// DefaultHTTPTimeout bounds each request made with a client from NewHTTPClient,
+// including reading the response body.
+const DefaultHTTPTimeout = 30 * time.Second
+
+// NewHTTPClient returns an HTTP client for plugins to use instead of http.DefaultClient.
+// Every request, including redirects, is checked against perms: the plugin must
+// declare network access, and if it lists AllowedHosts, the host must match one.
+// The client uses the HTTP_PROXY, HTTPS_PROXY and NO_PROXY settings the agent
+// starts plugins with, and times out after DefaultHTTPTimeout.
+//
+// Most plugins use BasePlugin.HTTPClient, which applies the plugin.yaml permissions.
+func NewHTTPClient(perms PluginPermissions) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	return &http.Client{
+		Timeout:   DefaultHTTPTimeout,
+		Transport: &allowlistTransport{perms: perms, base: transport},
+	}
+}
+
+// allowlistTransport refuses requests to hosts perms don't allow.
+type allowlistTransport struct {
+	perms PluginPermissions
+	base  http.RoundTripper
+}
+
+func (t *allowlistTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.perms.NetworkAccess {
+		return nil, fmt.Errorf("%w: %s (the plugin does not declare %s)", ErrHostNotAllowed, req.URL.Hostname(), PermissionNetworkAccess)
+	}
+	if !t.perms.AllowsHost(req.URL.Hostname()) {
+		return nil, fmt.Errorf("%w: %s", ErrHostNotAllowed, req.URL.Hostname())
+	}
+	return t.base.RoundTrip(req)
+}
+
+// AllowsHost reports whether p allows requests to host (without a port).
+// Plugins that declare network access without AllowedHosts may reach any host.
+func (p PluginPermissions) AllowsHost(host string) bool {
+	if !p.NetworkAccess {
+		return false
+	}
+	if len(p.AllowedHosts) == 0 {
+		return true
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, pattern := range p.AllowedHosts {
+		pattern = strings.ToLower(pattern)
+		if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+			if strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// validateHosts checks that the allowed hosts are well-formed and come with network access.
+func (p PluginPermissions) validateHosts() error {
+	if len(p.AllowedHosts) > 0 && !p.NetworkAccess {
+		return fmt.Errorf("allowed_hosts requires the %s permission", PermissionNetworkAccess)
+	}
+	for _, pattern := range p.AllowedHosts {
+		name := strings.TrimPrefix(pattern, "*.")
+		if name == "" || strings.ContainsAny(name, "*/:@ ") {
+			return fmt.Errorf("invalid allowed host %q (use a host name like api.example.com or *.example.com)", pattern)
+		}
+	}
+	return nil
+}
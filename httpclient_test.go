@@ -0,0 +1,83 @@
+package pluginapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPluginPermissions_AllowsHost(t *testing.T) {
+	perms := PluginPermissions{NetworkAccess: true, AllowedHosts: []string{"api.example.com", "*.cdn.example.com"}}
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"api.example.com", true},
+		{"API.Example.com.", true},
+		{"img.cdn.example.com", true},
+		{"cdn.example.com", false},
+		{"example.com", false},
+		{"api.example.com.evil.net", false},
+	}
+	for _, tt := range tests {
+		if got := perms.AllowsHost(tt.host); got != tt.want {
+			t.Errorf("AllowsHost(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+	if !(PluginPermissions{NetworkAccess: true}).AllowsHost("anywhere.net") {
+		t.Error("network access without an allowlist should cover every host")
+	}
+	if (PluginPermissions{}).AllowsHost("api.example.com") {
+		t.Error("plugins without network access should reach no host")
+	}
+}
+
+func TestNewHTTPClient_EnforcesAllowlist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, strings.Replace("http://"+r.Host+"/ok", "127.0.0.1", "localhost", 1), http.StatusFound)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(PluginPermissions{NetworkAccess: true, AllowedHosts: []string{"127.0.0.1"}})
+	resp, err := client.Get(server.URL + "/ok")
+	if err != nil {
+		t.Fatalf("request to an allowed host failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != "ok" {
+		t.Errorf("unexpected body %q", body)
+	}
+
+	if _, err := client.Get(server.URL + "/redirect"); !errors.Is(err, ErrHostNotAllowed) {
+		t.Errorf("expected a redirect to another host to be refused, got %v", err)
+	}
+	if _, err := NewHTTPClient(PluginPermissions{}).Get(server.URL + "/ok"); !errors.Is(err, ErrHostNotAllowed) {
+		t.Errorf("expected plugins without network access to be refused, got %v", err)
+	}
+}
+
+func TestBasePlugin_HTTPClient(t *testing.T) {
+	config, err := readPluginConfig(permissionsPluginYAML + "  network_access: true\n  allowed_hosts: [api.example.com]\n")
+	if err != nil {
+		t.Fatalf("readPluginConfig error: %v", err)
+	}
+	var base BasePlugin
+	base.SetPluginConfig(&config)
+	if _, err := base.HTTPClient().Get("http://other.example.com/"); !errors.Is(err, ErrHostNotAllowed) {
+		t.Errorf("expected ErrHostNotAllowed, got %v", err)
+	}
+
+	for _, yaml := range []string{"  allowed_hosts: [api.example.com]\n", "  network_access: true\n  allowed_hosts: [\"https://api.example.com\"]\n"} {
+		if _, err := readPluginConfig(permissionsPluginYAML + yaml); err == nil {
+			t.Errorf("expected %q to be rejected", yaml)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultIdempotencyTTL is how long IdempotencyGuard remembers results by default.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context that sends key with the next call.
+// Hosts should generate one key per logical call and reuse it when the same call
+// is retried after a reconnect, so the plugin can recognize the duplicate.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKey returns the idempotency key of the current call, or an empty string.
+func IdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// IdempotencyStore persists the results of completed calls by idempotency key.
+type IdempotencyStore interface {
+	// Load returns the result stored for key, if it has not expired
+	Load(key string) (result string, found bool, err error)
+	// Store records result for key until ttl elapses
+	Store(key, result string, ttl time.Duration) error
+}
+
+// IdempotencyGuard protects non-idempotent operations from running twice when a
+// host redelivers a call. Calls without an idempotency key always run.
+//
+// Example:
+//
+//	var createGuard = pluginapi.NewIdempotencyGuard(nil)
+//
+//	func handleCreateProject(ctx context.Context, t *myTool, p *Params) (string, error) {
+//	    return createGuard.Do(ctx, func(ctx context.Context) (string, error) {
+//	        return t.createProject(ctx, p.Name)
+//	    })
+//	}
+type IdempotencyGuard struct {
+	store IdempotencyStore
+	ttl   time.Duration
+
+	mu       sync.Mutex
+	inflight map[string]*idempotentCall
+}
+
+type idempotentCall struct {
+	done   chan struct{}
+	result string
+	err    error
+}
+
+// NewIdempotencyGuard creates a guard backed by store.
+// A nil store keeps results in memory, which protects against retries but not plugin restarts;
+// use NewSettingsIdempotencyStore to survive restarts.
+func NewIdempotencyGuard(store IdempotencyStore) *IdempotencyGuard {
+	if store == nil {
+		store = NewMemoryIdempotencyStore()
+	}
+	return &IdempotencyGuard{
+		store:    store,
+		ttl:      DefaultIdempotencyTTL,
+		inflight: make(map[string]*idempotentCall),
+	}
+}
+
+// WithTTL sets how long results are remembered. Returns the guard for chaining.
+func (g *IdempotencyGuard) WithTTL(ttl time.Duration) *IdempotencyGuard {
+	g.ttl = ttl
+	return g
+}
+
+// Do runs fn once per idempotency key. A duplicate call returns the stored result of
+// the first successful run; a duplicate that arrives while the first is still running
+// waits for it. Failed runs are not stored, so the host can retry them.
+func (g *IdempotencyGuard) Do(ctx context.Context, fn func(ctx context.Context) (string, error)) (string, error) {
+	key := IdempotencyKey(ctx)
+	if key == "" {
+		return fn(ctx)
+	}
+
+	g.mu.Lock()
+	if call, ok := g.inflight[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.result, call.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	result, found, err := g.store.Load(key)
+	if err != nil {
+		g.mu.Unlock()
+		return "", fmt.Errorf("failed to check idempotency key: %w", err)
+	}
+	if found {
+		g.mu.Unlock()
+		return result, nil
+	}
+	call := &idempotentCall{done: make(chan struct{})}
+	g.inflight[key] = call
+	g.mu.Unlock()
+
+	call.result, call.err = fn(ctx)
+	if call.err == nil {
+		if err := g.store.Store(key, call.result, g.ttl); err != nil {
+			call.err = fmt.Errorf("failed to record idempotency key: %w", err)
+		}
+	}
+
+	g.mu.Lock()
+	delete(g.inflight, key)
+	g.mu.Unlock()
+	close(call.done)
+
+	return call.result, call.err
+}
+
+// memoryIdempotencyStore keeps results in memory.
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]idempotencyEntry
+}
+
+type idempotencyEntry struct {
+	result    string
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore creates an in-memory IdempotencyStore.
+// Expired entries are pruned whenever a new result is stored.
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{entries: make(map[string]idempotencyEntry)}
+}
+
+func (s *memoryIdempotencyStore) Load(key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return "", false, nil
+	}
+	return entry.result, true, nil
+}
+
+func (s *memoryIdempotencyStore) Store(key, result string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = idempotencyEntry{result: result, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// idempotencySettingsPrefix namespaces idempotency records among the plugin's settings.
+const idempotencySettingsPrefix = "_idempotency:"
+
+// settingsIdempotencyStore keeps results in the plugin's settings file.
+type settingsIdempotencyStore struct {
+	settings SettingsManager
+}
+
+// NewSettingsIdempotencyStore creates an IdempotencyStore that persists results in the
+// plugin's settings (see BasePlugin.Settings), so duplicates are detected across restarts.
+// Records are stored under keys prefixed with "_idempotency:" and pruned once expired.
+func NewSettingsIdempotencyStore(settings SettingsManager) IdempotencyStore {
+	return &settingsIdempotencyStore{settings: settings}
+}
+
+func (s *settingsIdempotencyStore) Load(key string) (string, bool, error) {
+	value, err := s.settings.Get(idempotencySettingsPrefix + key)
+	if err != nil || value == nil {
+		return "", false, err
+	}
+	result, expiresAt, ok := decodeIdempotencyRecord(value)
+	if !ok || time.Now().Unix() >= expiresAt {
+		return "", false, nil
+	}
+	return result, true, nil
+}
+
+func (s *settingsIdempotencyStore) Store(key, result string, ttl time.Duration) error {
+	now := time.Now()
+	return s.settings.UpdateAll(func(settings map[string]interface{}) error {
+		for k, value := range settings {
+			if !strings.HasPrefix(k, idempotencySettingsPrefix) {
+				continue
+			}
+			if _, expiresAt, ok := decodeIdempotencyRecord(value); !ok || now.Unix() >= expiresAt {
+				delete(settings, k)
+			}
+		}
+		settings[idempotencySettingsPrefix+key] = map[string]interface{}{
+			"result":     result,
+			"expires_at": now.Add(ttl).Unix(),
+		}
+		return nil
+	})
+}
+
+// decodeIdempotencyRecord reads a record written by settingsIdempotencyStore.Store.
+// Records loaded from disk hold float64 numbers, while fresh ones hold int64.
+func decodeIdempotencyRecord(value interface{}) (string, int64, bool) {
+	record, ok := value.(map[string]interface{})
+	if !ok {
+		return "", 0, false
+	}
+	result, ok := record["result"].(string)
+	if !ok {
+		return "", 0, false
+	}
+	switch v := record["expires_at"].(type) {
+	case float64:
+		return result, int64(v), true
+	case int64:
+		return result, v, true
+	}
+	return "", 0, false
+}
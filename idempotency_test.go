@@ -0,0 +1,173 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyGuard_Do(t *testing.T) {
+	guard := NewIdempotencyGuard(nil)
+	var runs int
+	create := func(ctx context.Context) (string, error) {
+		runs++
+		return fmt.Sprintf("project-%d", runs), nil
+	}
+
+	ctx := WithIdempotencyKey(context.Background(), "call-1")
+	first, err := guard.Do(ctx, create)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	second, err := guard.Do(ctx, create)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if first != "project-1" || second != first || runs != 1 {
+		t.Errorf("duplicate call ran again: first=%q second=%q runs=%d", first, second, runs)
+	}
+
+	// Different keys and calls without keys always run
+	if got, _ := guard.Do(WithIdempotencyKey(context.Background(), "call-2"), create); got != "project-2" {
+		t.Errorf("new key returned %q", got)
+	}
+	if got, _ := guard.Do(context.Background(), create); got != "project-3" {
+		t.Errorf("keyless call returned %q", got)
+	}
+}
+
+func TestIdempotencyGuard_FailuresAreRetried(t *testing.T) {
+	guard := NewIdempotencyGuard(nil)
+	ctx := WithIdempotencyKey(context.Background(), "call-1")
+
+	_, err := guard.Do(ctx, func(ctx context.Context) (string, error) {
+		return "", errors.New("temporary failure")
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	got, err := guard.Do(ctx, func(ctx context.Context) (string, error) {
+		return "ok", nil
+	})
+	if err != nil || got != "ok" {
+		t.Errorf("retry after failure = %q, %v", got, err)
+	}
+}
+
+func TestIdempotencyGuard_ConcurrentDuplicates(t *testing.T) {
+	guard := NewIdempotencyGuard(nil)
+	ctx := WithIdempotencyKey(context.Background(), "call-1")
+
+	var runs atomic.Int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	results := make([]string, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = guard.Do(ctx, func(ctx context.Context) (string, error) {
+				runs.Add(1)
+				<-release
+				return "created", nil
+			})
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if runs.Load() != 1 {
+		t.Errorf("expected 1 run, got %d", runs.Load())
+	}
+	for i, r := range results {
+		if r != "created" {
+			t.Errorf("result[%d] = %q", i, r)
+		}
+	}
+}
+
+func TestIdempotencyGuard_TTL(t *testing.T) {
+	guard := NewIdempotencyGuard(nil).WithTTL(-time.Second)
+	ctx := WithIdempotencyKey(context.Background(), "call-1")
+	var runs int
+	fn := func(ctx context.Context) (string, error) {
+		runs++
+		return "ok", nil
+	}
+	_, _ = guard.Do(ctx, fn)
+	_, _ = guard.Do(ctx, fn)
+	if runs != 2 {
+		t.Errorf("expired result should not be reused, runs = %d", runs)
+	}
+}
+
+func TestSettingsIdempotencyStore(t *testing.T) {
+	dir := t.TempDir()
+	sm, err := NewSettingsManager(dir, "test-plugin")
+	if err != nil {
+		t.Fatalf("NewSettingsManager failed: %v", err)
+	}
+	store := NewSettingsIdempotencyStore(sm)
+
+	if err := store.Store("expired", "old", -time.Second); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if err := store.Store("call-1", "created", time.Hour); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	// Reload from disk, as after a plugin restart
+	sm, err = NewSettingsManager(dir, "test-plugin")
+	if err != nil {
+		t.Fatalf("NewSettingsManager failed: %v", err)
+	}
+	store = NewSettingsIdempotencyStore(sm)
+
+	got, found, err := store.Load("call-1")
+	if err != nil || !found || got != "created" {
+		t.Errorf("Load = %q, %v, %v", got, found, err)
+	}
+	if _, found, _ := store.Load("expired"); found {
+		t.Error("expired record should not be found")
+	}
+
+	all, _ := sm.GetAll()
+	if _, ok := all[idempotencySettingsPrefix+"expired"]; ok {
+		t.Error("expired record should have been pruned")
+	}
+}
+
+type idempotentTestTool struct {
+	BasePlugin
+	guard *IdempotencyGuard
+	runs  atomic.Int32
+}
+
+func (t *idempotentTestTool) Call(ctx context.Context, args string) (string, error) {
+	return t.guard.Do(ctx, func(ctx context.Context) (string, error) {
+		return fmt.Sprintf("run-%d", t.runs.Add(1)), nil
+	})
+}
+
+func TestGRPCClient_IdempotencyKey(t *testing.T) {
+	tool := &idempotentTestTool{guard: NewIdempotencyGuard(nil)}
+	client := newTestClient(t, tool)
+	ctx := WithIdempotencyKey(context.Background(), "retry-me")
+
+	first, err := client.Call(ctx, `{}`)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	second, err := client.Call(ctx, `{}`)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if first != second || tool.runs.Load() != 1 {
+		t.Errorf("retry was executed again: %q, %q", first, second)
+	}
+}
@@ -0,0 +1,107 @@
+package pluginapi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// MaxInlineImageSize is the largest image, in decoded bytes, that can be embedded in
+// a result. Larger images should be served from an absolute http(s) URL (see NewImageURLResult).
+const MaxInlineImageSize = 10 << 20
+
+// ImageData is the payload of a DisplayTypeImage result. Exactly one of Data and URL is set.
+type ImageData struct {
+	// MimeType is the image type (e.g., "image/png"); required for inline data
+	MimeType string `json:"mime_type,omitempty" yaml:"mime_type,omitempty"`
+	// Data is the base64-encoded (standard encoding) image
+	Data string `json:"data,omitempty" yaml:"data,omitempty"`
+	// URL is an http(s) URL the host loads the image from
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Alt is a text description for accessibility and for LLMs that can't see the image
+	Alt string `json:"alt,omitempty" yaml:"alt,omitempty"`
+	// Width and Height are the image's pixel dimensions, if known, so hosts can reserve space
+	Width  int `json:"width,omitempty" yaml:"width,omitempty"`
+	Height int `json:"height,omitempty" yaml:"height,omitempty"`
+}
+
+// NewImageResult creates a result that shows an image inline. data holds the raw
+// image bytes, which are base64-encoded for transport.
+//
+// Example:
+//
+//	png, err := renderWaveform(samples)
+//	if err != nil {
+//	    return "", err
+//	}
+//	return pluginapi.NewImageResult("Waveform", pluginapi.MIMETypePNG, png).ToJSON()
+func NewImageResult(title, mimeType string, data []byte) *StructuredResult {
+	return &StructuredResult{
+		DisplayType: DisplayTypeImage,
+		Title:       title,
+		Data: ImageData{
+			MimeType: mimeType,
+			Data:     base64.StdEncoding.EncodeToString(data),
+		},
+	}
+}
+
+// NewImageURLResult creates a result that shows the image at imageURL inline.
+// mimeType may be empty if the server reports it.
+func NewImageURLResult(title, mimeType, imageURL string) *StructuredResult {
+	return &StructuredResult{
+		DisplayType: DisplayTypeImage,
+		Title:       title,
+		Data: ImageData{
+			MimeType: mimeType,
+			URL:      imageURL,
+		},
+	}
+}
+
+// Bytes decodes the inline image data. Returns nil for URL images.
+func (d *ImageData) Bytes() ([]byte, error) {
+	if d.Data == "" {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(d.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image data: %w", err)
+	}
+	return data, nil
+}
+
+// Validate checks that the image has exactly one well-formed source.
+func (d *ImageData) Validate() error {
+	if d.MimeType != "" && !strings.HasPrefix(d.MimeType, "image/") {
+		return fmt.Errorf("mime type must be an image type (got %q)", d.MimeType)
+	}
+	if d.Width < 0 || d.Height < 0 {
+		return fmt.Errorf("image dimensions cannot be negative")
+	}
+
+	switch {
+	case d.Data != "" && d.URL != "":
+		return fmt.Errorf("image must have either data or a URL, not both")
+	case d.Data != "":
+		if d.MimeType == "" {
+			return fmt.Errorf("mime type is required for inline image data")
+		}
+		data, err := d.Bytes()
+		if err != nil {
+			return err
+		}
+		if len(data) > MaxInlineImageSize {
+			return fmt.Errorf("inline image exceeds %d bytes; serve it from a URL instead", MaxInlineImageSize)
+		}
+	case d.URL != "":
+		u, err := url.Parse(d.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("image URL must be an absolute http(s) URL (got %q)", d.URL)
+		}
+	default:
+		return fmt.Errorf("image data or URL is required")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package pluginapi
+
+import (
+	"bytes"
+	"testing"
+)
+
+var testPNG = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+func TestImageResult_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  *StructuredResult
+		wantErr bool
+	}{
+		{name: "inline", result: NewImageResult("Chart", MIMETypePNG, testPNG)},
+		{name: "url", result: NewImageURLResult("Screenshot", "", "https://example.com/shot.png")},
+		{name: "inline without mime type", result: NewImageResult("Chart", "", testPNG), wantErr: true},
+		{name: "not an image", result: NewImageResult("Report", MIMETypePDF, testPNG), wantErr: true},
+		{name: "file url", result: NewImageURLResult("Shot", "", "file:///etc/passwd"), wantErr: true},
+		{name: "relative url", result: NewImageURLResult("Shot", "", "shot.png"), wantErr: true},
+		{
+			name: "bad base64",
+			result: &StructuredResult{
+				DisplayType: DisplayTypeImage,
+				Data:        ImageData{MimeType: MIMETypePNG, Data: "not base64!"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "data and url",
+			result: &StructuredResult{
+				DisplayType: DisplayTypeImage,
+				Data:        ImageData{MimeType: MIMETypePNG, Data: "iVBORw==", URL: "https://example.com/a.png"},
+			},
+			wantErr: true,
+		},
+		{name: "too large", result: NewImageResult("Huge", MIMETypePNG, make([]byte, MaxInlineImageSize+1)), wantErr: true},
+		{name: "missing data", result: &StructuredResult{DisplayType: DisplayTypeImage}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.result.Validate()
+			if tt.wantErr && err == nil {
+				t.Error("expected validation error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestImageResult_RoundTrip(t *testing.T) {
+	jsonStr, err := NewImageResult("Waveform", MIMETypePNG, testPNG).ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	parsed, err := ParseStructuredResult(jsonStr)
+	if err != nil {
+		t.Fatalf("ParseStructuredResult failed: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Fatalf("parsed result failed validation: %v", err)
+	}
+
+	img, err := decodeResultData[ImageData](parsed.Data)
+	if err != nil {
+		t.Fatalf("decodeResultData failed: %v", err)
+	}
+	data, err := img.Bytes()
+	if err != nil || !bytes.Equal(data, testPNG) || img.MimeType != MIMETypePNG {
+		t.Errorf("got %+v (%v) after round trip", img, err)
+	}
+}
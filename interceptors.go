@@ -0,0 +1,123 @@
+package pluginapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// InterceptorProvider allows plugins to add gRPC middleware (e.g., auth, logging,
+// or metrics) to the server ServeGRPCPlugin creates.
+// Plugins can optionally implement this interface. The interceptors run in the order
+// given, inside the server's panic recovery, on every transport.
+type InterceptorProvider interface {
+	// UnaryInterceptors wrap every unary RPC, such as Call
+	UnaryInterceptors() []grpc.UnaryServerInterceptor
+	// StreamInterceptors wrap every streaming RPC, such as CallStream
+	StreamInterceptors() []grpc.StreamServerInterceptor
+}
+
+// serverInterceptors returns the interceptors a plugin server runs, outermost first.
+// Tracing and metrics wrap panic recovery, so they record recovered panics as failed calls.
+func serverInterceptors(tool PluginTool) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor) {
+	tool, _ = unwrapTool(tool)
+	tracer := tracerOf(tool)
+	unary := []grpc.UnaryServerInterceptor{invocationUnary, traceUnary(tracer), measureUnary, recoverUnary}
+	stream := []grpc.StreamServerInterceptor{invocationStream, traceStream(tracer), measureStream, recoverStream}
+	if provider, ok := tool.(InterceptorProvider); ok {
+		unary = append(unary, provider.UnaryInterceptors()...)
+		stream = append(stream, provider.StreamInterceptors()...)
+	}
+	return unary, stream
+}
+
+// serverOptions configures a grpc.Server to serve tool.
+func serverOptions(tool PluginTool) []grpc.ServerOption {
+	unary, stream := serverInterceptors(tool)
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+		grpc.MaxRecvMsgSize(MaxMessageSize),
+		grpc.MaxSendMsgSize(MaxMessageSize),
+	}
+}
+
+// chainUnaryServer combines interceptors into one, first outermost, for servers
+// without grpc.ChainUnaryInterceptor.
+func chainUnaryServer(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// chainStreamServer is chainUnaryServer for streaming RPCs.
+func chainStreamServer(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(srv any, stream grpc.ServerStream) error {
+				return interceptor(srv, stream, info, next)
+			}
+		}
+		return handler(srv, stream)
+	}
+}
+
+// ClientInterceptors is middleware for the RPCs a host makes to a plugin.
+// Unlike grpc.WithChainUnaryInterceptor it also works on a StdioClientConn.
+//
+// Example:
+//
+//	conn = pluginapi.ClientInterceptors{Unary: []grpc.UnaryClientInterceptor{logRPC}}.Wrap(conn)
+//	tool := pluginapi.NewToolServiceClient(conn)
+type ClientInterceptors struct {
+	// Unary wraps every unary RPC, first one outermost
+	Unary []grpc.UnaryClientInterceptor
+	// Stream wraps every streaming RPC, first one outermost
+	Stream []grpc.StreamClientInterceptor
+}
+
+// Wrap returns conn with the interceptors applied. Interceptors receive conn as
+// their cc argument if it is a *grpc.ClientConn, and nil otherwise.
+func (i ClientInterceptors) Wrap(conn grpc.ClientConnInterface) grpc.ClientConnInterface {
+	return &interceptedClientConn{conn: conn, interceptors: i}
+}
+
+type interceptedClientConn struct {
+	conn         grpc.ClientConnInterface
+	interceptors ClientInterceptors
+}
+
+func (c *interceptedClientConn) Invoke(ctx context.Context, method string, req, reply any, opts ...grpc.CallOption) error {
+	cc, _ := c.conn.(*grpc.ClientConn)
+	invoker := func(ctx context.Context, method string, req, reply any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.conn.Invoke(ctx, method, req, reply, opts...)
+	}
+	for i := len(c.interceptors.Unary) - 1; i >= 0; i-- {
+		interceptor, next := c.interceptors.Unary[i], invoker
+		invoker = func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return interceptor(ctx, method, req, reply, cc, next, opts...)
+		}
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (c *interceptedClientConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	cc, _ := c.conn.(*grpc.ClientConn)
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, _ *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return c.conn.NewStream(ctx, desc, method, opts...)
+	}
+	for i := len(c.interceptors.Stream) - 1; i >= 0; i-- {
+		interceptor, next := c.interceptors.Stream[i], streamer
+		streamer = func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return interceptor(ctx, desc, cc, method, next, opts...)
+		}
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
@@ -0,0 +1,117 @@
+package pluginapi
+
+import (
+	"context"
+	"path"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type interceptedTestTool struct {
+	streamingTestTool
+	mu    sync.Mutex
+	trace []string
+}
+
+func (t *interceptedTestTool) record(entry string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trace = append(t.trace, entry)
+}
+
+func (t *interceptedTestTool) UnaryInterceptors() []grpc.UnaryServerInterceptor {
+	requireToken := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		t.record("auth " + path.Base(info.FullMethod))
+		if md, _ := metadata.FromIncomingContext(ctx); len(md.Get("x-token")) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing token")
+		}
+		return handler(ctx, req)
+	}
+	logCall := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		t.record("log " + path.Base(info.FullMethod))
+		return handler(ctx, req)
+	}
+	return []grpc.UnaryServerInterceptor{requireToken, logCall}
+}
+
+func (t *interceptedTestTool) StreamInterceptors() []grpc.StreamServerInterceptor {
+	return []grpc.StreamServerInterceptor{
+		func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			t.record("stream " + path.Base(info.FullMethod))
+			return handler(srv, stream)
+		},
+	}
+}
+
+func TestInterceptorProvider(t *testing.T) {
+	tool := &interceptedTestTool{}
+	conn := newTestConn(t, tool, serverOptions(tool)...)
+
+	if _, err := (&grpcClient{client: NewToolServiceClient(conn)}).Call(context.Background(), "{}"); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without token, got %v", err)
+	}
+
+	addToken := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(metadata.AppendToOutgoingContext(ctx, "x-token", "secret"), method, req, reply, cc, opts...)
+	}
+	client := &grpcClient{client: NewToolServiceClient(ClientInterceptors{Unary: []grpc.UnaryClientInterceptor{addToken}}.Wrap(conn))}
+	if _, err := client.Call(context.Background(), "{}"); err != nil {
+		t.Fatalf("Call with token failed: %v", err)
+	}
+	if _, err := client.CallStream(context.Background(), "{}", func(CallChunk) error { return nil }); err != nil {
+		t.Fatalf("CallStream failed: %v", err)
+	}
+
+	want := []string{"auth Call", "auth Call", "log Call", "stream CallStream"}
+	if len(tool.trace) != len(want) {
+		t.Fatalf("trace = %v, want %v", tool.trace, want)
+	}
+	for i := range want {
+		if tool.trace[i] != want[i] {
+			t.Errorf("trace = %v, want %v", tool.trace, want)
+			break
+		}
+	}
+}
+
+func TestClientInterceptors_Order(t *testing.T) {
+	_, conn, _ := newStdioTestClient(t, &plainTestTool{})
+
+	var trace []string
+	tag := func(name string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			trace = append(trace, name+" "+path.Base(method))
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+	var streams int
+	countStreams := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		streams++
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+
+	wrapped := ClientInterceptors{
+		Unary:  []grpc.UnaryClientInterceptor{tag("outer"), tag("inner")},
+		Stream: []grpc.StreamClientInterceptor{countStreams},
+	}.Wrap(conn)
+	client := &grpcClient{client: NewToolServiceClient(wrapped)}
+
+	if err := client.HealthCheckCtx(context.Background()); err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+	if len(trace) != 2 || trace[0] != "outer HealthCheck" || trace[1] != "inner HealthCheck" {
+		t.Errorf("trace = %v", trace)
+	}
+
+	if _, err := client.CallStream(context.Background(), "{}", func(CallChunk) error { return nil }); err != nil {
+		t.Fatalf("CallStream failed: %v", err)
+	}
+	if streams != 1 {
+		t.Errorf("stream interceptor ran %d times, want 1", streams)
+	}
+}
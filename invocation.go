@@ -0,0 +1,62 @@
+package pluginapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// invocationIDMetadataKey carries the invocation ID in gRPC metadata.
+const invocationIDMetadataKey = "ori-invocation-id"
+
+type invocationIDContextKey struct{}
+
+// WithInvocationID returns a context that sends id with the calls made with it.
+// Hosts can pass their own request ID so one ID shows up in host and plugin logs;
+// otherwise the client generates one per call.
+func WithInvocationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, invocationIDContextKey{}, id)
+}
+
+// InvocationID returns the ID of the host invocation the current call belongs to,
+// or an empty string if the host sent none. Entries logged with the context (e.g.,
+// Logger().InfoContext(ctx, ...)) carry it as the "invocation_id" field, and
+// HostServices calls made with the context send it back to the host.
+func InvocationID(ctx context.Context) string {
+	id, _ := ctx.Value(invocationIDContextKey{}).(string)
+	return id
+}
+
+// withInvocationMetadata adds ctx's invocation ID, generating one if needed, to
+// the outgoing metadata.
+func withInvocationMetadata(ctx context.Context) context.Context {
+	id := InvocationID(ctx)
+	if id == "" {
+		id = newCallID()
+		ctx = WithInvocationID(ctx, id)
+	}
+	return metadata.AppendToOutgoingContext(ctx, invocationIDMetadataKey, id)
+}
+
+// incomingInvocationID sets the invocation ID from the incoming metadata on ctx.
+func incomingInvocationID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(invocationIDMetadataKey); len(values) > 0 {
+		return WithInvocationID(ctx, values[0])
+	}
+	return ctx
+}
+
+// invocationUnary makes the host's invocation ID available to handlers.
+func invocationUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(incomingInvocationID(ctx), req)
+}
+
+// invocationStream is invocationUnary for streaming RPCs.
+func invocationStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextServerStream{ServerStream: stream, ctx: incomingInvocationID(stream.Context())})
+}
@@ -0,0 +1,44 @@
+package pluginapi
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+type invocationTestTool struct {
+	BasePlugin
+	seen chan string
+}
+
+func (t *invocationTestTool) Call(ctx context.Context, args string) (string, error) {
+	t.seen <- InvocationID(ctx)
+	t.Logger().InfoContext(ctx, "handled")
+	return "", nil
+}
+
+func TestInvocationID_ReachesHandlers(t *testing.T) {
+	tool := &invocationTestTool{seen: make(chan string, 2)}
+	var stderr bytes.Buffer
+	tool.logBroker().fallback = &stderr
+	client := &grpcClient{client: NewToolServiceClient(newTestConn(t, tool, serverOptions(tool)...))}
+
+	ctx := WithInvocationID(context.Background(), "req-123")
+	if _, err := client.Call(ctx, "{}"); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if id := <-tool.seen; id != "req-123" {
+		t.Errorf("handler saw invocation ID %q, want req-123", id)
+	}
+	if !bytes.Contains(stderr.Bytes(), []byte(`invocation_id="req-123"`)) {
+		t.Errorf("expected the invocation ID in the log entry, got %q", stderr.String())
+	}
+
+	// Without one, the client generates an ID per call
+	if _, err := client.Call(context.Background(), "{}"); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if id := <-tool.seen; len(id) != 32 {
+		t.Errorf("expected a generated invocation ID, got %q", id)
+	}
+}
@@ -0,0 +1,126 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrTokenBudgetExceeded is returned by HostServices.Complete when the plugin has used
+// up the token budget the host allows it. Plugins should fall back to returning raw
+// data instead of retrying.
+var ErrTokenBudgetExceeded = errors.New("token budget exceeded")
+
+// Completion message roles.
+const (
+	CompletionRoleUser      = "user"
+	CompletionRoleAssistant = "assistant"
+)
+
+// CompletionMessage is one turn of a completion conversation.
+type CompletionMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// CompletionRequest asks the agent's active model for a completion.
+type CompletionRequest struct {
+	// SystemPrompt sets the model's instructions for this request only
+	SystemPrompt string `json:"system_prompt,omitempty"`
+	// Messages is the conversation to complete; the last message is usually from the user
+	Messages []CompletionMessage `json:"messages"`
+	// MaxTokens caps the output length; 0 uses the host's default.
+	// Hosts may lower it to fit the plugin's remaining budget.
+	MaxTokens int `json:"max_tokens,omitempty"`
+	// Temperature controls randomness; 0 uses the host's default
+	Temperature float64 `json:"temperature,omitempty"`
+}
+
+// Completion is the model's answer to a CompletionRequest.
+type Completion struct {
+	Text         string `json:"text"`
+	InputTokens  int    `json:"input_tokens"`
+	OutputTokens int    `json:"output_tokens"`
+	// Truncated is set when the output stopped at MaxTokens
+	Truncated bool `json:"truncated,omitempty"`
+	// RemainingTokens is what is left of the plugin's token budget, or 0 if the host doesn't track one
+	RemainingTokens int64 `json:"remaining_tokens,omitempty"`
+}
+
+// Summarize asks the agent's model to summarize text in at most maxTokens tokens.
+// It is a convenience for shrinking large results (e.g., a file's contents) before
+// returning them to the LLM.
+//
+// Example:
+//
+//	summary, err := pluginapi.Summarize(ctx, t.Host(), string(contents), 300)
+//	if errors.Is(err, pluginapi.ErrHostServiceUnavailable) || errors.Is(err, pluginapi.ErrTokenBudgetExceeded) {
+//	    summary = truncate(string(contents))
+//	}
+func Summarize(ctx context.Context, host HostServices, text string, maxTokens int) (string, error) {
+	completion, err := host.Complete(ctx, CompletionRequest{
+		SystemPrompt: "Summarize the user's text concisely. Keep names, numbers, and conclusions; drop everything else.",
+		Messages:     []CompletionMessage{{Role: CompletionRoleUser, Content: text}},
+		MaxTokens:    maxTokens,
+	})
+	if err != nil {
+		return "", err
+	}
+	return completion.Text, nil
+}
+
+func (UnimplementedHostServices) Complete(ctx context.Context, req CompletionRequest) (Completion, error) {
+	return Completion{}, ErrHostServiceUnavailable
+}
+
+func (s *hostServer) Complete(ctx context.Context, req *HostCompleteRequest) (*HostCompleteResponse, error) {
+	messages := make([]CompletionMessage, len(req.Messages))
+	for i, m := range req.Messages {
+		messages[i] = CompletionMessage{Role: m.Role, Content: m.Content}
+	}
+	completion, err := s.Impl.Complete(ctx, CompletionRequest{
+		SystemPrompt: req.SystemPrompt,
+		Messages:     messages,
+		MaxTokens:    int(req.MaxTokens),
+		Temperature:  req.Temperature,
+	})
+	if st := hostStatus(err); st != nil {
+		return nil, st
+	}
+	if err != nil {
+		return &HostCompleteResponse{Error: err.Error()}, nil
+	}
+	return &HostCompleteResponse{
+		Text:            completion.Text,
+		InputTokens:     int32(completion.InputTokens),
+		OutputTokens:    int32(completion.OutputTokens),
+		Truncated:       completion.Truncated,
+		RemainingTokens: completion.RemainingTokens,
+	}, nil
+}
+
+func (h *hostClient) Complete(ctx context.Context, req CompletionRequest) (Completion, error) {
+	messages := make([]*ProtoCompletionMessage, len(req.Messages))
+	for i, m := range req.Messages {
+		messages[i] = &ProtoCompletionMessage{Role: m.Role, Content: m.Content}
+	}
+	resp, err := h.client.Complete(h.outgoing(ctx), &HostCompleteRequest{
+		SystemPrompt: req.SystemPrompt,
+		Messages:     messages,
+		MaxTokens:    int32(req.MaxTokens),
+		Temperature:  req.Temperature,
+	})
+	if err != nil {
+		return Completion{}, hostSentinel(err)
+	}
+	if resp.Error != "" {
+		return Completion{}, fmt.Errorf("%s", resp.Error)
+	}
+	return Completion{
+		Text:            resp.Text,
+		InputTokens:     int(resp.InputTokens),
+		OutputTokens:    int(resp.OutputTokens),
+		Truncated:       resp.Truncated,
+		RemainingTokens: resp.RemainingTokens,
+	}, nil
+}
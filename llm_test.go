@@ -0,0 +1,71 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// budgetHostServices answers completions by echoing the last message in upper case,
+// charging one token per word against a fixed budget.
+type budgetHostServices struct {
+	UnimplementedHostServices
+	remaining int64
+	last      CompletionRequest
+}
+
+func (h *budgetHostServices) Complete(ctx context.Context, req CompletionRequest) (Completion, error) {
+	h.last = req
+	text := req.Messages[len(req.Messages)-1].Content
+	cost := int64(len(strings.Fields(text)))
+	if cost > h.remaining {
+		return Completion{}, ErrTokenBudgetExceeded
+	}
+	h.remaining -= cost
+	return Completion{
+		Text:            strings.ToUpper(text),
+		InputTokens:     int(cost),
+		OutputTokens:    int(cost),
+		RemainingTokens: h.remaining,
+	}, nil
+}
+
+type summarizingTestTool struct {
+	BasePlugin
+}
+
+func (t *summarizingTestTool) Call(ctx context.Context, args string) (string, error) {
+	summary, err := Summarize(ctx, t.Host(), args, 100)
+	if errors.Is(err, ErrTokenBudgetExceeded) {
+		return "over budget", nil
+	}
+	return summary, err
+}
+
+func TestHostServices_Complete(t *testing.T) {
+	host := &budgetHostServices{remaining: 5}
+	client := newTestClient(t, &summarizingTestTool{})
+	if err := client.ConnectHostServices(context.Background(), newTestHost(t, host), "summarizer"); err != nil {
+		t.Fatalf("ConnectHostServices failed: %v", err)
+	}
+
+	result, err := client.Call(context.Background(), "three small words")
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if result != "THREE SMALL WORDS" {
+		t.Errorf("result = %q", result)
+	}
+	if host.last.MaxTokens != 100 || host.last.SystemPrompt == "" || host.last.Messages[0].Role != CompletionRoleUser {
+		t.Errorf("unexpected request: %+v", host.last)
+	}
+
+	result, err = client.Call(context.Background(), "these words exceed the budget")
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if result != "over budget" {
+		t.Errorf("expected ErrTokenBudgetExceeded to reach the plugin, got %q", result)
+	}
+}
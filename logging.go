@@ -0,0 +1,189 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogEntry is a message logged through BasePlugin.Logger, as delivered to hosts
+// that subscribe with SubscribeLogs.
+type LogEntry struct {
+	Time    time.Time
+	Level   LogLevel
+	Message string
+	// Fields holds the structured attributes; grouped attributes are keyed "group.key"
+	Fields map[string]string
+}
+
+const (
+	// recentLogEntries is how many entries a new subscriber receives from before it
+	// subscribed, so startup logs reach the host
+	recentLogEntries = 256
+	// logSubscriberBuffer is how many entries a slow subscriber can fall behind
+	// before further entries are dropped for it
+	logSubscriberBuffer = 256
+)
+
+// logBroker fans a plugin's log entries out to the hosts subscribed to them.
+// Entries logged while nobody is subscribed also go to the fallback writer.
+type logBroker struct {
+	mu          sync.Mutex
+	recent      []LogEntry
+	subscribers map[chan LogEntry]struct{}
+	fallback    io.Writer
+}
+
+func newLogBroker() *logBroker {
+	return &logBroker{subscribers: make(map[chan LogEntry]struct{}), fallback: os.Stderr}
+}
+
+func (b *logBroker) publish(entry LogEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.recent) == recentLogEntries {
+		b.recent = slices.Delete(b.recent, 0, 1)
+	}
+	b.recent = append(b.recent, entry)
+
+	if len(b.subscribers) == 0 {
+		fmt.Fprintln(b.fallback, formatLogEntry(entry))
+		return
+	}
+	for ch := range b.subscribers {
+		select {
+		case ch <- entry:
+		default: // Subscriber is too slow; drop rather than block the plugin
+		}
+	}
+}
+
+// subscribe returns the recent entries and a channel receiving new ones, until
+// unsubscribe is called.
+func (b *logBroker) subscribe() (recent []LogEntry, entries <-chan LogEntry, unsubscribe func()) {
+	ch := make(chan LogEntry, logSubscriberBuffer)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[ch] = struct{}{}
+	return slices.Clone(b.recent), ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, ch)
+	}
+}
+
+func formatLogEntry(entry LogEntry) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s %s", entry.Time.Format(time.RFC3339), strings.ToUpper(string(entry.Level)), entry.Message)
+	for _, key := range slices.Sorted(maps.Keys(entry.Fields)) {
+		fmt.Fprintf(&sb, " %s=%q", key, entry.Fields[key])
+	}
+	return sb.String()
+}
+
+// logLevelRank orders LogLevels from LogDebug to LogError. Unknown levels rank as LogInfo.
+func logLevelRank(level LogLevel) int {
+	switch level {
+	case LogDebug:
+		return 0
+	case LogWarn:
+		return 2
+	case LogError:
+		return 3
+	default:
+		return 1
+	}
+}
+
+func logLevelFromSlog(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return LogDebug
+	case level < slog.LevelWarn:
+		return LogInfo
+	case level < slog.LevelError:
+		return LogWarn
+	default:
+		return LogError
+	}
+}
+
+// logHandler is the slog.Handler behind BasePlugin.Logger.
+type logHandler struct {
+	broker *logBroker
+	prefix string // Open groups, joined with dots
+	fields map[string]string
+}
+
+func (h *logHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *logHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := maps.Clone(h.fields)
+	if fields == nil {
+		fields = make(map[string]string)
+	}
+	if id := InvocationID(ctx); id != "" {
+		fields["invocation_id"] = id
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		addLogAttr(fields, h.prefix, attr)
+		return true
+	})
+	h.broker.publish(LogEntry{
+		Time:    record.Time,
+		Level:   logLevelFromSlog(record.Level),
+		Message: record.Message,
+		Fields:  fields,
+	})
+	return nil
+}
+
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := maps.Clone(h.fields)
+	if fields == nil {
+		fields = make(map[string]string)
+	}
+	for _, attr := range attrs {
+		addLogAttr(fields, h.prefix, attr)
+	}
+	return &logHandler{broker: h.broker, prefix: h.prefix, fields: fields}
+}
+
+func (h *logHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &logHandler{broker: h.broker, prefix: h.prefix + name + ".", fields: h.fields}
+}
+
+// addLogAttr flattens attr into fields, prefixing keys with their groups.
+func addLogAttr(fields map[string]string, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, member := range attr.Value.Group() {
+			addLogAttr(fields, prefix, member)
+		}
+		return
+	}
+	fields[prefix+attr.Key] = attr.Value.String()
+}
+
+// logSource is implemented by BasePlugin, whose Logger output grpcServer streams to hosts.
+type logSource interface {
+	logBroker() *logBroker
+}
@@ -0,0 +1,105 @@
+package pluginapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+var errStopLogs = errors.New("enough logs")
+
+func TestGRPCClient_SubscribeLogs(t *testing.T) {
+	tool := &plainTestTool{}
+	var stderr bytes.Buffer
+	tool.logBroker().fallback = &stderr
+	client := newTestClient(t, tool)
+
+	logger := tool.Logger()
+	logger.Debug("warming cache")
+	logger.Info("started", "port", 5000)
+	if !strings.Contains(stderr.String(), `INFO started port="5000"`) {
+		t.Errorf("expected unsubscribed entries on stderr, got %q", stderr.String())
+	}
+
+	entries := make(chan LogEntry, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- client.SubscribeLogs(context.Background(), LogInfo, func(entry LogEntry) error {
+			entries <- entry
+			if entry.Level == LogError {
+				return errStopLogs
+			}
+			return nil
+		})
+	}()
+
+	// Recent entries are replayed, filtered by level
+	first := <-entries
+	if first.Message != "started" || first.Level != LogInfo || first.Fields["port"] != "5000" || first.Time.IsZero() {
+		t.Errorf("unexpected replayed entry: %+v", first)
+	}
+
+	// Wait for the subscription before logging more
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		tool.logBroker().mu.Lock()
+		subscribed := len(tool.logBroker().subscribers) > 0
+		tool.logBroker().mu.Unlock()
+		if subscribed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the subscription")
+		}
+	}
+	stderr.Reset()
+	logger.With("job", "export").WithGroup("upload").Error("failed", slog.Int("attempt", 3), slog.Group("file", "name", "a.wav"))
+
+	entry := <-entries
+	want := map[string]string{"job": "export", "upload.attempt": "3", "upload.file.name": "a.wav"}
+	if entry.Level != LogError || entry.Message != "failed" || len(entry.Fields) != len(want) {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	for k, v := range want {
+		if entry.Fields[k] != v {
+			t.Errorf("field %s = %q, want %q", k, entry.Fields[k], v)
+		}
+	}
+	if err := <-done; !errors.Is(err, errStopLogs) {
+		t.Errorf("expected emit's error, got %v", err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("subscribed entries should not go to stderr, got %q", stderr.String())
+	}
+}
+
+func TestSubscribeLogs_EndsOnShutdown(t *testing.T) {
+	tool := &plainTestTool{}
+	tool.logBroker().fallback = &bytes.Buffer{}
+	srv := &grpcServer{Impl: tool}
+
+	stream := &fakeLogStream{ctx: context.Background()}
+	done := make(chan error, 1)
+	go func() { done <- srv.SubscribeLogs(&LogSubscribeRequest{}, stream) }()
+
+	srv.draining.start()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected a clean end, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SubscribeLogs kept running after draining started")
+	}
+}
+
+type fakeLogStream struct {
+	ToolService_SubscribeLogsServer
+	ctx context.Context
+}
+
+func (s *fakeLogStream) Context() context.Context  { return s.ctx }
+func (s *fakeLogStream) Send(*ProtoLogEntry) error { return nil }
@@ -0,0 +1,167 @@
+package pluginapi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// LatLng is a geographic coordinate in degrees.
+type LatLng struct {
+	Lat float64 `json:"lat" yaml:"lat"`
+	Lng float64 `json:"lng" yaml:"lng"`
+}
+
+// MapData is the payload of a DisplayTypeMap result.
+type MapData struct {
+	// GeoJSON is a GeoJSON object (usually a FeatureCollection) with the pins and regions to draw
+	GeoJSON interface{} `json:"geojson" yaml:"geojson"`
+	// Center is the initial map center; if nil, the host fits the view to the features
+	Center *LatLng `json:"center,omitempty" yaml:"center,omitempty"`
+	// Zoom is the initial zoom level (0 = whole world, 22 = building level); ignored without Center
+	Zoom int `json:"zoom,omitempty" yaml:"zoom,omitempty"`
+}
+
+// MaxMapZoom is the highest zoom level accepted by Validate.
+const MaxMapZoom = 22
+
+// GeoJSONFeature is a GeoJSON Feature.
+// Hosts show the "title" and "description" properties in the pin or region popup.
+type GeoJSONFeature struct {
+	Type       string                 `json:"type" yaml:"type"`
+	Geometry   GeoJSONGeometry        `json:"geometry" yaml:"geometry"`
+	Properties map[string]interface{} `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// GeoJSONGeometry is a GeoJSON geometry. Coordinates use GeoJSON's [lng, lat] order.
+type GeoJSONGeometry struct {
+	Type        string      `json:"type" yaml:"type"`
+	Coordinates interface{} `json:"coordinates" yaml:"coordinates"`
+}
+
+// GeoJSONFeatureCollection is a GeoJSON FeatureCollection.
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type" yaml:"type"`
+	Features []GeoJSONFeature `json:"features" yaml:"features"`
+}
+
+// NewFeatureCollection creates a GeoJSON FeatureCollection.
+func NewFeatureCollection(features ...GeoJSONFeature) GeoJSONFeatureCollection {
+	if features == nil {
+		features = []GeoJSONFeature{}
+	}
+	return GeoJSONFeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+// NewPointFeature creates a pin at the given coordinate.
+func NewPointFeature(at LatLng, properties map[string]interface{}) GeoJSONFeature {
+	return GeoJSONFeature{
+		Type: "Feature",
+		Geometry: GeoJSONGeometry{
+			Type:        "Point",
+			Coordinates: []float64{at.Lng, at.Lat},
+		},
+		Properties: properties,
+	}
+}
+
+// NewPolygonFeature creates a region from its outline. The ring is closed automatically.
+func NewPolygonFeature(outline []LatLng, properties map[string]interface{}) GeoJSONFeature {
+	ring := make([][]float64, 0, len(outline)+1)
+	for _, p := range outline {
+		ring = append(ring, []float64{p.Lng, p.Lat})
+	}
+	if len(outline) > 0 && outline[0] != outline[len(outline)-1] {
+		ring = append(ring, []float64{outline[0].Lng, outline[0].Lat})
+	}
+	return GeoJSONFeature{
+		Type: "Feature",
+		Geometry: GeoJSONGeometry{
+			Type:        "Polygon",
+			Coordinates: [][][]float64{ring},
+		},
+		Properties: properties,
+	}
+}
+
+// NewMapResult creates a StructuredResult that renders GeoJSON on a map.
+// geojson may be a GeoJSON value (e.g., from NewFeatureCollection), a decoded map, or raw JSON.
+// Pass a nil center to let the host fit the view to the features.
+//
+// Example:
+//
+//	pins := pluginapi.NewFeatureCollection(
+//	    pluginapi.NewPointFeature(pluginapi.LatLng{Lat: 52.52, Lng: 13.405}, map[string]interface{}{"title": "Berlin"}),
+//	)
+//	result := pluginapi.NewMapResult("Nearby", pins, nil, 0)
+func NewMapResult(title string, geojson interface{}, center *LatLng, zoom int) *StructuredResult {
+	// Decode raw JSON so the result serializes to both JSON and YAML;
+	// undecodable input is kept as-is and rejected by Validate
+	var raw []byte
+	switch v := geojson.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	}
+	if raw != nil {
+		var decoded interface{}
+		if err := json.Unmarshal(raw, &decoded); err == nil {
+			geojson = decoded
+		}
+	}
+	return &StructuredResult{
+		DisplayType: DisplayTypeMap,
+		Title:       title,
+		Data: MapData{
+			GeoJSON: geojson,
+			Center:  center,
+			Zoom:    zoom,
+		},
+	}
+}
+
+// Validate checks that the map payload is well-formed.
+func (m *MapData) Validate() error {
+	if m.Center != nil {
+		if err := m.Center.validate(); err != nil {
+			return fmt.Errorf("center: %w", err)
+		}
+	}
+	if m.Zoom < 0 || m.Zoom > MaxMapZoom {
+		return fmt.Errorf("zoom must be between 0 and %d (got %d)", MaxMapZoom, m.Zoom)
+	}
+	if m.GeoJSON == nil {
+		return fmt.Errorf("geojson is required")
+	}
+
+	raw, err := json.Marshal(m.GeoJSON)
+	if err != nil {
+		return fmt.Errorf("invalid geojson: %w", err)
+	}
+	var object struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return fmt.Errorf("geojson must be an object: %w", err)
+	}
+	switch object.Type {
+	case "FeatureCollection", "Feature", "Point", "MultiPoint", "LineString",
+		"MultiLineString", "Polygon", "MultiPolygon", "GeometryCollection":
+	case "":
+		return fmt.Errorf("geojson type is required")
+	default:
+		return fmt.Errorf("unsupported geojson type: %s", object.Type)
+	}
+	return nil
+}
+
+// validate checks that the coordinate is within range.
+func (p LatLng) validate() error {
+	if p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90 (got %v)", p.Lat)
+	}
+	if p.Lng < -180 || p.Lng > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180 (got %v)", p.Lng)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package pluginapi
+
+import (
+	"testing"
+)
+
+func TestMapResult_Validate(t *testing.T) {
+	berlin := LatLng{Lat: 52.52, Lng: 13.405}
+	pins := NewFeatureCollection(NewPointFeature(berlin, map[string]interface{}{"title": "Berlin"}))
+
+	tests := []struct {
+		name    string
+		result  *StructuredResult
+		wantErr bool
+	}{
+		{name: "feature collection", result: NewMapResult("Pins", pins, &berlin, 12)},
+		{name: "no center", result: NewMapResult("Pins", pins, nil, 0)},
+		{
+			name:   "polygon",
+			result: NewMapResult("Region", NewPolygonFeature([]LatLng{{0, 0}, {0, 1}, {1, 1}}, nil), nil, 0),
+		},
+		{name: "raw json", result: NewMapResult("Raw", `{"type":"Point","coordinates":[13.4,52.5]}`, nil, 0)},
+		{name: "invalid raw json", result: NewMapResult("Raw", `{`, nil, 0), wantErr: true},
+		{name: "missing geojson", result: NewMapResult("Empty", nil, nil, 0), wantErr: true},
+		{name: "unknown geojson type", result: NewMapResult("Bad", map[string]interface{}{"type": "Circle"}, nil, 0), wantErr: true},
+		{name: "latitude out of range", result: NewMapResult("Bad", pins, &LatLng{Lat: 91}, 3), wantErr: true},
+		{name: "zoom out of range", result: NewMapResult("Bad", pins, &berlin, 30), wantErr: true},
+		{name: "missing map data", result: &StructuredResult{DisplayType: DisplayTypeMap}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.result.Validate()
+			if tt.wantErr && err == nil {
+				t.Error("expected validation error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewPolygonFeature_ClosesRing(t *testing.T) {
+	feature := NewPolygonFeature([]LatLng{{Lat: 1, Lng: 2}, {Lat: 3, Lng: 4}, {Lat: 5, Lng: 6}}, nil)
+	rings := feature.Geometry.Coordinates.([][][]float64)
+	ring := rings[0]
+	if len(ring) != 4 {
+		t.Fatalf("expected closed ring of 4 positions, got %d", len(ring))
+	}
+	if ring[0][0] != 2 || ring[0][1] != 1 {
+		t.Errorf("expected [lng, lat] order, got %v", ring[0])
+	}
+	if ring[3][0] != ring[0][0] || ring[3][1] != ring[0][1] {
+		t.Errorf("ring not closed: %v", ring)
+	}
+}
+
+func TestMapResult_ValidateAfterParse(t *testing.T) {
+	center := LatLng{Lat: 48.85, Lng: 2.35}
+	result := NewMapResult("Paris", NewFeatureCollection(NewPointFeature(center, nil)), &center, 10)
+
+	jsonStr, err := result.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	parsed, err := ParseStructuredResult(jsonStr)
+	if err != nil {
+		t.Fatalf("ParseStructuredResult failed: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Errorf("unexpected validation error after parse: %v", err)
+	}
+
+	yamlStr, err := result.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+	parsed, err = FromYAML(yamlStr)
+	if err != nil {
+		t.Fatalf("FromYAML failed: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Errorf("unexpected validation error after YAML parse: %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+// Package markdown provides helpers for composing Markdown documents safely.
+// Use it with pluginapi.NewMarkdownResult so reports render correctly regardless
+// of the characters in user-provided data.
+//
+// Example:
+//
+//	doc := markdown.New().
+//	    Heading(1, "Build report").
+//	    Paragraph("Finished in " + markdown.Bold(elapsed)).
+//	    Table([]string{"Step", "Status"}, rows).
+//	    CodeBlock("bash", logTail)
+//	return pluginapi.NewMarkdownResult("Build report", doc.String()).ToJSON()
+package markdown
+
+import (
+	"fmt"
+	"strings"
+)
+
+// specialChars are characters with meaning in Markdown inline text.
+const specialChars = "\\`*_{}[]()#+-.!|<>~"
+
+// Escape backslash-escapes Markdown special characters so text renders literally.
+func Escape(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range text {
+		if strings.ContainsRune(specialChars, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Bold returns escaped text in bold.
+func Bold(text string) string {
+	return "**" + Escape(text) + "**"
+}
+
+// Italic returns escaped text in italics.
+func Italic(text string) string {
+	return "_" + Escape(text) + "_"
+}
+
+// Code returns text as inline code, using a backtick run longer than any in text.
+func Code(text string) string {
+	fence := strings.Repeat("`", longestRun(text, '`')+1)
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return fence + " " + text + " " + fence
+	}
+	return fence + text + fence
+}
+
+// Link returns a link with escaped text. Parentheses and spaces in url are percent-encoded.
+func Link(text, url string) string {
+	return "[" + Escape(text) + "](" + escapeURL(url) + ")"
+}
+
+// Heading returns a heading line. level is clamped to 1-6.
+func Heading(level int, text string) string {
+	if level < 1 {
+		level = 1
+	}
+	if level > 6 {
+		level = 6
+	}
+	return strings.Repeat("#", level) + " " + Escape(singleLine(text))
+}
+
+// CodeBlock returns a fenced code block. The fence is longer than any backtick
+// run in code, so code containing ``` cannot terminate the block early.
+func CodeBlock(language, code string) string {
+	fence := strings.Repeat("`", max(3, longestRun(code, '`')+1))
+	return fence + language + "\n" + strings.TrimSuffix(code, "\n") + "\n" + fence
+}
+
+// Table returns a table with escaped cells. Rows shorter than headers are padded;
+// longer rows are truncated. Newlines in cells are replaced with <br>.
+func Table(headers []string, rows [][]string) string {
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for i := range headers {
+			cell := ""
+			if i < len(cells) {
+				cell = tableCell(cells[i])
+			}
+			b.WriteString(" " + cell + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	writeRow(headers)
+	b.WriteString("|")
+	for range headers {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// List returns a bulleted list of escaped items.
+func List(items ...string) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = "- " + Escape(singleLine(item))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NumberedList returns a numbered list of escaped items.
+func NumberedList(items ...string) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = fmt.Sprintf("%d. %s", i+1, Escape(singleLine(item)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Quote returns escaped text as a block quote.
+func Quote(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = "> " + Escape(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Builder composes a Markdown document block by block.
+// Blocks are separated by blank lines. Text passed to Paragraph is NOT escaped,
+// so it can contain inline formatting built with Bold, Code, Link, etc.;
+// wrap untrusted text with Escape.
+type Builder struct {
+	blocks []string
+}
+
+// New creates an empty document builder.
+func New() *Builder {
+	return &Builder{}
+}
+
+// Heading appends a heading.
+func (b *Builder) Heading(level int, text string) *Builder {
+	return b.Raw(Heading(level, text))
+}
+
+// Paragraph appends a paragraph of (unescaped) Markdown text.
+func (b *Builder) Paragraph(text string) *Builder {
+	return b.Raw(text)
+}
+
+// Text appends a paragraph of escaped plain text.
+func (b *Builder) Text(text string) *Builder {
+	return b.Raw(Escape(text))
+}
+
+// CodeBlock appends a fenced code block.
+func (b *Builder) CodeBlock(language, code string) *Builder {
+	return b.Raw(CodeBlock(language, code))
+}
+
+// Table appends a table.
+func (b *Builder) Table(headers []string, rows [][]string) *Builder {
+	return b.Raw(Table(headers, rows))
+}
+
+// List appends a bulleted list.
+func (b *Builder) List(items ...string) *Builder {
+	return b.Raw(List(items...))
+}
+
+// NumberedList appends a numbered list.
+func (b *Builder) NumberedList(items ...string) *Builder {
+	return b.Raw(NumberedList(items...))
+}
+
+// Quote appends a block quote.
+func (b *Builder) Quote(text string) *Builder {
+	return b.Raw(Quote(text))
+}
+
+// Rule appends a horizontal rule.
+func (b *Builder) Rule() *Builder {
+	return b.Raw("---")
+}
+
+// Raw appends a pre-formatted Markdown block as-is.
+func (b *Builder) Raw(block string) *Builder {
+	b.blocks = append(b.blocks, block)
+	return b
+}
+
+// String returns the document.
+func (b *Builder) String() string {
+	return strings.Join(b.blocks, "\n\n")
+}
+
+// tableCell escapes a table cell, keeping it on a single line.
+func tableCell(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	parts := strings.Split(text, "\n")
+	for i, part := range parts {
+		parts[i] = Escape(part)
+	}
+	return strings.Join(parts, "<br>")
+}
+
+// singleLine collapses newlines so text stays within one block element.
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// escapeURL encodes characters that would break a Markdown link destination.
+func escapeURL(url string) string {
+	r := strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E")
+	return r.Replace(url)
+}
+
+// longestRun returns the length of the longest run of c in s.
+func longestRun(s string, c byte) int {
+	longest, current := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			current++
+			if current > longest {
+				longest = current
+			}
+		} else {
+			current = 0
+		}
+	}
+	return longest
+}
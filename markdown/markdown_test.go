@@ -0,0 +1,68 @@
+package markdown
+
+import "testing"
+
+func TestEscape(t *testing.T) {
+	got := Escape("a*b_c [x](y) #1 | <tag>")
+	want := `a\*b\_c \[x\]\(y\) \#1 \| \<tag\>`
+	if got != want {
+		t.Errorf("Escape() = %q, want %q", got, want)
+	}
+}
+
+func TestCodeBlock_LongerFence(t *testing.T) {
+	got := CodeBlock("md", "```go\nfmt.Println()\n```\n")
+	want := "````md\n```go\nfmt.Println()\n```\n````"
+	if got != want {
+		t.Errorf("CodeBlock() = %q, want %q", got, want)
+	}
+}
+
+func TestCode(t *testing.T) {
+	tests := map[string]string{
+		"plain":     "`plain`",
+		"a`b":       "``a`b``",
+		"`starts":   "`` `starts ``",
+		"a``b":      "```a``b```",
+		"no-ticks!": "`no-ticks!`",
+	}
+	for input, want := range tests {
+		if got := Code(input); got != want {
+			t.Errorf("Code(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+	got := Table([]string{"Name", "Note"}, [][]string{
+		{"a|b", "line1\nline2"},
+		{"short"},
+	})
+	want := "| Name | Note |\n| --- | --- |\n| a\\|b | line1<br>line2 |\n| short |  |"
+	if got != want {
+		t.Errorf("Table() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLinkAndHeading(t *testing.T) {
+	if got := Link("docs [v2]", "https://example.com/a (b)"); got != `[docs \[v2\]](https://example.com/a%20%28b%29)` {
+		t.Errorf("Link() = %q", got)
+	}
+	if got := Heading(9, "Title\nwith newline"); got != "###### Title with newline" {
+		t.Errorf("Heading() = %q", got)
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	doc := New().
+		Heading(1, "Report").
+		Paragraph("Status: "+Bold("ok")).
+		List("one", "two").
+		Rule().
+		String()
+
+	want := "# Report\n\nStatus: **ok**\n\n- one\n- two\n\n---"
+	if doc != want {
+		t.Errorf("Builder.String() =\n%s\nwant:\n%s", doc, want)
+	}
+}
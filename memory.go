@@ -0,0 +1,73 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultRecallLimit is used when Recall is called with k of 0.
+const DefaultRecallLimit = 5
+
+// MemoryRecord is a fact stored in the agent's memory.
+type MemoryRecord struct {
+	Key  string   `json:"key"`
+	Text string   `json:"text"`
+	Tags []string `json:"tags,omitempty"`
+	// Score is the record's relevance to the Recall query, higher is better
+	Score float64 `json:"score,omitempty"`
+}
+
+func (UnimplementedHostServices) Remember(ctx context.Context, key, text string, tags []string) error {
+	return ErrHostServiceUnavailable
+}
+
+func (UnimplementedHostServices) Recall(ctx context.Context, query string, k int) ([]MemoryRecord, error) {
+	return nil, ErrHostServiceUnavailable
+}
+
+func (s *hostServer) Remember(ctx context.Context, req *HostRememberRequest) (*ConfigResponse, error) {
+	return hostResponse(s.Impl.Remember(ctx, req.Key, req.Text, req.Tags))
+}
+
+func (s *hostServer) Recall(ctx context.Context, req *HostRecallRequest) (*HostRecallResponse, error) {
+	k := int(req.K)
+	if k <= 0 {
+		k = DefaultRecallLimit
+	}
+	records, err := s.Impl.Recall(ctx, req.Query, k)
+	if st := hostStatus(err); st != nil {
+		return nil, st
+	}
+	if err != nil {
+		return &HostRecallResponse{Error: err.Error()}, nil
+	}
+	if len(records) > k {
+		records = records[:k]
+	}
+
+	protoRecords := make([]*ProtoMemoryRecord, len(records))
+	for i, r := range records {
+		protoRecords[i] = &ProtoMemoryRecord{Key: r.Key, Text: r.Text, Tags: r.Tags, Score: r.Score}
+	}
+	return &HostRecallResponse{Records: protoRecords}, nil
+}
+
+func (h *hostClient) Remember(ctx context.Context, key, text string, tags []string) error {
+	return hostError(h.client.Remember(h.outgoing(ctx), &HostRememberRequest{Key: key, Text: text, Tags: tags}))
+}
+
+func (h *hostClient) Recall(ctx context.Context, query string, k int) ([]MemoryRecord, error) {
+	resp, err := h.client.Recall(h.outgoing(ctx), &HostRecallRequest{Query: query, K: int32(k)})
+	if err != nil {
+		return nil, hostSentinel(err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+
+	records := make([]MemoryRecord, len(resp.Records))
+	for i, r := range resp.Records {
+		records[i] = MemoryRecord{Key: r.Key, Text: r.Text, Tags: r.Tags, Score: r.Score}
+	}
+	return records, nil
+}
@@ -0,0 +1,88 @@
+package pluginapi
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// keywordMemoryHost scores memories by how many query words they contain, per plugin.
+type keywordMemoryHost struct {
+	UnimplementedHostServices
+	memories map[string]map[string]MemoryRecord
+}
+
+func (h *keywordMemoryHost) Remember(ctx context.Context, key, text string, tags []string) error {
+	plugin := HostCallerToken(ctx)
+	if h.memories[plugin] == nil {
+		h.memories[plugin] = make(map[string]MemoryRecord)
+	}
+	h.memories[plugin][key] = MemoryRecord{Key: key, Text: text, Tags: tags}
+	return nil
+}
+
+func (h *keywordMemoryHost) Recall(ctx context.Context, query string, k int) ([]MemoryRecord, error) {
+	var records []MemoryRecord
+	for _, r := range h.memories[HostCallerToken(ctx)] {
+		for _, word := range strings.Fields(query) {
+			if strings.Contains(r.Text, word) {
+				r.Score++
+			}
+		}
+		if r.Score > 0 {
+			records = append(records, r)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Score > records[j].Score })
+	return records, nil
+}
+
+func connectTestHost(t *testing.T, impl HostServices, token string) HostServices {
+	t.Helper()
+	var conn hostConnection
+	host, _, err := conn.connect(newTestHost(t, impl), token)
+	if err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	return host
+}
+
+func TestHostServices_RememberRecall(t *testing.T) {
+	impl := &keywordMemoryHost{memories: make(map[string]map[string]MemoryRecord)}
+	samples := connectTestHost(t, impl, "samples")
+	ctx := context.Background()
+
+	for key, text := range map[string]string{
+		"kick":  "deep kick drum at 90 bpm",
+		"snare": "crisp snare at 120 bpm",
+		"pad":   "warm analog pad",
+	} {
+		if err := samples.Remember(ctx, key, text, []string{"sample"}); err != nil {
+			t.Fatalf("Remember failed: %v", err)
+		}
+	}
+	if err := samples.Remember(ctx, "kick", "deep kick drum at 90 bpm, favourite", nil); err != nil {
+		t.Fatalf("Remember failed: %v", err)
+	}
+
+	records, err := samples.Recall(ctx, "kick bpm", 0)
+	if err != nil {
+		t.Fatalf("Recall failed: %v", err)
+	}
+	if len(records) != 2 || records[0].Key != "kick" || records[0].Score != 2 {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	if !strings.HasSuffix(records[0].Text, "favourite") || records[0].Tags != nil {
+		t.Errorf("Remember should replace memories with the same key, got %+v", records[0])
+	}
+
+	if records, _ := samples.Recall(ctx, "bpm", 1); len(records) != 1 {
+		t.Errorf("expected k to cap the results, got %+v", records)
+	}
+
+	other := connectTestHost(t, impl, "notes")
+	if records, _ := other.Recall(ctx, "kick", 0); len(records) != 0 {
+		t.Errorf("memories should be scoped to the calling plugin, got %+v", records)
+	}
+}
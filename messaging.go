@@ -0,0 +1,227 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MaxPluginMessageSize is the largest payload SendToPlugin accepts.
+const MaxPluginMessageSize = 1 << 20
+
+// pluginMessageQueueSize is how many undelivered messages a MessageBus subscription holds.
+const pluginMessageQueueSize = 64
+
+// PluginMessage is a message between plugins, routed by the host.
+type PluginMessage struct {
+	// From is the sending plugin, filled in by the host
+	From string `json:"from"`
+	// Topic names the kind of message (e.g., "midi.note_on"); receivers subscribe to topics
+	Topic string `json:"topic"`
+	// Payload is the plugin-defined content, usually JSON
+	Payload []byte `json:"payload,omitempty"`
+}
+
+// MessageListener allows plugins to receive messages from other plugins (see
+// HostServices.SendToPlugin). Plugins can optionally implement this interface; once
+// the host connects its services, the plugin is subscribed automatically and stays
+// subscribed across host reconnects.
+type MessageListener interface {
+	PluginTool
+	// MessageTopics lists the topics to receive; nil receives all of them
+	MessageTopics() []string
+	// OnPluginMessage is called for each message, one at a time
+	OnPluginMessage(msg PluginMessage)
+}
+
+func validatePluginMessage(topic string, payload []byte) error {
+	if topic == "" {
+		return fmt.Errorf("message topic is required")
+	}
+	if len(payload) > MaxPluginMessageSize {
+		return fmt.Errorf("message payload exceeds %d bytes", MaxPluginMessageSize)
+	}
+	return nil
+}
+
+// listenForMessages keeps listener subscribed to plugin messages until ctx ends or
+// the host turns out not to provide them.
+func listenForMessages(ctx context.Context, host HostServices, listener MessageListener) {
+	for {
+		err := host.SubscribeMessages(ctx, listener.MessageTopics(), func(msg PluginMessage) error {
+			listener.OnPluginMessage(msg)
+			return nil
+		})
+		if errors.Is(err, ErrHostServiceUnavailable) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventResubscribeDelay):
+		}
+	}
+}
+
+func (UnimplementedHostServices) SendToPlugin(ctx context.Context, target, topic string, payload []byte) error {
+	return ErrHostServiceUnavailable
+}
+
+func (UnimplementedHostServices) SubscribeMessages(ctx context.Context, topics []string, handle func(PluginMessage) error) error {
+	return ErrHostServiceUnavailable
+}
+
+func (s *hostServer) SendToPlugin(ctx context.Context, req *ProtoPluginMessage) (*ConfigResponse, error) {
+	if err := validatePluginMessage(req.Topic, req.Payload); err != nil {
+		return &ConfigResponse{Success: false, Error: err.Error()}, nil
+	}
+	return hostResponse(s.Impl.SendToPlugin(ctx, req.Target, req.Topic, req.Payload))
+}
+
+// SubscribeMessages only forwards the requested topics, even if the host sends others.
+func (s *hostServer) SubscribeMessages(req *HostSubscribeMessagesRequest, stream HostService_SubscribeMessagesServer) error {
+	err := s.Impl.SubscribeMessages(stream.Context(), req.Topics, func(msg PluginMessage) error {
+		if len(req.Topics) > 0 && !slices.Contains(req.Topics, msg.Topic) {
+			return nil
+		}
+		return stream.Send(&ProtoPluginMessage{From: msg.From, Topic: msg.Topic, Payload: msg.Payload})
+	})
+	if st := hostStatus(err); st != nil {
+		return st
+	}
+	return err
+}
+
+func (h *hostClient) SendToPlugin(ctx context.Context, target, topic string, payload []byte) error {
+	if err := validatePluginMessage(topic, payload); err != nil {
+		return err
+	}
+	return hostError(h.client.SendToPlugin(h.outgoing(ctx), &ProtoPluginMessage{
+		Target:  target,
+		Topic:   topic,
+		Payload: payload,
+	}))
+}
+
+func (h *hostClient) SubscribeMessages(ctx context.Context, topics []string, handle func(PluginMessage) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := h.client.SubscribeMessages(h.outgoing(ctx), &HostSubscribeMessagesRequest{Topics: topics})
+	if err != nil {
+		return hostSentinel(err)
+	}
+	for {
+		pm, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return hostSentinel(err)
+		}
+		if err := handle(PluginMessage{From: pm.From, Topic: pm.Topic, Payload: pm.Payload}); err != nil {
+			return err
+		}
+	}
+}
+
+// MessageBus is a host-side helper that routes plugin messages for SendToPlugin and
+// SubscribeMessages. Plugins are identified by any host-chosen name, typically the
+// one their HostCallerToken maps to. It is safe for concurrent use.
+//
+// Example:
+//
+//	func (h *agentHost) SendToPlugin(ctx context.Context, target, topic string, payload []byte) error {
+//	    return h.bus.Send(pluginapi.HostCallerToken(ctx), target, topic, payload)
+//	}
+//
+//	func (h *agentHost) SubscribeMessages(ctx context.Context, topics []string, handle func(pluginapi.PluginMessage) error) error {
+//	    return h.bus.Subscribe(ctx, pluginapi.HostCallerToken(ctx), topics, handle)
+//	}
+type MessageBus struct {
+	mu   sync.Mutex
+	subs map[*messageSubscription]struct{}
+}
+
+type messageSubscription struct {
+	plugin string
+	topics []string
+	queue  chan PluginMessage
+}
+
+// NewMessageBus creates a bus with no subscribers.
+func NewMessageBus() *MessageBus {
+	return &MessageBus{subs: make(map[*messageSubscription]struct{})}
+}
+
+// Send delivers a message from plugin from to target's subscriptions for topic, or
+// with an empty target, to every other plugin subscribed to topic. It fails if no
+// subscription receives the message or a receiver's queue is full; other receivers
+// still get the message.
+func (b *MessageBus) Send(from, target, topic string, payload []byte) error {
+	if err := validatePluginMessage(topic, payload); err != nil {
+		return err
+	}
+	msg := PluginMessage{From: from, Topic: topic, Payload: payload}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delivered := false
+	var behind string
+	for sub := range b.subs {
+		if (target != "" && sub.plugin != target) || (target == "" && sub.plugin == from) {
+			continue
+		}
+		if len(sub.topics) > 0 && !slices.Contains(sub.topics, topic) {
+			continue
+		}
+		select {
+		case sub.queue <- msg:
+			delivered = true
+		default:
+			behind = sub.plugin
+		}
+	}
+	if behind != "" {
+		return fmt.Errorf("plugin %q is not keeping up with messages", behind)
+	}
+	if !delivered {
+		if target == "" {
+			return fmt.Errorf("no plugin is subscribed to %q", topic)
+		}
+		return fmt.Errorf("plugin %q is not subscribed to %q", target, topic)
+	}
+	return nil
+}
+
+// Subscribe calls handle with the messages sent to plugin on topics (all topics if
+// none are given) until ctx ends or handle returns an error.
+func (b *MessageBus) Subscribe(ctx context.Context, plugin string, topics []string, handle func(PluginMessage) error) error {
+	sub := &messageSubscription{plugin: plugin, topics: topics, queue: make(chan PluginMessage, pluginMessageQueueSize)}
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		delete(b.subs, sub)
+		b.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg := <-sub.queue:
+			if err := handle(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package pluginapi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// busHost routes plugin messages with a MessageBus, naming plugins by their host token.
+type busHost struct {
+	UnimplementedHostServices
+	bus *MessageBus
+}
+
+func (h *busHost) SendToPlugin(ctx context.Context, target, topic string, payload []byte) error {
+	return h.bus.Send(HostCallerToken(ctx), target, topic, payload)
+}
+
+func (h *busHost) SubscribeMessages(ctx context.Context, topics []string, handle func(PluginMessage) error) error {
+	return h.bus.Subscribe(ctx, HostCallerToken(ctx), topics, handle)
+}
+
+type messageListenerTestTool struct {
+	plainTestTool
+	received chan PluginMessage
+}
+
+func (t *messageListenerTestTool) MessageTopics() []string {
+	return []string{"midi.note_on"}
+}
+
+func (t *messageListenerTestTool) OnPluginMessage(msg PluginMessage) {
+	t.received <- msg
+}
+
+func TestMessageListener(t *testing.T) {
+	impl := &busHost{bus: NewMessageBus()}
+	addr := newTestHost(t, impl)
+	daw := &messageListenerTestTool{received: make(chan PluginMessage, 4)}
+	if err := newTestClient(t, daw).ConnectHostServices(context.Background(), addr, "daw"); err != nil {
+		t.Fatalf("ConnectHostServices failed: %v", err)
+	}
+	midi := connectTestHost(t, impl, "midi")
+	ctx := context.Background()
+
+	// The listener subscribes in the background; retry until it has
+	deadline := time.Now().Add(5 * time.Second)
+	for midi.SendToPlugin(ctx, "daw", "midi.note_on", []byte(`{"note":60}`)) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("plugin did not subscribe to messages")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case msg := <-daw.received:
+		if msg.From != "midi" || msg.Topic != "midi.note_on" || string(msg.Payload) != `{"note":60}` {
+			t.Errorf("unexpected message %+v", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the message")
+	}
+
+	if err := midi.SendToPlugin(ctx, "", "midi.note_on", nil); err != nil {
+		t.Errorf("broadcast failed: %v", err)
+	}
+	if err := midi.SendToPlugin(ctx, "daw", "transport.play", nil); err == nil {
+		t.Error("expected an error for a topic the target is not subscribed to")
+	}
+	if err := midi.SendToPlugin(ctx, "daw", "", nil); err == nil {
+		t.Error("expected an error for a missing topic")
+	}
+}
@@ -0,0 +1,169 @@
+package pluginapi
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// CallMetrics describes one RPC a plugin served.
+type CallMetrics struct {
+	// Method is the RPC name (e.g., "Call"), the same for every protocol version
+	Method   string
+	Duration time.Duration
+	// Err is the failure, including calls that failed with a PluginError, nil on success
+	Err error
+	// RequestBytes and ResponseBytes are the encoded message sizes, summed over streams
+	RequestBytes  int
+	ResponseBytes int
+}
+
+// MetricsRecorder allows plugins to export per-call metrics (e.g., to Prometheus).
+// Plugins can optionally implement this interface. RecordCall runs after every RPC,
+// on the RPC's goroutine, so it should not block. Hosts can read the same numbers,
+// aggregated, with the GetStats RPC whether or not the plugin implements it.
+type MetricsRecorder interface {
+	RecordCall(metrics CallMetrics)
+}
+
+// MethodStats aggregates the calls of one RPC since the plugin started.
+type MethodStats struct {
+	Method        string
+	Calls         int64
+	Errors        int64
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+	RequestBytes  int64
+	ResponseBytes int64
+}
+
+// AverageDuration returns the mean call duration.
+func (s MethodStats) AverageDuration() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Calls)
+}
+
+// ErrorRate returns the fraction of calls that failed, from 0 to 1.
+func (s MethodStats) ErrorRate() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Calls)
+}
+
+// callStats aggregates CallMetrics by method for GetStats.
+// The zero value is ready to use.
+type callStats struct {
+	mu      sync.Mutex
+	methods map[string]*MethodStats
+}
+
+func (c *callStats) record(m CallMetrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.methods == nil {
+		c.methods = make(map[string]*MethodStats)
+	}
+	stats := c.methods[m.Method]
+	if stats == nil {
+		stats = &MethodStats{Method: m.Method}
+		c.methods[m.Method] = stats
+	}
+	stats.Calls++
+	if m.Err != nil {
+		stats.Errors++
+	}
+	stats.TotalDuration += m.Duration
+	stats.MaxDuration = max(stats.MaxDuration, m.Duration)
+	stats.RequestBytes += int64(m.RequestBytes)
+	stats.ResponseBytes += int64(m.ResponseBytes)
+}
+
+// snapshot returns the stats of every method called so far, sorted by method.
+func (c *callStats) snapshot() []MethodStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := make([]MethodStats, 0, len(c.methods))
+	for _, s := range c.methods {
+		stats = append(stats, *s)
+	}
+	slices.SortFunc(stats, func(a, b MethodStats) int { return strings.Compare(a.Method, b.Method) })
+	return stats
+}
+
+// measureUnary records CallMetrics for every unary RPC.
+func measureUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	recordCall(info.Server, CallMetrics{
+		Method:        path.Base(info.FullMethod),
+		Duration:      time.Since(start),
+		Err:           spanError(resp, err),
+		RequestBytes:  messageSize(req),
+		ResponseBytes: messageSize(resp),
+	})
+	return resp, err
+}
+
+// measureStream is measureUnary for streaming RPCs.
+func measureStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	measured := &measuredServerStream{ServerStream: stream}
+	err := handler(srv, measured)
+	recordCall(srv, CallMetrics{
+		Method:        path.Base(info.FullMethod),
+		Duration:      time.Since(start),
+		Err:           err,
+		RequestBytes:  int(measured.received.Load()),
+		ResponseBytes: int(measured.sent.Load()),
+	})
+	return err
+}
+
+// recordCall adds m to the plugin server's stats and passes it to the plugin's MetricsRecorder.
+func recordCall(srv any, m CallMetrics) {
+	s, ok := srv.(*grpcServer)
+	if !ok {
+		return
+	}
+	s.stats.record(m)
+	if recorder, ok := s.Impl.(MetricsRecorder); ok {
+		recorder.RecordCall(m)
+	}
+}
+
+func messageSize(m any) int {
+	if msg, ok := m.(proto.Message); ok {
+		return proto.Size(msg)
+	}
+	return 0
+}
+
+// measuredServerStream counts the bytes of the messages on a stream.
+// Bidirectional streams may send and receive on different goroutines.
+type measuredServerStream struct {
+	grpc.ServerStream
+	sent, received atomic.Int64
+}
+
+func (s *measuredServerStream) SendMsg(m any) error {
+	s.sent.Add(int64(messageSize(m)))
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s *measuredServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Add(int64(messageSize(m)))
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package pluginapi
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+type measuredTestTool struct {
+	streamingTestTool
+	mu       sync.Mutex
+	recorded []CallMetrics
+}
+
+func (t *measuredTestTool) RecordCall(m CallMetrics) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.recorded = append(t.recorded, m)
+}
+
+func TestGRPCClient_GetStats(t *testing.T) {
+	tool := &measuredTestTool{}
+	client := &grpcClient{client: NewToolServiceClient(newTestConn(t, tool, serverOptions(tool)...))}
+	ctx := context.Background()
+
+	if _, err := client.Call(ctx, "{}"); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if _, err := client.Call(ctx, "fail"); err == nil {
+		t.Fatal("expected the failing call to fail")
+	}
+	if _, err := client.CallStream(ctx, "{}", func(CallChunk) error { return nil }); err != nil {
+		t.Fatalf("CallStream failed: %v", err)
+	}
+
+	stats, err := client.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	byMethod := map[string]MethodStats{}
+	for _, s := range stats {
+		byMethod[s.Method] = s
+	}
+	call := byMethod["Call"]
+	if call.Calls != 2 || call.Errors != 1 || call.ErrorRate() != 0.5 {
+		t.Errorf("unexpected Call stats: %+v", call)
+	}
+	if call.RequestBytes == 0 || call.ResponseBytes == 0 || call.MaxDuration <= 0 || call.TotalDuration < call.MaxDuration {
+		t.Errorf("expected sizes and durations, got %+v", call)
+	}
+	stream := byMethod["CallStream"]
+	if stream.Calls != 1 || stream.Errors != 0 || stream.ResponseBytes == 0 {
+		t.Errorf("unexpected CallStream stats: %+v", stream)
+	}
+
+	tool.mu.Lock()
+	defer tool.mu.Unlock()
+	if len(tool.recorded) != 4 || tool.recorded[1].Method != "Call" || tool.recorded[1].Err == nil {
+		t.Errorf("unexpected recorded metrics: %+v", tool.recorded)
+	}
+}
+
+func TestMethodStats_EmptyRates(t *testing.T) {
+	var s MethodStats
+	if s.AverageDuration() != 0 || s.ErrorRate() != 0 {
+		t.Errorf("expected zero rates without calls, got %v and %v", s.AverageDuration(), s.ErrorRate())
+	}
+}
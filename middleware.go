@@ -0,0 +1,79 @@
+package pluginapi
+
+import (
+	"context"
+	"slices"
+)
+
+// CallHandler runs a tool call: it takes the args JSON and returns the result JSON.
+type CallHandler func(ctx context.Context, args string) (string, error)
+
+// Middleware wraps a CallHandler with code that runs before and after the call,
+// such as validation, logging, timing, or rewriting the result. It calls next to
+// continue the call, or returns without calling it to reject the call.
+//
+// Example:
+//
+//	func requireArgs(next pluginapi.CallHandler) pluginapi.CallHandler {
+//	    return func(ctx context.Context, args string) (string, error) {
+//	        if strings.TrimSpace(args) == "" {
+//	            return "", pluginapi.NewPluginError(pluginapi.ErrorCodeInvalidArgs, "arguments are required")
+//	        }
+//	        return next(ctx, args)
+//	    }
+//	}
+type Middleware func(next CallHandler) CallHandler
+
+// wrappedTool is a tool whose calls run through middleware (see WrapTool).
+type wrappedTool struct {
+	PluginTool
+	middleware Middleware
+}
+
+// Call runs the call through the middleware, for hosts using the tool in-process.
+func (w *wrappedTool) Call(ctx context.Context, args string) (string, error) {
+	return w.middleware(w.PluginTool.Call)(ctx, args)
+}
+
+// WrapTool returns tool with its calls running through middlewares, the first
+// outermost. Serve the result with ServeGRPCPlugin (or return it from a
+// ToolSetProvider's Tools) in place of tool.
+//
+// The middleware runs around every way the tool is called: Call, CallWithFiles,
+// CallStream, and StartTask. It sees the args and the final result; attachments,
+// stream chunks, and task progress go to the tool unchanged. The optional
+// interfaces tool implements keep working, since the server looks through the
+// wrapper for them.
+//
+// Example:
+//
+//	func main() {
+//	    tool := pluginapi.WrapTool(&WeatherTool{}, requireArgs)
+//	    pluginapi.ServeGRPCPlugin(tool, configYAML)
+//	}
+func WrapTool(tool PluginTool, middlewares ...Middleware) PluginTool {
+	middlewares = slices.Clone(middlewares)
+	chain := func(next CallHandler) CallHandler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+	return &wrappedTool{PluginTool: tool, middleware: chain}
+}
+
+// unwrapTool returns the tool WrapTool wrapped and the middleware its calls run
+// through. Tools that aren't wrapped are returned as-is with a middleware that
+// does nothing.
+func unwrapTool(tool PluginTool) (PluginTool, Middleware) {
+	middleware := func(next CallHandler) CallHandler { return next }
+	for {
+		wrapped, ok := tool.(*wrappedTool)
+		if !ok {
+			return tool, middleware
+		}
+		outer := middleware
+		middleware = func(next CallHandler) CallHandler { return outer(wrapped.middleware(next)) }
+		tool = wrapped.PluginTool
+	}
+}
@@ -0,0 +1,65 @@
+package pluginapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// tagResult returns middleware that records its name in calls and appends it to the result.
+func tagResult(name string, calls *[]string) Middleware {
+	return func(next CallHandler) CallHandler {
+		return func(ctx context.Context, args string) (string, error) {
+			*calls = append(*calls, name)
+			result, err := next(ctx, args)
+			return result + "+" + name, err
+		}
+	}
+}
+
+func rejectEmptyArgs(next CallHandler) CallHandler {
+	return func(ctx context.Context, args string) (string, error) {
+		if args == "" {
+			return "", NewPluginError(ErrorCodeInvalidArgs, "arguments are required")
+		}
+		return next(ctx, args)
+	}
+}
+
+func TestWrapTool(t *testing.T) {
+	var calls []string
+	tool := WrapTool(WrapTool(&streamingTestTool{}, tagResult("inner", &calls)), tagResult("outer", &calls), rejectEmptyArgs)
+	client := newTestClient(t, tool)
+	ctx := context.Background()
+
+	result, err := client.Call(ctx, "{}")
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if result != `{"done":true}+inner+outer` {
+		t.Errorf("Call = %q", result)
+	}
+	if strings.Join(calls, ",") != "outer,inner" {
+		t.Errorf("middleware ran in order %v, want outer,inner", calls)
+	}
+
+	// The wrapped tool still streams
+	var chunks int
+	result, err = client.CallStream(ctx, "{}", func(CallChunk) error {
+		chunks++
+		return nil
+	})
+	if err != nil || result != `{"done":true}+inner+outer` || chunks != 4 {
+		t.Errorf("CallStream = %q, %v with %d chunks", result, err, chunks)
+	}
+
+	if _, err := client.Call(ctx, ""); AsPluginError(err).Code != ErrorCodeInvalidArgs {
+		t.Errorf("expected invalid_args for empty args, got %v", err)
+	}
+
+	// Called in-process, the wrapper runs the same chain
+	calls = nil
+	if result, err := tool.Call(ctx, "{}"); err != nil || result != `{"done":true}+inner+outer` {
+		t.Errorf("in-process Call = %q, %v", result, err)
+	}
+}
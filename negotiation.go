@@ -0,0 +1,83 @@
+package pluginapi
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Wire protocol features hosts and plugins agree on with Negotiate. A side only
+// uses a feature once both have listed it.
+const (
+	// FeatureCompression is gzip-compressed requests and FileAttachment.Encoding
+	FeatureCompression = "compression"
+	// FeatureChunkedUploads is the CallWithFilesStream RPC
+	FeatureChunkedUploads = "chunked_uploads"
+	// FeatureFileReferences is attachments sent by reference (FileAttachment.URI)
+	FeatureFileReferences = "file_references"
+	// FeatureOutputFiles is files returned with call results (FileOutputProvider)
+	FeatureOutputFiles = "output_files"
+)
+
+// supportedAPIVersions lists the wire protocol versions this package serves, oldest first.
+var supportedAPIVersions = []string{"v1", "v2"}
+
+// supportedFeatures lists the wire protocol features this package implements.
+var supportedFeatures = []string{
+	FeatureCompression,
+	FeatureChunkedUploads,
+	FeatureFileReferences,
+	FeatureOutputFiles,
+}
+
+// SupportedAPIVersions returns the wire protocol versions this package can speak, oldest first.
+func SupportedAPIVersions() []string {
+	return slices.Clone(supportedAPIVersions)
+}
+
+// Negotiation is what a host and plugin agreed on with Negotiate.
+type Negotiation struct {
+	// APIVersion is the highest wire protocol version both sides speak (e.g., "v2")
+	APIVersion string
+	// PluginAPIVersions lists every version the plugin speaks
+	PluginAPIVersions []string
+	// Features lists the features both sides support (e.g., FeatureCompression)
+	Features []string
+}
+
+// Supports reports whether both sides agreed on feature.
+func (n Negotiation) Supports(feature string) bool {
+	return slices.Contains(n.Features, feature)
+}
+
+// highestCommonVersion returns the newest version in both lists, or "" if they share none.
+func highestCommonVersion(a, b []string) string {
+	best := ""
+	for _, version := range a {
+		if slices.Contains(b, version) && (best == "" || compareAPIVersions(version, best) > 0) {
+			best = version
+		}
+	}
+	return best
+}
+
+// compareAPIVersions orders versions of the form "v<N>" numerically, so "v10" sorts after "v9".
+func compareAPIVersions(a, b string) int {
+	na, errA := strconv.Atoi(strings.TrimPrefix(a, "v"))
+	nb, errB := strconv.Atoi(strings.TrimPrefix(b, "v"))
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return na - nb
+}
+
+// commonFeatures returns the features in requested that this package supports.
+func commonFeatures(requested []string) []string {
+	var common []string
+	for _, feature := range requested {
+		if slices.Contains(supportedFeatures, feature) && !slices.Contains(common, feature) {
+			common = append(common, feature)
+		}
+	}
+	return common
+}
@@ -0,0 +1,68 @@
+package pluginapi
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCClient_Negotiate(t *testing.T) {
+	n, err := newTestClient(t, &plainTestTool{}).Negotiate(context.Background())
+	if err != nil {
+		t.Fatalf("Negotiate failed: %v", err)
+	}
+	if n.APIVersion != "v2" || !slices.Equal(n.PluginAPIVersions, []string{"v1", "v2"}) {
+		t.Errorf("unexpected negotiation: %+v", n)
+	}
+	if !n.Supports(FeatureChunkedUploads) || !n.Supports(FeatureOutputFiles) || n.Supports("teleportation") {
+		t.Errorf("unexpected features: %v", n.Features)
+	}
+
+	// Plugins built before negotiation answer with Unimplemented
+	server := grpc.NewServer()
+	RegisterToolServiceServer(server, UnimplementedToolServiceServer{})
+	old := &grpcClient{client: NewToolServiceClient(dialTestServer(t, server))}
+	if n, err := old.Negotiate(context.Background()); err != nil || n.APIVersion != "v1" || len(n.Features) != 0 {
+		t.Errorf("expected v1 without features for an old plugin, got %+v, %v", n, err)
+	}
+}
+
+func TestGRPCServer_Negotiate(t *testing.T) {
+	srv := &grpcServer{Impl: &plainTestTool{}}
+
+	// An older host agrees on the version it speaks and the features it knows
+	resp, err := srv.Negotiate(context.Background(), &NegotiateRequest{
+		ApiVersions: []string{"v1"},
+		Features:    []string{FeatureCompression, "holograms"},
+	})
+	if err != nil {
+		t.Fatalf("Negotiate failed: %v", err)
+	}
+	if resp.ApiVersion != "v1" || !slices.Equal(resp.Features, []string{FeatureCompression}) {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	// A newer host settles on the plugin's highest version
+	resp, err = srv.Negotiate(context.Background(), &NegotiateRequest{ApiVersions: []string{"v1", "v2", "v3"}})
+	if err != nil || resp.ApiVersion != "v2" {
+		t.Errorf("expected v2, got %+v, %v", resp, err)
+	}
+
+	_, err = srv.Negotiate(context.Background(), &NegotiateRequest{ApiVersions: []string{"v9"}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition without a common version, got %v", err)
+	}
+}
+
+func TestHighestCommonVersion(t *testing.T) {
+	if got := highestCommonVersion([]string{"v2", "v10", "v9"}, []string{"v9", "v10"}); got != "v10" {
+		t.Errorf("got %q, want v10", got)
+	}
+	if got := highestCommonVersion([]string{"v1"}, []string{"v2"}); got != "" {
+		t.Errorf("got %q, want none", got)
+	}
+}
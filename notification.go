@@ -0,0 +1,112 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// NotificationLevel sets the styling of a notification.
+type NotificationLevel string
+
+const (
+	NotificationInfo    NotificationLevel = "info"
+	NotificationSuccess NotificationLevel = "success"
+	NotificationWarning NotificationLevel = "warning"
+	NotificationError   NotificationLevel = "error"
+)
+
+// NotificationData is the payload of a DisplayTypeNotification result.
+type NotificationData struct {
+	Level   NotificationLevel `json:"level" yaml:"level"`
+	Message string            `json:"message" yaml:"message"`
+	// AutoDismissMs hints how long the host shows the notification; 0 uses the host default
+	AutoDismissMs int `json:"autoDismissMs,omitempty" yaml:"autoDismissMs,omitempty"`
+	// Persistent asks the host to keep the notification until the user dismisses it
+	Persistent bool `json:"persistent,omitempty" yaml:"persistent,omitempty"`
+}
+
+// NewNotificationResult creates a lightweight toast-style result for short confirmations
+// where a table or modal would be overkill.
+//
+// Example:
+//
+//	return pluginapi.NewNotificationResult(pluginapi.NotificationSuccess, "Project created").
+//	    WithAutoDismiss(3 * time.Second).ToJSON()
+func NewNotificationResult(level NotificationLevel, message string) *StructuredResult {
+	return &StructuredResult{
+		DisplayType: DisplayTypeNotification,
+		Data: NotificationData{
+			Level:   level,
+			Message: message,
+		},
+	}
+}
+
+// WithAutoDismiss sets how long a notification result stays visible.
+// A zero duration keeps it until the user dismisses it.
+// It has no effect on other display types. Returns the result for chaining.
+func (sr *StructuredResult) WithAutoDismiss(after time.Duration) *StructuredResult {
+	if sr.DisplayType != DisplayTypeNotification {
+		return sr
+	}
+	n, err := decodeResultData[NotificationData](sr.Data)
+	if err != nil {
+		return sr
+	}
+	n.AutoDismissMs = int(after / time.Millisecond)
+	n.Persistent = after <= 0
+	if n.Persistent {
+		n.AutoDismissMs = 0
+	}
+	sr.Data = *n
+	return sr
+}
+
+// Validate checks that the notification payload is well-formed.
+func (n *NotificationData) Validate() error {
+	switch n.Level {
+	case NotificationInfo, NotificationSuccess, NotificationWarning, NotificationError:
+	case "":
+		return fmt.Errorf("notification level is required")
+	default:
+		return fmt.Errorf("unsupported notification level: %s", n.Level)
+	}
+	if n.Message == "" {
+		return fmt.Errorf("notification message is required")
+	}
+	if n.AutoDismissMs < 0 {
+		return fmt.Errorf("autoDismissMs cannot be negative (got %d)", n.AutoDismissMs)
+	}
+	if n.Persistent && n.AutoDismissMs > 0 {
+		return fmt.Errorf("persistent notifications cannot set autoDismissMs")
+	}
+	return nil
+}
+
+func (UnimplementedHostServices) Notify(ctx context.Context, level NotificationLevel, title, body string) error {
+	return ErrHostServiceUnavailable
+}
+
+func (s *hostServer) Notify(ctx context.Context, req *ProtoNotification) (*ConfigResponse, error) {
+	level := NotificationLevel(req.Level)
+	switch level {
+	case NotificationInfo, NotificationSuccess, NotificationWarning, NotificationError:
+	case "":
+		level = NotificationInfo
+	default:
+		return &ConfigResponse{Success: false, Error: fmt.Sprintf("unsupported notification level: %s", req.Level)}, nil
+	}
+	if req.Title == "" && req.Body == "" {
+		return &ConfigResponse{Success: false, Error: "notification title or body is required"}, nil
+	}
+	return hostResponse(s.Impl.Notify(ctx, level, req.Title, req.Body))
+}
+
+func (h *hostClient) Notify(ctx context.Context, level NotificationLevel, title, body string) error {
+	return hostError(h.client.Notify(h.outgoing(ctx), &ProtoNotification{
+		Level: string(level),
+		Title: title,
+		Body:  body,
+	}))
+}
@@ -0,0 +1,110 @@
+package pluginapi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNotificationResult_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  *StructuredResult
+		wantErr bool
+	}{
+		{name: "success", result: NewNotificationResult(NotificationSuccess, "Project created")},
+		{name: "auto dismiss", result: NewNotificationResult(NotificationInfo, "Saved").WithAutoDismiss(3 * time.Second)},
+		{name: "persistent", result: NewNotificationResult(NotificationError, "Sync failed").WithAutoDismiss(0)},
+		{name: "unknown level", result: NewNotificationResult("fatal", "Boom"), wantErr: true},
+		{name: "empty message", result: NewNotificationResult(NotificationWarning, ""), wantErr: true},
+		{
+			name: "persistent with timeout",
+			result: &StructuredResult{
+				DisplayType: DisplayTypeNotification,
+				Data:        NotificationData{Level: NotificationInfo, Message: "x", Persistent: true, AutoDismissMs: 100},
+			},
+			wantErr: true,
+		},
+		{name: "missing data", result: &StructuredResult{DisplayType: DisplayTypeNotification}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.result.Validate()
+			if tt.wantErr && err == nil {
+				t.Error("expected validation error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNotificationResult_WithAutoDismiss(t *testing.T) {
+	result := NewNotificationResult(NotificationSuccess, "Done").WithAutoDismiss(1500 * time.Millisecond)
+	n := result.Data.(NotificationData)
+	if n.AutoDismissMs != 1500 || n.Persistent {
+		t.Errorf("got %+v, want autoDismissMs 1500 and not persistent", n)
+	}
+
+	// Applies to notifications parsed from JSON as well
+	jsonStr, err := result.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	parsed, err := ParseStructuredResult(jsonStr)
+	if err != nil {
+		t.Fatalf("ParseStructuredResult failed: %v", err)
+	}
+	n = parsed.WithAutoDismiss(0).Data.(NotificationData)
+	if !n.Persistent || n.AutoDismissMs != 0 {
+		t.Errorf("got %+v, want persistent", n)
+	}
+
+	// No effect on other display types
+	text := NewTextResult("hello").WithAutoDismiss(time.Second)
+	if text.Data != "hello" {
+		t.Errorf("text result data changed: %v", text.Data)
+	}
+}
+
+type notifyingHostServices struct {
+	UnimplementedHostServices
+	received []string
+}
+
+func (h *notifyingHostServices) Notify(ctx context.Context, level NotificationLevel, title, body string) error {
+	h.received = append(h.received, string(level)+"|"+title+"|"+body+"|"+HostCallerToken(ctx))
+	return nil
+}
+
+func TestHostServices_Notify(t *testing.T) {
+	impl := &notifyingHostServices{}
+	host := connectTestHost(t, impl, "exporter")
+	ctx := context.Background()
+
+	if err := host.Notify(ctx, NotificationSuccess, "Export finished", "42 tracks written"); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	if err := host.Notify(ctx, "", "", "Still working"); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	want := []string{
+		"success|Export finished|42 tracks written|exporter",
+		"info||Still working|exporter",
+	}
+	if len(impl.received) != 2 || impl.received[0] != want[0] || impl.received[1] != want[1] {
+		t.Errorf("received = %q, want %q", impl.received, want)
+	}
+
+	if err := host.Notify(ctx, "urgent", "x", "y"); err == nil {
+		t.Error("expected error for unsupported level")
+	}
+	if err := host.Notify(ctx, NotificationInfo, "", ""); err == nil {
+		t.Error("expected error for empty notification")
+	}
+	if len(impl.received) != 2 {
+		t.Errorf("invalid notifications should not reach the host, got %q", impl.received)
+	}
+}
@@ -0,0 +1,556 @@
+package pluginapi
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuth flows supported by OAuthHelper.
+const (
+	// OAuthFlowDevice shows the user a code to enter on the provider's site (RFC 8628)
+	OAuthFlowDevice = "device"
+	// OAuthFlowAuthorizationCode opens the provider's consent page in a browser and
+	// exchanges the code it redirects back with, using PKCE
+	OAuthFlowAuthorizationCode = "authorization_code"
+)
+
+// ErrOAuthNotAuthorized is returned by OAuthHelper.Token when the user has not
+// authorized the plugin yet, or the provider no longer accepts the stored token.
+var ErrOAuthNotAuthorized = errors.New("not authorized with OAuth provider")
+
+// OAuthConfig declares the OAuth provider a plugin authorizes with, usually in the
+// oauth section of plugin.yaml:
+//
+//	oauth:
+//	  provider: github
+//	  flow: device
+//	  client_id: Iv1.0123456789abcdef
+//	  device_auth_url: https://github.com/login/device/code
+//	  token_url: https://github.com/login/oauth/access_token
+//	  scopes: [repo]
+type OAuthConfig struct {
+	// Provider names the provider (e.g., "github"); tokens are stored per provider
+	Provider string `yaml:"provider"`
+	// Flow is OAuthFlowDevice or OAuthFlowAuthorizationCode; empty picks the device
+	// flow if DeviceAuthURL is set
+	Flow     string `yaml:"flow,omitempty"`
+	ClientID string `yaml:"client_id"`
+	// ClientSecret is only for providers that insist on one for installed apps;
+	// it ships with the plugin, so it is not actually secret
+	ClientSecret  string   `yaml:"client_secret,omitempty"`
+	AuthURL       string   `yaml:"auth_url,omitempty"`
+	TokenURL      string   `yaml:"token_url"`
+	DeviceAuthURL string   `yaml:"device_auth_url,omitempty"`
+	RedirectURL   string   `yaml:"redirect_url,omitempty"`
+	Scopes        []string `yaml:"scopes,omitempty"`
+}
+
+func (c OAuthConfig) flow() string {
+	if c.Flow == "" && c.DeviceAuthURL != "" {
+		return OAuthFlowDevice
+	}
+	if c.Flow == "" {
+		return OAuthFlowAuthorizationCode
+	}
+	return c.Flow
+}
+
+// Validate checks that the config has everything its flow needs.
+func (c OAuthConfig) Validate() error {
+	if c.Provider == "" {
+		return fmt.Errorf("oauth: provider is required")
+	}
+	if c.ClientID == "" {
+		return fmt.Errorf("oauth: client_id is required")
+	}
+	urls := map[string]string{"token_url": c.TokenURL}
+	switch c.flow() {
+	case OAuthFlowDevice:
+		urls["device_auth_url"] = c.DeviceAuthURL
+	case OAuthFlowAuthorizationCode:
+		urls["auth_url"] = c.AuthURL
+		urls["redirect_url"] = c.RedirectURL
+	default:
+		return fmt.Errorf("oauth: unknown flow %q (expected %s or %s)", c.Flow, OAuthFlowDevice, OAuthFlowAuthorizationCode)
+	}
+	for field, raw := range urls {
+		if raw == "" {
+			return fmt.Errorf("oauth: %s is required for the %s flow", field, c.flow())
+		}
+		if _, err := url.ParseRequestURI(raw); err != nil {
+			return fmt.Errorf("oauth: invalid %s: %s", field, raw)
+		}
+	}
+	return nil
+}
+
+// AuthorizationRequest asks the host to have the user authorize the plugin with
+// an OAuth provider (see HostServices.RequestAuthorization).
+type AuthorizationRequest struct {
+	Provider string
+	// URL is the provider's consent page, or for the device flow its verification page
+	URL string
+	// UserCode is the code the user enters at URL (device flow only)
+	UserCode string
+	// RedirectURL is where the provider sends the browser afterwards (authorization code flow only)
+	RedirectURL string
+}
+
+// OAuthToken is a token issued by an OAuth provider.
+type OAuthToken struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"` // Zero if the token doesn't expire
+	Scope        string    `json:"scope,omitempty"`
+}
+
+// oauthExpiryDelta refreshes tokens a little early, so they don't expire in flight.
+const oauthExpiryDelta = time.Minute
+
+// Valid reports whether the token has an access token that isn't about to expire.
+func (t *OAuthToken) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(oauthExpiryDelta).Before(t.Expiry)
+}
+
+// OAuthError is an error response from an OAuth provider.
+type OAuthError struct {
+	// Code is the OAuth error code (e.g., "invalid_grant", "access_denied")
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("oauth: %s: %s", e.Code, e.Description)
+	}
+	return "oauth: " + e.Code
+}
+
+// OAuthHelper authorizes a plugin with an OAuth provider and keeps its token.
+// The browser part of a flow is delegated to the host with RequestAuthorization;
+// tokens are stored in the host's keychain, or in the plugin's settings when the
+// host has none, and refreshed when they expire.
+//
+// Plugins declaring an oauth section in plugin.yaml get one from BasePlugin.OAuth:
+//
+//	token, err := t.OAuth().AccessToken(ctx)
+//	if err != nil {
+//	    return "", err
+//	}
+//	req.Header.Set("Authorization", "Bearer "+token)
+type OAuthHelper struct {
+	// HTTPClient sends the requests to the provider (nil uses http.DefaultClient)
+	HTTPClient *http.Client
+
+	config       OAuthConfig
+	host         func() HostServices
+	settings     func() SettingsManager
+	mu           sync.Mutex    // Serializes refreshes and flows, so each runs once
+	pollInterval time.Duration // Overrides the device flow's polling interval in tests
+}
+
+// NewOAuthHelper creates a helper for the provider in config. settings may be nil
+// if tokens should only be kept in the host's keychain.
+func NewOAuthHelper(config OAuthConfig, host HostServices, settings SettingsManager) *OAuthHelper {
+	return &OAuthHelper{
+		config:   config,
+		host:     func() HostServices { return host },
+		settings: func() SettingsManager { return settings },
+	}
+}
+
+// Token returns the stored token, refreshing it if it expired. Returns
+// ErrOAuthNotAuthorized if there is no usable token; call Authorize then.
+func (h *OAuthHelper) Token(ctx context.Context) (*OAuthToken, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.tokenLocked(ctx)
+}
+
+func (h *OAuthHelper) tokenLocked(ctx context.Context) (*OAuthToken, error) {
+	token, err := h.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token.Valid() {
+		return token, nil
+	}
+	if token == nil || token.RefreshToken == "" {
+		return nil, ErrOAuthNotAuthorized
+	}
+
+	refreshed, err := h.exchange(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {token.RefreshToken},
+	})
+	var oauthErr *OAuthError
+	if errors.As(err, &oauthErr) && oauthErr.Code == "invalid_grant" {
+		_ = h.delete(ctx)
+		return nil, fmt.Errorf("%w: %v", ErrOAuthNotAuthorized, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+	if err := h.save(ctx, refreshed); err != nil {
+		return nil, err
+	}
+	return refreshed, nil
+}
+
+// Authorize runs the configured flow, with the host showing the user the
+// provider's page, and stores the resulting token.
+func (h *OAuthHelper) Authorize(ctx context.Context) (*OAuthToken, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.authorizeLocked(ctx)
+}
+
+func (h *OAuthHelper) authorizeLocked(ctx context.Context) (*OAuthToken, error) {
+	if err := h.config.Validate(); err != nil {
+		return nil, err
+	}
+	var token *OAuthToken
+	var err error
+	if h.config.flow() == OAuthFlowDevice {
+		token, err = h.deviceFlow(ctx)
+	} else {
+		token, err = h.authorizationCodeFlow(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := h.save(ctx, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// AccessToken returns an access token for API requests, asking the user to
+// authorize the plugin first if there is no usable token.
+func (h *OAuthHelper) AccessToken(ctx context.Context) (string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	token, err := h.tokenLocked(ctx)
+	if errors.Is(err, ErrOAuthNotAuthorized) {
+		token, err = h.authorizeLocked(ctx)
+	}
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// SignOut forgets the stored token.
+func (h *OAuthHelper) SignOut(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.delete(ctx)
+}
+
+func (h *OAuthHelper) deviceFlow(ctx context.Context) (*OAuthToken, error) {
+	var device struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationURI         string `json:"verification_uri"`
+		VerificationURIComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int    `json:"expires_in"`
+		Interval                int    `json:"interval"`
+	}
+	params := url.Values{"client_id": {h.config.ClientID}}
+	if len(h.config.Scopes) > 0 {
+		params.Set("scope", strings.Join(h.config.Scopes, " "))
+	}
+	if err := h.post(ctx, h.config.DeviceAuthURL, params, &device); err != nil {
+		return nil, fmt.Errorf("failed to start device authorization: %w", err)
+	}
+
+	verificationURL := device.VerificationURIComplete
+	if verificationURL == "" {
+		verificationURL = device.VerificationURI
+	}
+	if _, err := h.host().RequestAuthorization(ctx, AuthorizationRequest{
+		Provider: h.config.Provider,
+		URL:      verificationURL,
+		UserCode: device.UserCode,
+	}); err != nil {
+		return nil, err
+	}
+
+	if device.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(device.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+	interval := time.Duration(max(device.Interval, 5)) * time.Second
+	if h.pollInterval > 0 {
+		interval = h.pollInterval
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("device authorization did not complete: %w", ctx.Err())
+		case <-time.After(interval):
+		}
+		token, err := h.exchange(ctx, url.Values{
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"device_code": {device.DeviceCode},
+		})
+		var oauthErr *OAuthError
+		switch {
+		case errors.As(err, &oauthErr) && oauthErr.Code == "authorization_pending":
+		case errors.As(err, &oauthErr) && oauthErr.Code == "slow_down":
+			interval += 5 * time.Second
+		default:
+			return token, err
+		}
+	}
+}
+
+func (h *OAuthHelper) authorizationCodeFlow(ctx context.Context) (*OAuthToken, error) {
+	state := randomOAuthValue(16)
+	verifier := randomOAuthValue(32)
+	challenge := sha256.Sum256([]byte(verifier))
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {h.config.ClientID},
+		"redirect_uri":          {h.config.RedirectURL},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	if len(h.config.Scopes) > 0 {
+		params.Set("scope", strings.Join(h.config.Scopes, " "))
+	}
+	separator := "?"
+	if strings.Contains(h.config.AuthURL, "?") {
+		separator = "&"
+	}
+
+	callback, err := h.host().RequestAuthorization(ctx, AuthorizationRequest{
+		Provider:    h.config.Provider,
+		URL:         h.config.AuthURL + separator + params.Encode(),
+		RedirectURL: h.config.RedirectURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	callbackURL, err := url.Parse(callback)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authorization callback: %w", err)
+	}
+	query := callbackURL.Query()
+	if code := query.Get("error"); code != "" {
+		return nil, &OAuthError{Code: code, Description: query.Get("error_description")}
+	}
+	if query.Get("state") != state {
+		return nil, fmt.Errorf("authorization callback has the wrong state")
+	}
+	if query.Get("code") == "" {
+		return nil, fmt.Errorf("authorization callback has no code")
+	}
+
+	return h.exchange(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {query.Get("code")},
+		"redirect_uri":  {h.config.RedirectURL},
+		"code_verifier": {verifier},
+	})
+}
+
+// exchange requests a token from the token endpoint.
+func (h *OAuthHelper) exchange(ctx context.Context, params url.Values) (*OAuthToken, error) {
+	params.Set("client_id", h.config.ClientID)
+	if h.config.ClientSecret != "" {
+		params.Set("client_secret", h.config.ClientSecret)
+	}
+	var resp struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+		Scope        string `json:"scope"`
+	}
+	if err := h.post(ctx, h.config.TokenURL, params, &resp); err != nil {
+		return nil, err
+	}
+	if resp.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access_token")
+	}
+
+	token := &OAuthToken{
+		AccessToken:  resp.AccessToken,
+		TokenType:    resp.TokenType,
+		RefreshToken: resp.RefreshToken,
+		Scope:        resp.Scope,
+	}
+	if resp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// maxOAuthResponseSize bounds the responses read from providers.
+const maxOAuthResponseSize = 1 << 20
+
+// post sends a form to endpoint and decodes the JSON response into out.
+// OAuth error responses are returned as *OAuthError; some providers send them
+// with a 200 status.
+func (h *OAuthHelper) post(ctx context.Context, endpoint string, params url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := h.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOAuthResponseSize))
+	if err != nil {
+		return err
+	}
+
+	var oauthErr struct {
+		Code        string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Code != "" {
+		return &OAuthError{Code: oauthErr.Code, Description: oauthErr.Description}
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", endpoint, resp.Status)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", endpoint, err)
+	}
+	return nil
+}
+
+// randomOAuthValue returns n random bytes, base64url-encoded, for states and PKCE verifiers.
+func randomOAuthValue(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// tokenKey is the secret (or setting) the provider's token is stored under.
+func (h *OAuthHelper) tokenKey() string {
+	return "oauth_token_" + h.config.Provider
+}
+
+// load returns the stored token, or nil if there is none.
+func (h *OAuthHelper) load(ctx context.Context) (*OAuthToken, error) {
+	data, err := h.host().GetSecret(ctx, h.tokenKey())
+	switch {
+	case errors.Is(err, ErrSecretNotFound):
+		return nil, nil
+	case errors.Is(err, ErrHostServiceUnavailable):
+		settings := h.settings()
+		if settings == nil {
+			return nil, nil
+		}
+		if data, err = settings.GetString(h.tokenKey()); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	}
+	if data == "" {
+		return nil, nil
+	}
+
+	var token OAuthToken
+	if err := json.Unmarshal([]byte(data), &token); err != nil {
+		return nil, fmt.Errorf("invalid stored %s token: %w", h.config.Provider, err)
+	}
+	return &token, nil
+}
+
+func (h *OAuthHelper) save(ctx context.Context, token *OAuthToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	err = h.host().SetSecret(ctx, h.tokenKey(), string(data))
+	if errors.Is(err, ErrHostServiceUnavailable) {
+		settings := h.settings()
+		if settings == nil {
+			return fmt.Errorf("cannot store %s token: no keychain or settings available", h.config.Provider)
+		}
+		return settings.Set(h.tokenKey(), string(data))
+	}
+	return err
+}
+
+func (h *OAuthHelper) delete(ctx context.Context) error {
+	err := h.host().SetSecret(ctx, h.tokenKey(), "")
+	if errors.Is(err, ErrHostServiceUnavailable) {
+		if settings := h.settings(); settings != nil {
+			return settings.Delete(h.tokenKey())
+		}
+		return nil
+	}
+	return err
+}
+
+func (UnimplementedHostServices) RequestAuthorization(ctx context.Context, req AuthorizationRequest) (string, error) {
+	return "", ErrHostServiceUnavailable
+}
+
+func (s *hostServer) RequestAuthorization(ctx context.Context, req *HostAuthorizationRequest) (*HostAuthorizationResponse, error) {
+	callback, err := s.Impl.RequestAuthorization(ctx, AuthorizationRequest{
+		Provider:    req.Provider,
+		URL:         req.Url,
+		UserCode:    req.UserCode,
+		RedirectURL: req.RedirectUrl,
+	})
+	if st := hostStatus(err); st != nil {
+		return nil, st
+	}
+	if err != nil {
+		return &HostAuthorizationResponse{Error: err.Error()}, nil
+	}
+	return &HostAuthorizationResponse{CallbackUrl: callback}, nil
+}
+
+func (h *hostClient) RequestAuthorization(ctx context.Context, req AuthorizationRequest) (string, error) {
+	resp, err := h.client.RequestAuthorization(h.outgoing(ctx), &HostAuthorizationRequest{
+		Provider:    req.Provider,
+		Url:         req.URL,
+		UserCode:    req.UserCode,
+		RedirectUrl: req.RedirectURL,
+	})
+	if err != nil {
+		return "", hostSentinel(err)
+	}
+	if resp.Error != "" {
+		return "", fmt.Errorf("%s", resp.Error)
+	}
+	return resp.CallbackUrl, nil
+}
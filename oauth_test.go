@@ -0,0 +1,218 @@
+package pluginapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newOAuthTestProvider serves the device and token endpoints of an OAuth provider.
+// challenge is the PKCE code_challenge the host saw, checked when a code is exchanged.
+func newOAuthTestProvider(t *testing.T, challenge *string, polls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	reply := func(w http.ResponseWriter, status int, body map[string]interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, http.StatusOK, map[string]interface{}{
+			"device_code": "dc", "user_code": "ABCD-1234", "verification_uri": "https://example.com/device", "expires_in": 60,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("client_id") != "client" {
+			reply(w, http.StatusUnauthorized, map[string]interface{}{"error": "invalid_client"})
+			return
+		}
+		switch r.FormValue("grant_type") {
+		case "urn:ietf:params:oauth:grant-type:device_code":
+			if polls.Add(1) == 1 {
+				reply(w, http.StatusBadRequest, map[string]interface{}{"error": "authorization_pending"})
+				return
+			}
+			reply(w, http.StatusOK, map[string]interface{}{"access_token": "dev-token", "expires_in": 3600, "refresh_token": "r1"})
+		case "authorization_code":
+			sum := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+			if r.FormValue("code") != "the-code" || base64.RawURLEncoding.EncodeToString(sum[:]) != *challenge {
+				reply(w, http.StatusBadRequest, map[string]interface{}{"error": "invalid_grant", "error_description": "bad code or verifier"})
+				return
+			}
+			// Expires within oauthExpiryDelta, so the next Token refreshes it
+			reply(w, http.StatusOK, map[string]interface{}{"access_token": "code-token", "expires_in": 1, "refresh_token": "r2"})
+		case "refresh_token":
+			if r.FormValue("refresh_token") == "revoked" {
+				reply(w, http.StatusBadRequest, map[string]interface{}{"error": "invalid_grant"})
+				return
+			}
+			reply(w, http.StatusOK, map[string]interface{}{"access_token": "refreshed", "expires_in": 3600})
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// oauthTestHost plays the agent: it "opens" authorization pages and keeps secrets.
+type oauthTestHost struct {
+	keychainHost
+	challenge string
+	shown     AuthorizationRequest
+}
+
+func (h *oauthTestHost) RequestAuthorization(ctx context.Context, req AuthorizationRequest) (string, error) {
+	h.shown = req
+	if req.UserCode != "" {
+		return "", nil
+	}
+	page, err := url.Parse(req.URL)
+	if err != nil {
+		return "", err
+	}
+	h.challenge = page.Query().Get("code_challenge")
+	return req.RedirectURL + "?code=the-code&state=" + page.Query().Get("state"), nil
+}
+
+func TestOAuthHelper_DeviceFlow(t *testing.T) {
+	var polls atomic.Int32
+	provider := newOAuthTestProvider(t, nil, &polls)
+	host := &oauthTestHost{keychainHost: keychainHost{secrets: make(map[string]string)}}
+	helper := NewOAuthHelper(OAuthConfig{
+		Provider:      "example",
+		ClientID:      "client",
+		DeviceAuthURL: provider.URL + "/device",
+		TokenURL:      provider.URL + "/token",
+	}, host, nil)
+	helper.pollInterval = time.Millisecond
+	ctx := context.Background()
+
+	if _, err := helper.Token(ctx); !errors.Is(err, ErrOAuthNotAuthorized) {
+		t.Fatalf("expected ErrOAuthNotAuthorized before authorizing, got %v", err)
+	}
+	token, err := helper.AccessToken(ctx)
+	if err != nil || token != "dev-token" {
+		t.Fatalf("AccessToken = %q, %v", token, err)
+	}
+	if host.shown.UserCode != "ABCD-1234" || host.shown.URL != "https://example.com/device" || host.shown.Provider != "example" {
+		t.Errorf("unexpected authorization shown: %+v", host.shown)
+	}
+	if polls.Load() != 2 {
+		t.Errorf("expected one pending poll before the token, got %d polls", polls.Load())
+	}
+	if !strings.Contains(host.secrets["/oauth_token_example"], "dev-token") {
+		t.Errorf("expected the token in the keychain, got %v", host.secrets)
+	}
+
+	if token, _ := helper.AccessToken(ctx); token != "dev-token" || polls.Load() != 2 {
+		t.Errorf("expected the stored token without a new flow, got %q after %d polls", token, polls.Load())
+	}
+	if err := helper.SignOut(ctx); err != nil || len(host.secrets) != 0 {
+		t.Errorf("SignOut = %v, secrets left: %v", err, host.secrets)
+	}
+}
+
+func TestOAuthHelper_AuthorizationCodeFlow(t *testing.T) {
+	host := &oauthTestHost{keychainHost: keychainHost{secrets: make(map[string]string)}}
+	provider := newOAuthTestProvider(t, &host.challenge, new(atomic.Int32))
+	helper := NewOAuthHelper(OAuthConfig{
+		Provider:    "example",
+		ClientID:    "client",
+		AuthURL:     provider.URL + "/authorize",
+		TokenURL:    provider.URL + "/token",
+		RedirectURL: "http://127.0.0.1:8765/callback",
+		Scopes:      []string{"read", "write"},
+	}, host, nil)
+	ctx := context.Background()
+
+	token, err := helper.Authorize(ctx)
+	if err != nil || token.AccessToken != "code-token" {
+		t.Fatalf("Authorize = %+v, %v", token, err)
+	}
+	page, _ := url.Parse(host.shown.URL)
+	if page.Query().Get("scope") != "read write" || page.Query().Get("code_challenge_method") != "S256" || host.shown.RedirectURL == "" {
+		t.Errorf("unexpected authorization URL %s", host.shown.URL)
+	}
+
+	token, err = helper.Token(ctx)
+	if err != nil || token.AccessToken != "refreshed" || token.RefreshToken != "r2" {
+		t.Errorf("expected a refreshed token keeping its refresh token, got %+v, %v", token, err)
+	}
+}
+
+func TestOAuthHelper_RevokedRefreshToken(t *testing.T) {
+	provider := newOAuthTestProvider(t, nil, new(atomic.Int32))
+	settings, err := NewSettingsManager(t.TempDir(), "example")
+	if err != nil {
+		t.Fatalf("NewSettingsManager failed: %v", err)
+	}
+	// Without a keychain, tokens go to the settings
+	helper := NewOAuthHelper(OAuthConfig{
+		Provider:      "example",
+		ClientID:      "client",
+		DeviceAuthURL: provider.URL + "/device",
+		TokenURL:      provider.URL + "/token",
+	}, UnimplementedHostServices{}, settings)
+	ctx := context.Background()
+
+	if err := helper.save(ctx, &OAuthToken{AccessToken: "old", RefreshToken: "revoked", Expiry: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if stored, _ := settings.GetString("oauth_token_example"); !strings.Contains(stored, "revoked") {
+		t.Fatalf("expected the token in the settings, got %q", stored)
+	}
+	if _, err := helper.Token(ctx); !errors.Is(err, ErrOAuthNotAuthorized) {
+		t.Errorf("expected ErrOAuthNotAuthorized for a revoked refresh token, got %v", err)
+	}
+	if stored, _ := settings.Get("oauth_token_example"); stored != nil {
+		t.Errorf("expected the revoked token to be forgotten, got %v", stored)
+	}
+}
+
+func TestHostServices_RequestAuthorization(t *testing.T) {
+	impl := &oauthTestHost{keychainHost: keychainHost{secrets: make(map[string]string)}}
+	host := connectTestHost(t, impl, "github")
+
+	callback, err := host.RequestAuthorization(context.Background(), AuthorizationRequest{
+		Provider:    "github",
+		URL:         "https://example.com/authorize?state=xyz",
+		RedirectURL: "http://127.0.0.1/callback",
+	})
+	if err != nil || callback != "http://127.0.0.1/callback?code=the-code&state=xyz" {
+		t.Errorf("RequestAuthorization = %q, %v", callback, err)
+	}
+}
+
+func TestOAuthConfig_Validate(t *testing.T) {
+	valid := OAuthConfig{Provider: "p", ClientID: "c", DeviceAuthURL: "https://p/device", TokenURL: "https://p/token"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid config, got %v", err)
+	}
+
+	tests := []struct {
+		modify func(*OAuthConfig)
+		want   string
+	}{
+		{func(c *OAuthConfig) { c.ClientID = "" }, "client_id"},
+		{func(c *OAuthConfig) { c.TokenURL = "" }, "token_url"},
+		{func(c *OAuthConfig) { c.Flow = "implicit" }, "unknown flow"},
+		{func(c *OAuthConfig) { c.Flow = OAuthFlowAuthorizationCode; c.AuthURL = "https://p/auth" }, "redirect_url"},
+		{func(c *OAuthConfig) { c.DeviceAuthURL = "not a url" }, "invalid device_auth_url"},
+	}
+	for _, tt := range tests {
+		config := valid
+		tt.modify(&config)
+		if err := config.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Validate(%+v) = %v, want error containing %q", config, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package pluginapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Standard pagination parameter names and limits.
+// Operations marked with `pagination: true` in plugin.yaml get these parameters injected.
+const (
+	PaginationCursorParam = "cursor"
+	PaginationLimitParam  = "limit"
+
+	// DefaultPageLimit is used when no limit is given
+	DefaultPageLimit = 20
+	// MaxPageLimit caps the limit; larger values are clamped
+	MaxPageLimit = 100
+)
+
+// PaginationParams holds the standard paging arguments for list operations.
+// Cursor is opaque to the caller: it is empty for the first page and otherwise
+// the nextCursor value returned with the previous page (see WithNextCursor).
+type PaginationParams struct {
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+// ParsePagination extracts and normalizes paging arguments from a Call's JSON args.
+// A missing or zero limit becomes DefaultPageLimit; limits above MaxPageLimit are clamped.
+//
+// Example:
+//
+//	func (t *myTool) Call(ctx context.Context, args string) (string, error) {
+//	    page, err := pluginapi.ParsePagination(args)
+//	    if err != nil {
+//	        return "", err
+//	    }
+//	    offset, err := page.Offset()
+//	    ...
+//	}
+func ParsePagination(args string) (PaginationParams, error) {
+	var params PaginationParams
+	if strings.TrimSpace(args) != "" {
+		if err := json.Unmarshal([]byte(args), &params); err != nil {
+			return PaginationParams{}, fmt.Errorf("invalid pagination arguments: %w", err)
+		}
+	}
+	if params.Limit < 0 {
+		return PaginationParams{}, fmt.Errorf("limit must be positive (got %d)", params.Limit)
+	}
+	return params.Normalized(), nil
+}
+
+// Normalized returns a copy with the default limit applied and the limit clamped to MaxPageLimit.
+func (p PaginationParams) Normalized() PaginationParams {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+	return p
+}
+
+// Offset decodes a cursor created by OffsetCursor. An empty cursor means offset 0.
+func (p PaginationParams) Offset() (int, error) {
+	if p.Cursor == "" {
+		return 0, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(p.Cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(decoded), "offset:"))
+	if err != nil || offset < 0 || !strings.HasPrefix(string(decoded), "offset:") {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	return offset, nil
+}
+
+// OffsetCursor encodes an offset as an opaque cursor for offset-based paging.
+func OffsetCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+// WithNextCursor records the cursor for the next page in the result metadata
+// so the agent can fetch further pages. An empty cursor marks the last page.
+// Returns the result for chaining.
+func (sr *StructuredResult) WithNextCursor(cursor string) *StructuredResult {
+	if sr.Metadata == nil {
+		sr.Metadata = make(map[string]any)
+	}
+	if cursor == "" {
+		delete(sr.Metadata, "nextCursor")
+		return sr
+	}
+	sr.Metadata["nextCursor"] = cursor
+	return sr
+}
+
+// NextCursor returns the cursor for the next page, or "" if this is the last page.
+func (sr *StructuredResult) NextCursor() string {
+	cursor, _ := sr.Metadata["nextCursor"].(string)
+	return cursor
+}
+
+// PaginationParameters returns the YAML parameter definitions injected into
+// operations marked with `pagination: true`.
+func PaginationParameters() []YAMLToolParameter {
+	minLimit, maxLimit := float64(1), float64(MaxPageLimit)
+	return []YAMLToolParameter{
+		{
+			Name:        PaginationCursorParam,
+			Type:        "string",
+			Description: "Cursor returned as nextCursor by the previous page; omit for the first page",
+		},
+		{
+			Name:        PaginationLimitParam,
+			Type:        "integer",
+			Description: fmt.Sprintf("Maximum number of items to return (default %d, max %d)", DefaultPageLimit, MaxPageLimit),
+			Default:     DefaultPageLimit,
+			Min:         &minLimit,
+			Max:         &maxLimit,
+		},
+	}
+}
+
+// AllParameters returns the operation's parameters, including the standard
+// pagination parameters when Pagination is set. Explicitly declared parameters
+// with the same names take precedence.
+func (op YAMLOperationDefinition) AllParameters() []YAMLToolParameter {
+	if !op.Pagination {
+		return op.Parameters
+	}
+	params := append([]YAMLToolParameter{}, op.Parameters...)
+	for _, p := range PaginationParameters() {
+		if _, exists := findParameter(op.Parameters, p.Name); !exists {
+			params = append(params, p)
+		}
+	}
+	return params
+}
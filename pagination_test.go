@@ -0,0 +1,114 @@
+package pluginapi
+
+import (
+	"testing"
+)
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		want    PaginationParams
+		wantErr bool
+	}{
+		{name: "empty args", args: "", want: PaginationParams{Limit: DefaultPageLimit}},
+		{name: "no paging args", args: `{"operation":"list"}`, want: PaginationParams{Limit: DefaultPageLimit}},
+		{name: "cursor and limit", args: `{"cursor":"abc","limit":5}`, want: PaginationParams{Cursor: "abc", Limit: 5}},
+		{name: "limit clamped", args: `{"limit":1000}`, want: PaginationParams{Limit: MaxPageLimit}},
+		{name: "negative limit", args: `{"limit":-1}`, wantErr: true},
+		{name: "invalid json", args: `{`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePagination(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePagination() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePagination() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginationOffsetCursor(t *testing.T) {
+	page := PaginationParams{Cursor: OffsetCursor(40)}
+	offset, err := page.Offset()
+	if err != nil {
+		t.Fatalf("Offset() error = %v", err)
+	}
+	if offset != 40 {
+		t.Errorf("Offset() = %d, want 40", offset)
+	}
+
+	if offset, err := (PaginationParams{}).Offset(); err != nil || offset != 0 {
+		t.Errorf("empty cursor Offset() = %d, %v; want 0, nil", offset, err)
+	}
+	if _, err := (PaginationParams{Cursor: "not-a-cursor"}).Offset(); err == nil {
+		t.Error("expected error for invalid cursor")
+	}
+}
+
+func TestStructuredResult_NextCursor(t *testing.T) {
+	result := NewListResult("Items", []string{"a"}).WithNextCursor("next")
+	if got := result.NextCursor(); got != "next" {
+		t.Errorf("NextCursor() = %q, want %q", got, "next")
+	}
+
+	result.WithNextCursor("")
+	if got := result.NextCursor(); got != "" {
+		t.Errorf("NextCursor() after last page = %q, want empty", got)
+	}
+}
+
+func TestPaginationInjectedIntoOperations(t *testing.T) {
+	toolDef := &YAMLToolDefinition{
+		Name:        "paged",
+		Description: "paged tool",
+		Parameters: []YAMLToolParameter{
+			{Name: "operation", Type: "string", Description: "operation", Required: true},
+		},
+		Operations: map[string]YAMLOperationDefinition{
+			"list": {Pagination: true},
+			"get": {
+				Parameters: []YAMLToolParameter{
+					{Name: "id", Type: "string", Description: "id", Required: true},
+				},
+			},
+		},
+	}
+
+	if err := ValidateYAMLToolDefinition(toolDef); err != nil {
+		t.Fatalf("ValidateYAMLToolDefinition() error = %v", err)
+	}
+
+	tool, err := toolDef.ToToolDefinition()
+	if err != nil {
+		t.Fatalf("ToToolDefinition() error = %v", err)
+	}
+	props := tool.Parameters["properties"].(map[string]interface{})
+	limit, ok := props[PaginationLimitParam].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected limit property in schema, got %v", props)
+	}
+	if limit["type"] != "integer" {
+		t.Errorf("limit type = %v, want integer", limit["type"])
+	}
+	if _, ok := props[PaginationCursorParam]; !ok {
+		t.Error("expected cursor property in schema")
+	}
+
+	ops := GetOperationsFromYAML(toolDef)
+	for _, op := range ops {
+		hasLimit := false
+		for _, p := range op.Parameters {
+			if p == PaginationLimitParam {
+				hasLimit = true
+			}
+		}
+		if hasLimit != (op.Name == "list") {
+			t.Errorf("operation %s: has limit = %v", op.Name, hasLimit)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package pluginapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// knownPermissions lists the permission types in display order.
+var knownPermissions = []PermissionType{
+	PermissionFileAccess,
+	PermissionNetworkAccess,
+	PermissionSystemCommands,
+}
+
+// IsValid reports whether p is a known permission type.
+func (p PermissionType) IsValid() bool {
+	for _, known := range knownPermissions {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Has reports whether the permission is granted or required.
+func (p PluginPermissions) Has(perm PermissionType) bool {
+	switch perm {
+	case PermissionFileAccess:
+		return p.FileAccess
+	case PermissionNetworkAccess:
+		return p.NetworkAccess
+	case PermissionSystemCommands:
+		return p.SystemCommands
+	}
+	return false
+}
+
+// With returns a copy of p with the given permissions added.
+// Hosts use it to record a grant after the user approves a prompt.
+func (p PluginPermissions) With(perms ...PermissionType) PluginPermissions {
+	for _, perm := range perms {
+		switch perm {
+		case PermissionFileAccess:
+			p.FileAccess = true
+		case PermissionNetworkAccess:
+			p.NetworkAccess = true
+		case PermissionSystemCommands:
+			p.SystemCommands = true
+		}
+	}
+	return p
+}
+
+// Types returns the permissions set in p.
+func (p PluginPermissions) Types() []PermissionType {
+	var types []PermissionType
+	for _, perm := range knownPermissions {
+		if p.Has(perm) {
+			types = append(types, perm)
+		}
+	}
+	return types
+}
+
+// MissingPermissions returns the permissions the operation requires that are not in granted.
+func (op OperationInfo) MissingPermissions(granted PluginPermissions) []PermissionType {
+	var missing []PermissionType
+	for _, perm := range op.RequiredPermissions {
+		if !granted.Has(perm) {
+			missing = append(missing, perm)
+		}
+	}
+	return missing
+}
+
+// PermissionDeniedError reports that an operation needs permissions the host has not granted.
+// Hosts can check for it with errors.As and prompt the user for Missing before retrying.
+type PermissionDeniedError struct {
+	// Operation is the operation that was attempted
+	Operation string
+	// Missing lists the permissions that still need to be granted
+	Missing []PermissionType
+}
+
+func (e *PermissionDeniedError) Error() string {
+	names := make([]string, len(e.Missing))
+	for i, perm := range e.Missing {
+		names[i] = string(perm)
+	}
+	return fmt.Sprintf("operation %q requires permissions not granted: %s", e.Operation, strings.Join(names, ", "))
+}
+
+// CheckOperationPermissions is the enforcement hook hosts call before dispatching
+// an operation. It returns a *PermissionDeniedError if the operation requires
+// permissions not in granted. Operations that declare no permissions, or are not
+// listed in operations, are allowed; plugin-wide permissions still apply to them.
+//
+// Example:
+//
+//	ops := client.GetOperations()
+//	if err := pluginapi.CheckOperationPermissions(ops, "delete_files", granted); err != nil {
+//	    var denied *pluginapi.PermissionDeniedError
+//	    if errors.As(err, &denied) && promptUser(denied.Missing) {
+//	        granted = granted.With(denied.Missing...)
+//	    }
+//	}
+func CheckOperationPermissions(operations []OperationInfo, operation string, granted PluginPermissions) error {
+	for _, op := range operations {
+		if op.Name != operation {
+			continue
+		}
+		if missing := op.MissingPermissions(granted); len(missing) > 0 {
+			return &PermissionDeniedError{Operation: operation, Missing: missing}
+		}
+		return nil
+	}
+	return nil
+}
+
+// ValidateOperationPermissions checks that every permission an operation requires
+// is a known type and is covered by the plugin-wide declared permissions,
+// so hosts never need to grant something the plugin did not ask for up front.
+func ValidateOperationPermissions(operations []OperationInfo, declared PluginPermissions) error {
+	for _, op := range operations {
+		for _, perm := range op.RequiredPermissions {
+			if !perm.IsValid() {
+				return fmt.Errorf("operation %q requires unknown permission %q", op.Name, perm)
+			}
+			if !declared.Has(perm) {
+				return fmt.Errorf("operation %q requires the %s permission, which the plugin does not declare", op.Name, perm)
+			}
+		}
+	}
+	return nil
+}
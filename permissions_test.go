@@ -0,0 +1,204 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const permissionsToolYAML = `
+name: files
+description: Manage files
+parameters:
+  - name: operation
+    type: string
+    description: Operation to perform
+    required: true
+operations:
+  list_files:
+    parameters:
+      - name: path
+        type: string
+        description: Directory to list
+  delete_files:
+    permissions: [file_access]
+    parameters:
+      - name: path
+        type: string
+        description: Files to delete
+        required: true
+  sync:
+    permissions: [file_access, network_access]
+`
+
+func TestGetOperationsFromYAML_Permissions(t *testing.T) {
+	var toolDef YAMLToolDefinition
+	if err := yaml.Unmarshal([]byte(permissionsToolYAML), &toolDef); err != nil {
+		t.Fatalf("failed to parse YAML: %v", err)
+	}
+	if err := ValidateYAMLToolDefinition(&toolDef); err != nil {
+		t.Fatalf("ValidateYAMLToolDefinition() error = %v", err)
+	}
+
+	want := map[string][]PermissionType{
+		"delete_files": {PermissionFileAccess},
+		"list_files":   nil,
+		"sync":         {PermissionFileAccess, PermissionNetworkAccess},
+	}
+	for _, op := range GetOperationsFromYAML(&toolDef) {
+		if !reflect.DeepEqual(op.RequiredPermissions, want[op.Name]) {
+			t.Errorf("operation %s: permissions = %v, want %v", op.Name, op.RequiredPermissions, want[op.Name])
+		}
+	}
+
+	toolDef.Operations["sync"] = YAMLOperationDefinition{Permissions: []PermissionType{"root"}}
+	if err := ValidateYAMLToolDefinition(&toolDef); err == nil || !strings.Contains(err.Error(), "root") {
+		t.Errorf("expected unknown permission error, got %v", err)
+	}
+}
+
+func TestCheckOperationPermissions(t *testing.T) {
+	ops := []OperationInfo{
+		{Name: "list_files"},
+		{Name: "delete_files", RequiredPermissions: []PermissionType{PermissionFileAccess}},
+		{Name: "sync", RequiredPermissions: []PermissionType{PermissionFileAccess, PermissionNetworkAccess}},
+	}
+
+	var granted PluginPermissions
+	if err := CheckOperationPermissions(ops, "list_files", granted); err != nil {
+		t.Errorf("list_files should need no permissions: %v", err)
+	}
+	if err := CheckOperationPermissions(ops, "unknown", granted); err != nil {
+		t.Errorf("undeclared operations should be allowed: %v", err)
+	}
+
+	err := CheckOperationPermissions(ops, "sync", granted)
+	var denied *PermissionDeniedError
+	if !errors.As(err, &denied) {
+		t.Fatalf("expected PermissionDeniedError, got %v", err)
+	}
+	if denied.Operation != "sync" || len(denied.Missing) != 2 {
+		t.Errorf("unexpected error: %+v", denied)
+	}
+
+	granted = granted.With(PermissionFileAccess)
+	if err := CheckOperationPermissions(ops, "delete_files", granted); err != nil {
+		t.Errorf("delete_files should be allowed after grant: %v", err)
+	}
+	if err := CheckOperationPermissions(ops, "sync", granted); !errors.As(err, &denied) ||
+		!reflect.DeepEqual(denied.Missing, []PermissionType{PermissionNetworkAccess}) {
+		t.Errorf("expected only network access missing, got %v", err)
+	}
+	if got := granted.Types(); !reflect.DeepEqual(got, []PermissionType{PermissionFileAccess}) {
+		t.Errorf("Types() = %v", got)
+	}
+}
+
+func TestValidateOperationPermissions(t *testing.T) {
+	ops := []OperationInfo{{Name: "delete_files", RequiredPermissions: []PermissionType{PermissionFileAccess}}}
+
+	if err := ValidateOperationPermissions(ops, PluginPermissions{FileAccess: true}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateOperationPermissions(ops, PluginPermissions{NetworkAccess: true}); err == nil {
+		t.Error("expected error for undeclared permission")
+	}
+	ops[0].RequiredPermissions = []PermissionType{"root"}
+	if err := ValidateOperationPermissions(ops, PluginPermissions{FileAccess: true}); err == nil {
+		t.Error("expected error for unknown permission")
+	}
+}
+
+type operationsTestTool struct {
+	BasePlugin
+}
+
+func (t *operationsTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *operationsTestTool) GetOperations() []OperationInfo {
+	return []OperationInfo{
+		{Name: "delete_files", Parameters: []string{"path"}, RequiredPermissions: []PermissionType{PermissionFileAccess}},
+	}
+}
+
+func TestGRPCClient_GetOperations_Permissions(t *testing.T) {
+	ops := newTestClient(t, &operationsTestTool{}).GetOperations()
+	if len(ops) != 1 {
+		t.Fatalf("expected 1 operation, got %+v", ops)
+	}
+	if !reflect.DeepEqual(ops[0].RequiredPermissions, []PermissionType{PermissionFileAccess}) {
+		t.Errorf("RequiredPermissions = %v", ops[0].RequiredPermissions)
+	}
+}
+
+const permissionsPluginYAML = `
+name: files
+version: 1.0.0
+description: Manage files
+license: MIT
+repository: https://github.com/test/files
+maintainers:
+  - name: Test
+    email: test@test.com
+platforms:
+  - os: linux
+    architectures: [amd64]
+permissions:
+  file_access: true
+  description: Reads and deletes files in the workspace
+`
+
+func TestBasePlugin_GetRequiredPermissionsFromYAML(t *testing.T) {
+	config, err := readPluginConfig(permissionsPluginYAML)
+	if err != nil {
+		t.Fatalf("readPluginConfig error: %v", err)
+	}
+	var base BasePlugin
+	base.SetPluginConfig(&config)
+
+	want := PluginPermissions{FileAccess: true, Description: "Reads and deletes files in the workspace"}
+	if got := base.GetRequiredPermissions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetRequiredPermissions() = %+v, want %+v", got, want)
+	}
+
+	undeclared := permissionsPluginYAML + `
+tool_definition:
+  description: Sync files
+  operations:
+    sync:
+      permissions: [network_access]
+`
+	if _, err := readPluginConfig(undeclared); err == nil || !strings.Contains(err.Error(), "network_access") {
+		t.Errorf("expected undeclared permission error, got %v", err)
+	}
+}
+
+type permissionsTestTool struct {
+	BasePlugin
+}
+
+func (t *permissionsTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *permissionsTestTool) GetRequiredPermissions() PluginPermissions {
+	return PluginPermissions{NetworkAccess: true, SystemCommands: true, Description: "Runs git"}
+}
+
+func TestGRPCClient_GetRequiredPermissions(t *testing.T) {
+	got := newTestClient(t, &permissionsTestTool{}).GetRequiredPermissions()
+	want := PluginPermissions{NetworkAccess: true, SystemCommands: true, Description: "Runs git"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetRequiredPermissions() = %+v, want %+v", got, want)
+	}
+
+	if got := newTestClient(t, &plainTestTool{}).GetRequiredPermissions(); !reflect.DeepEqual(got, PluginPermissions{}) {
+		t.Errorf("expected no permissions, got %+v", got)
+	}
+}
@@ -0,0 +1,161 @@
+package pluginapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode classifies why a call failed, so hosts can decide whether to retry,
+// ask the user for something, or report a bug without parsing error messages.
+type ErrorCode string
+
+const (
+	// ErrorCodeInvalidArgs means the arguments were rejected; the LLM should fix them and retry
+	ErrorCodeInvalidArgs ErrorCode = "invalid_args"
+	// ErrorCodeAuth means credentials or permissions are missing, expired, or insufficient
+	ErrorCodeAuth ErrorCode = "auth"
+	// ErrorCodeRateLimited means an upstream service is throttling requests
+	ErrorCodeRateLimited ErrorCode = "rate_limited"
+	// ErrorCodeInternal is any other failure
+	ErrorCodeInternal ErrorCode = "internal"
+)
+
+// PluginError is a classified call failure. Return one from Call (directly or
+// wrapped) to tell the host what went wrong; it survives the gRPC boundary, so
+// hosts can use errors.As on the error returned by the client.
+//
+// Example:
+//
+//	if resp.StatusCode == http.StatusTooManyRequests {
+//	    return "", pluginapi.NewPluginError(pluginapi.ErrorCodeRateLimited, "weather API quota exhausted").
+//	        WithSuggestion("Try again in a few minutes")
+//	}
+type PluginError struct {
+	// Code classifies the failure
+	Code ErrorCode
+	// Message is what Error returns
+	Message string
+	// Retryable reports whether the same call may succeed if tried again later
+	Retryable bool
+	// Suggestion is an optional user-facing hint (e.g., "Reconnect your GitHub account in Settings")
+	Suggestion string
+	// Err is the underlying cause, if any. On the host side it holds the
+	// ValidationErrors of an invalid_args failure.
+	Err error
+	// Stack is the plugin's stack trace when the failure was a recovered panic
+	Stack string
+}
+
+// NewPluginError creates an error with the given code and message.
+// Rate-limited errors are retryable by default.
+func NewPluginError(code ErrorCode, message string) *PluginError {
+	return &PluginError{
+		Code:      code,
+		Message:   message,
+		Retryable: code == ErrorCodeRateLimited,
+	}
+}
+
+// WrapPluginError classifies err with the given code, keeping err as the cause.
+func WrapPluginError(code ErrorCode, err error) *PluginError {
+	e := NewPluginError(code, err.Error())
+	e.Err = err
+	return e
+}
+
+func (e *PluginError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying cause.
+func (e *PluginError) Unwrap() error {
+	return e.Err
+}
+
+// WithSuggestion returns a copy of e with the given user-facing suggestion.
+func (e *PluginError) WithSuggestion(suggestion string) *PluginError {
+	c := *e
+	c.Suggestion = suggestion
+	return &c
+}
+
+// WithRetryable returns a copy of e with Retryable set.
+func (e *PluginError) WithRetryable(retryable bool) *PluginError {
+	c := *e
+	c.Retryable = retryable
+	return &c
+}
+
+// AsPluginError returns err as a *PluginError. A PluginError anywhere in the chain
+// is returned as-is; other errors are classified by type: validation failures
+// and unsupported dry runs are invalid_args, permission denials are auth, and
+// everything else is internal. It returns nil for a nil error.
+func AsPluginError(err error) *PluginError {
+	if err == nil {
+		return nil
+	}
+	var pluginErr *PluginError
+	if errors.As(err, &pluginErr) {
+		return pluginErr
+	}
+
+	var validationErrs ValidationErrors
+	var fieldErr *FieldError
+	var deniedErr *PermissionDeniedError
+	switch {
+	case errors.As(err, &validationErrs), errors.As(err, &fieldErr), errors.Is(err, ErrDryRunUnsupported):
+		return WrapPluginError(ErrorCodeInvalidArgs, err)
+	case errors.As(err, &deniedErr):
+		return WrapPluginError(ErrorCodeAuth, err)
+	default:
+		return WrapPluginError(ErrorCodeInternal, err)
+	}
+}
+
+// pluginErrorToProto converts a call error for the wire.
+func pluginErrorToProto(err error) *ProtoPluginError {
+	pluginErr := AsPluginError(err)
+	if pluginErr == nil {
+		return nil
+	}
+	protoErr := &ProtoPluginError{
+		Code:       string(pluginErr.Code),
+		Message:    err.Error(),
+		Retryable:  pluginErr.Retryable,
+		Suggestion: pluginErr.Suggestion,
+		Stack:      pluginErr.Stack,
+	}
+	var validationErrs ValidationErrors
+	var fieldErr *FieldError
+	if errors.As(err, &validationErrs) {
+		for _, fe := range validationErrs {
+			protoErr.FieldErrors = append(protoErr.FieldErrors, &ProtoFieldError{Field: fe.Field, Message: fe.Message})
+		}
+	} else if errors.As(err, &fieldErr) {
+		protoErr.FieldErrors = []*ProtoFieldError{{Field: fieldErr.Field, Message: fieldErr.Message}}
+	}
+	return protoErr
+}
+
+// callError rebuilds a call error received over the wire. Plugins built against
+// older versions of this package only send the message.
+func callError(message string, protoErr *ProtoPluginError) error {
+	if protoErr == nil {
+		return fmt.Errorf("%s", message)
+	}
+	pluginErr := &PluginError{
+		Code:       ErrorCode(protoErr.Code),
+		Message:    protoErr.Message,
+		Retryable:  protoErr.Retryable,
+		Suggestion: protoErr.Suggestion,
+		Stack:      protoErr.Stack,
+	}
+	if len(protoErr.FieldErrors) > 0 {
+		validationErrs := make(ValidationErrors, len(protoErr.FieldErrors))
+		for i, fe := range protoErr.FieldErrors {
+			validationErrs[i] = &FieldError{Field: fe.Field, Message: fe.Message}
+		}
+		pluginErr.Err = validationErrs
+	}
+	return pluginErr
+}
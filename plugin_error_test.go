@@ -0,0 +1,115 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type failingTestTool struct {
+	BasePlugin
+	err error
+}
+
+func (t *failingTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", t.err
+}
+
+func TestNewPluginError(t *testing.T) {
+	err := NewPluginError(ErrorCodeRateLimited, "quota exhausted")
+	if !err.Retryable {
+		t.Error("rate-limited errors should be retryable by default")
+	}
+	withHint := err.WithSuggestion("Try again later").WithRetryable(false)
+	if withHint.Suggestion != "Try again later" || withHint.Retryable {
+		t.Errorf("unexpected copy: %+v", withHint)
+	}
+	if err.Suggestion != "" || !err.Retryable {
+		t.Error("With methods should not modify the original")
+	}
+
+	cause := errors.New("token expired")
+	wrapped := WrapPluginError(ErrorCodeAuth, cause)
+	if wrapped.Error() != "token expired" || !errors.Is(wrapped, cause) {
+		t.Errorf("wrapped error lost its cause: %v", wrapped)
+	}
+}
+
+func TestAsPluginError(t *testing.T) {
+	var validationErrs ValidationErrors
+	validationErrs.Add("city", "city is required")
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{"plain", errors.New("boom"), ErrorCodeInternal},
+		{"validation", validationErrs, ErrorCodeInvalidArgs},
+		{"dry run", fmt.Errorf("%w: delete", ErrDryRunUnsupported), ErrorCodeInvalidArgs},
+		{"permission", &PermissionDeniedError{Operation: "delete", Missing: []PermissionType{PermissionFileAccess}}, ErrorCodeAuth},
+		{"wrapped plugin error", fmt.Errorf("fetch: %w", NewPluginError(ErrorCodeRateLimited, "slow down")), ErrorCodeRateLimited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AsPluginError(tt.err); got.Code != tt.want {
+				t.Errorf("Code = %q, want %q", got.Code, tt.want)
+			}
+		})
+	}
+
+	if AsPluginError(nil) != nil {
+		t.Error("nil error should stay nil")
+	}
+}
+
+func TestGRPCClient_Call_PreservesPluginError(t *testing.T) {
+	client := newTestClient(t, &failingTestTool{
+		err: NewPluginError(ErrorCodeAuth, "GitHub token expired").WithSuggestion("Reconnect GitHub in Settings"),
+	})
+
+	_, err := client.Call(context.Background(), `{}`)
+	var pluginErr *PluginError
+	if !errors.As(err, &pluginErr) {
+		t.Fatalf("expected *PluginError, got %T: %v", err, err)
+	}
+	if pluginErr.Code != ErrorCodeAuth || pluginErr.Retryable || pluginErr.Suggestion != "Reconnect GitHub in Settings" {
+		t.Errorf("unexpected error: %+v", pluginErr)
+	}
+	if err.Error() != "GitHub token expired" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestGRPCClient_Call_PreservesValidationErrors(t *testing.T) {
+	var validationErrs ValidationErrors
+	validationErrs.Add("city", "city is required")
+	validationErrs.Add("units", "units must be one of: metric, imperial")
+	client := newTestClient(t, &failingTestTool{err: validationErrs.Err()})
+
+	_, err := client.Call(context.Background(), `{}`)
+	if AsPluginError(err).Code != ErrorCodeInvalidArgs {
+		t.Errorf("expected invalid_args, got %v", err)
+	}
+	var received ValidationErrors
+	if !errors.As(err, &received) {
+		t.Fatalf("expected ValidationErrors in chain, got %T", err)
+	}
+	if fields := received.Fields(); len(fields) != 2 || fields[0] != "city" || fields[1] != "units" {
+		t.Errorf("Fields() = %v", fields)
+	}
+	if err.Error() != validationErrs.Error() {
+		t.Errorf("Error() = %q, want %q", err.Error(), validationErrs.Error())
+	}
+}
+
+func TestGRPCClient_CallStream_PreservesPluginError(t *testing.T) {
+	client := newTestClient(t, &failingTestTool{err: NewPluginError(ErrorCodeRateLimited, "slow down")})
+
+	_, err := client.CallStream(context.Background(), `{}`, func(CallChunk) error { return nil })
+	var pluginErr *PluginError
+	if !errors.As(err, &pluginErr) || pluginErr.Code != ErrorCodeRateLimited || !pluginErr.Retryable {
+		t.Errorf("expected retryable rate_limited error, got %#v", err)
+	}
+}
@@ -2,6 +2,7 @@ package pluginapi
 
 import (
 	"context"
+	"time"
 )
 
 // PluginTool is the interface that plugins must implement to be used as tools.
@@ -48,6 +49,26 @@ type HealthCheckProvider interface {
 	HealthCheck() error
 }
 
+// Initializer allows plugins to get ready before their first call.
+// Plugins can optionally implement this to warm caches or validate credentials up front.
+// The host calls it after launch, once SetAgentContext has been delivered, and may call
+// it again after the configuration changes.
+type Initializer interface {
+	// Initialize prepares the plugin and reports whether it is ready to serve calls.
+	// Return a *PluginError (e.g., ErrorCodeAuth with a suggestion) so the host can tell
+	// the user how to fix the problem instead of failing on the first Call.
+	Initialize(ctx context.Context) error
+}
+
+// ShutdownHandler allows plugins to clean up when the agent stops or unloads them.
+// Plugins can optionally implement this to flush caches, close database connections,
+// and persist state. It is called once, either when the host asks over RPC or when
+// ServeGRPCPlugin receives SIGTERM, whichever comes first.
+type ShutdownHandler interface {
+	// Shutdown releases the plugin's resources. ctx bounds how long cleanup may take.
+	Shutdown(ctx context.Context) error
+}
+
 // DefaultSettingsProvider allows plugins to provide default configuration values.
 // This is useful for plugins that need default file paths or configuration.
 type DefaultSettingsProvider interface {
@@ -68,6 +89,18 @@ type AgentContext struct {
 	// CurrentLocation is the current detected location zone name (e.g., "Home", "Office", "Unknown")
 	// This field is populated by the location manager and provides environmental context to plugins
 	CurrentLocation string
+	// ConversationID identifies the active conversation
+	ConversationID string
+	// UserDisplayName is how the user wants to be addressed (e.g., "Sam")
+	UserDisplayName string
+	// Locale is the user's BCP 47 language tag (e.g., "en-US", "de-DE")
+	Locale string
+	// Timezone is the user's IANA time zone name (e.g., "Europe/Berlin"); see TimeLocation
+	Timezone string
+	// LLMModel is the model the agent is currently using (e.g., "gpt-4o", "llama3")
+	LLMModel string
+	// WorkspaceRoot is the root directory of the agent's workspace, where user files live
+	WorkspaceRoot string
 }
 
 // AgentAwareTool extends PluginTool with agent context information.
@@ -78,6 +111,16 @@ type AgentAwareTool interface {
 	SetAgentContext(ctx AgentContext)
 }
 
+// AgentContextUpdateListener allows plugins to react when the agent context changes
+// while they run (e.g., the user moves and CurrentLocation changes).
+// Plugins can optionally implement this interface; SetAgentContext still receives
+// every update, so plugins that only read the stored context don't need it.
+type AgentContextUpdateListener interface {
+	PluginTool
+	// OnAgentContextUpdate is called after each change with the old and new context
+	OnAgentContextUpdate(previous, current AgentContext)
+}
+
 // ConfigVariableType represents the type of a configuration variable.
 type ConfigVariableType string
 
@@ -91,6 +134,9 @@ const (
 	ConfigTypePassword ConfigVariableType = "password"
 	ConfigTypeURL      ConfigVariableType = "url"
 	ConfigTypeEmail    ConfigVariableType = "email"
+	// ConfigTypeSecret values are kept in the agent's keychain (see HostServices.GetSecret);
+	// hosts don't write them to the settings file
+	ConfigTypeSecret ConfigVariableType = "secret"
 )
 
 // ConfigVariable describes a configuration variable that the plugin requires.
@@ -160,6 +206,30 @@ type WebPageProvider interface {
 	GetWebPages() []string
 }
 
+// WebPageInfo describes a web page for the agent's plugin-pages menu.
+type WebPageInfo struct {
+	// Path is the page path passed to ServeWebPage (e.g., "marketplace")
+	Path string `json:"path"`
+	// Title is the menu title (e.g., "Script Marketplace")
+	Title string `json:"title"`
+	// Icon is an icon name or emoji shown next to the title (optional)
+	Icon string `json:"icon,omitempty"`
+	// Description is a short tooltip (optional)
+	Description string `json:"description,omitempty"`
+	// RequiresAuth indicates the page needs the plugin to be configured first
+	RequiresAuth bool `json:"requires_auth,omitempty"`
+	// ShowInMenu lists the page in the menu; detail pages reached via links set this to false
+	ShowInMenu bool `json:"show_in_menu"`
+}
+
+// WebPageInfoProvider extends WebPageProvider with menu metadata for each page.
+// Plugins that only implement WebPageProvider are shown with their bare paths.
+type WebPageInfoProvider interface {
+	WebPageProvider
+	// GetWebPageInfo returns metadata for each page this plugin provides
+	GetWebPageInfo() []WebPageInfo
+}
+
 // CategoryProvider allows plugins to declare their category/tags for organization.
 // Plugins can optionally implement this interface to specify which category they belong to.
 type CategoryProvider interface {
@@ -176,6 +246,22 @@ type OperationInfo struct {
 	Parameters []string
 	// RequiredParameters is a list of required parameter names
 	RequiredParameters []string
+	// RequiredPermissions lists the permissions this operation needs (e.g., only
+	// "delete_files" needs file access). Empty means the operation needs none beyond
+	// what is already granted; see CheckOperationPermissions.
+	RequiredPermissions []PermissionType
+	// SupportsDryRun indicates the operation can preview its effects (see IsDryRun)
+	SupportsDryRun bool
+	// Timeout is the default deadline for calls to this operation (0 = none).
+	// A sooner deadline set by the host takes precedence.
+	Timeout time.Duration
+	// Examples are few-shot calls of this operation, including the operation argument
+	Examples []ToolExample
+	// Deprecation marks the operation as legacy, so hosts can warn users who call it
+	Deprecation Deprecation
+	// CostHint is the operation's expected latency and cost, for planners choosing
+	// between operations
+	CostHint CostHint
 }
 
 // OperationsProvider allows plugins to expose their operation-specific parameters.
@@ -204,6 +290,14 @@ type PluginPermissions struct {
 	SystemCommands bool `json:"system_commands"`
 	// Description provides context about why these permissions are needed
 	Description string `json:"description,omitempty"`
+	// ReadPaths limits file reads to these directories (absolute or "~/..."); see SafeOpen.
+	// If neither ReadPaths nor WritePaths is set, FileAccess covers every path.
+	ReadPaths []string `json:"read_paths,omitempty"`
+	// WritePaths limits file writes to these directories, which may also be read; see SafeWrite
+	WritePaths []string `json:"write_paths,omitempty"`
+	// AllowedHosts limits network requests to these hosts (e.g., "api.example.com",
+	// "*.example.com"); see NewHTTPClient. If empty, NetworkAccess covers every host.
+	AllowedHosts []string `json:"allowed_hosts,omitempty"`
 }
 
 // PermissionProvider allows plugins to declare required system permissions.
@@ -213,6 +307,94 @@ type PermissionProvider interface {
 	GetRequiredPermissions() PluginPermissions
 }
 
+// SystemPromptProvider allows plugins to contribute to the agent's system prompt.
+// Plugins can optionally implement this interface to describe how the LLM should use
+// the tool (usage tips, constraints, conventions) without the agent hardcoding plugin knowledge.
+type SystemPromptProvider interface {
+	// GetSystemPromptFragment returns a short fragment appended to the system prompt.
+	// Keep it to a few sentences; return an empty string to contribute nothing.
+	GetSystemPromptFragment() string
+}
+
+// PromptRequest identifies the conversation a PromptContributor contributes to.
+type PromptRequest struct {
+	// ConversationID identifies the conversation being started or resumed
+	ConversationID string
+	// AgentName is the agent the conversation belongs to
+	AgentName string
+}
+
+// PromptContributor is like SystemPromptProvider, but the agent asks for the fragment
+// again for each conversation, so it can reflect current state such as the open
+// project or domain conventions that depend on it.
+// Plugins can optionally implement this interface; it takes precedence over
+// SystemPromptProvider.
+type PromptContributor interface {
+	// ContributePrompt returns a short fragment for the system prompt of the given
+	// conversation, or an empty string to contribute nothing. It should answer quickly,
+	// since the agent waits for it before the first turn.
+	ContributePrompt(ctx context.Context, req PromptRequest) (string, error)
+}
+
+// EmbeddingProvider allows plugins to expose an embedding backend to the agent.
+// Plugins can optionally implement this interface so ori-agent can swap embedding
+// engines (local models, remote APIs) through the same mechanism it uses for tools.
+type EmbeddingProvider interface {
+	// Embed returns one vector per input text, in the same order as texts.
+	// All vectors must have the same dimension.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// StatefulPlugin allows plugins to include their state in agent backups.
+// Plugins can optionally implement this interface to snapshot data that lives
+// outside the settings file (e.g., files under the agent directory, caches, local databases)
+// so hosts can restore it onto a fresh machine.
+type StatefulPlugin interface {
+	// SnapshotState returns an opaque serialization of the plugin's current state.
+	// The format is plugin-defined; ArchiveDirectory can be used for file-based state.
+	SnapshotState(ctx context.Context) ([]byte, error)
+
+	// RestoreState replaces the plugin's state with a snapshot previously
+	// returned by SnapshotState, possibly from another machine.
+	RestoreState(ctx context.Context, state []byte) error
+}
+
+// StatePorter allows plugins to export all of their data so an agent can be migrated
+// to another machine. Unlike StatefulPlugin, which covers only data outside the
+// settings file, the export is self-contained: settings plus any private files.
+// ExportPluginState and ImportPluginState implement the common case.
+type StatePorter interface {
+	// ExportState returns the plugin's settings and private data as an opaque archive.
+	ExportState() ([]byte, error)
+
+	// ImportState replaces the plugin's settings and private data with an archive
+	// previously returned by ExportState, possibly on another machine.
+	ImportState(state []byte) error
+}
+
+// HandoffState carries transferable state from one plugin version to the next.
+type HandoffState struct {
+	// FromVersion is the version of the plugin that produced the state
+	FromVersion string
+	// Data is the opaque plugin-defined state
+	Data []byte
+}
+
+// HandoffProvider allows stateful plugins to be upgraded without losing work.
+// During an upgrade the supervisor starts the new version, calls PrepareHandoff on
+// the outgoing version, passes the result to the new version's ReceiveHandoff,
+// and only then switches traffic over.
+type HandoffProvider interface {
+	// PrepareHandoff serializes transferable state (active jobs, caches, etc.).
+	// The outgoing version should stop accepting new work before returning,
+	// since it will be shut down once the handoff completes.
+	PrepareHandoff(ctx context.Context) (HandoffState, error)
+
+	// ReceiveHandoff loads state produced by PrepareHandoff on the previous version.
+	// Implementations should check FromVersion and reject formats they can't read.
+	ReceiveHandoff(ctx context.Context, state HandoffState) error
+}
+
 // =============================================================================
 // File Attachment Support
 // =============================================================================
@@ -234,6 +416,13 @@ const (
 
 	// Document MIME types
 	MIMETypePDF = "application/pdf"
+
+	// Image MIME types
+	MIMETypePNG  = "image/png"
+	MIMETypeJPEG = "image/jpeg"
+	MIMETypeGIF  = "image/gif"
+	MIMETypeWebP = "image/webp"
+	MIMETypeSVG  = "image/svg+xml"
 )
 
 // Common file extensions for file attachments
@@ -261,6 +450,13 @@ type FileAttachment struct {
 	Size int64
 	// Content is the raw file content
 	Content []byte
+	// Encoding is AttachmentEncodingGzip if Content is compressed (see Compress), empty otherwise.
+	// Plugins always receive raw content; hosts compress to keep large files under message limits.
+	Encoding string
+	// URI locates the file on a filesystem shared by host and plugin (an absolute path
+	// or file:// URI) when it is sent by reference with no Content. See NewFileReference;
+	// read the content with Open.
+	URI string
 }
 
 // FileAttachmentHandler is an optional interface that plugins can implement
@@ -281,6 +477,17 @@ type FileAttachmentHandler interface {
 	CallWithFiles(ctx context.Context, args string, files []FileAttachment) (string, error)
 }
 
+// FileOutputProvider allows plugins to return generated files (e.g., rendered audio,
+// exported CSV, PDFs) alongside the text result, instead of writing them to disk
+// paths the host can't see.
+// Plugins can optionally implement this interface. CallStream still returns text only.
+type FileOutputProvider interface {
+	// CallWithOutputFiles is called instead of Call and CallWithFiles.
+	// files holds the attachments when the plugin also implements FileAttachmentHandler, nil otherwise.
+	// Compress large outputs (see FileAttachment.Compress) to keep them under MaxMessageSize.
+	CallWithOutputFiles(ctx context.Context, args string, files []FileAttachment) (result string, outputs []FileAttachment, err error)
+}
+
 // IsFileTypeAccepted checks if a file matches any of the accepted types.
 // acceptedTypes can contain MIME types (e.g., "audio/wav") or extensions (e.g., ".wav")
 // filename is the original filename used for extension matching
@@ -0,0 +1,213 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit caps how often the host may call a plugin, so an LLM stuck in a loop
+// cannot exhaust the quota of the upstream API behind it.
+type RateLimit struct {
+	// Operation is the operation limited, or empty for all calls to the tool
+	Operation string `json:"operation,omitempty"`
+	// Requests is the number of calls allowed per Per
+	Requests int           `json:"requests"`
+	Per      time.Duration `json:"per"`
+	// Burst is the number of calls allowed at once (0 = Requests)
+	Burst int `json:"burst,omitempty"`
+}
+
+// RateLimitProvider allows plugins to declare rate limits the host enforces before
+// calls reach the plugin. BasePlugin implements it with the rate_limit entries of
+// plugin.yaml:
+//
+//	tool_definition:
+//	  rate_limit: 60/minute
+//	  operations:
+//	    search:
+//	      rate_limit: 10/minute
+type RateLimitProvider interface {
+	// GetRateLimits returns the plugin's rate limits; a call must satisfy every
+	// limit for its operation and the tool-wide ones
+	GetRateLimits() []RateLimit
+}
+
+// rateLimitUnits are the period names ParseRateLimit accepts besides Go durations.
+var rateLimitUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "second": time.Second,
+	"m": time.Minute, "min": time.Minute, "minute": time.Minute,
+	"h": time.Hour, "hour": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour,
+}
+
+// ParseRateLimit parses a rate limit spec of the form "<requests>/<period>", where
+// period is second, minute, hour or day (or s, m, h, d) or a Go duration, e.g.
+// "10/minute" or "100/15m". The returned limit has no Operation.
+func ParseRateLimit(spec string) (RateLimit, error) {
+	requestsPart, periodPart, ok := strings.Cut(strings.TrimSpace(spec), "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: expected <requests>/<period>, e.g. 10/minute", spec)
+	}
+	requests, err := strconv.Atoi(strings.TrimSpace(requestsPart))
+	if err != nil || requests <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", spec)
+	}
+	periodPart = strings.TrimSpace(periodPart)
+	per, ok := rateLimitUnits[periodPart]
+	if !ok {
+		if per, err = time.ParseDuration(periodPart); err != nil || per <= 0 {
+			return RateLimit{}, fmt.Errorf("invalid rate limit %q: unknown period %q", spec, periodPart)
+		}
+	}
+	return RateLimit{Requests: requests, Per: per}, nil
+}
+
+// String formats the limit like "10/1m0s".
+func (l RateLimit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Per)
+}
+
+// RateLimitsFromYAML returns the rate limits declared in a YAMLToolDefinition:
+// the tool-wide one first, then one per operation in name order.
+// Invalid specs are skipped; ValidateYAMLToolDefinition reports them.
+func RateLimitsFromYAML(toolDef *YAMLToolDefinition) []RateLimit {
+	if toolDef == nil {
+		return nil
+	}
+	var limits []RateLimit
+	if limit, err := ParseRateLimit(toolDef.RateLimit); err == nil {
+		limits = append(limits, limit)
+	}
+	for _, opName := range sortedOperationNames(toolDef.Operations) {
+		if spec := toolDef.Operations[opName].RateLimit; spec != "" {
+			if limit, err := ParseRateLimit(spec); err == nil {
+				limit.Operation = opName
+				limits = append(limits, limit)
+			}
+		}
+	}
+	return limits
+}
+
+// RateLimitError reports that a call was throttled by a RateLimiter.
+type RateLimitError struct {
+	Operation string
+	Limit     RateLimit
+	// RetryAfter is how long until the call would be allowed
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	target := "tool"
+	if e.Limit.Operation != "" {
+		target = fmt.Sprintf("operation %q", e.Limit.Operation)
+	}
+	return fmt.Sprintf("%s is limited to %d calls per %s; retry in %s",
+		target, e.Limit.Requests, e.Limit.Per, e.RetryAfter.Round(time.Second))
+}
+
+// RateLimiter enforces a plugin's rate limits on the host side, with a token
+// bucket per limit. It is safe for concurrent use.
+//
+// Example:
+//
+//	limiter := pluginapi.NewRateLimiter(client.GetRateLimits())
+//	if err := limiter.Allow(pluginapi.OperationFromArgs(args)); err != nil {
+//	    return err // tell the model to slow down instead of calling the plugin
+//	}
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets []*rateBucket
+	now     func() time.Time // Replaced in tests
+}
+
+type rateBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter for limits. Limits with no requests or period are ignored.
+func NewRateLimiter(limits []RateLimit) *RateLimiter {
+	r := &RateLimiter{now: time.Now}
+	for _, limit := range limits {
+		if limit.Requests <= 0 || limit.Per <= 0 {
+			continue
+		}
+		if limit.Burst <= 0 {
+			limit.Burst = limit.Requests
+		}
+		r.buckets = append(r.buckets, &rateBucket{limit: limit, tokens: float64(limit.Burst)})
+	}
+	return r
+}
+
+// Allow records a call to operation if every applicable limit has room for it.
+// Otherwise nothing is recorded and it returns a retryable rate_limited
+// *PluginError wrapping a *RateLimitError.
+func (r *RateLimiter) Allow(operation string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+
+	var throttled *RateLimitError
+	var applicable []*rateBucket
+	for _, b := range r.buckets {
+		if b.limit.Operation != "" && b.limit.Operation != operation {
+			continue
+		}
+		b.refill(now)
+		applicable = append(applicable, b)
+		if b.tokens < 1 {
+			wait := b.untilToken()
+			if throttled == nil || wait > throttled.RetryAfter {
+				throttled = &RateLimitError{Operation: operation, Limit: b.limit, RetryAfter: wait}
+			}
+		}
+	}
+	if throttled != nil {
+		return WrapPluginError(ErrorCodeRateLimited, throttled)
+	}
+	for _, b := range applicable {
+		b.tokens--
+	}
+	return nil
+}
+
+// Wait blocks until a call to operation is allowed, then records it.
+// It returns ctx's error if ctx ends first.
+func (r *RateLimiter) Wait(ctx context.Context, operation string) error {
+	for {
+		err := r.Allow(operation)
+		var throttled *RateLimitError
+		if !errors.As(err, &throttled) {
+			return err
+		}
+		timer := time.NewTimer(throttled.RetryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (b *rateBucket) refill(now time.Time) {
+	if !b.last.IsZero() {
+		rate := float64(b.limit.Requests) / b.limit.Per.Seconds()
+		b.tokens = min(float64(b.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*rate)
+	}
+	b.last = now
+}
+
+// untilToken returns how long until the bucket holds a whole token.
+func (b *rateBucket) untilToken() time.Duration {
+	rate := float64(b.limit.Requests) / b.limit.Per.Seconds()
+	return time.Duration((1 - b.tokens) / rate * float64(time.Second))
+}
@@ -0,0 +1,146 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		spec string
+		want RateLimit
+	}{
+		{"10/minute", RateLimit{Requests: 10, Per: time.Minute}},
+		{"5/s", RateLimit{Requests: 5, Per: time.Second}},
+		{" 1000 / day ", RateLimit{Requests: 1000, Per: 24 * time.Hour}},
+		{"100/15m", RateLimit{Requests: 100, Per: 15 * time.Minute}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRateLimit(tt.spec)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseRateLimit(%q) = %+v, %v, want %+v", tt.spec, got, err, tt.want)
+		}
+	}
+	for _, spec := range []string{"", "10", "0/minute", "ten/minute", "10/fortnight", "10/-1m"} {
+		if _, err := ParseRateLimit(spec); err == nil {
+			t.Errorf("ParseRateLimit(%q) should fail", spec)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter([]RateLimit{
+		{Requests: 3, Per: time.Minute},
+		{Operation: "search", Requests: 1, Per: 10 * time.Second},
+	})
+	limiter.now = func() time.Time { return now }
+
+	if err := limiter.Allow("search"); err != nil {
+		t.Fatalf("first search should be allowed: %v", err)
+	}
+	err := limiter.Allow("search")
+	var pluginErr *PluginError
+	var throttled *RateLimitError
+	if !errors.As(err, &pluginErr) || pluginErr.Code != ErrorCodeRateLimited || !pluginErr.Retryable {
+		t.Fatalf("expected a retryable rate_limited PluginError, got %v", err)
+	}
+	if !errors.As(err, &throttled) || throttled.Limit.Operation != "search" || throttled.RetryAfter != 10*time.Second {
+		t.Errorf("unexpected RateLimitError: %+v", throttled)
+	}
+
+	// A throttled call doesn't use up the tool-wide limit
+	if err := limiter.Allow("forecast"); err != nil {
+		t.Errorf("forecast should be allowed: %v", err)
+	}
+	if err := limiter.Allow("forecast"); err != nil {
+		t.Errorf("forecast should be allowed: %v", err)
+	}
+	if err := limiter.Allow("forecast"); !errors.As(err, &throttled) || throttled.Limit.Operation != "" || throttled.RetryAfter != 20*time.Second {
+		t.Errorf("expected the tool-wide limit to apply, got %v", err)
+	}
+
+	now = now.Add(20 * time.Second)
+	if err := limiter.Allow("search"); err != nil {
+		t.Errorf("search should be allowed after the limits refill: %v", err)
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := NewRateLimiter([]RateLimit{{Requests: 1, Per: 20 * time.Millisecond}})
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(ctx, ""); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("second call should have waited, took %v", elapsed)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := limiter.Wait(cancelled, ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+const rateLimitedToolYAML = `
+name: weather
+description: Weather lookups
+rate_limit: 60/minute
+parameters:
+  - name: operation
+    type: string
+    description: What to do
+    required: true
+operations:
+  forecast:
+    parameters: []
+  search:
+    rate_limit: 10/minute
+`
+
+type rateLimitedTestTool struct {
+	plainTestTool
+	toolDef *YAMLToolDefinition
+}
+
+func (t *rateLimitedTestTool) GetRateLimits() []RateLimit {
+	return RateLimitsFromYAML(t.toolDef)
+}
+
+func TestRateLimits_FromYAML(t *testing.T) {
+	var toolDef YAMLToolDefinition
+	if err := yaml.Unmarshal([]byte(rateLimitedToolYAML), &toolDef); err != nil {
+		t.Fatalf("invalid YAML: %v", err)
+	}
+	if err := ValidateYAMLToolDefinition(&toolDef); err != nil {
+		t.Fatalf("ValidateYAMLToolDefinition failed: %v", err)
+	}
+
+	client := newTestClient(t, &rateLimitedTestTool{toolDef: &toolDef})
+	limits := client.GetRateLimits()
+	want := []RateLimit{
+		{Requests: 60, Per: time.Minute},
+		{Operation: "search", Requests: 10, Per: time.Minute},
+	}
+	if len(limits) != len(want) || limits[0] != want[0] || limits[1] != want[1] {
+		t.Errorf("GetRateLimits = %+v, want %+v", limits, want)
+	}
+	caps, err := client.GetCapabilities(context.Background())
+	if err != nil || len(caps.RateLimits) != 2 || !caps.Implements("RateLimitProvider") {
+		t.Errorf("unexpected capabilities: %+v, %v", caps.RateLimits, err)
+	}
+
+	toolDef.Operations["search"] = YAMLOperationDefinition{RateLimit: "lots"}
+	if err := ValidateYAMLToolDefinition(&toolDef); err == nil || !strings.Contains(err.Error(), `operation "search": invalid rate limit`) {
+		t.Errorf("expected invalid rate_limit error, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package pluginapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	rpcv2 "github.com/oriagent/ori-pluginapi/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// readyLinePrefix starts the readiness line, so hosts can pick it out of
+// whatever else the plugin prints.
+const readyLinePrefix = "ORI_PLUGIN_READY "
+
+// ReadyInfo is announced on stdout by ServeGRPCPlugin once it is listening, as a
+// single line of the form
+//
+//	ORI_PLUGIN_READY {"network":"tcp","address":"127.0.0.1:50051","port":50051,"pid":4242,"api_version":"v1"}
+//
+// Hosts can wait for it (see ParseReadyLine) instead of retry-dialing the plugin.
+// It is not printed over the stdio transport, where stdout carries the RPCs.
+type ReadyInfo struct {
+	// Network is "tcp" or "unix"
+	Network string `json:"network"`
+	// Address is the host:port or socket path the plugin listens on
+	Address string `json:"address"`
+	// Port is the TCP port, zero for unix sockets
+	Port int `json:"port,omitempty"`
+	// PID is the plugin's process ID
+	PID int `json:"pid"`
+	// APIVersion is the plugin API version (e.g., "v1")
+	APIVersion string `json:"api_version"`
+	// TLS reports whether the plugin serves TLS
+	TLS bool `json:"tls,omitempty"`
+}
+
+// ParseReadyLine parses a line of plugin output. It returns false if the line
+// is not a readiness line.
+func ParseReadyLine(line string) (ReadyInfo, bool) {
+	data, ok := strings.CutPrefix(strings.TrimSpace(line), readyLinePrefix)
+	if !ok {
+		return ReadyInfo{}, false
+	}
+	var info ReadyInfo
+	if err := json.Unmarshal([]byte(data), &info); err != nil {
+		return ReadyInfo{}, false
+	}
+	return info, true
+}
+
+// newReadyInfo describes a plugin listening on lis.
+func newReadyInfo(lis net.Listener, apiVersion string, tls bool) ReadyInfo {
+	info := ReadyInfo{
+		Network:    lis.Addr().Network(),
+		Address:    lis.Addr().String(),
+		PID:        os.Getpid(),
+		APIVersion: apiVersion,
+		TLS:        tls,
+	}
+	if addr, ok := lis.Addr().(*net.TCPAddr); ok {
+		info.Port = addr.Port
+	}
+	return info
+}
+
+// writeReadyLine prints info as a readiness line.
+func writeReadyLine(w io.Writer, info ReadyInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", readyLinePrefix, data)
+	return err
+}
+
+// registerHealth registers the standard gRPC health service on server, reporting
+// the overall server and both tool service versions as serving.
+func registerHealth(server *grpc.Server) *health.Server {
+	healthServer := health.NewServer()
+	for _, service := range []string{"", ToolService_ServiceDesc.ServiceName, rpcv2.ToolService_ServiceDesc.ServiceName} {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+	return healthServer
+}
@@ -0,0 +1,69 @@
+package pluginapi
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestReadyLine(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	var out bytes.Buffer
+	if err := writeReadyLine(&out, newReadyInfo(lis, "v1", true)); err != nil {
+		t.Fatalf("writeReadyLine failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "ORI_PLUGIN_READY {") || !strings.HasSuffix(out.String(), "}\n") {
+		t.Errorf("unexpected readiness line %q", out.String())
+	}
+
+	info, ok := ParseReadyLine(out.String())
+	if !ok {
+		t.Fatalf("ParseReadyLine rejected %q", out.String())
+	}
+	want := ReadyInfo{
+		Network:    "tcp",
+		Address:    lis.Addr().String(),
+		Port:       lis.Addr().(*net.TCPAddr).Port,
+		PID:        os.Getpid(),
+		APIVersion: "v1",
+		TLS:        true,
+	}
+	if info != want {
+		t.Errorf("got %+v, want %+v", info, want)
+	}
+
+	for _, line := range []string{"", "starting up", "ORI_PLUGIN_READY not json"} {
+		if _, ok := ParseReadyLine(line); ok {
+			t.Errorf("expected %q not to parse as a readiness line", line)
+		}
+	}
+}
+
+func TestRegisterHealth(t *testing.T) {
+	server := grpc.NewServer()
+	registerToolServices(server, &plainTestTool{})
+	registerHealth(server)
+	client := healthpb.NewHealthClient(dialTestServer(t, server))
+
+	for _, service := range []string{"", "pluginapi.ToolService", "pluginapi.v2.ToolService"} {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Errorf("health check for %q failed: %v", service, err)
+			continue
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("expected %q to be serving, got %v", service, resp.Status)
+		}
+	}
+}
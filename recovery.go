@@ -0,0 +1,97 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime/debug"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HealthStatus is the result of a plugin health check.
+type HealthStatus struct {
+	// Err is the plugin's own health check failure, nil if healthy
+	Err error
+	// RecoveredPanics counts handler panics the plugin has survived since it started
+	RecoveredPanics int64
+	// LastPanic describes the most recent of them
+	LastPanic string
+}
+
+// panicCounter records recovered panics for HealthStatus.
+// The zero value is ready to use.
+type panicCounter struct {
+	mu    sync.Mutex
+	count int64
+	last  string
+}
+
+func (c *panicCounter) record(description string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.count++
+	c.last = description
+}
+
+func (c *panicCounter) snapshot() (int64, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.count, c.last
+}
+
+// recoverUnary keeps a panicking handler from killing the plugin process.
+// Call and CallWithFiles report the panic as an internal PluginError carrying the
+// stack trace, like any other failed call; other RPCs fail with codes.Internal.
+// Panics in goroutines the plugin starts itself cannot be recovered here.
+func recoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pluginErr := recordPanic(info.Server, info.FullMethod, r)
+			switch path.Base(info.FullMethod) {
+			case "Call", "CallWithFiles":
+				resp, err = callErrorResponse(pluginErr), nil
+			default:
+				resp, err = nil, status.Error(codes.Internal, pluginErr.Message)
+			}
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// recoverStream is recoverUnary for streaming RPCs. A panicking CallStream or
+// CallWithFilesStream ends with an error response, so hosts see the same
+// PluginError as from Call.
+func recoverStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pluginErr := recordPanic(srv, info.FullMethod, r)
+			switch path.Base(info.FullMethod) {
+			case "CallStream":
+				err = stream.SendMsg(&CallStreamChunk{
+					Done:            true,
+					Error:           pluginErr.Message,
+					StructuredError: pluginErrorToProto(pluginErr),
+				})
+			case "CallWithFilesStream":
+				err = stream.SendMsg(callErrorResponse(pluginErr))
+			default:
+				err = status.Error(codes.Internal, pluginErr.Message)
+			}
+		}
+	}()
+	return handler(srv, stream)
+}
+
+// recordPanic counts a recovered panic on the plugin's server and describes it as an error.
+func recordPanic(srv any, fullMethod string, recovered any) *PluginError {
+	pluginErr := NewPluginError(ErrorCodeInternal, fmt.Sprintf("plugin panicked in %s: %v", path.Base(fullMethod), recovered))
+	pluginErr.Stack = string(debug.Stack())
+	if s, ok := srv.(*grpcServer); ok {
+		s.panics.record(pluginErr.Message)
+	}
+	return pluginErr
+}
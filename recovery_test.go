@@ -0,0 +1,80 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type panickingTestTool struct {
+	BasePlugin
+}
+
+func (t *panickingTestTool) Call(ctx context.Context, args string) (string, error) {
+	if args == "panic" {
+		var m map[string]int
+		m["boom"]++
+	}
+	return "ok", nil
+}
+
+func (t *panickingTestTool) CallStream(ctx context.Context, args string, emit func(CallChunk) error) (string, error) {
+	panic("stream exploded")
+}
+
+func (t *panickingTestTool) GetDefaultSettings() (string, error) {
+	panic("no settings")
+}
+
+func TestRecovery_CallPanicBecomesPluginError(t *testing.T) {
+	client := &grpcClient{client: NewToolServiceClient(newTestConn(t, &panickingTestTool{}, serverOptions(&panickingTestTool{})...))}
+	ctx := context.Background()
+
+	_, err := client.Call(ctx, "panic")
+	var pluginErr *PluginError
+	if !errors.As(err, &pluginErr) {
+		t.Fatalf("expected PluginError, got %v", err)
+	}
+	if pluginErr.Code != ErrorCodeInternal || !strings.Contains(pluginErr.Message, "assignment to entry in nil map") {
+		t.Errorf("unexpected error: %+v", pluginErr)
+	}
+	if !strings.Contains(pluginErr.Stack, "panickingTestTool") {
+		t.Errorf("stack should point at the panic, got:\n%s", pluginErr.Stack)
+	}
+
+	// The plugin survives and keeps serving
+	if result, err := client.Call(ctx, "fine"); err != nil || result != "ok" {
+		t.Errorf("Call after panic = %q, %v", result, err)
+	}
+
+	_, err = client.CallStream(ctx, "{}", func(CallChunk) error { return nil })
+	if !errors.As(err, &pluginErr) || !strings.Contains(pluginErr.Message, "stream exploded") {
+		t.Errorf("expected PluginError from CallStream, got %v", err)
+	}
+
+	if _, err := client.GetDefaultSettingsCtx(ctx); status.Code(err) != codes.Internal {
+		t.Errorf("expected Internal from other RPCs, got %v", err)
+	}
+
+	health, err := client.HealthStatus(ctx)
+	if err != nil {
+		t.Fatalf("HealthStatus failed: %v", err)
+	}
+	if health.Err != nil || health.RecoveredPanics != 3 || !strings.Contains(health.LastPanic, "no settings") {
+		t.Errorf("unexpected health status: %+v", health)
+	}
+}
+
+func TestRecovery_StdioTransport(t *testing.T) {
+	client, _, _ := newStdioTestClient(t, &panickingTestTool{})
+
+	_, err := client.Call(context.Background(), "panic")
+	var pluginErr *PluginError
+	if !errors.As(err, &pluginErr) || pluginErr.Stack == "" {
+		t.Errorf("expected PluginError with stack over stdio, got %v", err)
+	}
+}
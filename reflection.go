@@ -0,0 +1,38 @@
+package pluginapi
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// EnvGRPCReflection enables the gRPC reflection service when set to a true value
+// (e.g., "1"), so plugins can be explored during development without a custom client:
+//
+//	ORI_PLUGIN_GRPC_REFLECTION=1 ORI_PLUGIN_GRPC_PORT=50051 ./my-plugin &
+//	grpcurl -plaintext localhost:50051 list
+//	grpcurl -plaintext -d '{"args_json": "{}"}' localhost:50051 pluginapi.ToolService/Call
+//
+// Reflection exposes the plugin's full API surface, so leave it off in production.
+// It defaults to on in dev mode (see EnvDev) and is not available over the stdio transport.
+const EnvGRPCReflection = "ORI_PLUGIN_GRPC_REFLECTION"
+
+// reflectionEnabled reports whether EnvGRPCReflection, or failing that EnvDev, asks for reflection.
+func reflectionEnabled() bool {
+	value := strings.TrimSpace(os.Getenv(EnvGRPCReflection))
+	if value == "" {
+		return devMode()
+	}
+	enabled, _ := strconv.ParseBool(value)
+	return enabled
+}
+
+// registerReflection registers the reflection service on server if EnvGRPCReflection is set.
+func registerReflection(server *grpc.Server) {
+	if reflectionEnabled() {
+		reflection.Register(server)
+	}
+}
@@ -0,0 +1,86 @@
+package pluginapi
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialTestServer serves server over an in-memory connection and dials it.
+func dialTestServer(t *testing.T, server *grpc.Server) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial test server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// listServices lists the services a server exposes through reflection.
+func listServices(t *testing.T, server *grpc.Server) ([]string, error) {
+	t.Helper()
+	conn := dialTestServer(t, server)
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		names = append(names, service.Name)
+	}
+	return names, nil
+}
+
+func TestRegisterReflection(t *testing.T) {
+	t.Setenv(EnvGRPCReflection, "1")
+	server := grpc.NewServer()
+	registerToolServices(server, &plainTestTool{})
+	registerReflection(server)
+
+	names, err := listServices(t, server)
+	if err != nil {
+		t.Fatalf("reflection request failed: %v", err)
+	}
+	for _, want := range []string{"pluginapi.ToolService", "pluginapi.v2.ToolService"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("expected %s in %v", want, names)
+		}
+	}
+}
+
+func TestRegisterReflection_OffByDefault(t *testing.T) {
+	t.Setenv(EnvGRPCReflection, "")
+	t.Setenv(EnvDev, "")
+	server := grpc.NewServer()
+	registerToolServices(server, &plainTestTool{})
+	registerReflection(server)
+
+	if names, err := listServices(t, server); err == nil {
+		t.Errorf("expected reflection to be unavailable, got services %v", names)
+	}
+}
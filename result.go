@@ -11,12 +11,21 @@ import (
 type DisplayType string
 
 const (
-	DisplayTypeText  DisplayType = "text"  // Plain text response
-	DisplayTypeTable DisplayType = "table" // Tabular data
-	DisplayTypeModal DisplayType = "modal" // Modal/popup with interactive elements
-	DisplayTypeCard  DisplayType = "card"  // Card-based layout
-	DisplayTypeList  DisplayType = "list"  // Simple list
-	DisplayTypeJSON  DisplayType = "json"  // Raw JSON viewer
+	DisplayTypeText         DisplayType = "text"         // Plain text response
+	DisplayTypeTable        DisplayType = "table"        // Tabular data
+	DisplayTypeModal        DisplayType = "modal"        // Modal/popup with interactive elements
+	DisplayTypeCard         DisplayType = "card"         // Card-based layout
+	DisplayTypeList         DisplayType = "list"         // Simple list
+	DisplayTypeJSON         DisplayType = "json"         // Raw JSON viewer
+	DisplayTypeUI           DisplayType = "ui"           // Declarative component tree (see UIComponent)
+	DisplayTypeChart        DisplayType = "chart"        // Chart (see ChartData)
+	DisplayTypeMarkdown     DisplayType = "markdown"     // Markdown document (see the markdown package)
+	DisplayTypeMap          DisplayType = "map"          // GeoJSON pins and regions on a map (see MapData)
+	DisplayTypeNotification DisplayType = "notification" // Toast-style confirmation (see NotificationData)
+	DisplayTypeDeepLink     DisplayType = "deeplink"     // Navigation link to a page, settings, or file (see DeepLinkData)
+	DisplayTypePlan         DisplayType = "plan"         // Preview of the changes a dry run would make (see PlanData)
+	DisplayTypeImage        DisplayType = "image"        // Inline image (see ImageData)
+	DisplayTypeAudio        DisplayType = "audio"        // Playable audio clip (see AudioData)
 )
 
 // StructuredResult represents a plugin result with metadata about how to display it
@@ -46,6 +55,107 @@ func (sr *StructuredResult) ToYAML() (string, error) {
 	return string(data), nil
 }
 
+// Validate checks that the result is well-formed for its display type.
+// Hosts can call this before rendering to reject malformed plugin output.
+func (sr *StructuredResult) Validate() error {
+	if sr.DisplayType == "" {
+		return fmt.Errorf("displayType is required")
+	}
+	validate, ok := resultDataValidators[sr.DisplayType]
+	if !ok {
+		return fmt.Errorf("unsupported displayType: %s", sr.DisplayType)
+	}
+	if validate != nil {
+		if err := validate(sr.Data); err != nil {
+			return err
+		}
+	}
+
+	for _, actions := range [][]ResultAction{sr.Actions(), sr.RowActions()} {
+		for i, action := range actions {
+			if action.Label == "" || action.Operation == "" {
+				return fmt.Errorf("action[%d]: label and operation are required", i)
+			}
+		}
+	}
+	for i, call := range sr.SuggestedCalls() {
+		if call.Operation == "" {
+			return fmt.Errorf("suggested_calls[%d]: operation is required", i)
+		}
+	}
+
+	return nil
+}
+
+// resultDataValidators lists the supported display types with the check their
+// Data must pass, or nil for display types whose Data is free-form.
+var resultDataValidators = map[DisplayType]func(data any) error{
+	DisplayTypeText:  nil,
+	DisplayTypeTable: nil,
+	DisplayTypeModal: nil,
+	DisplayTypeCard:  nil,
+	DisplayTypeList:  nil,
+	DisplayTypeJSON:  nil,
+	DisplayTypeMarkdown: func(data any) error {
+		if _, ok := data.(string); !ok {
+			return fmt.Errorf("markdown data must be a string (got %T)", data)
+		}
+		return nil
+	},
+	DisplayTypeUI:           validateResultData[UIComponent]("UI"),
+	DisplayTypeChart:        validateResultData[ChartData]("chart"),
+	DisplayTypeMap:          validateResultData[MapData]("map"),
+	DisplayTypeNotification: validateResultData[NotificationData]("notification"),
+	DisplayTypeDeepLink:     validateResultData[DeepLinkData]("deep link"),
+	DisplayTypePlan:         validateResultData[PlanData]("plan"),
+	DisplayTypeImage:        validateResultData[ImageData]("image"),
+	DisplayTypeAudio:        validateResultData[AudioData]("audio"),
+}
+
+// validateResultData returns a check that decodes Data into T and validates it.
+// name describes T in error messages.
+func validateResultData[T any, PT interface {
+	*T
+	Validate() error
+}](name string) func(data any) error {
+	return func(data any) error {
+		value, err := decodeResultData[T](data)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+		if err := PT(value).Validate(); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+		return nil
+	}
+}
+
+// decodeResultData converts a result's Data into T.
+// Results parsed from JSON or YAML hold generic maps, which are converted via JSON.
+func decodeResultData[T any](data any) (*T, error) {
+	switch v := data.(type) {
+	case T:
+		return &v, nil
+	case *T:
+		if v == nil {
+			return nil, fmt.Errorf("data is required")
+		}
+		return v, nil
+	case nil:
+		return nil, fmt.Errorf("data is required")
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data: %w", err)
+	}
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, fmt.Errorf("invalid data: %w", err)
+	}
+	return &value, nil
+}
+
 // FromJSON parses a JSON string into a StructuredResult
 func FromJSON(jsonStr string) (*StructuredResult, error) {
 	var sr StructuredResult
@@ -121,6 +231,16 @@ func NewTextResult(text string) *StructuredResult {
 	}
 }
 
+// NewMarkdownResult creates a StructuredResult for a Markdown document.
+// Build the document with the markdown package to get correct escaping.
+func NewMarkdownResult(title, markdown string) *StructuredResult {
+	return &StructuredResult{
+		DisplayType: DisplayTypeMarkdown,
+		Title:       title,
+		Data:        markdown,
+	}
+}
+
 // NewListResult creates a StructuredResult for list display
 func NewListResult(title string, items interface{}) *StructuredResult {
 	return &StructuredResult{
@@ -129,3 +249,20 @@ func NewListResult(title string, items interface{}) *StructuredResult {
 		Data:        items,
 	}
 }
+
+// NewUIResult creates a StructuredResult that renders a declarative component tree.
+//
+// Example:
+//
+//	result := pluginapi.NewUIResult("Project", pluginapi.UIColumn(
+//	    pluginapi.UIRow(pluginapi.UIText(p.Name), pluginapi.UIBadge(p.Status, "success")),
+//	    pluginapi.UIInput("new_name", "Rename to", p.Name),
+//	    pluginapi.UIButton("Rename", "rename_project", map[string]interface{}{"id": p.ID}),
+//	))
+func NewUIResult(title string, root UIComponent) *StructuredResult {
+	return &StructuredResult{
+		DisplayType: DisplayTypeUI,
+		Title:       title,
+		Data:        root,
+	}
+}
@@ -0,0 +1,133 @@
+package pluginapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ResultAction describes a button attached to a StructuredResult.
+//
+// Callback contract: when the user clicks the button, the host calls the same plugin's
+// Call method with a JSON object built from Args plus "operation": Operation.
+// The result of that call is rendered like any other tool result. Plugins therefore
+// need no extra code to handle actions beyond supporting the operation itself.
+//
+// For row actions (see AddRowAction), string argument values of the form "{{field}}"
+// are replaced with the value of field in the clicked row before the call is made.
+type ResultAction struct {
+	// Label is the button text (e.g., "Open", "Delete")
+	Label string `json:"label" yaml:"label"`
+	// Operation is the value passed as the "operation" argument
+	Operation string `json:"operation" yaml:"operation"`
+	// Args are prefilled arguments for the call
+	Args map[string]interface{} `json:"args,omitempty" yaml:"args,omitempty"`
+	// Confirm is an optional prompt shown before running the action (e.g., for deletes)
+	Confirm string `json:"confirm,omitempty" yaml:"confirm,omitempty"`
+}
+
+// Metadata keys used for result actions
+const (
+	metadataKeyActions    = "actions"
+	metadataKeyRowActions = "rowActions"
+)
+
+// AddAction attaches a button to the result that calls the plugin with the given
+// operation and arguments. Returns the result for chaining.
+//
+// Example:
+//
+//	result := pluginapi.NewTextResult("Project created").
+//	    AddAction("Open", "open_project", map[string]interface{}{"id": id})
+func (sr *StructuredResult) AddAction(label, operation string, args map[string]interface{}) *StructuredResult {
+	return sr.WithActions(ResultAction{Label: label, Operation: operation, Args: args})
+}
+
+// WithActions attaches one or more result-level buttons. Returns the result for chaining.
+func (sr *StructuredResult) WithActions(actions ...ResultAction) *StructuredResult {
+	sr.appendActions(metadataKeyActions, actions)
+	return sr
+}
+
+// AddRowAction attaches a button to every row of a table or list result.
+// String argument values of the form "{{field}}" are filled in from the clicked row.
+// Returns the result for chaining.
+//
+// Example:
+//
+//	result := pluginapi.NewTableResult("Projects", []string{"id", "name"}, rows).
+//	    AddRowAction("Open", "open_project", map[string]interface{}{"id": "{{id}}"}).
+//	    AddRowAction("Delete", "delete_project", map[string]interface{}{"id": "{{id}}"})
+func (sr *StructuredResult) AddRowAction(label, operation string, args map[string]interface{}) *StructuredResult {
+	sr.appendActions(metadataKeyRowActions, []ResultAction{{Label: label, Operation: operation, Args: args}})
+	return sr
+}
+
+// Actions returns the result-level actions, including results decoded from JSON or YAML.
+func (sr *StructuredResult) Actions() []ResultAction {
+	return sr.actions(metadataKeyActions)
+}
+
+// RowActions returns the per-row actions, including results decoded from JSON or YAML.
+func (sr *StructuredResult) RowActions() []ResultAction {
+	return sr.actions(metadataKeyRowActions)
+}
+
+func (sr *StructuredResult) appendActions(key string, actions []ResultAction) {
+	if sr.Metadata == nil {
+		sr.Metadata = make(map[string]any)
+	}
+	sr.Metadata[key] = append(sr.actions(key), actions...)
+}
+
+// actions reads actions from metadata. After decoding, metadata holds generic
+// maps rather than ResultAction values, so those are converted via JSON.
+func (sr *StructuredResult) actions(key string) []ResultAction {
+	raw, ok := sr.Metadata[key]
+	if !ok || raw == nil {
+		return nil
+	}
+	if actions, ok := raw.([]ResultAction); ok {
+		return actions
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var actions []ResultAction
+	if err := json.Unmarshal(data, &actions); err != nil {
+		return nil
+	}
+	return actions
+}
+
+// CallArgs builds the JSON arguments the host passes to the plugin's Call method
+// when this action is triggered. row supplies values for "{{field}}" placeholders
+// and may be nil for result-level actions.
+func (a ResultAction) CallArgs(row map[string]interface{}) (string, error) {
+	args := make(map[string]interface{}, len(a.Args)+1)
+	for k, v := range a.Args {
+		args[k] = resolveActionPlaceholder(v, row)
+	}
+	args["operation"] = a.Operation
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal action arguments: %w", err)
+	}
+	return string(data), nil
+}
+
+// resolveActionPlaceholder replaces a "{{field}}" string with the row's value for field.
+func resolveActionPlaceholder(value interface{}, row map[string]interface{}) interface{} {
+	str, ok := value.(string)
+	if !ok || row == nil || !strings.HasPrefix(str, "{{") || !strings.HasSuffix(str, "}}") {
+		return value
+	}
+	field := strings.TrimSpace(str[2 : len(str)-2])
+	if rowValue, ok := row[field]; ok {
+		return rowValue
+	}
+	return value
+}
@@ -0,0 +1,52 @@
+package pluginapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestResultActions_RoundTrip(t *testing.T) {
+	rows := []map[string]string{{"id": "p1", "name": "Demo"}}
+	result := NewTableResult("Projects", []string{"id", "name"}, rows).
+		AddAction("New project", "create_project", nil).
+		AddRowAction("Open", "open_project", map[string]interface{}{"id": "{{id}}"}).
+		AddRowAction("Delete", "delete_project", map[string]interface{}{"id": "{{id}}", "force": true})
+
+	jsonStr, err := result.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+
+	parsed, err := ParseStructuredResult(jsonStr)
+	if err != nil {
+		t.Fatalf("ParseStructuredResult failed: %v", err)
+	}
+
+	actions := parsed.Actions()
+	if len(actions) != 1 || actions[0].Operation != "create_project" {
+		t.Errorf("unexpected actions: %+v", actions)
+	}
+
+	rowActions := parsed.RowActions()
+	if len(rowActions) != 2 || rowActions[0].Label != "Open" || rowActions[1].Label != "Delete" {
+		t.Fatalf("unexpected row actions: %+v", rowActions)
+	}
+
+	// Columns metadata is preserved alongside actions
+	if _, ok := parsed.Metadata["columns"]; !ok {
+		t.Error("expected columns metadata to be preserved")
+	}
+
+	argsJSON, err := rowActions[1].CallArgs(map[string]interface{}{"id": "p1", "name": "Demo"})
+	if err != nil {
+		t.Fatalf("CallArgs failed: %v", err)
+	}
+
+	var args map[string]interface{}
+	if err := json.Unmarshal([]byte(argsJSON), &args); err != nil {
+		t.Fatalf("failed to decode call args: %v", err)
+	}
+	if args["operation"] != "delete_project" || args["id"] != "p1" || args["force"] != true {
+		t.Errorf("unexpected call args: %v", args)
+	}
+}
@@ -0,0 +1,81 @@
+package pluginapi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SuggestedCall hints a sensible next step to the agent after a tool result,
+// e.g. suggesting "open_project" right after "create_project".
+// Unlike ResultAction, suggestions are not shown as buttons; they are passed to
+// the LLM, which decides whether to follow them.
+type SuggestedCall struct {
+	// Tool is the tool to call; empty means the plugin that produced the result
+	Tool string `json:"tool,omitempty" yaml:"tool,omitempty"`
+	// Operation is the value passed as the "operation" argument
+	Operation string `json:"operation" yaml:"operation"`
+	// Args are suggested arguments for the call
+	Args map[string]interface{} `json:"args,omitempty" yaml:"args,omitempty"`
+	// Reason briefly explains why the call is a good next step
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// Metadata key used for tool-chaining hints
+const metadataKeySuggestedCalls = "suggested_calls"
+
+// SuggestCall hints that the agent may want to call operation next on the same plugin.
+// Returns the result for chaining.
+//
+// Example:
+//
+//	result := pluginapi.NewTextResult("Project created").
+//	    SuggestCall("open_project", map[string]interface{}{"id": id}, "Open the new project to start editing")
+func (sr *StructuredResult) SuggestCall(operation string, args map[string]interface{}, reason string) *StructuredResult {
+	return sr.WithSuggestedCalls(SuggestedCall{Operation: operation, Args: args, Reason: reason})
+}
+
+// WithSuggestedCalls attaches one or more next-step hints, including calls to other tools.
+// Returns the result for chaining.
+func (sr *StructuredResult) WithSuggestedCalls(calls ...SuggestedCall) *StructuredResult {
+	if sr.Metadata == nil {
+		sr.Metadata = make(map[string]any)
+	}
+	sr.Metadata[metadataKeySuggestedCalls] = append(sr.SuggestedCalls(), calls...)
+	return sr
+}
+
+// SuggestedCalls returns the next-step hints, including results decoded from JSON or YAML.
+func (sr *StructuredResult) SuggestedCalls() []SuggestedCall {
+	raw, ok := sr.Metadata[metadataKeySuggestedCalls]
+	if !ok || raw == nil {
+		return nil
+	}
+	if calls, ok := raw.([]SuggestedCall); ok {
+		return calls
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var calls []SuggestedCall
+	if err := json.Unmarshal(data, &calls); err != nil {
+		return nil
+	}
+	return calls
+}
+
+// CallArgs builds the JSON arguments for the suggested call, with "operation" set.
+func (c SuggestedCall) CallArgs() (string, error) {
+	args := make(map[string]interface{}, len(c.Args)+1)
+	for k, v := range c.Args {
+		args[k] = v
+	}
+	args["operation"] = c.Operation
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal suggested call arguments: %w", err)
+	}
+	return string(data), nil
+}
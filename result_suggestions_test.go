@@ -0,0 +1,46 @@
+package pluginapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSuggestedCalls_RoundTrip(t *testing.T) {
+	result := NewTextResult("Project created").
+		SuggestCall("open_project", map[string]interface{}{"id": "p1"}, "Open the new project").
+		WithSuggestedCalls(SuggestedCall{Tool: "git", Operation: "init", Reason: "Put the project under version control"})
+
+	jsonStr, err := result.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	parsed, err := ParseStructuredResult(jsonStr)
+	if err != nil {
+		t.Fatalf("ParseStructuredResult failed: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Fatalf("parsed result failed validation: %v", err)
+	}
+
+	calls := parsed.SuggestedCalls()
+	if len(calls) != 2 || calls[0].Operation != "open_project" || calls[1].Tool != "git" {
+		t.Fatalf("unexpected suggested calls: %+v", calls)
+	}
+
+	argsJSON, err := calls[0].CallArgs()
+	if err != nil {
+		t.Fatalf("CallArgs failed: %v", err)
+	}
+	var args map[string]interface{}
+	if err := json.Unmarshal([]byte(argsJSON), &args); err != nil {
+		t.Fatalf("failed to decode call args: %v", err)
+	}
+	if args["operation"] != "open_project" || args["id"] != "p1" {
+		t.Errorf("unexpected call args: %v", args)
+	}
+
+	invalid := NewTextResult("x").WithSuggestedCalls(SuggestedCall{Reason: "no operation"})
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected validation error for suggested call without operation")
+	}
+}
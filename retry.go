@@ -0,0 +1,112 @@
+package pluginapi
+
+import (
+	"context"
+	"math/rand/v2"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy retries unary RPCs that fail with codes.Unavailable, as they do while
+// a plugin restarts, drains for shutdown, or when its connection resets.
+// The zero value retries up to 3 attempts with backoff starting at 100ms.
+//
+// Calls that may have side effects (Call, CallWithFiles, ExecuteScheduledTask and
+// StartTask, and the HostService CallTool, Complete, Notify, Remember, Log,
+// ReportUsage, SendToPlugin and RequestAuthorization) are only retried when they
+// carry an idempotency key (see WithIdempotencyKey), because a reset connection
+// doesn't prove the plugin never ran them.
+//
+// Example:
+//
+//	retry := pluginapi.RetryPolicy{MaxAttempts: 5}
+//	conn = pluginapi.ClientInterceptors{Unary: []grpc.UnaryClientInterceptor{retry.UnaryInterceptor()}}.Wrap(conn)
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt; defaults to 3
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry; defaults to 100ms
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts; defaults to 2s
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff after each retry; defaults to 2
+	Multiplier float64
+}
+
+// nonIdempotentMethods are RPCs that are only safe to retry with an idempotency key.
+var nonIdempotentMethods = map[string]bool{
+	"Call":          true,
+	"CallWithFiles": true,
+	"CallTool":      true,
+	"Complete":      true,
+	"Notify":        true,
+	"Remember":      true,
+	"Log":           true,
+	"ReportUsage":   true,
+	"SendToPlugin":  true,
+
+	"RequestAuthorization": true,
+
+	"ExecuteScheduledTask": true,
+	"StartTask":            true,
+}
+
+// UnaryInterceptor returns a client interceptor that applies the policy.
+func (p RetryPolicy) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	p = p.withDefaults()
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !retryable(method, req) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		backoff := p.InitialBackoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable || attempt >= p.MaxAttempts {
+				return err
+			}
+
+			timer := time.NewTimer(jitter(backoff))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+			backoff = min(time.Duration(float64(backoff)*p.Multiplier), p.MaxBackoff)
+		}
+	}
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = 100 * time.Millisecond
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = 2 * time.Second
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = 2
+	}
+	return p
+}
+
+// retryable reports whether the RPC can safely run more than once.
+func retryable(method string, req any) bool {
+	if !nonIdempotentMethods[path.Base(method)] {
+		return true
+	}
+	keyed, ok := req.(interface{ GetIdempotencyKey() string })
+	return ok && keyed.GetIdempotencyKey() != ""
+}
+
+// jitter spreads d by ±20%, so plugins restarting together aren't retried in lockstep.
+func jitter(d time.Duration) time.Duration {
+	return time.Duration(float64(d) * (0.8 + 0.4*rand.Float64()))
+}
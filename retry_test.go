@@ -0,0 +1,98 @@
+package pluginapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flakyInvoker fails with code for the first failures attempts.
+func flakyInvoker(failures int, code codes.Code, attempts *int) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*attempts++
+		if *attempts <= failures {
+			return status.Error(code, "connection reset")
+		}
+		return nil
+	}
+}
+
+func TestRetryPolicy_RetriesUnavailable(t *testing.T) {
+	retry := RetryPolicy{InitialBackoff: time.Millisecond}.UnaryInterceptor()
+	ctx := context.Background()
+
+	var attempts int
+	err := retry(ctx, "/pluginapi.ToolService/GetDefinition", &Empty{}, &ToolDefinition{}, nil, flakyInvoker(2, codes.Unavailable, &attempts))
+	if err != nil || attempts != 3 {
+		t.Errorf("expected success on the third attempt, got %v after %d", err, attempts)
+	}
+
+	attempts = 0
+	err = retry(ctx, "/pluginapi.ToolService/GetDefinition", &Empty{}, &ToolDefinition{}, nil, flakyInvoker(5, codes.Unavailable, &attempts))
+	if status.Code(err) != codes.Unavailable || attempts != 3 {
+		t.Errorf("expected to give up after 3 attempts, got %v after %d", err, attempts)
+	}
+
+	attempts = 0
+	err = retry(ctx, "/pluginapi.ToolService/GetDefinition", &Empty{}, &ToolDefinition{}, nil, flakyInvoker(1, codes.InvalidArgument, &attempts))
+	if status.Code(err) != codes.InvalidArgument || attempts != 1 {
+		t.Errorf("expected no retry for InvalidArgument, got %v after %d", err, attempts)
+	}
+}
+
+func TestRetryPolicy_CallsNeedIdempotencyKey(t *testing.T) {
+	retry := RetryPolicy{InitialBackoff: time.Millisecond}.UnaryInterceptor()
+	ctx := context.Background()
+
+	var attempts int
+	err := retry(ctx, "/pluginapi.ToolService/Call", &CallRequest{ArgsJson: "{}"}, &CallResponse{}, nil, flakyInvoker(1, codes.Unavailable, &attempts))
+	if status.Code(err) != codes.Unavailable || attempts != 1 {
+		t.Errorf("Call without an idempotency key must not be retried, got %v after %d", err, attempts)
+	}
+
+	attempts = 0
+	req := &CallRequest{ArgsJson: "{}", IdempotencyKey: "create-42"}
+	err = retry(ctx, "/pluginapi.v2.ToolService/Call", req, &CallResponse{}, nil, flakyInvoker(1, codes.Unavailable, &attempts))
+	if err != nil || attempts != 2 {
+		t.Errorf("Call with an idempotency key should be retried, got %v after %d", err, attempts)
+	}
+}
+
+func TestRetryPolicy_StopsWhenContextDone(t *testing.T) {
+	retry := RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour}.UnaryInterceptor()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var attempts int
+	start := time.Now()
+	err := retry(ctx, "/pluginapi.ToolService/HealthCheck", &Empty{}, &HealthCheckResponse{}, nil, flakyInvoker(10, codes.Unavailable, &attempts))
+	if status.Code(err) != codes.Unavailable || attempts != 1 || time.Since(start) > 2*time.Second {
+		t.Errorf("expected to stop waiting when the context ended, got %v after %d attempts", err, attempts)
+	}
+}
+
+func TestRetryPolicy_OverStdio(t *testing.T) {
+	tool := &drainTestTool{started: make(chan struct{}), release: make(chan struct{}), shutdown: make(chan bool, 1)}
+	_, conn, _ := newStdioTestClient(t, tool)
+	retry := RetryPolicy{InitialBackoff: time.Millisecond}
+	client := &grpcClient{client: NewToolServiceClient(ClientInterceptors{Unary: []grpc.UnaryClientInterceptor{retry.UnaryInterceptor()}}.Wrap(conn))}
+
+	if result, err := client.Call(context.Background(), "{}"); err != nil || result != "done" {
+		t.Errorf("Call through the retry interceptor = %q, %v", result, err)
+	}
+}
+
+func TestRetryPolicy_SendToPluginNotRetried(t *testing.T) {
+	retry := RetryPolicy{InitialBackoff: time.Millisecond}.UnaryInterceptor()
+
+	var attempts int
+	req := &ProtoPluginMessage{Target: "daw", Topic: "midi.note_on"}
+	err := retry(context.Background(), "/pluginapi.HostService/SendToPlugin", req, &ConfigResponse{}, nil, flakyInvoker(1, codes.Unavailable, &attempts))
+	if status.Code(err) != codes.Unavailable || attempts != 1 {
+		t.Errorf("SendToPlugin must not be retried, got %v after %d", err, attempts)
+	}
+}
@@ -0,0 +1,11 @@
+// Package rpcv2 contains the generated gRPC types for version 2 of the plugin wire protocol.
+//
+// Wire protocol versions live in their own packages (rpc/v2, rpc/v3, ...) with their own
+// proto package, so a host can talk to plugins compiled against older versions while
+// newer plugins adopt new RPCs. Plugins built with ServeGRPCPlugin serve every supported
+// version from the same implementation; hosts pick the newest version a plugin answers.
+//
+// Version 2 is wire-compatible with the unversioned v1 protocol in the root package:
+// messages keep their field numbers, so pluginapi.ConvertRPCMessage can translate
+// between a v1 message and its v2 counterpart.
+package rpcv2
@@ -0,0 +1,7674 @@
+// Version 2 of the plugin wire protocol.
+//
+// Every message keeps the field numbers of the unversioned v1 protocol
+// (proto/tool.proto), so v1 and v2 messages can be converted by re-encoding.
+// Changes that would break compiled plugins belong in a new version, not here.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: pluginapi/rpc/v2/tool.proto
+
+package rpcv2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Empty message for RPCs that don't need parameters
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{0}
+}
+
+// ToolDefinition represents a generic, provider-agnostic tool definition.
+// This format works with any LLM provider (OpenAI, Claude, Ollama, etc.)
+// and will be automatically translated to provider-specific formats by the server.
+type ToolDefinition struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	ParametersJson string                 `protobuf:"bytes,3,opt,name=parameters_json,json=parametersJson,proto3" json:"parameters_json,omitempty"` // JSON-encoded JSON Schema for parameters
+	Version        string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`                                     // Changes whenever a dynamic definition does (empty = static)
+	Annotations    *ProtoToolAnnotations  `protobuf:"bytes,5,opt,name=annotations,proto3" json:"annotations,omitempty"`
+	Examples       []*ProtoToolExample    `protobuf:"bytes,6,rep,name=examples,proto3" json:"examples,omitempty"`
+	Deprecation    *ProtoDeprecation      `protobuf:"bytes,7,opt,name=deprecation,proto3" json:"deprecation,omitempty"` // Unset unless the tool is deprecated
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ToolDefinition) Reset() {
+	*x = ToolDefinition{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolDefinition) ProtoMessage() {}
+
+func (x *ToolDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolDefinition.ProtoReflect.Descriptor instead.
+func (*ToolDefinition) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ToolDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolDefinition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ToolDefinition) GetParametersJson() string {
+	if x != nil {
+		return x.ParametersJson
+	}
+	return ""
+}
+
+func (x *ToolDefinition) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ToolDefinition) GetAnnotations() *ProtoToolAnnotations {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *ToolDefinition) GetExamples() []*ProtoToolExample {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+func (x *ToolDefinition) GetDeprecation() *ProtoDeprecation {
+	if x != nil {
+		return x.Deprecation
+	}
+	return nil
+}
+
+// ProtoDeprecation marks a tool or operation as legacy (see Deprecation)
+type ProtoDeprecation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deprecated    bool                   `protobuf:"varint,1,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,3,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoDeprecation) Reset() {
+	*x = ProtoDeprecation{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoDeprecation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoDeprecation) ProtoMessage() {}
+
+func (x *ProtoDeprecation) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoDeprecation.ProtoReflect.Descriptor instead.
+func (*ProtoDeprecation) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProtoDeprecation) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *ProtoDeprecation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ProtoDeprecation) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+// ProtoToolExample is a few-shot example of a tool call
+type ProtoToolExample struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArgsJson      string                 `protobuf:"bytes,1,opt,name=args_json,json=argsJson,proto3" json:"args_json,omitempty"` // JSON-encoded call arguments
+	Summary       string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`                   // What the call does or returns
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoToolExample) Reset() {
+	*x = ProtoToolExample{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoToolExample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoToolExample) ProtoMessage() {}
+
+func (x *ProtoToolExample) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoToolExample.ProtoReflect.Descriptor instead.
+func (*ProtoToolExample) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProtoToolExample) GetArgsJson() string {
+	if x != nil {
+		return x.ArgsJson
+	}
+	return ""
+}
+
+func (x *ProtoToolExample) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+// ProtoToolAnnotations are behavioral hints about a tool (see ToolAnnotations)
+type ProtoToolAnnotations struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReadOnly      bool                   `protobuf:"varint,1,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	Destructive   bool                   `protobuf:"varint,2,opt,name=destructive,proto3" json:"destructive,omitempty"`
+	Idempotent    bool                   `protobuf:"varint,3,opt,name=idempotent,proto3" json:"idempotent,omitempty"`
+	OpenWorld     bool                   `protobuf:"varint,4,opt,name=open_world,json=openWorld,proto3" json:"open_world,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoToolAnnotations) Reset() {
+	*x = ProtoToolAnnotations{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoToolAnnotations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoToolAnnotations) ProtoMessage() {}
+
+func (x *ProtoToolAnnotations) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoToolAnnotations.ProtoReflect.Descriptor instead.
+func (*ProtoToolAnnotations) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProtoToolAnnotations) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *ProtoToolAnnotations) GetDestructive() bool {
+	if x != nil {
+		return x.Destructive
+	}
+	return false
+}
+
+func (x *ProtoToolAnnotations) GetIdempotent() bool {
+	if x != nil {
+		return x.Idempotent
+	}
+	return false
+}
+
+func (x *ProtoToolAnnotations) GetOpenWorld() bool {
+	if x != nil {
+		return x.OpenWorld
+	}
+	return false
+}
+
+// ToolSetResponse lists the tools a plugin serves
+type ToolSetResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Tools           []*ToolDefinition      `protobuf:"bytes,1,rep,name=tools,proto3" json:"tools,omitempty"`
+	SupportsToolSet bool                   `protobuf:"varint,2,opt,name=supports_tool_set,json=supportsToolSet,proto3" json:"supports_tool_set,omitempty"` // False if the plugin serves only its own tool
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ToolSetResponse) Reset() {
+	*x = ToolSetResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolSetResponse) ProtoMessage() {}
+
+func (x *ToolSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolSetResponse.ProtoReflect.Descriptor instead.
+func (*ToolSetResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ToolSetResponse) GetTools() []*ToolDefinition {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *ToolSetResponse) GetSupportsToolSet() bool {
+	if x != nil {
+		return x.SupportsToolSet
+	}
+	return false
+}
+
+// CallRequest contains the arguments for calling a tool
+type CallRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ArgsJson       string                 `protobuf:"bytes,1,opt,name=args_json,json=argsJson,proto3" json:"args_json,omitempty"`                                                           // JSON-encoded tool arguments
+	Metadata       map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Host-controlled call metadata (e.g., source), not seen by the LLM
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`                                         // Identifies retries of the same logical call (empty = none)
+	CallId         string                 `protobuf:"bytes,4,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`                                                                 // Identifies this call for CancelCall (empty = not cancellable by ID)
+	ToolName       string                 `protobuf:"bytes,5,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`                                                           // Tool to call in a plugin serving several (empty = the plugin's own tool)
+	CallMeta       *ProtoCallMeta         `protobuf:"bytes,6,opt,name=call_meta,json=callMeta,proto3" json:"call_meta,omitempty"`                                                           // Who and what the call was made for (unset = unknown)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CallRequest) Reset() {
+	*x = CallRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallRequest) ProtoMessage() {}
+
+func (x *CallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallRequest.ProtoReflect.Descriptor instead.
+func (*CallRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CallRequest) GetArgsJson() string {
+	if x != nil {
+		return x.ArgsJson
+	}
+	return ""
+}
+
+func (x *CallRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CallRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CallRequest) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+func (x *CallRequest) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+func (x *CallRequest) GetCallMeta() *ProtoCallMeta {
+	if x != nil {
+		return x.CallMeta
+	}
+	return nil
+}
+
+// ProtoCallMeta describes the invocation a call belongs to
+type ProtoCallMeta struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Agent          string                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`                                         // Calling agent
+	ConversationId string                 `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"` // Conversation the call was made in
+	MessageId      string                 `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`                // User message that started the invocation
+	Model          string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`                                         // LLM model that requested the call
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProtoCallMeta) Reset() {
+	*x = ProtoCallMeta{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoCallMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoCallMeta) ProtoMessage() {}
+
+func (x *ProtoCallMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoCallMeta.ProtoReflect.Descriptor instead.
+func (*ProtoCallMeta) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProtoCallMeta) GetAgent() string {
+	if x != nil {
+		return x.Agent
+	}
+	return ""
+}
+
+func (x *ProtoCallMeta) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ProtoCallMeta) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ProtoCallMeta) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+// CallResponse contains the result of a tool call
+type CallResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ResultJson      string                 `protobuf:"bytes,1,opt,name=result_json,json=resultJson,proto3" json:"result_json,omitempty"`                // JSON-encoded result on success
+	Error           string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`                                            // Error message on failure (empty on success)
+	StructuredError *ProtoPluginError      `protobuf:"bytes,3,opt,name=structured_error,json=structuredError,proto3" json:"structured_error,omitempty"` // Classified failure; error is kept for older hosts
+	Files           []*ProtoFileAttachment `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`                                            // Generated output files (FileOutputProvider)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CallResponse) Reset() {
+	*x = CallResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallResponse) ProtoMessage() {}
+
+func (x *CallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallResponse.ProtoReflect.Descriptor instead.
+func (*CallResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CallResponse) GetResultJson() string {
+	if x != nil {
+		return x.ResultJson
+	}
+	return ""
+}
+
+func (x *CallResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CallResponse) GetStructuredError() *ProtoPluginError {
+	if x != nil {
+		return x.StructuredError
+	}
+	return nil
+}
+
+func (x *CallResponse) GetFiles() []*ProtoFileAttachment {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// ProtoPluginError is a classified call failure
+type ProtoPluginError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // invalid_args, auth, rate_limited, internal
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Retryable     bool                   `protobuf:"varint,3,opt,name=retryable,proto3" json:"retryable,omitempty"`                       // True if the same call may succeed later
+	Suggestion    string                 `protobuf:"bytes,4,opt,name=suggestion,proto3" json:"suggestion,omitempty"`                      // Optional user-facing hint for fixing the failure
+	FieldErrors   []*ProtoFieldError     `protobuf:"bytes,5,rep,name=field_errors,json=fieldErrors,proto3" json:"field_errors,omitempty"` // Individual failures for invalid_args
+	Stack         string                 `protobuf:"bytes,6,opt,name=stack,proto3" json:"stack,omitempty"`                                // Plugin stack trace, set for recovered panics
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoPluginError) Reset() {
+	*x = ProtoPluginError{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoPluginError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoPluginError) ProtoMessage() {}
+
+func (x *ProtoPluginError) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoPluginError.ProtoReflect.Descriptor instead.
+func (*ProtoPluginError) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ProtoPluginError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ProtoPluginError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ProtoPluginError) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+func (x *ProtoPluginError) GetSuggestion() string {
+	if x != nil {
+		return x.Suggestion
+	}
+	return ""
+}
+
+func (x *ProtoPluginError) GetFieldErrors() []*ProtoFieldError {
+	if x != nil {
+		return x.FieldErrors
+	}
+	return nil
+}
+
+func (x *ProtoPluginError) GetStack() string {
+	if x != nil {
+		return x.Stack
+	}
+	return ""
+}
+
+// ProtoFieldError is a single validation failure
+type ProtoFieldError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoFieldError) Reset() {
+	*x = ProtoFieldError{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoFieldError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoFieldError) ProtoMessage() {}
+
+func (x *ProtoFieldError) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoFieldError.ProtoReflect.Descriptor instead.
+func (*ProtoFieldError) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProtoFieldError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ProtoFieldError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// CancelCallRequest identifies a running call to cancel
+type CancelCallRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CallId        string                 `protobuf:"bytes,1,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelCallRequest) Reset() {
+	*x = CancelCallRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelCallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelCallRequest) ProtoMessage() {}
+
+func (x *CancelCallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelCallRequest.ProtoReflect.Descriptor instead.
+func (*CancelCallRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelCallRequest) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+// StartTaskRequest starts a background task with the given arguments
+type StartTaskRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ArgsJson       string                 `protobuf:"bytes,1,opt,name=args_json,json=argsJson,proto3" json:"args_json,omitempty"`                                                           // JSON-encoded tool arguments
+	Metadata       map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Host-controlled call metadata, as for Call
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`                                         // A retried start with the same key returns the same task
+	ToolName       string                 `protobuf:"bytes,4,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`                                                           // Tool to run in a plugin serving several (empty = the plugin's own tool)
+	CallMeta       *ProtoCallMeta         `protobuf:"bytes,5,opt,name=call_meta,json=callMeta,proto3" json:"call_meta,omitempty"`                                                           // Invocation metadata, as for Call
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StartTaskRequest) Reset() {
+	*x = StartTaskRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTaskRequest) ProtoMessage() {}
+
+func (x *StartTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTaskRequest.ProtoReflect.Descriptor instead.
+func (*StartTaskRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StartTaskRequest) GetArgsJson() string {
+	if x != nil {
+		return x.ArgsJson
+	}
+	return ""
+}
+
+func (x *StartTaskRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *StartTaskRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *StartTaskRequest) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+func (x *StartTaskRequest) GetCallMeta() *ProtoCallMeta {
+	if x != nil {
+		return x.CallMeta
+	}
+	return nil
+}
+
+// StartTaskResponse identifies the started task
+type StartTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTaskResponse) Reset() {
+	*x = StartTaskResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTaskResponse) ProtoMessage() {}
+
+func (x *StartTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTaskResponse.ProtoReflect.Descriptor instead.
+func (*StartTaskResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StartTaskResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// TaskRequest identifies a background task
+type TaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskRequest) Reset() {
+	*x = TaskRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskRequest) ProtoMessage() {}
+
+func (x *TaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskRequest.ProtoReflect.Descriptor instead.
+func (*TaskRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// TaskStatusResponse is a snapshot of a background task
+type TaskStatusResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TaskId          string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	State           string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`                                            // running, succeeded, failed, or cancelled
+	Percent         float64                `protobuf:"fixed64,3,opt,name=percent,proto3" json:"percent,omitempty"`                                      // Progress from 0 to 100 (negative = unknown)
+	Message         string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                                        // Latest status message
+	ResultJson      string                 `protobuf:"bytes,5,opt,name=result_json,json=resultJson,proto3" json:"result_json,omitempty"`                // Result once succeeded
+	Error           string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`                                            // Why the task failed or was cancelled
+	StructuredError *ProtoPluginError      `protobuf:"bytes,7,opt,name=structured_error,json=structuredError,proto3" json:"structured_error,omitempty"` // Classified failure
+	StartedUnixMs   int64                  `protobuf:"varint,8,opt,name=started_unix_ms,json=startedUnixMs,proto3" json:"started_unix_ms,omitempty"`
+	FinishedUnixMs  int64                  `protobuf:"varint,9,opt,name=finished_unix_ms,json=finishedUnixMs,proto3" json:"finished_unix_ms,omitempty"` // 0 while running
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TaskStatusResponse) Reset() {
+	*x = TaskStatusResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskStatusResponse) ProtoMessage() {}
+
+func (x *TaskStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskStatusResponse.ProtoReflect.Descriptor instead.
+func (*TaskStatusResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TaskStatusResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskStatusResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *TaskStatusResponse) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *TaskStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TaskStatusResponse) GetResultJson() string {
+	if x != nil {
+		return x.ResultJson
+	}
+	return ""
+}
+
+func (x *TaskStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TaskStatusResponse) GetStructuredError() *ProtoPluginError {
+	if x != nil {
+		return x.StructuredError
+	}
+	return nil
+}
+
+func (x *TaskStatusResponse) GetStartedUnixMs() int64 {
+	if x != nil {
+		return x.StartedUnixMs
+	}
+	return 0
+}
+
+func (x *TaskStatusResponse) GetFinishedUnixMs() int64 {
+	if x != nil {
+		return x.FinishedUnixMs
+	}
+	return 0
+}
+
+// CallStreamChunk is one update from a streaming tool call
+type CallStreamChunk struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Message           string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`                                               // Human-readable progress message
+	Progress          float64                `protobuf:"fixed64,2,opt,name=progress,proto3" json:"progress,omitempty"`                                           // Completed fraction (0-1), negative if unknown
+	Partial           string                 `protobuf:"bytes,3,opt,name=partial,proto3" json:"partial,omitempty"`                                               // Partial result (tool-defined format)
+	Done              bool                   `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`                                                    // True on the last chunk
+	ResultJson        string                 `protobuf:"bytes,5,opt,name=result_json,json=resultJson,proto3" json:"result_json,omitempty"`                       // Final result (only when done)
+	Error             string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`                                                   // Final error (only when done, empty on success)
+	SupportsStreaming bool                   `protobuf:"varint,7,opt,name=supports_streaming,json=supportsStreaming,proto3" json:"supports_streaming,omitempty"` // False if the plugin answered via Call with a single chunk
+	StructuredError   *ProtoPluginError      `protobuf:"bytes,8,opt,name=structured_error,json=structuredError,proto3" json:"structured_error,omitempty"`        // Classified final error (only when done)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CallStreamChunk) Reset() {
+	*x = CallStreamChunk{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallStreamChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallStreamChunk) ProtoMessage() {}
+
+func (x *CallStreamChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallStreamChunk.ProtoReflect.Descriptor instead.
+func (*CallStreamChunk) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CallStreamChunk) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CallStreamChunk) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *CallStreamChunk) GetPartial() string {
+	if x != nil {
+		return x.Partial
+	}
+	return ""
+}
+
+func (x *CallStreamChunk) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *CallStreamChunk) GetResultJson() string {
+	if x != nil {
+		return x.ResultJson
+	}
+	return ""
+}
+
+func (x *CallStreamChunk) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CallStreamChunk) GetSupportsStreaming() bool {
+	if x != nil {
+		return x.SupportsStreaming
+	}
+	return false
+}
+
+func (x *CallStreamChunk) GetStructuredError() *ProtoPluginError {
+	if x != nil {
+		return x.StructuredError
+	}
+	return nil
+}
+
+// VersionResponse contains the plugin version
+type VersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionResponse) Reset() {
+	*x = VersionResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionResponse) ProtoMessage() {}
+
+func (x *VersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *VersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// AgentContextRequest provides current agent information to the plugin
+type AgentContextRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                              // Agent name (e.g., "default", "my-agent")
+	ConfigPath      string                 `protobuf:"bytes,2,opt,name=config_path,json=configPath,proto3" json:"config_path,omitempty"`                // Path to agent's config.json
+	SettingsPath    string                 `protobuf:"bytes,3,opt,name=settings_path,json=settingsPath,proto3" json:"settings_path,omitempty"`          // Path to agent's agent_settings.json
+	AgentDir        string                 `protobuf:"bytes,4,opt,name=agent_dir,json=agentDir,proto3" json:"agent_dir,omitempty"`                      // Path to agent's directory
+	CurrentLocation string                 `protobuf:"bytes,5,opt,name=current_location,json=currentLocation,proto3" json:"current_location,omitempty"` // Detected location zone (e.g., "Home")
+	ConversationId  string                 `protobuf:"bytes,6,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`    // Active conversation
+	UserDisplayName string                 `protobuf:"bytes,7,opt,name=user_display_name,json=userDisplayName,proto3" json:"user_display_name,omitempty"`
+	Locale          string                 `protobuf:"bytes,8,opt,name=locale,proto3" json:"locale,omitempty"`                                     // BCP 47 tag (e.g., "en-US")
+	Timezone        string                 `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`                                 // IANA name (e.g., "Europe/Berlin")
+	LlmModel        string                 `protobuf:"bytes,10,opt,name=llm_model,json=llmModel,proto3" json:"llm_model,omitempty"`                // Active LLM model (e.g., "gpt-4o")
+	WorkspaceRoot   string                 `protobuf:"bytes,11,opt,name=workspace_root,json=workspaceRoot,proto3" json:"workspace_root,omitempty"` // Root of the agent's workspace on disk
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AgentContextRequest) Reset() {
+	*x = AgentContextRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentContextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentContextRequest) ProtoMessage() {}
+
+func (x *AgentContextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentContextRequest.ProtoReflect.Descriptor instead.
+func (*AgentContextRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AgentContextRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AgentContextRequest) GetConfigPath() string {
+	if x != nil {
+		return x.ConfigPath
+	}
+	return ""
+}
+
+func (x *AgentContextRequest) GetSettingsPath() string {
+	if x != nil {
+		return x.SettingsPath
+	}
+	return ""
+}
+
+func (x *AgentContextRequest) GetAgentDir() string {
+	if x != nil {
+		return x.AgentDir
+	}
+	return ""
+}
+
+func (x *AgentContextRequest) GetCurrentLocation() string {
+	if x != nil {
+		return x.CurrentLocation
+	}
+	return ""
+}
+
+func (x *AgentContextRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *AgentContextRequest) GetUserDisplayName() string {
+	if x != nil {
+		return x.UserDisplayName
+	}
+	return ""
+}
+
+func (x *AgentContextRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *AgentContextRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *AgentContextRequest) GetLlmModel() string {
+	if x != nil {
+		return x.LlmModel
+	}
+	return ""
+}
+
+func (x *AgentContextRequest) GetWorkspaceRoot() string {
+	if x != nil {
+		return x.WorkspaceRoot
+	}
+	return ""
+}
+
+// SettingsResponse contains plugin settings as JSON
+type SettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SettingsJson  string                 `protobuf:"bytes,1,opt,name=settings_json,json=settingsJson,proto3" json:"settings_json,omitempty"` // JSON-encoded settings
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`                                   // Error message on failure (empty on success)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SettingsResponse) Reset() {
+	*x = SettingsResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettingsResponse) ProtoMessage() {}
+
+func (x *SettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettingsResponse.ProtoReflect.Descriptor instead.
+func (*SettingsResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SettingsResponse) GetSettingsJson() string {
+	if x != nil {
+		return x.SettingsJson
+	}
+	return ""
+}
+
+func (x *SettingsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ProtoConfigVariable describes a single configuration variable (protobuf version)
+type ProtoConfigVariable struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Key              string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Type             string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // "string", "int", "filepath", "dirpath", "password", etc.
+	Required         bool                   `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
+	DefaultValueJson string                 `protobuf:"bytes,6,opt,name=default_value_json,json=defaultValueJson,proto3" json:"default_value_json,omitempty"` // JSON-encoded default value (optional)
+	Validation       string                 `protobuf:"bytes,7,opt,name=validation,proto3" json:"validation,omitempty"`                                       // Validation rules (optional)
+	Options          []string               `protobuf:"bytes,8,rep,name=options,proto3" json:"options,omitempty"`                                             // List of valid options (optional)
+	Placeholder      string                 `protobuf:"bytes,9,opt,name=placeholder,proto3" json:"placeholder,omitempty"`                                     // Placeholder text (optional)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ProtoConfigVariable) Reset() {
+	*x = ProtoConfigVariable{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoConfigVariable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoConfigVariable) ProtoMessage() {}
+
+func (x *ProtoConfigVariable) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoConfigVariable.ProtoReflect.Descriptor instead.
+func (*ProtoConfigVariable) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ProtoConfigVariable) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ProtoConfigVariable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProtoConfigVariable) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProtoConfigVariable) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProtoConfigVariable) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *ProtoConfigVariable) GetDefaultValueJson() string {
+	if x != nil {
+		return x.DefaultValueJson
+	}
+	return ""
+}
+
+func (x *ProtoConfigVariable) GetValidation() string {
+	if x != nil {
+		return x.Validation
+	}
+	return ""
+}
+
+func (x *ProtoConfigVariable) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ProtoConfigVariable) GetPlaceholder() string {
+	if x != nil {
+		return x.Placeholder
+	}
+	return ""
+}
+
+// ConfigVariablesResponse contains the list of required config variables
+type ConfigVariablesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConfigVars    []*ProtoConfigVariable `protobuf:"bytes,1,rep,name=config_vars,json=configVars,proto3" json:"config_vars,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigVariablesResponse) Reset() {
+	*x = ConfigVariablesResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigVariablesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigVariablesResponse) ProtoMessage() {}
+
+func (x *ConfigVariablesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigVariablesResponse.ProtoReflect.Descriptor instead.
+func (*ConfigVariablesResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ConfigVariablesResponse) GetConfigVars() []*ProtoConfigVariable {
+	if x != nil {
+		return x.ConfigVars
+	}
+	return nil
+}
+
+// ValidateConfigRequest contains configuration to validate
+type ValidateConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConfigJson    string                 `protobuf:"bytes,1,opt,name=config_json,json=configJson,proto3" json:"config_json,omitempty"` // JSON-encoded configuration map
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateConfigRequest) Reset() {
+	*x = ValidateConfigRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateConfigRequest) ProtoMessage() {}
+
+func (x *ValidateConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateConfigRequest.ProtoReflect.Descriptor instead.
+func (*ValidateConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ValidateConfigRequest) GetConfigJson() string {
+	if x != nil {
+		return x.ConfigJson
+	}
+	return ""
+}
+
+// InitializeConfigRequest contains configuration for initialization
+type InitializeConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConfigJson    string                 `protobuf:"bytes,1,opt,name=config_json,json=configJson,proto3" json:"config_json,omitempty"` // JSON-encoded configuration map
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitializeConfigRequest) Reset() {
+	*x = InitializeConfigRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitializeConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitializeConfigRequest) ProtoMessage() {}
+
+func (x *InitializeConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitializeConfigRequest.ProtoReflect.Descriptor instead.
+func (*InitializeConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *InitializeConfigRequest) GetConfigJson() string {
+	if x != nil {
+		return x.ConfigJson
+	}
+	return ""
+}
+
+// ConfigChangedRequest reports an edit to the plugin's configuration
+type ConfigChangedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangedKeys   []string               `protobuf:"bytes,1,rep,name=changed_keys,json=changedKeys,proto3" json:"changed_keys,omitempty"` // Keys that were added, modified or removed
+	ConfigJson    string                 `protobuf:"bytes,2,opt,name=config_json,json=configJson,proto3" json:"config_json,omitempty"`    // JSON-encoded full configuration after the edit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigChangedRequest) Reset() {
+	*x = ConfigChangedRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigChangedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigChangedRequest) ProtoMessage() {}
+
+func (x *ConfigChangedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigChangedRequest.ProtoReflect.Descriptor instead.
+func (*ConfigChangedRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ConfigChangedRequest) GetChangedKeys() []string {
+	if x != nil {
+		return x.ChangedKeys
+	}
+	return nil
+}
+
+func (x *ConfigChangedRequest) GetConfigJson() string {
+	if x != nil {
+		return x.ConfigJson
+	}
+	return ""
+}
+
+// ConfigResponse contains the result of config operations
+type ConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"` // Error message on failure (empty on success)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigResponse) Reset() {
+	*x = ConfigResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigResponse) ProtoMessage() {}
+
+func (x *ConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigResponse.ProtoReflect.Descriptor instead.
+func (*ConfigResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ConfigResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfigResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Maintainer represents a single plugin maintainer/contributor
+type Maintainer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                 // Full name
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`               // Contact email
+	Organization  string                 `protobuf:"bytes,3,opt,name=organization,proto3" json:"organization,omitempty"` // Organization affiliation
+	Website       string                 `protobuf:"bytes,4,opt,name=website,proto3" json:"website,omitempty"`           // Personal/project website
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`                 // "author", "maintainer", "contributor"
+	Primary       bool                   `protobuf:"varint,6,opt,name=primary,proto3" json:"primary,omitempty"`          // Is this the primary/original author?
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Maintainer) Reset() {
+	*x = Maintainer{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Maintainer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Maintainer) ProtoMessage() {}
+
+func (x *Maintainer) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Maintainer.ProtoReflect.Descriptor instead.
+func (*Maintainer) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Maintainer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Maintainer) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Maintainer) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *Maintainer) GetWebsite() string {
+	if x != nil {
+		return x.Website
+	}
+	return ""
+}
+
+func (x *Maintainer) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Maintainer) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+// Platform represents a supported operating system and its architectures
+type Platform struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Os            string                 `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`                       // Operating system (e.g., "darwin", "linux", "windows")
+	Architectures []string               `protobuf:"bytes,2,rep,name=architectures,proto3" json:"architectures,omitempty"` // Supported architectures (e.g., "amd64", "arm64")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Platform) Reset() {
+	*x = Platform{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Platform) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Platform) ProtoMessage() {}
+
+func (x *Platform) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Platform.ProtoReflect.Descriptor instead.
+func (*Platform) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Platform) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *Platform) GetArchitectures() []string {
+	if x != nil {
+		return x.Architectures
+	}
+	return nil
+}
+
+// Requirements represents plugin dependencies and version requirements
+type Requirements struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	MinOriVersion string                   `protobuf:"bytes,1,opt,name=min_ori_version,json=minOriVersion,proto3" json:"min_ori_version,omitempty"` // Minimum ori-agent version required
+	Dependencies  []string                 `protobuf:"bytes,2,rep,name=dependencies,proto3" json:"dependencies,omitempty"`                          // List of required plugin names
+	Plugins       []*ProtoPluginDependency `protobuf:"bytes,3,rep,name=plugins,proto3" json:"plugins,omitempty"`                                    // Required plugins with version constraints
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Requirements) Reset() {
+	*x = Requirements{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Requirements) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Requirements) ProtoMessage() {}
+
+func (x *Requirements) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Requirements.ProtoReflect.Descriptor instead.
+func (*Requirements) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Requirements) GetMinOriVersion() string {
+	if x != nil {
+		return x.MinOriVersion
+	}
+	return ""
+}
+
+func (x *Requirements) GetDependencies() []string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *Requirements) GetPlugins() []*ProtoPluginDependency {
+	if x != nil {
+		return x.Plugins
+	}
+	return nil
+}
+
+// ProtoPluginDependency is a plugin another plugin requires
+type ProtoPluginDependency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Constraint    string                 `protobuf:"bytes,2,opt,name=constraint,proto3" json:"constraint,omitempty"` // Semver constraint (e.g., ">= 1.2.0"); empty accepts any version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoPluginDependency) Reset() {
+	*x = ProtoPluginDependency{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoPluginDependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoPluginDependency) ProtoMessage() {}
+
+func (x *ProtoPluginDependency) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoPluginDependency.ProtoReflect.Descriptor instead.
+func (*ProtoPluginDependency) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ProtoPluginDependency) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProtoPluginDependency) GetConstraint() string {
+	if x != nil {
+		return x.Constraint
+	}
+	return ""
+}
+
+// PluginMetadata contains comprehensive plugin information
+type PluginMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`               // Plugin name
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`         // Plugin version (semver)
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"` // Short description of the plugin
+	License       string                 `protobuf:"bytes,4,opt,name=license,proto3" json:"license,omitempty"`         // e.g., "MIT", "Apache-2.0", "GPL-3.0"
+	Repository    string                 `protobuf:"bytes,5,opt,name=repository,proto3" json:"repository,omitempty"`   // Source code repository URL
+	Maintainers   []*Maintainer          `protobuf:"bytes,6,rep,name=maintainers,proto3" json:"maintainers,omitempty"`
+	Platforms     []*Platform            `protobuf:"bytes,7,rep,name=platforms,proto3" json:"platforms,omitempty"`       // Supported platforms
+	Requirements  *Requirements          `protobuf:"bytes,8,opt,name=requirements,proto3" json:"requirements,omitempty"` // Plugin requirements
+	Tags          []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`                 // Plugin tags (normalized, e.g., "dev-tools", "audio")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginMetadata) Reset() {
+	*x = PluginMetadata{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginMetadata) ProtoMessage() {}
+
+func (x *PluginMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginMetadata.ProtoReflect.Descriptor instead.
+func (*PluginMetadata) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PluginMetadata) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PluginMetadata) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PluginMetadata) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PluginMetadata) GetLicense() string {
+	if x != nil {
+		return x.License
+	}
+	return ""
+}
+
+func (x *PluginMetadata) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *PluginMetadata) GetMaintainers() []*Maintainer {
+	if x != nil {
+		return x.Maintainers
+	}
+	return nil
+}
+
+func (x *PluginMetadata) GetPlatforms() []*Platform {
+	if x != nil {
+		return x.Platforms
+	}
+	return nil
+}
+
+func (x *PluginMetadata) GetRequirements() *Requirements {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+func (x *PluginMetadata) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// MetadataResponse contains plugin metadata
+type MetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *PluginMetadata        `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"` // Error message on failure (empty on success)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetadataResponse) Reset() {
+	*x = MetadataResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataResponse) ProtoMessage() {}
+
+func (x *MetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataResponse.ProtoReflect.Descriptor instead.
+func (*MetadataResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MetadataResponse) GetMetadata() *PluginMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *MetadataResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// CompatibilityInfoResponse contains plugin compatibility information
+type CompatibilityInfoResponse struct {
+	state              protoimpl.MessageState   `protogen:"open.v1"`
+	MinAgentVersion    string                   `protobuf:"bytes,1,opt,name=min_agent_version,json=minAgentVersion,proto3" json:"min_agent_version,omitempty"`        // Minimum ori-agent version required
+	MaxAgentVersion    string                   `protobuf:"bytes,2,opt,name=max_agent_version,json=maxAgentVersion,proto3" json:"max_agent_version,omitempty"`        // Maximum ori-agent version supported
+	ApiVersion         string                   `protobuf:"bytes,3,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`                         // Plugin API version
+	PluginDependencies []*ProtoPluginDependency `protobuf:"bytes,4,rep,name=plugin_dependencies,json=pluginDependencies,proto3" json:"plugin_dependencies,omitempty"` // Plugins that must be installed
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CompatibilityInfoResponse) Reset() {
+	*x = CompatibilityInfoResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompatibilityInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompatibilityInfoResponse) ProtoMessage() {}
+
+func (x *CompatibilityInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompatibilityInfoResponse.ProtoReflect.Descriptor instead.
+func (*CompatibilityInfoResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CompatibilityInfoResponse) GetMinAgentVersion() string {
+	if x != nil {
+		return x.MinAgentVersion
+	}
+	return ""
+}
+
+func (x *CompatibilityInfoResponse) GetMaxAgentVersion() string {
+	if x != nil {
+		return x.MaxAgentVersion
+	}
+	return ""
+}
+
+func (x *CompatibilityInfoResponse) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *CompatibilityInfoResponse) GetPluginDependencies() []*ProtoPluginDependency {
+	if x != nil {
+		return x.PluginDependencies
+	}
+	return nil
+}
+
+// WebPagesResponse contains the list of available web pages
+type WebPagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pages         []string               `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"` // List of page paths (e.g., "marketplace", "settings")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebPagesResponse) Reset() {
+	*x = WebPagesResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebPagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebPagesResponse) ProtoMessage() {}
+
+func (x *WebPagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebPagesResponse.ProtoReflect.Descriptor instead.
+func (*WebPagesResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *WebPagesResponse) GetPages() []string {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+// WebPageRequest contains the web page request parameters
+type WebPageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                                                             // The requested path (e.g., "marketplace")
+	Query         map[string]string      `protobuf:"bytes,2,rep,name=query,proto3" json:"query,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // URL query parameters
+	IfNoneMatch   string                 `protobuf:"bytes,3,opt,name=if_none_match,json=ifNoneMatch,proto3" json:"if_none_match,omitempty"`                                          // ETag from a previous response; matching content is not resent
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebPageRequest) Reset() {
+	*x = WebPageRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebPageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebPageRequest) ProtoMessage() {}
+
+func (x *WebPageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebPageRequest.ProtoReflect.Descriptor instead.
+func (*WebPageRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *WebPageRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *WebPageRequest) GetQuery() map[string]string {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *WebPageRequest) GetIfNoneMatch() string {
+	if x != nil {
+		return x.IfNoneMatch
+	}
+	return ""
+}
+
+// WebPageResponse contains the web page content
+type WebPageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`                             // HTML or JSON content
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`  // MIME type (e.g., "text/html", "application/json")
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                 // Error message on failure (empty on success)
+	Body          []byte                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`                                   // Binary content (e.g., images, fonts), set instead of content when not valid UTF-8
+	Etag          string                 `protobuf:"bytes,5,opt,name=etag,proto3" json:"etag,omitempty"`                                   // Strong ETag for the content
+	NotModified   bool                   `protobuf:"varint,6,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"` // True if if_none_match matched; content and body are empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebPageResponse) Reset() {
+	*x = WebPageResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebPageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebPageResponse) ProtoMessage() {}
+
+func (x *WebPageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebPageResponse.ProtoReflect.Descriptor instead.
+func (*WebPageResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WebPageResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *WebPageResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *WebPageResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebPageResponse) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *WebPageResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *WebPageResponse) GetNotModified() bool {
+	if x != nil {
+		return x.NotModified
+	}
+	return false
+}
+
+// ProtoWebPageInfo describes a web page for the agent's plugin-pages menu
+type ProtoWebPageInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                      // Page path passed to ServeWebPage (e.g., "marketplace")
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                                    // Menu title (e.g., "Script Marketplace")
+	Icon          string                 `protobuf:"bytes,3,opt,name=icon,proto3" json:"icon,omitempty"`                                      // Icon name or emoji
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`                        // Short description shown as a tooltip
+	RequiresAuth  bool                   `protobuf:"varint,5,opt,name=requires_auth,json=requiresAuth,proto3" json:"requires_auth,omitempty"` // True if the page needs the plugin to be configured first
+	ShowInMenu    bool                   `protobuf:"varint,6,opt,name=show_in_menu,json=showInMenu,proto3" json:"show_in_menu,omitempty"`     // False for pages only reachable via links (e.g., detail pages)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoWebPageInfo) Reset() {
+	*x = ProtoWebPageInfo{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoWebPageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoWebPageInfo) ProtoMessage() {}
+
+func (x *ProtoWebPageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoWebPageInfo.ProtoReflect.Descriptor instead.
+func (*ProtoWebPageInfo) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ProtoWebPageInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ProtoWebPageInfo) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ProtoWebPageInfo) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *ProtoWebPageInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProtoWebPageInfo) GetRequiresAuth() bool {
+	if x != nil {
+		return x.RequiresAuth
+	}
+	return false
+}
+
+func (x *ProtoWebPageInfo) GetShowInMenu() bool {
+	if x != nil {
+		return x.ShowInMenu
+	}
+	return false
+}
+
+// WebPageInfoResponse contains metadata for each web page
+type WebPageInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pages         []*ProtoWebPageInfo    `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebPageInfoResponse) Reset() {
+	*x = WebPageInfoResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebPageInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebPageInfoResponse) ProtoMessage() {}
+
+func (x *WebPageInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebPageInfoResponse.ProtoReflect.Descriptor instead.
+func (*WebPageInfoResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *WebPageInfoResponse) GetPages() []*ProtoWebPageInfo {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+// ProtoFileAttachment represents a file attached to a plugin call (proto version)
+// Note: Named ProtoFileAttachment to avoid conflict with pluginapi.FileAttachment
+type ProtoFileAttachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`         // Original filename (e.g., "drums.wav")
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`         // MIME type (e.g., "audio/wav", "application/zip")
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`        // File size in bytes
+	Content       []byte                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`   // Raw file content
+	Encoding      string                 `protobuf:"bytes,5,opt,name=encoding,proto3" json:"encoding,omitempty"` // "gzip" if content is compressed (empty = raw)
+	Uri           string                 `protobuf:"bytes,6,opt,name=uri,proto3" json:"uri,omitempty"`           // Path or file:// URI on a shared filesystem when sent by reference (content empty)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoFileAttachment) Reset() {
+	*x = ProtoFileAttachment{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoFileAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoFileAttachment) ProtoMessage() {}
+
+func (x *ProtoFileAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoFileAttachment.ProtoReflect.Descriptor instead.
+func (*ProtoFileAttachment) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ProtoFileAttachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProtoFileAttachment) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProtoFileAttachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ProtoFileAttachment) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ProtoFileAttachment) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *ProtoFileAttachment) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// AcceptsFilesResponse contains the list of accepted file types
+type AcceptsFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AcceptedTypes []string               `protobuf:"bytes,1,rep,name=accepted_types,json=acceptedTypes,proto3" json:"accepted_types,omitempty"`  // MIME types or extensions (e.g., ".wav", "audio/wav")
+	SupportsFiles bool                   `protobuf:"varint,2,opt,name=supports_files,json=supportsFiles,proto3" json:"supports_files,omitempty"` // True if plugin implements FileAttachmentHandler
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptsFilesResponse) Reset() {
+	*x = AcceptsFilesResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptsFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptsFilesResponse) ProtoMessage() {}
+
+func (x *AcceptsFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptsFilesResponse.ProtoReflect.Descriptor instead.
+func (*AcceptsFilesResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AcceptsFilesResponse) GetAcceptedTypes() []string {
+	if x != nil {
+		return x.AcceptedTypes
+	}
+	return nil
+}
+
+func (x *AcceptsFilesResponse) GetSupportsFiles() bool {
+	if x != nil {
+		return x.SupportsFiles
+	}
+	return false
+}
+
+// CallWithFilesRequest contains arguments and file attachments for a tool call
+type CallWithFilesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ArgsJson       string                 `protobuf:"bytes,1,opt,name=args_json,json=argsJson,proto3" json:"args_json,omitempty"`                                                           // JSON-encoded tool arguments
+	Files          []*ProtoFileAttachment `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`                                                                                 // File attachments
+	Metadata       map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Host-controlled call metadata (e.g., source), not seen by the LLM
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`                                         // Identifies retries of the same logical call (empty = none)
+	CallId         string                 `protobuf:"bytes,5,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`                                                                 // Identifies this call for CancelCall (empty = not cancellable by ID)
+	ToolName       string                 `protobuf:"bytes,6,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`                                                           // Tool to call in a plugin serving several (empty = the plugin's own tool)
+	CallMeta       *ProtoCallMeta         `protobuf:"bytes,7,opt,name=call_meta,json=callMeta,proto3" json:"call_meta,omitempty"`                                                           // Invocation metadata, as for Call
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CallWithFilesRequest) Reset() {
+	*x = CallWithFilesRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallWithFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallWithFilesRequest) ProtoMessage() {}
+
+func (x *CallWithFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallWithFilesRequest.ProtoReflect.Descriptor instead.
+func (*CallWithFilesRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CallWithFilesRequest) GetArgsJson() string {
+	if x != nil {
+		return x.ArgsJson
+	}
+	return ""
+}
+
+func (x *CallWithFilesRequest) GetFiles() []*ProtoFileAttachment {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *CallWithFilesRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CallWithFilesRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CallWithFilesRequest) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+func (x *CallWithFilesRequest) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+func (x *CallWithFilesRequest) GetCallMeta() *ProtoCallMeta {
+	if x != nil {
+		return x.CallMeta
+	}
+	return nil
+}
+
+// FileUploadChunk is one message of a CallWithFilesStream upload: first the call,
+// then for each file its metadata followed by its content in order
+type FileUploadChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Part:
+	//
+	//	*FileUploadChunk_Call
+	//	*FileUploadChunk_File
+	//	*FileUploadChunk_Data
+	Part          isFileUploadChunk_Part `protobuf_oneof:"part"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileUploadChunk) Reset() {
+	*x = FileUploadChunk{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileUploadChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileUploadChunk) ProtoMessage() {}
+
+func (x *FileUploadChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileUploadChunk.ProtoReflect.Descriptor instead.
+func (*FileUploadChunk) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *FileUploadChunk) GetPart() isFileUploadChunk_Part {
+	if x != nil {
+		return x.Part
+	}
+	return nil
+}
+
+func (x *FileUploadChunk) GetCall() *CallWithFilesRequest {
+	if x != nil {
+		if x, ok := x.Part.(*FileUploadChunk_Call); ok {
+			return x.Call
+		}
+	}
+	return nil
+}
+
+func (x *FileUploadChunk) GetFile() *ProtoFileAttachment {
+	if x != nil {
+		if x, ok := x.Part.(*FileUploadChunk_File); ok {
+			return x.File
+		}
+	}
+	return nil
+}
+
+func (x *FileUploadChunk) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Part.(*FileUploadChunk_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isFileUploadChunk_Part interface {
+	isFileUploadChunk_Part()
+}
+
+type FileUploadChunk_Call struct {
+	Call *CallWithFilesRequest `protobuf:"bytes,1,opt,name=call,proto3,oneof"` // First message only; files are sent separately
+}
+
+type FileUploadChunk_File struct {
+	File *ProtoFileAttachment `protobuf:"bytes,2,opt,name=file,proto3,oneof"` // Starts the next file; content holds its first bytes, if any
+}
+
+type FileUploadChunk_Data struct {
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3,oneof"` // More content of the current file
+}
+
+func (*FileUploadChunk_Call) isFileUploadChunk_Part() {}
+
+func (*FileUploadChunk_File) isFileUploadChunk_Part() {}
+
+func (*FileUploadChunk_Data) isFileUploadChunk_Part() {}
+
+// ProtoOperationInfo describes a single operation and its parameters
+type ProtoOperationInfo struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                          // Operation name (e.g., "create_project")
+	Parameters          []string               `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty"`                                              // Parameter names for this operation
+	RequiredParameters  []string               `protobuf:"bytes,3,rep,name=required_parameters,json=requiredParameters,proto3" json:"required_parameters,omitempty"`    // Required parameter names
+	RequiredPermissions []string               `protobuf:"bytes,4,rep,name=required_permissions,json=requiredPermissions,proto3" json:"required_permissions,omitempty"` // Permissions this operation needs (e.g., "file_access")
+	SupportsDryRun      bool                   `protobuf:"varint,5,opt,name=supports_dry_run,json=supportsDryRun,proto3" json:"supports_dry_run,omitempty"`             // True if the operation can preview its effects
+	TimeoutMs           int64                  `protobuf:"varint,6,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`                              // Default call deadline in milliseconds (0 = none)
+	Examples            []*ProtoToolExample    `protobuf:"bytes,7,rep,name=examples,proto3" json:"examples,omitempty"`                                                  // Few-shot calls of this operation
+	Deprecation         *ProtoDeprecation      `protobuf:"bytes,8,opt,name=deprecation,proto3" json:"deprecation,omitempty"`                                            // Unset unless the operation is deprecated
+	Latency             string                 `protobuf:"bytes,9,opt,name=latency,proto3" json:"latency,omitempty"`                                                    // Expected latency class: "fast", "moderate", "slow" (empty = unknown)
+	Cost                float64                `protobuf:"fixed64,10,opt,name=cost,proto3" json:"cost,omitempty"`                                                       // Relative monetary cost per call (0 = free or unknown)
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ProtoOperationInfo) Reset() {
+	*x = ProtoOperationInfo{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoOperationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoOperationInfo) ProtoMessage() {}
+
+func (x *ProtoOperationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoOperationInfo.ProtoReflect.Descriptor instead.
+func (*ProtoOperationInfo) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ProtoOperationInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProtoOperationInfo) GetParameters() []string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *ProtoOperationInfo) GetRequiredParameters() []string {
+	if x != nil {
+		return x.RequiredParameters
+	}
+	return nil
+}
+
+func (x *ProtoOperationInfo) GetRequiredPermissions() []string {
+	if x != nil {
+		return x.RequiredPermissions
+	}
+	return nil
+}
+
+func (x *ProtoOperationInfo) GetSupportsDryRun() bool {
+	if x != nil {
+		return x.SupportsDryRun
+	}
+	return false
+}
+
+func (x *ProtoOperationInfo) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *ProtoOperationInfo) GetExamples() []*ProtoToolExample {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+func (x *ProtoOperationInfo) GetDeprecation() *ProtoDeprecation {
+	if x != nil {
+		return x.Deprecation
+	}
+	return nil
+}
+
+func (x *ProtoOperationInfo) GetLatency() string {
+	if x != nil {
+		return x.Latency
+	}
+	return ""
+}
+
+func (x *ProtoOperationInfo) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+// OperationsResponse contains the list of operations with their parameters
+type OperationsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Operations         []*ProtoOperationInfo  `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	SupportsOperations bool                   `protobuf:"varint,2,opt,name=supports_operations,json=supportsOperations,proto3" json:"supports_operations,omitempty"` // True if plugin implements OperationsProvider
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *OperationsResponse) Reset() {
+	*x = OperationsResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationsResponse) ProtoMessage() {}
+
+func (x *OperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationsResponse.ProtoReflect.Descriptor instead.
+func (*OperationsResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *OperationsResponse) GetOperations() []*ProtoOperationInfo {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *OperationsResponse) GetSupportsOperations() bool {
+	if x != nil {
+		return x.SupportsOperations
+	}
+	return false
+}
+
+// StateSnapshotResponse contains a serialized snapshot of plugin state
+type StateSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         []byte                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`                                       // Opaque plugin-defined state
+	SupportsState bool                   `protobuf:"varint,2,opt,name=supports_state,json=supportsState,proto3" json:"supports_state,omitempty"` // True if plugin implements StatefulPlugin (StatePorter for ExportState)
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                       // Error message on failure (empty on success)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateSnapshotResponse) Reset() {
+	*x = StateSnapshotResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateSnapshotResponse) ProtoMessage() {}
+
+func (x *StateSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*StateSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *StateSnapshotResponse) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *StateSnapshotResponse) GetSupportsState() bool {
+	if x != nil {
+		return x.SupportsState
+	}
+	return false
+}
+
+func (x *StateSnapshotResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// RestoreStateRequest contains a snapshot previously returned by SnapshotState
+type RestoreStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         []byte                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"` // Opaque plugin-defined state
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreStateRequest) Reset() {
+	*x = RestoreStateRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStateRequest) ProtoMessage() {}
+
+func (x *RestoreStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStateRequest.ProtoReflect.Descriptor instead.
+func (*RestoreStateRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RestoreStateRequest) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+// HandoffResponse contains state serialized by the outgoing plugin version
+type HandoffResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	State           []byte                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`                                             // Opaque plugin-defined handoff state
+	Version         string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                                         // Version of the plugin that produced the state
+	SupportsHandoff bool                   `protobuf:"varint,3,opt,name=supports_handoff,json=supportsHandoff,proto3" json:"supports_handoff,omitempty"` // True if plugin implements HandoffProvider
+	Error           string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                                             // Error message on failure (empty on success)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HandoffResponse) Reset() {
+	*x = HandoffResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HandoffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandoffResponse) ProtoMessage() {}
+
+func (x *HandoffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandoffResponse.ProtoReflect.Descriptor instead.
+func (*HandoffResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *HandoffResponse) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *HandoffResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HandoffResponse) GetSupportsHandoff() bool {
+	if x != nil {
+		return x.SupportsHandoff
+	}
+	return false
+}
+
+func (x *HandoffResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// HandoffRequest passes handoff state to the incoming plugin version
+type HandoffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         []byte                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`                                // State returned by PrepareHandoff
+	FromVersion   string                 `protobuf:"bytes,2,opt,name=from_version,json=fromVersion,proto3" json:"from_version,omitempty"` // Version of the plugin that produced the state
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HandoffRequest) Reset() {
+	*x = HandoffRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HandoffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandoffRequest) ProtoMessage() {}
+
+func (x *HandoffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandoffRequest.ProtoReflect.Descriptor instead.
+func (*HandoffRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *HandoffRequest) GetState() []byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *HandoffRequest) GetFromVersion() string {
+	if x != nil {
+		return x.FromVersion
+	}
+	return ""
+}
+
+// SystemPromptResponse contains the plugin's system prompt fragment
+type SystemPromptResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Fragment             string                 `protobuf:"bytes,1,opt,name=fragment,proto3" json:"fragment,omitempty"`                                                        // Usage tips and constraints for the LLM
+	SupportsSystemPrompt bool                   `protobuf:"varint,2,opt,name=supports_system_prompt,json=supportsSystemPrompt,proto3" json:"supports_system_prompt,omitempty"` // True if plugin implements SystemPromptProvider
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SystemPromptResponse) Reset() {
+	*x = SystemPromptResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemPromptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemPromptResponse) ProtoMessage() {}
+
+func (x *SystemPromptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemPromptResponse.ProtoReflect.Descriptor instead.
+func (*SystemPromptResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SystemPromptResponse) GetFragment() string {
+	if x != nil {
+		return x.Fragment
+	}
+	return ""
+}
+
+func (x *SystemPromptResponse) GetSupportsSystemPrompt() bool {
+	if x != nil {
+		return x.SupportsSystemPrompt
+	}
+	return false
+}
+
+// PromptContributionRequest identifies the conversation a fragment is for
+type PromptContributionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentName      string                 `protobuf:"bytes,2,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PromptContributionRequest) Reset() {
+	*x = PromptContributionRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptContributionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptContributionRequest) ProtoMessage() {}
+
+func (x *PromptContributionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptContributionRequest.ProtoReflect.Descriptor instead.
+func (*PromptContributionRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PromptContributionRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *PromptContributionRequest) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+// PromptContributionResponse contains a per-conversation system prompt fragment
+type PromptContributionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fragment      string                 `protobuf:"bytes,1,opt,name=fragment,proto3" json:"fragment,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"` // Why the plugin could not contribute (empty on success)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptContributionResponse) Reset() {
+	*x = PromptContributionResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptContributionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptContributionResponse) ProtoMessage() {}
+
+func (x *PromptContributionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptContributionResponse.ProtoReflect.Descriptor instead.
+func (*PromptContributionResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PromptContributionResponse) GetFragment() string {
+	if x != nil {
+		return x.Fragment
+	}
+	return ""
+}
+
+func (x *PromptContributionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// EnrichContextRequest describes the LLM turn about to run
+type EnrichContextRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	AgentName      string                 `protobuf:"bytes,2,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	UserMessage    string                 `protobuf:"bytes,3,opt,name=user_message,json=userMessage,proto3" json:"user_message,omitempty"` // The message that starts the turn
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EnrichContextRequest) Reset() {
+	*x = EnrichContextRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrichContextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrichContextRequest) ProtoMessage() {}
+
+func (x *EnrichContextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrichContextRequest.ProtoReflect.Descriptor instead.
+func (*EnrichContextRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *EnrichContextRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *EnrichContextRequest) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *EnrichContextRequest) GetUserMessage() string {
+	if x != nil {
+		return x.UserMessage
+	}
+	return ""
+}
+
+// ProtoContextItem is one piece of structured context
+type ProtoContextItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`                       // e.g., "Current DAW session"
+	DataJson      string                 `protobuf:"bytes,2,opt,name=data_json,json=dataJson,proto3" json:"data_json,omitempty"` // JSON-encoded context
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoContextItem) Reset() {
+	*x = ProtoContextItem{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoContextItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoContextItem) ProtoMessage() {}
+
+func (x *ProtoContextItem) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoContextItem.ProtoReflect.Descriptor instead.
+func (*ProtoContextItem) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ProtoContextItem) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ProtoContextItem) GetDataJson() string {
+	if x != nil {
+		return x.DataJson
+	}
+	return ""
+}
+
+// EnrichContextResponse contains the plugin's context for the turn
+type EnrichContextResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Items              []*ProtoContextItem    `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	SupportsEnrichment bool                   `protobuf:"varint,2,opt,name=supports_enrichment,json=supportsEnrichment,proto3" json:"supports_enrichment,omitempty"` // True if plugin implements ContextEnricher
+	Error              string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                                      // Why the plugin could not provide context (empty on success)
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EnrichContextResponse) Reset() {
+	*x = EnrichContextResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrichContextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrichContextResponse) ProtoMessage() {}
+
+func (x *EnrichContextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrichContextResponse.ProtoReflect.Descriptor instead.
+func (*EnrichContextResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *EnrichContextResponse) GetItems() []*ProtoContextItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *EnrichContextResponse) GetSupportsEnrichment() bool {
+	if x != nil {
+		return x.SupportsEnrichment
+	}
+	return false
+}
+
+func (x *EnrichContextResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// EmbedRequest contains the texts to embed
+type EmbedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Texts         []string               `protobuf:"bytes,1,rep,name=texts,proto3" json:"texts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbedRequest) Reset() {
+	*x = EmbedRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedRequest) ProtoMessage() {}
+
+func (x *EmbedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedRequest.ProtoReflect.Descriptor instead.
+func (*EmbedRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *EmbedRequest) GetTexts() []string {
+	if x != nil {
+		return x.Texts
+	}
+	return nil
+}
+
+// Embedding is a single embedding vector
+type Embedding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float32              `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Embedding) Reset() {
+	*x = Embedding{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Embedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Embedding) ProtoMessage() {}
+
+func (x *Embedding) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Embedding.ProtoReflect.Descriptor instead.
+func (*Embedding) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Embedding) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// EmbedResponse contains one embedding per input text, in request order
+type EmbedResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Embeddings         []*Embedding           `protobuf:"bytes,1,rep,name=embeddings,proto3" json:"embeddings,omitempty"`
+	SupportsEmbeddings bool                   `protobuf:"varint,2,opt,name=supports_embeddings,json=supportsEmbeddings,proto3" json:"supports_embeddings,omitempty"` // True if plugin implements EmbeddingProvider
+	Error              string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                                      // Error message on failure (empty on success)
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EmbedResponse) Reset() {
+	*x = EmbedResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedResponse) ProtoMessage() {}
+
+func (x *EmbedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedResponse.ProtoReflect.Descriptor instead.
+func (*EmbedResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *EmbedResponse) GetEmbeddings() []*Embedding {
+	if x != nil {
+		return x.Embeddings
+	}
+	return nil
+}
+
+func (x *EmbedResponse) GetSupportsEmbeddings() bool {
+	if x != nil {
+		return x.SupportsEmbeddings
+	}
+	return false
+}
+
+func (x *EmbedResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ProtoFileWatch describes a directory to watch
+type ProtoFileWatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`            // Absolute directory path
+	Glob          string                 `protobuf:"bytes,2,opt,name=glob,proto3" json:"glob,omitempty"`            // File name filter (e.g., "*.rpp"), empty matches everything
+	Recursive     bool                   `protobuf:"varint,3,opt,name=recursive,proto3" json:"recursive,omitempty"` // Include subdirectories
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoFileWatch) Reset() {
+	*x = ProtoFileWatch{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoFileWatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoFileWatch) ProtoMessage() {}
+
+func (x *ProtoFileWatch) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoFileWatch.ProtoReflect.Descriptor instead.
+func (*ProtoFileWatch) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ProtoFileWatch) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ProtoFileWatch) GetGlob() string {
+	if x != nil {
+		return x.Glob
+	}
+	return ""
+}
+
+func (x *ProtoFileWatch) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+// FileWatchesResponse contains the plugin's watch declarations
+type FileWatchesResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Watches           []*ProtoFileWatch      `protobuf:"bytes,1,rep,name=watches,proto3" json:"watches,omitempty"`
+	SupportsFileWatch bool                   `protobuf:"varint,2,opt,name=supports_file_watch,json=supportsFileWatch,proto3" json:"supports_file_watch,omitempty"` // True if plugin implements FileWatchProvider
+	Error             string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                                     // Validation error (empty if watches are valid)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FileWatchesResponse) Reset() {
+	*x = FileWatchesResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileWatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileWatchesResponse) ProtoMessage() {}
+
+func (x *FileWatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileWatchesResponse.ProtoReflect.Descriptor instead.
+func (*FileWatchesResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *FileWatchesResponse) GetWatches() []*ProtoFileWatch {
+	if x != nil {
+		return x.Watches
+	}
+	return nil
+}
+
+func (x *FileWatchesResponse) GetSupportsFileWatch() bool {
+	if x != nil {
+		return x.SupportsFileWatch
+	}
+	return false
+}
+
+func (x *FileWatchesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ProtoFileChangeEvent describes a single change to a watched file
+type ProtoFileChangeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                      // Absolute path of the changed file
+	Op            string                 `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`                          // "created", "modified", "deleted", "renamed"
+	OldPath       string                 `protobuf:"bytes,3,opt,name=old_path,json=oldPath,proto3" json:"old_path,omitempty"` // Previous path for renames
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoFileChangeEvent) Reset() {
+	*x = ProtoFileChangeEvent{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoFileChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoFileChangeEvent) ProtoMessage() {}
+
+func (x *ProtoFileChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoFileChangeEvent.ProtoReflect.Descriptor instead.
+func (*ProtoFileChangeEvent) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ProtoFileChangeEvent) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ProtoFileChangeEvent) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *ProtoFileChangeEvent) GetOldPath() string {
+	if x != nil {
+		return x.OldPath
+	}
+	return ""
+}
+
+// FileChangesRequest carries a batch of file change events
+type FileChangesRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Events        []*ProtoFileChangeEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileChangesRequest) Reset() {
+	*x = FileChangesRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChangesRequest) ProtoMessage() {}
+
+func (x *FileChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChangesRequest.ProtoReflect.Descriptor instead.
+func (*FileChangesRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *FileChangesRequest) GetEvents() []*ProtoFileChangeEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// ProtoScheduledTask is a periodic job declared by the plugin
+type ProtoScheduledTask struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Schedule      string                 `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"` // Cron spec (e.g., "*/15 * * * *" or "@daily")
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	TimeoutMs     int64                  `protobuf:"varint,4,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"` // Deadline of each run in milliseconds (0 = none)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoScheduledTask) Reset() {
+	*x = ProtoScheduledTask{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoScheduledTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoScheduledTask) ProtoMessage() {}
+
+func (x *ProtoScheduledTask) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoScheduledTask.ProtoReflect.Descriptor instead.
+func (*ProtoScheduledTask) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ProtoScheduledTask) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProtoScheduledTask) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *ProtoScheduledTask) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProtoScheduledTask) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+// ScheduledTasksResponse contains the plugin's scheduled tasks
+type ScheduledTasksResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Tasks                  []*ProtoScheduledTask  `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	SupportsScheduledTasks bool                   `protobuf:"varint,2,opt,name=supports_scheduled_tasks,json=supportsScheduledTasks,proto3" json:"supports_scheduled_tasks,omitempty"` // True if plugin implements ScheduledTaskProvider
+	Error                  string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                                                    // Validation error (empty if tasks are valid)
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ScheduledTasksResponse) Reset() {
+	*x = ScheduledTasksResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTasksResponse) ProtoMessage() {}
+
+func (x *ScheduledTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTasksResponse.ProtoReflect.Descriptor instead.
+func (*ScheduledTasksResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ScheduledTasksResponse) GetTasks() []*ProtoScheduledTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ScheduledTasksResponse) GetSupportsScheduledTasks() bool {
+	if x != nil {
+		return x.SupportsScheduledTasks
+	}
+	return false
+}
+
+func (x *ScheduledTasksResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ExecuteScheduledTaskRequest asks the plugin to run a due task
+type ExecuteScheduledTaskRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ScheduledAtUnixMs int64                  `protobuf:"varint,2,opt,name=scheduled_at_unix_ms,json=scheduledAtUnixMs,proto3" json:"scheduled_at_unix_ms,omitempty"` // When the run was due
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ExecuteScheduledTaskRequest) Reset() {
+	*x = ExecuteScheduledTaskRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteScheduledTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteScheduledTaskRequest) ProtoMessage() {}
+
+func (x *ExecuteScheduledTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteScheduledTaskRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteScheduledTaskRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ExecuteScheduledTaskRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExecuteScheduledTaskRequest) GetScheduledAtUnixMs() int64 {
+	if x != nil {
+		return x.ScheduledAtUnixMs
+	}
+	return 0
+}
+
+// HealthCheckResponse contains the result of a plugin health check
+type HealthCheckResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	SupportsHealthCheck bool                   `protobuf:"varint,1,opt,name=supports_health_check,json=supportsHealthCheck,proto3" json:"supports_health_check,omitempty"` // True if plugin implements HealthCheckProvider
+	Error               string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`                                                           // Why the plugin is unhealthy (empty if healthy)
+	RecoveredPanics     int64                  `protobuf:"varint,3,opt,name=recovered_panics,json=recoveredPanics,proto3" json:"recovered_panics,omitempty"`               // Handler panics recovered since the plugin started
+	LastPanic           string                 `protobuf:"bytes,4,opt,name=last_panic,json=lastPanic,proto3" json:"last_panic,omitempty"`                                  // Description of the most recent recovered panic
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *HealthCheckResponse) Reset() {
+	*x = HealthCheckResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckResponse) ProtoMessage() {}
+
+func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
+func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *HealthCheckResponse) GetSupportsHealthCheck() bool {
+	if x != nil {
+		return x.SupportsHealthCheck
+	}
+	return false
+}
+
+func (x *HealthCheckResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *HealthCheckResponse) GetRecoveredPanics() int64 {
+	if x != nil {
+		return x.RecoveredPanics
+	}
+	return 0
+}
+
+func (x *HealthCheckResponse) GetLastPanic() string {
+	if x != nil {
+		return x.LastPanic
+	}
+	return ""
+}
+
+// PermissionsResponse contains the system permissions a plugin requires
+type PermissionsResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	FileAccess          bool                   `protobuf:"varint,1,opt,name=file_access,json=fileAccess,proto3" json:"file_access,omitempty"`
+	NetworkAccess       bool                   `protobuf:"varint,2,opt,name=network_access,json=networkAccess,proto3" json:"network_access,omitempty"`
+	SystemCommands      bool                   `protobuf:"varint,3,opt,name=system_commands,json=systemCommands,proto3" json:"system_commands,omitempty"`
+	Description         string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`                                             // Why the permissions are needed
+	SupportsPermissions bool                   `protobuf:"varint,5,opt,name=supports_permissions,json=supportsPermissions,proto3" json:"supports_permissions,omitempty"` // True if plugin implements PermissionProvider
+	ReadPaths           []string               `protobuf:"bytes,6,rep,name=read_paths,json=readPaths,proto3" json:"read_paths,omitempty"`                                // Directories file reads are limited to
+	WritePaths          []string               `protobuf:"bytes,7,rep,name=write_paths,json=writePaths,proto3" json:"write_paths,omitempty"`                             // Directories file writes are limited to
+	AllowedHosts        []string               `protobuf:"bytes,8,rep,name=allowed_hosts,json=allowedHosts,proto3" json:"allowed_hosts,omitempty"`                       // Hosts network requests are limited to
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PermissionsResponse) Reset() {
+	*x = PermissionsResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionsResponse) ProtoMessage() {}
+
+func (x *PermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionsResponse.ProtoReflect.Descriptor instead.
+func (*PermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *PermissionsResponse) GetFileAccess() bool {
+	if x != nil {
+		return x.FileAccess
+	}
+	return false
+}
+
+func (x *PermissionsResponse) GetNetworkAccess() bool {
+	if x != nil {
+		return x.NetworkAccess
+	}
+	return false
+}
+
+func (x *PermissionsResponse) GetSystemCommands() bool {
+	if x != nil {
+		return x.SystemCommands
+	}
+	return false
+}
+
+func (x *PermissionsResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PermissionsResponse) GetSupportsPermissions() bool {
+	if x != nil {
+		return x.SupportsPermissions
+	}
+	return false
+}
+
+func (x *PermissionsResponse) GetReadPaths() []string {
+	if x != nil {
+		return x.ReadPaths
+	}
+	return nil
+}
+
+func (x *PermissionsResponse) GetWritePaths() []string {
+	if x != nil {
+		return x.WritePaths
+	}
+	return nil
+}
+
+func (x *PermissionsResponse) GetAllowedHosts() []string {
+	if x != nil {
+		return x.AllowedHosts
+	}
+	return nil
+}
+
+// ProtoRateLimit caps how often an operation (or the whole tool) may be called
+type ProtoRateLimit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"` // Empty for all calls to the tool
+	Requests      int32                  `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	PerMs         int64                  `protobuf:"varint,3,opt,name=per_ms,json=perMs,proto3" json:"per_ms,omitempty"`
+	Burst         int32                  `protobuf:"varint,4,opt,name=burst,proto3" json:"burst,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoRateLimit) Reset() {
+	*x = ProtoRateLimit{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoRateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoRateLimit) ProtoMessage() {}
+
+func (x *ProtoRateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoRateLimit.ProtoReflect.Descriptor instead.
+func (*ProtoRateLimit) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ProtoRateLimit) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *ProtoRateLimit) GetRequests() int32 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *ProtoRateLimit) GetPerMs() int64 {
+	if x != nil {
+		return x.PerMs
+	}
+	return 0
+}
+
+func (x *ProtoRateLimit) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+// RateLimitsResponse contains the plugin's rate limits
+type RateLimitsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Limits             []*ProtoRateLimit      `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
+	SupportsRateLimits bool                   `protobuf:"varint,2,opt,name=supports_rate_limits,json=supportsRateLimits,proto3" json:"supports_rate_limits,omitempty"` // True if plugin implements RateLimitProvider
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RateLimitsResponse) Reset() {
+	*x = RateLimitsResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitsResponse) ProtoMessage() {}
+
+func (x *RateLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitsResponse.ProtoReflect.Descriptor instead.
+func (*RateLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *RateLimitsResponse) GetLimits() []*ProtoRateLimit {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *RateLimitsResponse) GetSupportsRateLimits() bool {
+	if x != nil {
+		return x.SupportsRateLimits
+	}
+	return false
+}
+
+// CategoryResponse contains the plugin's category
+type CategoryResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Category         string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`                                          // Category name, or comma-separated names
+	SupportsCategory bool                   `protobuf:"varint,2,opt,name=supports_category,json=supportsCategory,proto3" json:"supports_category,omitempty"` // True if plugin implements CategoryProvider
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CategoryResponse) Reset() {
+	*x = CategoryResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryResponse) ProtoMessage() {}
+
+func (x *CategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryResponse.ProtoReflect.Descriptor instead.
+func (*CategoryResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CategoryResponse) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategoryResponse) GetSupportsCategory() bool {
+	if x != nil {
+		return x.SupportsCategory
+	}
+	return false
+}
+
+// InitializeResponse reports whether the plugin is ready to serve calls
+type InitializeResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SupportsInitialize bool                   `protobuf:"varint,1,opt,name=supports_initialize,json=supportsInitialize,proto3" json:"supports_initialize,omitempty"` // True if plugin implements Initializer
+	Error              string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`                                                      // Why the plugin is not ready (empty if ready)
+	StructuredError    *ProtoPluginError      `protobuf:"bytes,3,opt,name=structured_error,json=structuredError,proto3" json:"structured_error,omitempty"`           // Classified readiness failure
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *InitializeResponse) Reset() {
+	*x = InitializeResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitializeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitializeResponse) ProtoMessage() {}
+
+func (x *InitializeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitializeResponse.ProtoReflect.Descriptor instead.
+func (*InitializeResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *InitializeResponse) GetSupportsInitialize() bool {
+	if x != nil {
+		return x.SupportsInitialize
+	}
+	return false
+}
+
+func (x *InitializeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *InitializeResponse) GetStructuredError() *ProtoPluginError {
+	if x != nil {
+		return x.StructuredError
+	}
+	return nil
+}
+
+// NegotiateRequest lists what the host speaks
+type NegotiateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiVersions   []string               `protobuf:"bytes,1,rep,name=api_versions,json=apiVersions,proto3" json:"api_versions,omitempty"` // Wire protocol versions the host speaks (e.g., "v1", "v2")
+	Features      []string               `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`                          // Wire protocol features the host supports
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NegotiateRequest) Reset() {
+	*x = NegotiateRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NegotiateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NegotiateRequest) ProtoMessage() {}
+
+func (x *NegotiateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NegotiateRequest.ProtoReflect.Descriptor instead.
+func (*NegotiateRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *NegotiateRequest) GetApiVersions() []string {
+	if x != nil {
+		return x.ApiVersions
+	}
+	return nil
+}
+
+func (x *NegotiateRequest) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// NegotiateResponse is what the plugin agreed to
+type NegotiateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiVersion    string                 `protobuf:"bytes,1,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`    // Highest version both sides speak
+	ApiVersions   []string               `protobuf:"bytes,2,rep,name=api_versions,json=apiVersions,proto3" json:"api_versions,omitempty"` // Every version the plugin speaks
+	Features      []string               `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty"`                          // Features both sides support
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NegotiateResponse) Reset() {
+	*x = NegotiateResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NegotiateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NegotiateResponse) ProtoMessage() {}
+
+func (x *NegotiateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NegotiateResponse.ProtoReflect.Descriptor instead.
+func (*NegotiateResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *NegotiateResponse) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *NegotiateResponse) GetApiVersions() []string {
+	if x != nil {
+		return x.ApiVersions
+	}
+	return nil
+}
+
+func (x *NegotiateResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// CapabilitiesResponse describes every optional feature the plugin implements,
+// with the same content as the individual discovery RPCs
+type CapabilitiesResponse struct {
+	state          protoimpl.MessageState     `protogen:"open.v1"`
+	Interfaces     []string                   `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"` // Optional interfaces implemented (e.g., "WebPageProvider")
+	Version        *VersionResponse           `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Compatibility  *CompatibilityInfoResponse `protobuf:"bytes,3,opt,name=compatibility,proto3" json:"compatibility,omitempty"`
+	Metadata       *MetadataResponse          `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	WebPages       *WebPageInfoResponse       `protobuf:"bytes,5,opt,name=web_pages,json=webPages,proto3" json:"web_pages,omitempty"`
+	Files          *AcceptsFilesResponse      `protobuf:"bytes,6,opt,name=files,proto3" json:"files,omitempty"`
+	Operations     *OperationsResponse        `protobuf:"bytes,7,opt,name=operations,proto3" json:"operations,omitempty"`
+	SystemPrompt   *SystemPromptResponse      `protobuf:"bytes,8,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	FileWatches    *FileWatchesResponse       `protobuf:"bytes,9,opt,name=file_watches,json=fileWatches,proto3" json:"file_watches,omitempty"`
+	Permissions    *PermissionsResponse       `protobuf:"bytes,10,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	Category       *CategoryResponse          `protobuf:"bytes,11,opt,name=category,proto3" json:"category,omitempty"`
+	ScheduledTasks *ScheduledTasksResponse    `protobuf:"bytes,12,opt,name=scheduled_tasks,json=scheduledTasks,proto3" json:"scheduled_tasks,omitempty"`
+	RateLimits     *RateLimitsResponse        `protobuf:"bytes,13,opt,name=rate_limits,json=rateLimits,proto3" json:"rate_limits,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CapabilitiesResponse) Reset() {
+	*x = CapabilitiesResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapabilitiesResponse) ProtoMessage() {}
+
+func (x *CapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CapabilitiesResponse) GetInterfaces() []string {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetVersion() *VersionResponse {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetCompatibility() *CompatibilityInfoResponse {
+	if x != nil {
+		return x.Compatibility
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetMetadata() *MetadataResponse {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetWebPages() *WebPageInfoResponse {
+	if x != nil {
+		return x.WebPages
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetFiles() *AcceptsFilesResponse {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetOperations() *OperationsResponse {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetSystemPrompt() *SystemPromptResponse {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetFileWatches() *FileWatchesResponse {
+	if x != nil {
+		return x.FileWatches
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetPermissions() *PermissionsResponse {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetCategory() *CategoryResponse {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetScheduledTasks() *ScheduledTasksResponse {
+	if x != nil {
+		return x.ScheduledTasks
+	}
+	return nil
+}
+
+func (x *CapabilitiesResponse) GetRateLimits() *RateLimitsResponse {
+	if x != nil {
+		return x.RateLimits
+	}
+	return nil
+}
+
+// ProtoMethodStats aggregates the calls of one RPC
+type ProtoMethodStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Method          string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"` // RPC name (e.g., "Call")
+	Calls           int64                  `protobuf:"varint,2,opt,name=calls,proto3" json:"calls,omitempty"`
+	Errors          int64                  `protobuf:"varint,3,opt,name=errors,proto3" json:"errors,omitempty"` // Calls that failed, including PluginErrors
+	TotalDurationUs int64                  `protobuf:"varint,4,opt,name=total_duration_us,json=totalDurationUs,proto3" json:"total_duration_us,omitempty"`
+	MaxDurationUs   int64                  `protobuf:"varint,5,opt,name=max_duration_us,json=maxDurationUs,proto3" json:"max_duration_us,omitempty"`
+	RequestBytes    int64                  `protobuf:"varint,6,opt,name=request_bytes,json=requestBytes,proto3" json:"request_bytes,omitempty"`
+	ResponseBytes   int64                  `protobuf:"varint,7,opt,name=response_bytes,json=responseBytes,proto3" json:"response_bytes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProtoMethodStats) Reset() {
+	*x = ProtoMethodStats{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoMethodStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoMethodStats) ProtoMessage() {}
+
+func (x *ProtoMethodStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoMethodStats.ProtoReflect.Descriptor instead.
+func (*ProtoMethodStats) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ProtoMethodStats) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ProtoMethodStats) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *ProtoMethodStats) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *ProtoMethodStats) GetTotalDurationUs() int64 {
+	if x != nil {
+		return x.TotalDurationUs
+	}
+	return 0
+}
+
+func (x *ProtoMethodStats) GetMaxDurationUs() int64 {
+	if x != nil {
+		return x.MaxDurationUs
+	}
+	return 0
+}
+
+func (x *ProtoMethodStats) GetRequestBytes() int64 {
+	if x != nil {
+		return x.RequestBytes
+	}
+	return 0
+}
+
+func (x *ProtoMethodStats) GetResponseBytes() int64 {
+	if x != nil {
+		return x.ResponseBytes
+	}
+	return 0
+}
+
+// StatsResponse contains the plugin's per-RPC metrics
+type StatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Methods       []*ProtoMethodStats    `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"` // Sorted by method
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *StatsResponse) GetMethods() []*ProtoMethodStats {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+// LogSubscribeRequest selects the plugin log entries a host receives
+type LogSubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinLevel      string                 `protobuf:"bytes,1,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"` // debug, info, warn, error (empty = debug)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogSubscribeRequest) Reset() {
+	*x = LogSubscribeRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogSubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogSubscribeRequest) ProtoMessage() {}
+
+func (x *LogSubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogSubscribeRequest.ProtoReflect.Descriptor instead.
+func (*LogSubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *LogSubscribeRequest) GetMinLevel() string {
+	if x != nil {
+		return x.MinLevel
+	}
+	return ""
+}
+
+// ProtoLogEntry is one message logged by the plugin
+type ProtoLogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TimeUnixNano  int64                  `protobuf:"varint,1,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"` // debug, info, warn, error
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Fields        map[string]string      `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Structured attributes, grouped keys joined with "."
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoLogEntry) Reset() {
+	*x = ProtoLogEntry{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoLogEntry) ProtoMessage() {}
+
+func (x *ProtoLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoLogEntry.ProtoReflect.Descriptor instead.
+func (*ProtoLogEntry) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ProtoLogEntry) GetTimeUnixNano() int64 {
+	if x != nil {
+		return x.TimeUnixNano
+	}
+	return 0
+}
+
+func (x *ProtoLogEntry) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *ProtoLogEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ProtoLogEntry) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// HostServicesRequest tells the plugin how to reach the agent's HostService
+type HostServicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"` // host:port of the agent's HostService
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`     // Identifies the plugin to the agent on every callback
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostServicesRequest) Reset() {
+	*x = HostServicesRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostServicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostServicesRequest) ProtoMessage() {}
+
+func (x *HostServicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostServicesRequest.ProtoReflect.Descriptor instead.
+func (*HostServicesRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *HostServicesRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *HostServicesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// HostLogRequest is a log message from a plugin
+type HostLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"` // debug, info, warn, error
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Fields        map[string]string      `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Structured context (e.g., "path": "/tmp/x")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostLogRequest) Reset() {
+	*x = HostLogRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostLogRequest) ProtoMessage() {}
+
+func (x *HostLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostLogRequest.ProtoReflect.Descriptor instead.
+func (*HostLogRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *HostLogRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *HostLogRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *HostLogRequest) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// ProtoCompletionMessage is one message of a completion conversation
+type ProtoCompletionMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // user or assistant
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoCompletionMessage) Reset() {
+	*x = ProtoCompletionMessage{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoCompletionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoCompletionMessage) ProtoMessage() {}
+
+func (x *ProtoCompletionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoCompletionMessage.ProtoReflect.Descriptor instead.
+func (*ProtoCompletionMessage) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ProtoCompletionMessage) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ProtoCompletionMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// HostCompleteRequest asks the agent's active model for a completion
+type HostCompleteRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	SystemPrompt  string                    `protobuf:"bytes,1,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	Messages      []*ProtoCompletionMessage `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	MaxTokens     int32                     `protobuf:"varint,3,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"` // Output token cap (0 = host default)
+	Temperature   float64                   `protobuf:"fixed64,4,opt,name=temperature,proto3" json:"temperature,omitempty"`             // 0 = host default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostCompleteRequest) Reset() {
+	*x = HostCompleteRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostCompleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostCompleteRequest) ProtoMessage() {}
+
+func (x *HostCompleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostCompleteRequest.ProtoReflect.Descriptor instead.
+func (*HostCompleteRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *HostCompleteRequest) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *HostCompleteRequest) GetMessages() []*ProtoCompletionMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *HostCompleteRequest) GetMaxTokens() int32 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
+func (x *HostCompleteRequest) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+// HostCompleteResponse contains a completion from the agent's model
+type HostCompleteResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Text            string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	InputTokens     int32                  `protobuf:"varint,2,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens    int32                  `protobuf:"varint,3,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	Truncated       bool                   `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`                                    // True if the output stopped at max_tokens
+	RemainingTokens int64                  `protobuf:"varint,5,opt,name=remaining_tokens,json=remainingTokens,proto3" json:"remaining_tokens,omitempty"` // Tokens left in the plugin's budget (0 = not tracked)
+	Error           string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HostCompleteResponse) Reset() {
+	*x = HostCompleteResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostCompleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostCompleteResponse) ProtoMessage() {}
+
+func (x *HostCompleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostCompleteResponse.ProtoReflect.Descriptor instead.
+func (*HostCompleteResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *HostCompleteResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *HostCompleteResponse) GetInputTokens() int32 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *HostCompleteResponse) GetOutputTokens() int32 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *HostCompleteResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *HostCompleteResponse) GetRemainingTokens() int64 {
+	if x != nil {
+		return x.RemainingTokens
+	}
+	return 0
+}
+
+func (x *HostCompleteResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// HostCallToolRequest asks the agent to invoke another tool
+type HostCallToolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Registered tool name
+	ArgsJson      string                 `protobuf:"bytes,2,opt,name=args_json,json=argsJson,proto3" json:"args_json,omitempty"`
+	CallChain     []string               `protobuf:"bytes,3,rep,name=call_chain,json=callChain,proto3" json:"call_chain,omitempty"`                                                        // Tools already on the call stack, outermost first
+	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Call metadata to forward to the tool
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostCallToolRequest) Reset() {
+	*x = HostCallToolRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostCallToolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostCallToolRequest) ProtoMessage() {}
+
+func (x *HostCallToolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostCallToolRequest.ProtoReflect.Descriptor instead.
+func (*HostCallToolRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *HostCallToolRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HostCallToolRequest) GetArgsJson() string {
+	if x != nil {
+		return x.ArgsJson
+	}
+	return ""
+}
+
+func (x *HostCallToolRequest) GetCallChain() []string {
+	if x != nil {
+		return x.CallChain
+	}
+	return nil
+}
+
+func (x *HostCallToolRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// HostConversationRequest asks for recent conversation messages
+type HostConversationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                                                                                // Most recent messages to return (0 = host default)
+	Metadata      map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Call metadata identifying the conversation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostConversationRequest) Reset() {
+	*x = HostConversationRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostConversationRequest) ProtoMessage() {}
+
+func (x *HostConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostConversationRequest.ProtoReflect.Descriptor instead.
+func (*HostConversationRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *HostConversationRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *HostConversationRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// ProtoConversationMessage is one message of a conversation
+type ProtoConversationMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // user, assistant, or tool
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	TimestampMs   int64                  `protobuf:"varint,3,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"` // Unix milliseconds
+	Redacted      bool                   `protobuf:"varint,4,opt,name=redacted,proto3" json:"redacted,omitempty"`                          // True if the host removed content it will not share
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoConversationMessage) Reset() {
+	*x = ProtoConversationMessage{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoConversationMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoConversationMessage) ProtoMessage() {}
+
+func (x *ProtoConversationMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoConversationMessage.ProtoReflect.Descriptor instead.
+func (*ProtoConversationMessage) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ProtoConversationMessage) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ProtoConversationMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ProtoConversationMessage) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *ProtoConversationMessage) GetRedacted() bool {
+	if x != nil {
+		return x.Redacted
+	}
+	return false
+}
+
+// HostConversationResponse contains recent conversation messages, oldest first
+type HostConversationResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Messages      []*ProtoConversationMessage `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Error         string                      `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostConversationResponse) Reset() {
+	*x = HostConversationResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostConversationResponse) ProtoMessage() {}
+
+func (x *HostConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostConversationResponse.ProtoReflect.Descriptor instead.
+func (*HostConversationResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *HostConversationResponse) GetMessages() []*ProtoConversationMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *HostConversationResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// HostRememberRequest stores a fact in the agent's memory
+type HostRememberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // Replaces an earlier memory with the same key
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostRememberRequest) Reset() {
+	*x = HostRememberRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostRememberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostRememberRequest) ProtoMessage() {}
+
+func (x *HostRememberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostRememberRequest.ProtoReflect.Descriptor instead.
+func (*HostRememberRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *HostRememberRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HostRememberRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *HostRememberRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// HostRecallRequest searches the agent's memory
+type HostRecallRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	K             int32                  `protobuf:"varint,2,opt,name=k,proto3" json:"k,omitempty"` // Maximum results (0 = host default)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostRecallRequest) Reset() {
+	*x = HostRecallRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostRecallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostRecallRequest) ProtoMessage() {}
+
+func (x *HostRecallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostRecallRequest.ProtoReflect.Descriptor instead.
+func (*HostRecallRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *HostRecallRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *HostRecallRequest) GetK() int32 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+// ProtoMemoryRecord is one remembered fact
+type ProtoMemoryRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"` // Relevance to the query, higher is better
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoMemoryRecord) Reset() {
+	*x = ProtoMemoryRecord{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoMemoryRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoMemoryRecord) ProtoMessage() {}
+
+func (x *ProtoMemoryRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoMemoryRecord.ProtoReflect.Descriptor instead.
+func (*ProtoMemoryRecord) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ProtoMemoryRecord) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ProtoMemoryRecord) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ProtoMemoryRecord) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ProtoMemoryRecord) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// HostRecallResponse contains the memories most relevant to a query, best first
+type HostRecallResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*ProtoMemoryRecord   `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostRecallResponse) Reset() {
+	*x = HostRecallResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostRecallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostRecallResponse) ProtoMessage() {}
+
+func (x *HostRecallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostRecallResponse.ProtoReflect.Descriptor instead.
+func (*HostRecallResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *HostRecallResponse) GetRecords() []*ProtoMemoryRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *HostRecallResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ProtoNotification is a notification from a plugin to the user
+type ProtoNotification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"` // info, success, warning, error
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoNotification) Reset() {
+	*x = ProtoNotification{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoNotification) ProtoMessage() {}
+
+func (x *ProtoNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoNotification.ProtoReflect.Descriptor instead.
+func (*ProtoNotification) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ProtoNotification) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *ProtoNotification) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ProtoNotification) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// HostSubscribeEventsRequest selects the agent events a plugin receives
+type HostSubscribeEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []string               `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"` // Event types to receive (empty = all)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostSubscribeEventsRequest) Reset() {
+	*x = HostSubscribeEventsRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostSubscribeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostSubscribeEventsRequest) ProtoMessage() {}
+
+func (x *HostSubscribeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostSubscribeEventsRequest.ProtoReflect.Descriptor instead.
+func (*HostSubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *HostSubscribeEventsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+// ProtoPluginMessage is a message between plugins
+type ProtoPluginMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"` // SendToPlugin: receiving plugin (empty = every subscriber)
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`     // SubscribeMessages: sending plugin, filled in by the host
+	Topic         string                 `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoPluginMessage) Reset() {
+	*x = ProtoPluginMessage{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoPluginMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoPluginMessage) ProtoMessage() {}
+
+func (x *ProtoPluginMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoPluginMessage.ProtoReflect.Descriptor instead.
+func (*ProtoPluginMessage) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *ProtoPluginMessage) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ProtoPluginMessage) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ProtoPluginMessage) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *ProtoPluginMessage) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// HostSubscribeMessagesRequest selects the plugin messages a plugin receives
+type HostSubscribeMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topics        []string               `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"` // Topics to receive (empty = all)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostSubscribeMessagesRequest) Reset() {
+	*x = HostSubscribeMessagesRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostSubscribeMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostSubscribeMessagesRequest) ProtoMessage() {}
+
+func (x *HostSubscribeMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostSubscribeMessagesRequest.ProtoReflect.Descriptor instead.
+func (*HostSubscribeMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *HostSubscribeMessagesRequest) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+// DefinitionChangedRequest announces a new version of the plugin's tool definitions
+type DefinitionChangedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DefinitionChangedRequest) Reset() {
+	*x = DefinitionChangedRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DefinitionChangedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefinitionChangedRequest) ProtoMessage() {}
+
+func (x *DefinitionChangedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefinitionChangedRequest.ProtoReflect.Descriptor instead.
+func (*DefinitionChangedRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *DefinitionChangedRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// HostSecretRequest names a secret of the calling plugin
+type HostSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // SetSecret only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostSecretRequest) Reset() {
+	*x = HostSecretRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostSecretRequest) ProtoMessage() {}
+
+func (x *HostSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostSecretRequest.ProtoReflect.Descriptor instead.
+func (*HostSecretRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *HostSecretRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HostSecretRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// HostSecretResponse contains a secret read from the keychain
+type HostSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostSecretResponse) Reset() {
+	*x = HostSecretResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostSecretResponse) ProtoMessage() {}
+
+func (x *HostSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostSecretResponse.ProtoReflect.Descriptor instead.
+func (*HostSecretResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *HostSecretResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *HostSecretResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// HostAuthorizationRequest describes an OAuth authorization for the user to complete
+type HostAuthorizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`                          // e.g., "github"
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`                                    // Authorization or verification URL to open
+	UserCode      string                 `protobuf:"bytes,3,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"`          // Device flow: code the user enters at url
+	RedirectUrl   string                 `protobuf:"bytes,4,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"` // Redirect flow: URL the provider redirects the browser to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostAuthorizationRequest) Reset() {
+	*x = HostAuthorizationRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostAuthorizationRequest) ProtoMessage() {}
+
+func (x *HostAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*HostAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *HostAuthorizationRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *HostAuthorizationRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *HostAuthorizationRequest) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+func (x *HostAuthorizationRequest) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+// HostAuthorizationResponse contains the result of an authorization
+type HostAuthorizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CallbackUrl   string                 `protobuf:"bytes,1,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"` // Redirect flow: the URL the browser was redirected to
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostAuthorizationResponse) Reset() {
+	*x = HostAuthorizationResponse{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostAuthorizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostAuthorizationResponse) ProtoMessage() {}
+
+func (x *HostAuthorizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostAuthorizationResponse.ProtoReflect.Descriptor instead.
+func (*HostAuthorizationResponse) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *HostAuthorizationResponse) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *HostAuthorizationResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// HostUsageRequest reports upstream API consumption (deltas, not totals)
+type HostUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"` // Upstream API name; empty for the plugin's main API
+	Requests      int64                  `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	InputTokens   int64                  `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens  int64                  `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	BytesSent     int64                  `protobuf:"varint,5,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived int64                  `protobuf:"varint,6,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostUsageRequest) Reset() {
+	*x = HostUsageRequest{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostUsageRequest) ProtoMessage() {}
+
+func (x *HostUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostUsageRequest.ProtoReflect.Descriptor instead.
+func (*HostUsageRequest) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *HostUsageRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *HostUsageRequest) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *HostUsageRequest) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *HostUsageRequest) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *HostUsageRequest) GetBytesSent() int64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *HostUsageRequest) GetBytesReceived() int64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+// ProtoAgentEvent is an event in the agent
+type ProtoAgentEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                  // e.g., location_changed, settings_changed
+	PayloadJson   string                 `protobuf:"bytes,2,opt,name=payload_json,json=payloadJson,proto3" json:"payload_json,omitempty"` // JSON-encoded event struct
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoAgentEvent) Reset() {
+	*x = ProtoAgentEvent{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoAgentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoAgentEvent) ProtoMessage() {}
+
+func (x *ProtoAgentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoAgentEvent.ProtoReflect.Descriptor instead.
+func (*ProtoAgentEvent) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *ProtoAgentEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProtoAgentEvent) GetPayloadJson() string {
+	if x != nil {
+		return x.PayloadJson
+	}
+	return ""
+}
+
+// StdioFrame is one message of the stdio transport, used where plugins cannot
+// open listeners. RPCs are multiplexed by stream_id; see stdio.go for the protocol.
+type StdioFrame struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StreamId      uint64                 `protobuf:"varint,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`                         // Opens the stream (e.g., "/pluginapi.ToolService/Call")
+	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`                       // A serialized request or response message
+	CloseSend     bool                   `protobuf:"varint,4,opt,name=close_send,json=closeSend,proto3" json:"close_send,omitempty"` // Client: no more requests on this stream
+	Cancel        bool                   `protobuf:"varint,5,opt,name=cancel,proto3" json:"cancel,omitempty"`                        // Client: abandon the stream
+	Done          bool                   `protobuf:"varint,6,opt,name=done,proto3" json:"done,omitempty"`                            // Server: the RPC finished with code and error
+	Code          int32                  `protobuf:"varint,7,opt,name=code,proto3" json:"code,omitempty"`                            // gRPC status code
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`                           // gRPC status message
+	TimeoutMs     int64                  `protobuf:"varint,9,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"` // Set with method when the client has a deadline
+	Metadata      []*StdioMetadata       `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty"`                    // Set with method: the client's outgoing gRPC metadata
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StdioFrame) Reset() {
+	*x = StdioFrame{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StdioFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StdioFrame) ProtoMessage() {}
+
+func (x *StdioFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StdioFrame.ProtoReflect.Descriptor instead.
+func (*StdioFrame) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *StdioFrame) GetStreamId() uint64 {
+	if x != nil {
+		return x.StreamId
+	}
+	return 0
+}
+
+func (x *StdioFrame) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *StdioFrame) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *StdioFrame) GetCloseSend() bool {
+	if x != nil {
+		return x.CloseSend
+	}
+	return false
+}
+
+func (x *StdioFrame) GetCancel() bool {
+	if x != nil {
+		return x.Cancel
+	}
+	return false
+}
+
+func (x *StdioFrame) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *StdioFrame) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *StdioFrame) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StdioFrame) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *StdioFrame) GetMetadata() []*StdioMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// StdioMetadata is one gRPC metadata key and its values
+type StdioMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Values        []string               `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StdioMetadata) Reset() {
+	*x = StdioMetadata{}
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StdioMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StdioMetadata) ProtoMessage() {}
+
+func (x *StdioMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_pluginapi_rpc_v2_tool_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StdioMetadata.ProtoReflect.Descriptor instead.
+func (*StdioMetadata) Descriptor() ([]byte, []int) {
+	return file_pluginapi_rpc_v2_tool_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *StdioMetadata) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *StdioMetadata) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_pluginapi_rpc_v2_tool_proto protoreflect.FileDescriptor
+
+const file_pluginapi_rpc_v2_tool_proto_rawDesc = "" +
+	"\n" +
+	"\x1bpluginapi/rpc/v2/tool.proto\x12\fpluginapi.v2\"\a\n" +
+	"\x05Empty\"\xcd\x02\n" +
+	"\x0eToolDefinition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12'\n" +
+	"\x0fparameters_json\x18\x03 \x01(\tR\x0eparametersJson\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12D\n" +
+	"\vannotations\x18\x05 \x01(\v2\".pluginapi.v2.ProtoToolAnnotationsR\vannotations\x12:\n" +
+	"\bexamples\x18\x06 \x03(\v2\x1e.pluginapi.v2.ProtoToolExampleR\bexamples\x12@\n" +
+	"\vdeprecation\x18\a \x01(\v2\x1e.pluginapi.v2.ProtoDeprecationR\vdeprecation\"m\n" +
+	"\x10ProtoDeprecation\x12\x1e\n" +
+	"\n" +
+	"deprecated\x18\x01 \x01(\bR\n" +
+	"deprecated\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\vreplaced_by\x18\x03 \x01(\tR\n" +
+	"replacedBy\"I\n" +
+	"\x10ProtoToolExample\x12\x1b\n" +
+	"\targs_json\x18\x01 \x01(\tR\bargsJson\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\"\x94\x01\n" +
+	"\x14ProtoToolAnnotations\x12\x1b\n" +
+	"\tread_only\x18\x01 \x01(\bR\breadOnly\x12 \n" +
+	"\vdestructive\x18\x02 \x01(\bR\vdestructive\x12\x1e\n" +
+	"\n" +
+	"idempotent\x18\x03 \x01(\bR\n" +
+	"idempotent\x12\x1d\n" +
+	"\n" +
+	"open_world\x18\x04 \x01(\bR\topenWorld\"q\n" +
+	"\x0fToolSetResponse\x122\n" +
+	"\x05tools\x18\x01 \x03(\v2\x1c.pluginapi.v2.ToolDefinitionR\x05tools\x12*\n" +
+	"\x11supports_tool_set\x18\x02 \x01(\bR\x0fsupportsToolSet\"\xc5\x02\n" +
+	"\vCallRequest\x12\x1b\n" +
+	"\targs_json\x18\x01 \x01(\tR\bargsJson\x12C\n" +
+	"\bmetadata\x18\x02 \x03(\v2'.pluginapi.v2.CallRequest.MetadataEntryR\bmetadata\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\x12\x17\n" +
+	"\acall_id\x18\x04 \x01(\tR\x06callId\x12\x1b\n" +
+	"\ttool_name\x18\x05 \x01(\tR\btoolName\x128\n" +
+	"\tcall_meta\x18\x06 \x01(\v2\x1b.pluginapi.v2.ProtoCallMetaR\bcallMeta\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x83\x01\n" +
+	"\rProtoCallMeta\x12\x14\n" +
+	"\x05agent\x18\x01 \x01(\tR\x05agent\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\"\xc9\x01\n" +
+	"\fCallResponse\x12\x1f\n" +
+	"\vresult_json\x18\x01 \x01(\tR\n" +
+	"resultJson\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12I\n" +
+	"\x10structured_error\x18\x03 \x01(\v2\x1e.pluginapi.v2.ProtoPluginErrorR\x0fstructuredError\x127\n" +
+	"\x05files\x18\x04 \x03(\v2!.pluginapi.v2.ProtoFileAttachmentR\x05files\"\xd6\x01\n" +
+	"\x10ProtoPluginError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
+	"\tretryable\x18\x03 \x01(\bR\tretryable\x12\x1e\n" +
+	"\n" +
+	"suggestion\x18\x04 \x01(\tR\n" +
+	"suggestion\x12@\n" +
+	"\ffield_errors\x18\x05 \x03(\v2\x1d.pluginapi.v2.ProtoFieldErrorR\vfieldErrors\x12\x14\n" +
+	"\x05stack\x18\x06 \x01(\tR\x05stack\"A\n" +
+	"\x0fProtoFieldError\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\",\n" +
+	"\x11CancelCallRequest\x12\x17\n" +
+	"\acall_id\x18\x01 \x01(\tR\x06callId\"\xb6\x02\n" +
+	"\x10StartTaskRequest\x12\x1b\n" +
+	"\targs_json\x18\x01 \x01(\tR\bargsJson\x12H\n" +
+	"\bmetadata\x18\x02 \x03(\v2,.pluginapi.v2.StartTaskRequest.MetadataEntryR\bmetadata\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\x12\x1b\n" +
+	"\ttool_name\x18\x04 \x01(\tR\btoolName\x128\n" +
+	"\tcall_meta\x18\x05 \x01(\v2\x1b.pluginapi.v2.ProtoCallMetaR\bcallMeta\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\",\n" +
+	"\x11StartTaskResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"&\n" +
+	"\vTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\xcb\x02\n" +
+	"\x12TaskStatusResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x18\n" +
+	"\apercent\x18\x03 \x01(\x01R\apercent\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1f\n" +
+	"\vresult_json\x18\x05 \x01(\tR\n" +
+	"resultJson\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12I\n" +
+	"\x10structured_error\x18\a \x01(\v2\x1e.pluginapi.v2.ProtoPluginErrorR\x0fstructuredError\x12&\n" +
+	"\x0fstarted_unix_ms\x18\b \x01(\x03R\rstartedUnixMs\x12(\n" +
+	"\x10finished_unix_ms\x18\t \x01(\x03R\x0efinishedUnixMs\"\xa6\x02\n" +
+	"\x0fCallStreamChunk\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1a\n" +
+	"\bprogress\x18\x02 \x01(\x01R\bprogress\x12\x18\n" +
+	"\apartial\x18\x03 \x01(\tR\apartial\x12\x12\n" +
+	"\x04done\x18\x04 \x01(\bR\x04done\x12\x1f\n" +
+	"\vresult_json\x18\x05 \x01(\tR\n" +
+	"resultJson\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12-\n" +
+	"\x12supports_streaming\x18\a \x01(\bR\x11supportsStreaming\x12I\n" +
+	"\x10structured_error\x18\b \x01(\v2\x1e.pluginapi.v2.ProtoPluginErrorR\x0fstructuredError\"+\n" +
+	"\x0fVersionResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\"\x84\x03\n" +
+	"\x13AgentContextRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vconfig_path\x18\x02 \x01(\tR\n" +
+	"configPath\x12#\n" +
+	"\rsettings_path\x18\x03 \x01(\tR\fsettingsPath\x12\x1b\n" +
+	"\tagent_dir\x18\x04 \x01(\tR\bagentDir\x12)\n" +
+	"\x10current_location\x18\x05 \x01(\tR\x0fcurrentLocation\x12'\n" +
+	"\x0fconversation_id\x18\x06 \x01(\tR\x0econversationId\x12*\n" +
+	"\x11user_display_name\x18\a \x01(\tR\x0fuserDisplayName\x12\x16\n" +
+	"\x06locale\x18\b \x01(\tR\x06locale\x12\x1a\n" +
+	"\btimezone\x18\t \x01(\tR\btimezone\x12\x1b\n" +
+	"\tllm_model\x18\n" +
+	" \x01(\tR\bllmModel\x12%\n" +
+	"\x0eworkspace_root\x18\v \x01(\tR\rworkspaceRoot\"M\n" +
+	"\x10SettingsResponse\x12#\n" +
+	"\rsettings_json\x18\x01 \x01(\tR\fsettingsJson\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x97\x02\n" +
+	"\x13ProtoConfigVariable\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1a\n" +
+	"\brequired\x18\x05 \x01(\bR\brequired\x12,\n" +
+	"\x12default_value_json\x18\x06 \x01(\tR\x10defaultValueJson\x12\x1e\n" +
+	"\n" +
+	"validation\x18\a \x01(\tR\n" +
+	"validation\x12\x18\n" +
+	"\aoptions\x18\b \x03(\tR\aoptions\x12 \n" +
+	"\vplaceholder\x18\t \x01(\tR\vplaceholder\"]\n" +
+	"\x17ConfigVariablesResponse\x12B\n" +
+	"\vconfig_vars\x18\x01 \x03(\v2!.pluginapi.v2.ProtoConfigVariableR\n" +
+	"configVars\"8\n" +
+	"\x15ValidateConfigRequest\x12\x1f\n" +
+	"\vconfig_json\x18\x01 \x01(\tR\n" +
+	"configJson\":\n" +
+	"\x17InitializeConfigRequest\x12\x1f\n" +
+	"\vconfig_json\x18\x01 \x01(\tR\n" +
+	"configJson\"Z\n" +
+	"\x14ConfigChangedRequest\x12!\n" +
+	"\fchanged_keys\x18\x01 \x03(\tR\vchangedKeys\x12\x1f\n" +
+	"\vconfig_json\x18\x02 \x01(\tR\n" +
+	"configJson\"@\n" +
+	"\x0eConfigResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xa2\x01\n" +
+	"\n" +
+	"Maintainer\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\"\n" +
+	"\forganization\x18\x03 \x01(\tR\forganization\x12\x18\n" +
+	"\awebsite\x18\x04 \x01(\tR\awebsite\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x18\n" +
+	"\aprimary\x18\x06 \x01(\bR\aprimary\"@\n" +
+	"\bPlatform\x12\x0e\n" +
+	"\x02os\x18\x01 \x01(\tR\x02os\x12$\n" +
+	"\rarchitectures\x18\x02 \x03(\tR\rarchitectures\"\x99\x01\n" +
+	"\fRequirements\x12&\n" +
+	"\x0fmin_ori_version\x18\x01 \x01(\tR\rminOriVersion\x12\"\n" +
+	"\fdependencies\x18\x02 \x03(\tR\fdependencies\x12=\n" +
+	"\aplugins\x18\x03 \x03(\v2#.pluginapi.v2.ProtoPluginDependencyR\aplugins\"K\n" +
+	"\x15ProtoPluginDependency\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"constraint\x18\x02 \x01(\tR\n" +
+	"constraint\"\xe0\x02\n" +
+	"\x0ePluginMetadata\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\alicense\x18\x04 \x01(\tR\alicense\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tR\n" +
+	"repository\x12:\n" +
+	"\vmaintainers\x18\x06 \x03(\v2\x18.pluginapi.v2.MaintainerR\vmaintainers\x124\n" +
+	"\tplatforms\x18\a \x03(\v2\x16.pluginapi.v2.PlatformR\tplatforms\x12>\n" +
+	"\frequirements\x18\b \x01(\v2\x1a.pluginapi.v2.RequirementsR\frequirements\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\"b\n" +
+	"\x10MetadataResponse\x128\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1c.pluginapi.v2.PluginMetadataR\bmetadata\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xea\x01\n" +
+	"\x19CompatibilityInfoResponse\x12*\n" +
+	"\x11min_agent_version\x18\x01 \x01(\tR\x0fminAgentVersion\x12*\n" +
+	"\x11max_agent_version\x18\x02 \x01(\tR\x0fmaxAgentVersion\x12\x1f\n" +
+	"\vapi_version\x18\x03 \x01(\tR\n" +
+	"apiVersion\x12T\n" +
+	"\x13plugin_dependencies\x18\x04 \x03(\v2#.pluginapi.v2.ProtoPluginDependencyR\x12pluginDependencies\"(\n" +
+	"\x10WebPagesResponse\x12\x14\n" +
+	"\x05pages\x18\x01 \x03(\tR\x05pages\"\xc1\x01\n" +
+	"\x0eWebPageRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12=\n" +
+	"\x05query\x18\x02 \x03(\v2'.pluginapi.v2.WebPageRequest.QueryEntryR\x05query\x12\"\n" +
+	"\rif_none_match\x18\x03 \x01(\tR\vifNoneMatch\x1a8\n" +
+	"\n" +
+	"QueryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xaf\x01\n" +
+	"\x0fWebPageResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\fR\x04body\x12\x12\n" +
+	"\x04etag\x18\x05 \x01(\tR\x04etag\x12!\n" +
+	"\fnot_modified\x18\x06 \x01(\bR\vnotModified\"\xb9\x01\n" +
+	"\x10ProtoWebPageInfo\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04icon\x18\x03 \x01(\tR\x04icon\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12#\n" +
+	"\rrequires_auth\x18\x05 \x01(\bR\frequiresAuth\x12 \n" +
+	"\fshow_in_menu\x18\x06 \x01(\bR\n" +
+	"showInMenu\"K\n" +
+	"\x13WebPageInfoResponse\x124\n" +
+	"\x05pages\x18\x01 \x03(\v2\x1e.pluginapi.v2.ProtoWebPageInfoR\x05pages\"\x99\x01\n" +
+	"\x13ProtoFileAttachment\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\fR\acontent\x12\x1a\n" +
+	"\bencoding\x18\x05 \x01(\tR\bencoding\x12\x10\n" +
+	"\x03uri\x18\x06 \x01(\tR\x03uri\"d\n" +
+	"\x14AcceptsFilesResponse\x12%\n" +
+	"\x0eaccepted_types\x18\x01 \x03(\tR\racceptedTypes\x12%\n" +
+	"\x0esupports_files\x18\x02 \x01(\bR\rsupportsFiles\"\x90\x03\n" +
+	"\x14CallWithFilesRequest\x12\x1b\n" +
+	"\targs_json\x18\x01 \x01(\tR\bargsJson\x127\n" +
+	"\x05files\x18\x02 \x03(\v2!.pluginapi.v2.ProtoFileAttachmentR\x05files\x12L\n" +
+	"\bmetadata\x18\x03 \x03(\v20.pluginapi.v2.CallWithFilesRequest.MetadataEntryR\bmetadata\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x12\x17\n" +
+	"\acall_id\x18\x05 \x01(\tR\x06callId\x12\x1b\n" +
+	"\ttool_name\x18\x06 \x01(\tR\btoolName\x128\n" +
+	"\tcall_meta\x18\a \x01(\v2\x1b.pluginapi.v2.ProtoCallMetaR\bcallMeta\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa2\x01\n" +
+	"\x0fFileUploadChunk\x128\n" +
+	"\x04call\x18\x01 \x01(\v2\".pluginapi.v2.CallWithFilesRequestH\x00R\x04call\x127\n" +
+	"\x04file\x18\x02 \x01(\v2!.pluginapi.v2.ProtoFileAttachmentH\x00R\x04file\x12\x14\n" +
+	"\x04data\x18\x03 \x01(\fH\x00R\x04dataB\x06\n" +
+	"\x04part\"\xa1\x03\n" +
+	"\x12ProtoOperationInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"parameters\x18\x02 \x03(\tR\n" +
+	"parameters\x12/\n" +
+	"\x13required_parameters\x18\x03 \x03(\tR\x12requiredParameters\x121\n" +
+	"\x14required_permissions\x18\x04 \x03(\tR\x13requiredPermissions\x12(\n" +
+	"\x10supports_dry_run\x18\x05 \x01(\bR\x0esupportsDryRun\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x06 \x01(\x03R\ttimeoutMs\x12:\n" +
+	"\bexamples\x18\a \x03(\v2\x1e.pluginapi.v2.ProtoToolExampleR\bexamples\x12@\n" +
+	"\vdeprecation\x18\b \x01(\v2\x1e.pluginapi.v2.ProtoDeprecationR\vdeprecation\x12\x18\n" +
+	"\alatency\x18\t \x01(\tR\alatency\x12\x12\n" +
+	"\x04cost\x18\n" +
+	" \x01(\x01R\x04cost\"\x87\x01\n" +
+	"\x12OperationsResponse\x12@\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2 .pluginapi.v2.ProtoOperationInfoR\n" +
+	"operations\x12/\n" +
+	"\x13supports_operations\x18\x02 \x01(\bR\x12supportsOperations\"j\n" +
+	"\x15StateSnapshotResponse\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\fR\x05state\x12%\n" +
+	"\x0esupports_state\x18\x02 \x01(\bR\rsupportsState\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"+\n" +
+	"\x13RestoreStateRequest\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\fR\x05state\"\x82\x01\n" +
+	"\x0fHandoffResponse\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\fR\x05state\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12)\n" +
+	"\x10supports_handoff\x18\x03 \x01(\bR\x0fsupportsHandoff\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"I\n" +
+	"\x0eHandoffRequest\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\fR\x05state\x12!\n" +
+	"\ffrom_version\x18\x02 \x01(\tR\vfromVersion\"h\n" +
+	"\x14SystemPromptResponse\x12\x1a\n" +
+	"\bfragment\x18\x01 \x01(\tR\bfragment\x124\n" +
+	"\x16supports_system_prompt\x18\x02 \x01(\bR\x14supportsSystemPrompt\"c\n" +
+	"\x19PromptContributionRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x02 \x01(\tR\tagentName\"N\n" +
+	"\x1aPromptContributionResponse\x12\x1a\n" +
+	"\bfragment\x18\x01 \x01(\tR\bfragment\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x81\x01\n" +
+	"\x14EnrichContextRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x02 \x01(\tR\tagentName\x12!\n" +
+	"\fuser_message\x18\x03 \x01(\tR\vuserMessage\"E\n" +
+	"\x10ProtoContextItem\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x1b\n" +
+	"\tdata_json\x18\x02 \x01(\tR\bdataJson\"\x94\x01\n" +
+	"\x15EnrichContextResponse\x124\n" +
+	"\x05items\x18\x01 \x03(\v2\x1e.pluginapi.v2.ProtoContextItemR\x05items\x12/\n" +
+	"\x13supports_enrichment\x18\x02 \x01(\bR\x12supportsEnrichment\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"$\n" +
+	"\fEmbedRequest\x12\x14\n" +
+	"\x05texts\x18\x01 \x03(\tR\x05texts\"#\n" +
+	"\tEmbedding\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x02R\x06values\"\x8f\x01\n" +
+	"\rEmbedResponse\x127\n" +
+	"\n" +
+	"embeddings\x18\x01 \x03(\v2\x17.pluginapi.v2.EmbeddingR\n" +
+	"embeddings\x12/\n" +
+	"\x13supports_embeddings\x18\x02 \x01(\bR\x12supportsEmbeddings\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"V\n" +
+	"\x0eProtoFileWatch\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04glob\x18\x02 \x01(\tR\x04glob\x12\x1c\n" +
+	"\trecursive\x18\x03 \x01(\bR\trecursive\"\x93\x01\n" +
+	"\x13FileWatchesResponse\x126\n" +
+	"\awatches\x18\x01 \x03(\v2\x1c.pluginapi.v2.ProtoFileWatchR\awatches\x12.\n" +
+	"\x13supports_file_watch\x18\x02 \x01(\bR\x11supportsFileWatch\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"U\n" +
+	"\x14ProtoFileChangeEvent\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x0e\n" +
+	"\x02op\x18\x02 \x01(\tR\x02op\x12\x19\n" +
+	"\bold_path\x18\x03 \x01(\tR\aoldPath\"P\n" +
+	"\x12FileChangesRequest\x12:\n" +
+	"\x06events\x18\x01 \x03(\v2\".pluginapi.v2.ProtoFileChangeEventR\x06events\"\x85\x01\n" +
+	"\x12ProtoScheduledTask\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bschedule\x18\x02 \x01(\tR\bschedule\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x04 \x01(\x03R\ttimeoutMs\"\xa0\x01\n" +
+	"\x16ScheduledTasksResponse\x126\n" +
+	"\x05tasks\x18\x01 \x03(\v2 .pluginapi.v2.ProtoScheduledTaskR\x05tasks\x128\n" +
+	"\x18supports_scheduled_tasks\x18\x02 \x01(\bR\x16supportsScheduledTasks\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"b\n" +
+	"\x1bExecuteScheduledTaskRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12/\n" +
+	"\x14scheduled_at_unix_ms\x18\x02 \x01(\x03R\x11scheduledAtUnixMs\"\xa9\x01\n" +
+	"\x13HealthCheckResponse\x122\n" +
+	"\x15supports_health_check\x18\x01 \x01(\bR\x13supportsHealthCheck\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12)\n" +
+	"\x10recovered_panics\x18\x03 \x01(\x03R\x0frecoveredPanics\x12\x1d\n" +
+	"\n" +
+	"last_panic\x18\x04 \x01(\tR\tlastPanic\"\xc0\x02\n" +
+	"\x13PermissionsResponse\x12\x1f\n" +
+	"\vfile_access\x18\x01 \x01(\bR\n" +
+	"fileAccess\x12%\n" +
+	"\x0enetwork_access\x18\x02 \x01(\bR\rnetworkAccess\x12'\n" +
+	"\x0fsystem_commands\x18\x03 \x01(\bR\x0esystemCommands\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x121\n" +
+	"\x14supports_permissions\x18\x05 \x01(\bR\x13supportsPermissions\x12\x1d\n" +
+	"\n" +
+	"read_paths\x18\x06 \x03(\tR\treadPaths\x12\x1f\n" +
+	"\vwrite_paths\x18\a \x03(\tR\n" +
+	"writePaths\x12#\n" +
+	"\rallowed_hosts\x18\b \x03(\tR\fallowedHosts\"w\n" +
+	"\x0eProtoRateLimit\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x1a\n" +
+	"\brequests\x18\x02 \x01(\x05R\brequests\x12\x15\n" +
+	"\x06per_ms\x18\x03 \x01(\x03R\x05perMs\x12\x14\n" +
+	"\x05burst\x18\x04 \x01(\x05R\x05burst\"|\n" +
+	"\x12RateLimitsResponse\x124\n" +
+	"\x06limits\x18\x01 \x03(\v2\x1c.pluginapi.v2.ProtoRateLimitR\x06limits\x120\n" +
+	"\x14supports_rate_limits\x18\x02 \x01(\bR\x12supportsRateLimits\"[\n" +
+	"\x10CategoryResponse\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12+\n" +
+	"\x11supports_category\x18\x02 \x01(\bR\x10supportsCategory\"\xa6\x01\n" +
+	"\x12InitializeResponse\x12/\n" +
+	"\x13supports_initialize\x18\x01 \x01(\bR\x12supportsInitialize\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12I\n" +
+	"\x10structured_error\x18\x03 \x01(\v2\x1e.pluginapi.v2.ProtoPluginErrorR\x0fstructuredError\"Q\n" +
+	"\x10NegotiateRequest\x12!\n" +
+	"\fapi_versions\x18\x01 \x03(\tR\vapiVersions\x12\x1a\n" +
+	"\bfeatures\x18\x02 \x03(\tR\bfeatures\"s\n" +
+	"\x11NegotiateResponse\x12\x1f\n" +
+	"\vapi_version\x18\x01 \x01(\tR\n" +
+	"apiVersion\x12!\n" +
+	"\fapi_versions\x18\x02 \x03(\tR\vapiVersions\x12\x1a\n" +
+	"\bfeatures\x18\x03 \x03(\tR\bfeatures\"\xd8\x06\n" +
+	"\x14CapabilitiesResponse\x12\x1e\n" +
+	"\n" +
+	"interfaces\x18\x01 \x03(\tR\n" +
+	"interfaces\x127\n" +
+	"\aversion\x18\x02 \x01(\v2\x1d.pluginapi.v2.VersionResponseR\aversion\x12M\n" +
+	"\rcompatibility\x18\x03 \x01(\v2'.pluginapi.v2.CompatibilityInfoResponseR\rcompatibility\x12:\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x1e.pluginapi.v2.MetadataResponseR\bmetadata\x12>\n" +
+	"\tweb_pages\x18\x05 \x01(\v2!.pluginapi.v2.WebPageInfoResponseR\bwebPages\x128\n" +
+	"\x05files\x18\x06 \x01(\v2\".pluginapi.v2.AcceptsFilesResponseR\x05files\x12@\n" +
+	"\n" +
+	"operations\x18\a \x01(\v2 .pluginapi.v2.OperationsResponseR\n" +
+	"operations\x12G\n" +
+	"\rsystem_prompt\x18\b \x01(\v2\".pluginapi.v2.SystemPromptResponseR\fsystemPrompt\x12D\n" +
+	"\ffile_watches\x18\t \x01(\v2!.pluginapi.v2.FileWatchesResponseR\vfileWatches\x12C\n" +
+	"\vpermissions\x18\n" +
+	" \x01(\v2!.pluginapi.v2.PermissionsResponseR\vpermissions\x12:\n" +
+	"\bcategory\x18\v \x01(\v2\x1e.pluginapi.v2.CategoryResponseR\bcategory\x12M\n" +
+	"\x0fscheduled_tasks\x18\f \x01(\v2$.pluginapi.v2.ScheduledTasksResponseR\x0escheduledTasks\x12A\n" +
+	"\vrate_limits\x18\r \x01(\v2 .pluginapi.v2.RateLimitsResponseR\n" +
+	"rateLimits\"\xf8\x01\n" +
+	"\x10ProtoMethodStats\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x14\n" +
+	"\x05calls\x18\x02 \x01(\x03R\x05calls\x12\x16\n" +
+	"\x06errors\x18\x03 \x01(\x03R\x06errors\x12*\n" +
+	"\x11total_duration_us\x18\x04 \x01(\x03R\x0ftotalDurationUs\x12&\n" +
+	"\x0fmax_duration_us\x18\x05 \x01(\x03R\rmaxDurationUs\x12#\n" +
+	"\rrequest_bytes\x18\x06 \x01(\x03R\frequestBytes\x12%\n" +
+	"\x0eresponse_bytes\x18\a \x01(\x03R\rresponseBytes\"I\n" +
+	"\rStatsResponse\x128\n" +
+	"\amethods\x18\x01 \x03(\v2\x1e.pluginapi.v2.ProtoMethodStatsR\amethods\"2\n" +
+	"\x13LogSubscribeRequest\x12\x1b\n" +
+	"\tmin_level\x18\x01 \x01(\tR\bminLevel\"\xe1\x01\n" +
+	"\rProtoLogEntry\x12$\n" +
+	"\x0etime_unix_nano\x18\x01 \x01(\x03R\ftimeUnixNano\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12?\n" +
+	"\x06fields\x18\x04 \x03(\v2'.pluginapi.v2.ProtoLogEntry.FieldsEntryR\x06fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"E\n" +
+	"\x13HostServicesRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\xbd\x01\n" +
+	"\x0eHostLogRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12@\n" +
+	"\x06fields\x18\x03 \x03(\v2(.pluginapi.v2.HostLogRequest.FieldsEntryR\x06fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"F\n" +
+	"\x16ProtoCompletionMessage\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"\xbd\x01\n" +
+	"\x13HostCompleteRequest\x12#\n" +
+	"\rsystem_prompt\x18\x01 \x01(\tR\fsystemPrompt\x12@\n" +
+	"\bmessages\x18\x02 \x03(\v2$.pluginapi.v2.ProtoCompletionMessageR\bmessages\x12\x1d\n" +
+	"\n" +
+	"max_tokens\x18\x03 \x01(\x05R\tmaxTokens\x12 \n" +
+	"\vtemperature\x18\x04 \x01(\x01R\vtemperature\"\xd1\x01\n" +
+	"\x14HostCompleteResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12!\n" +
+	"\finput_tokens\x18\x02 \x01(\x05R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x03 \x01(\x05R\foutputTokens\x12\x1c\n" +
+	"\ttruncated\x18\x04 \x01(\bR\ttruncated\x12)\n" +
+	"\x10remaining_tokens\x18\x05 \x01(\x03R\x0fremainingTokens\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\xef\x01\n" +
+	"\x13HostCallToolRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\targs_json\x18\x02 \x01(\tR\bargsJson\x12\x1d\n" +
+	"\n" +
+	"call_chain\x18\x03 \x03(\tR\tcallChain\x12K\n" +
+	"\bmetadata\x18\x04 \x03(\v2/.pluginapi.v2.HostCallToolRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbd\x01\n" +
+	"\x17HostConversationRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12O\n" +
+	"\bmetadata\x18\x02 \x03(\v23.pluginapi.v2.HostConversationRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x87\x01\n" +
+	"\x18ProtoConversationMessage\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12!\n" +
+	"\ftimestamp_ms\x18\x03 \x01(\x03R\vtimestampMs\x12\x1a\n" +
+	"\bredacted\x18\x04 \x01(\bR\bredacted\"t\n" +
+	"\x18HostConversationResponse\x12B\n" +
+	"\bmessages\x18\x01 \x03(\v2&.pluginapi.v2.ProtoConversationMessageR\bmessages\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"O\n" +
+	"\x13HostRememberRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\"7\n" +
+	"\x11HostRecallRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\f\n" +
+	"\x01k\x18\x02 \x01(\x05R\x01k\"c\n" +
+	"\x11ProtoMemoryRecord\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\"e\n" +
+	"\x12HostRecallResponse\x129\n" +
+	"\arecords\x18\x01 \x03(\v2\x1f.pluginapi.v2.ProtoMemoryRecordR\arecords\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"S\n" +
+	"\x11ProtoNotification\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"2\n" +
+	"\x1aHostSubscribeEventsRequest\x12\x14\n" +
+	"\x05types\x18\x01 \x03(\tR\x05types\"p\n" +
+	"\x12ProtoPluginMessage\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x14\n" +
+	"\x05topic\x18\x03 \x01(\tR\x05topic\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\fR\apayload\"6\n" +
+	"\x1cHostSubscribeMessagesRequest\x12\x16\n" +
+	"\x06topics\x18\x01 \x03(\tR\x06topics\"4\n" +
+	"\x18DefinitionChangedRequest\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\";\n" +
+	"\x11HostSecretRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"@\n" +
+	"\x12HostSecretResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x88\x01\n" +
+	"\x18HostAuthorizationRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1b\n" +
+	"\tuser_code\x18\x03 \x01(\tR\buserCode\x12!\n" +
+	"\fredirect_url\x18\x04 \x01(\tR\vredirectUrl\"T\n" +
+	"\x19HostAuthorizationResponse\x12!\n" +
+	"\fcallback_url\x18\x01 \x01(\tR\vcallbackUrl\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xd6\x01\n" +
+	"\x10HostUsageRequest\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1a\n" +
+	"\brequests\x18\x02 \x01(\x03R\brequests\x12!\n" +
+	"\finput_tokens\x18\x03 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x04 \x01(\x03R\foutputTokens\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x05 \x01(\x03R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\x06 \x01(\x03R\rbytesReceived\"H\n" +
+	"\x0fProtoAgentEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12!\n" +
+	"\fpayload_json\x18\x02 \x01(\tR\vpayloadJson\"\xa8\x02\n" +
+	"\n" +
+	"StdioFrame\x12\x1b\n" +
+	"\tstream_id\x18\x01 \x01(\x04R\bstreamId\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\x12\x1d\n" +
+	"\n" +
+	"close_send\x18\x04 \x01(\bR\tcloseSend\x12\x16\n" +
+	"\x06cancel\x18\x05 \x01(\bR\x06cancel\x12\x12\n" +
+	"\x04done\x18\x06 \x01(\bR\x04done\x12\x12\n" +
+	"\x04code\x18\a \x01(\x05R\x04code\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\t \x01(\x03R\ttimeoutMs\x127\n" +
+	"\bmetadata\x18\n" +
+	" \x03(\v2\x1b.pluginapi.v2.StdioMetadataR\bmetadata\"9\n" +
+	"\rStdioMetadata\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values2\xe3\x1e\n" +
+	"\vToolService\x12B\n" +
+	"\rGetDefinition\x12\x13.pluginapi.v2.Empty\x1a\x1c.pluginapi.v2.ToolDefinition\x12>\n" +
+	"\bGetTools\x12\x13.pluginapi.v2.Empty\x1a\x1d.pluginapi.v2.ToolSetResponse\x12=\n" +
+	"\x04Call\x12\x19.pluginapi.v2.CallRequest\x1a\x1a.pluginapi.v2.CallResponse\x12H\n" +
+	"\n" +
+	"CallStream\x12\x19.pluginapi.v2.CallRequest\x1a\x1d.pluginapi.v2.CallStreamChunk0\x01\x12K\n" +
+	"\n" +
+	"CancelCall\x12\x1f.pluginapi.v2.CancelCallRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12L\n" +
+	"\tStartTask\x12\x1e.pluginapi.v2.StartTaskRequest\x1a\x1f.pluginapi.v2.StartTaskResponse\x12L\n" +
+	"\rGetTaskStatus\x12\x19.pluginapi.v2.TaskRequest\x1a .pluginapi.v2.TaskStatusResponse\x12E\n" +
+	"\n" +
+	"CancelTask\x12\x19.pluginapi.v2.TaskRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12@\n" +
+	"\n" +
+	"GetVersion\x12\x13.pluginapi.v2.Empty\x1a\x1d.pluginapi.v2.VersionResponse\x12I\n" +
+	"\x0fSetAgentContext\x12!.pluginapi.v2.AgentContextRequest\x1a\x13.pluginapi.v2.Empty\x12L\n" +
+	"\x12UpdateAgentContext\x12!.pluginapi.v2.AgentContextRequest\x1a\x13.pluginapi.v2.Empty\x12I\n" +
+	"\x12GetDefaultSettings\x12\x13.pluginapi.v2.Empty\x1a\x1e.pluginapi.v2.SettingsResponse\x12O\n" +
+	"\x11GetRequiredConfig\x12\x13.pluginapi.v2.Empty\x1a%.pluginapi.v2.ConfigVariablesResponse\x12S\n" +
+	"\x0eValidateConfig\x12#.pluginapi.v2.ValidateConfigRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12[\n" +
+	"\x14InitializeWithConfig\x12%.pluginapi.v2.InitializeConfigRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12W\n" +
+	"\x13NotifyConfigChanged\x12\".pluginapi.v2.ConfigChangedRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12O\n" +
+	"\n" +
+	"TestConfig\x12#.pluginapi.v2.ValidateConfigRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12B\n" +
+	"\vGetMetadata\x12\x13.pluginapi.v2.Empty\x1a\x1e.pluginapi.v2.MetadataResponse\x12T\n" +
+	"\x14GetCompatibilityInfo\x12\x13.pluginapi.v2.Empty\x1a'.pluginapi.v2.CompatibilityInfoResponse\x12B\n" +
+	"\vGetWebPages\x12\x13.pluginapi.v2.Empty\x1a\x1e.pluginapi.v2.WebPagesResponse\x12K\n" +
+	"\fServeWebPage\x12\x1c.pluginapi.v2.WebPageRequest\x1a\x1d.pluginapi.v2.WebPageResponse\x12H\n" +
+	"\x0eGetWebPageInfo\x12\x13.pluginapi.v2.Empty\x1a!.pluginapi.v2.WebPageInfoResponse\x12G\n" +
+	"\fAcceptsFiles\x12\x13.pluginapi.v2.Empty\x1a\".pluginapi.v2.AcceptsFilesResponse\x12O\n" +
+	"\rCallWithFiles\x12\".pluginapi.v2.CallWithFilesRequest\x1a\x1a.pluginapi.v2.CallResponse\x12R\n" +
+	"\x13CallWithFilesStream\x12\x1d.pluginapi.v2.FileUploadChunk\x1a\x1a.pluginapi.v2.CallResponse(\x01\x12F\n" +
+	"\rGetOperations\x12\x13.pluginapi.v2.Empty\x1a .pluginapi.v2.OperationsResponse\x12I\n" +
+	"\rSnapshotState\x12\x13.pluginapi.v2.Empty\x1a#.pluginapi.v2.StateSnapshotResponse\x12O\n" +
+	"\fRestoreState\x12!.pluginapi.v2.RestoreStateRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12G\n" +
+	"\vExportState\x12\x13.pluginapi.v2.Empty\x1a#.pluginapi.v2.StateSnapshotResponse\x12N\n" +
+	"\vImportState\x12!.pluginapi.v2.RestoreStateRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12D\n" +
+	"\x0ePrepareHandoff\x12\x13.pluginapi.v2.Empty\x1a\x1d.pluginapi.v2.HandoffResponse\x12L\n" +
+	"\x0eReceiveHandoff\x12\x1c.pluginapi.v2.HandoffRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12R\n" +
+	"\x17GetSystemPromptFragment\x12\x13.pluginapi.v2.Empty\x1a\".pluginapi.v2.SystemPromptResponse\x12e\n" +
+	"\x10ContributePrompt\x12'.pluginapi.v2.PromptContributionRequest\x1a(.pluginapi.v2.PromptContributionResponse\x12X\n" +
+	"\rEnrichContext\x12\".pluginapi.v2.EnrichContextRequest\x1a#.pluginapi.v2.EnrichContextResponse\x12@\n" +
+	"\x05Embed\x12\x1a.pluginapi.v2.EmbedRequest\x1a\x1b.pluginapi.v2.EmbedResponse\x12H\n" +
+	"\x0eGetFileWatches\x12\x13.pluginapi.v2.Empty\x1a!.pluginapi.v2.FileWatchesResponse\x12V\n" +
+	"\x10WatchFileChanges\x12 .pluginapi.v2.FileChangesRequest\x1a\x1c.pluginapi.v2.ConfigResponse(\x010\x01\x12N\n" +
+	"\x11GetScheduledTasks\x12\x13.pluginapi.v2.Empty\x1a$.pluginapi.v2.ScheduledTasksResponse\x12_\n" +
+	"\x14ExecuteScheduledTask\x12).pluginapi.v2.ExecuteScheduledTaskRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12E\n" +
+	"\vHealthCheck\x12\x13.pluginapi.v2.Empty\x1a!.pluginapi.v2.HealthCheckResponse\x12P\n" +
+	"\x16GetRequiredPermissions\x12\x13.pluginapi.v2.Empty\x1a!.pluginapi.v2.PermissionsResponse\x12B\n" +
+	"\vGetCategory\x12\x13.pluginapi.v2.Empty\x1a\x1e.pluginapi.v2.CategoryResponse\x12F\n" +
+	"\rGetRateLimits\x12\x13.pluginapi.v2.Empty\x1a .pluginapi.v2.RateLimitsResponse\x12=\n" +
+	"\bShutdown\x12\x13.pluginapi.v2.Empty\x1a\x1c.pluginapi.v2.ConfigResponse\x12C\n" +
+	"\n" +
+	"Initialize\x12\x13.pluginapi.v2.Empty\x1a .pluginapi.v2.InitializeResponse\x12R\n" +
+	"\x0fSetHostServices\x12!.pluginapi.v2.HostServicesRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12L\n" +
+	"\tNegotiate\x12\x1e.pluginapi.v2.NegotiateRequest\x1a\x1f.pluginapi.v2.NegotiateResponse\x12J\n" +
+	"\x0fGetCapabilities\x12\x13.pluginapi.v2.Empty\x1a\".pluginapi.v2.CapabilitiesResponse\x12<\n" +
+	"\bGetStats\x12\x13.pluginapi.v2.Empty\x1a\x1b.pluginapi.v2.StatsResponse\x12Q\n" +
+	"\rSubscribeLogs\x12!.pluginapi.v2.LogSubscribeRequest\x1a\x1b.pluginapi.v2.ProtoLogEntry0\x012\xc1\n" +
+	"\n" +
+	"\vHostService\x12A\n" +
+	"\x03Log\x12\x1c.pluginapi.v2.HostLogRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12Q\n" +
+	"\bComplete\x12!.pluginapi.v2.HostCompleteRequest\x1a\".pluginapi.v2.HostCompleteResponse\x12I\n" +
+	"\bCallTool\x12!.pluginapi.v2.HostCallToolRequest\x1a\x1a.pluginapi.v2.CallResponse\x12g\n" +
+	"\x16GetConversationHistory\x12%.pluginapi.v2.HostConversationRequest\x1a&.pluginapi.v2.HostConversationResponse\x12K\n" +
+	"\bRemember\x12!.pluginapi.v2.HostRememberRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12K\n" +
+	"\x06Recall\x12\x1f.pluginapi.v2.HostRecallRequest\x1a .pluginapi.v2.HostRecallResponse\x12E\n" +
+	"\n" +
+	"Embeddings\x12\x1a.pluginapi.v2.EmbedRequest\x1a\x1b.pluginapi.v2.EmbedResponse\x12G\n" +
+	"\x06Notify\x12\x1f.pluginapi.v2.ProtoNotification\x1a\x1c.pluginapi.v2.ConfigResponse\x12\\\n" +
+	"\x0fSubscribeEvents\x12(.pluginapi.v2.HostSubscribeEventsRequest\x1a\x1d.pluginapi.v2.ProtoAgentEvent0\x01\x12N\n" +
+	"\fSendToPlugin\x12 .pluginapi.v2.ProtoPluginMessage\x1a\x1c.pluginapi.v2.ConfigResponse\x12c\n" +
+	"\x11SubscribeMessages\x12*.pluginapi.v2.HostSubscribeMessagesRequest\x1a .pluginapi.v2.ProtoPluginMessage0\x01\x12Y\n" +
+	"\x11DefinitionChanged\x12&.pluginapi.v2.DefinitionChangedRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12N\n" +
+	"\tGetSecret\x12\x1f.pluginapi.v2.HostSecretRequest\x1a .pluginapi.v2.HostSecretResponse\x12J\n" +
+	"\tSetSecret\x12\x1f.pluginapi.v2.HostSecretRequest\x1a\x1c.pluginapi.v2.ConfigResponse\x12g\n" +
+	"\x14RequestAuthorization\x12&.pluginapi.v2.HostAuthorizationRequest\x1a'.pluginapi.v2.HostAuthorizationResponse\x12K\n" +
+	"\vReportUsage\x12\x1e.pluginapi.v2.HostUsageRequest\x1a\x1c.pluginapi.v2.ConfigResponseB0Z.github.com/oriagent/ori-pluginapi/rpc/v2;rpcv2b\x06proto3"
+
+var (
+	file_pluginapi_rpc_v2_tool_proto_rawDescOnce sync.Once
+	file_pluginapi_rpc_v2_tool_proto_rawDescData []byte
+)
+
+func file_pluginapi_rpc_v2_tool_proto_rawDescGZIP() []byte {
+	file_pluginapi_rpc_v2_tool_proto_rawDescOnce.Do(func() {
+		file_pluginapi_rpc_v2_tool_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pluginapi_rpc_v2_tool_proto_rawDesc), len(file_pluginapi_rpc_v2_tool_proto_rawDesc)))
+	})
+	return file_pluginapi_rpc_v2_tool_proto_rawDescData
+}
+
+var file_pluginapi_rpc_v2_tool_proto_msgTypes = make([]protoimpl.MessageInfo, 111)
+var file_pluginapi_rpc_v2_tool_proto_goTypes = []any{
+	(*Empty)(nil),                        // 0: pluginapi.v2.Empty
+	(*ToolDefinition)(nil),               // 1: pluginapi.v2.ToolDefinition
+	(*ProtoDeprecation)(nil),             // 2: pluginapi.v2.ProtoDeprecation
+	(*ProtoToolExample)(nil),             // 3: pluginapi.v2.ProtoToolExample
+	(*ProtoToolAnnotations)(nil),         // 4: pluginapi.v2.ProtoToolAnnotations
+	(*ToolSetResponse)(nil),              // 5: pluginapi.v2.ToolSetResponse
+	(*CallRequest)(nil),                  // 6: pluginapi.v2.CallRequest
+	(*ProtoCallMeta)(nil),                // 7: pluginapi.v2.ProtoCallMeta
+	(*CallResponse)(nil),                 // 8: pluginapi.v2.CallResponse
+	(*ProtoPluginError)(nil),             // 9: pluginapi.v2.ProtoPluginError
+	(*ProtoFieldError)(nil),              // 10: pluginapi.v2.ProtoFieldError
+	(*CancelCallRequest)(nil),            // 11: pluginapi.v2.CancelCallRequest
+	(*StartTaskRequest)(nil),             // 12: pluginapi.v2.StartTaskRequest
+	(*StartTaskResponse)(nil),            // 13: pluginapi.v2.StartTaskResponse
+	(*TaskRequest)(nil),                  // 14: pluginapi.v2.TaskRequest
+	(*TaskStatusResponse)(nil),           // 15: pluginapi.v2.TaskStatusResponse
+	(*CallStreamChunk)(nil),              // 16: pluginapi.v2.CallStreamChunk
+	(*VersionResponse)(nil),              // 17: pluginapi.v2.VersionResponse
+	(*AgentContextRequest)(nil),          // 18: pluginapi.v2.AgentContextRequest
+	(*SettingsResponse)(nil),             // 19: pluginapi.v2.SettingsResponse
+	(*ProtoConfigVariable)(nil),          // 20: pluginapi.v2.ProtoConfigVariable
+	(*ConfigVariablesResponse)(nil),      // 21: pluginapi.v2.ConfigVariablesResponse
+	(*ValidateConfigRequest)(nil),        // 22: pluginapi.v2.ValidateConfigRequest
+	(*InitializeConfigRequest)(nil),      // 23: pluginapi.v2.InitializeConfigRequest
+	(*ConfigChangedRequest)(nil),         // 24: pluginapi.v2.ConfigChangedRequest
+	(*ConfigResponse)(nil),               // 25: pluginapi.v2.ConfigResponse
+	(*Maintainer)(nil),                   // 26: pluginapi.v2.Maintainer
+	(*Platform)(nil),                     // 27: pluginapi.v2.Platform
+	(*Requirements)(nil),                 // 28: pluginapi.v2.Requirements
+	(*ProtoPluginDependency)(nil),        // 29: pluginapi.v2.ProtoPluginDependency
+	(*PluginMetadata)(nil),               // 30: pluginapi.v2.PluginMetadata
+	(*MetadataResponse)(nil),             // 31: pluginapi.v2.MetadataResponse
+	(*CompatibilityInfoResponse)(nil),    // 32: pluginapi.v2.CompatibilityInfoResponse
+	(*WebPagesResponse)(nil),             // 33: pluginapi.v2.WebPagesResponse
+	(*WebPageRequest)(nil),               // 34: pluginapi.v2.WebPageRequest
+	(*WebPageResponse)(nil),              // 35: pluginapi.v2.WebPageResponse
+	(*ProtoWebPageInfo)(nil),             // 36: pluginapi.v2.ProtoWebPageInfo
+	(*WebPageInfoResponse)(nil),          // 37: pluginapi.v2.WebPageInfoResponse
+	(*ProtoFileAttachment)(nil),          // 38: pluginapi.v2.ProtoFileAttachment
+	(*AcceptsFilesResponse)(nil),         // 39: pluginapi.v2.AcceptsFilesResponse
+	(*CallWithFilesRequest)(nil),         // 40: pluginapi.v2.CallWithFilesRequest
+	(*FileUploadChunk)(nil),              // 41: pluginapi.v2.FileUploadChunk
+	(*ProtoOperationInfo)(nil),           // 42: pluginapi.v2.ProtoOperationInfo
+	(*OperationsResponse)(nil),           // 43: pluginapi.v2.OperationsResponse
+	(*StateSnapshotResponse)(nil),        // 44: pluginapi.v2.StateSnapshotResponse
+	(*RestoreStateRequest)(nil),          // 45: pluginapi.v2.RestoreStateRequest
+	(*HandoffResponse)(nil),              // 46: pluginapi.v2.HandoffResponse
+	(*HandoffRequest)(nil),               // 47: pluginapi.v2.HandoffRequest
+	(*SystemPromptResponse)(nil),         // 48: pluginapi.v2.SystemPromptResponse
+	(*PromptContributionRequest)(nil),    // 49: pluginapi.v2.PromptContributionRequest
+	(*PromptContributionResponse)(nil),   // 50: pluginapi.v2.PromptContributionResponse
+	(*EnrichContextRequest)(nil),         // 51: pluginapi.v2.EnrichContextRequest
+	(*ProtoContextItem)(nil),             // 52: pluginapi.v2.ProtoContextItem
+	(*EnrichContextResponse)(nil),        // 53: pluginapi.v2.EnrichContextResponse
+	(*EmbedRequest)(nil),                 // 54: pluginapi.v2.EmbedRequest
+	(*Embedding)(nil),                    // 55: pluginapi.v2.Embedding
+	(*EmbedResponse)(nil),                // 56: pluginapi.v2.EmbedResponse
+	(*ProtoFileWatch)(nil),               // 57: pluginapi.v2.ProtoFileWatch
+	(*FileWatchesResponse)(nil),          // 58: pluginapi.v2.FileWatchesResponse
+	(*ProtoFileChangeEvent)(nil),         // 59: pluginapi.v2.ProtoFileChangeEvent
+	(*FileChangesRequest)(nil),           // 60: pluginapi.v2.FileChangesRequest
+	(*ProtoScheduledTask)(nil),           // 61: pluginapi.v2.ProtoScheduledTask
+	(*ScheduledTasksResponse)(nil),       // 62: pluginapi.v2.ScheduledTasksResponse
+	(*ExecuteScheduledTaskRequest)(nil),  // 63: pluginapi.v2.ExecuteScheduledTaskRequest
+	(*HealthCheckResponse)(nil),          // 64: pluginapi.v2.HealthCheckResponse
+	(*PermissionsResponse)(nil),          // 65: pluginapi.v2.PermissionsResponse
+	(*ProtoRateLimit)(nil),               // 66: pluginapi.v2.ProtoRateLimit
+	(*RateLimitsResponse)(nil),           // 67: pluginapi.v2.RateLimitsResponse
+	(*CategoryResponse)(nil),             // 68: pluginapi.v2.CategoryResponse
+	(*InitializeResponse)(nil),           // 69: pluginapi.v2.InitializeResponse
+	(*NegotiateRequest)(nil),             // 70: pluginapi.v2.NegotiateRequest
+	(*NegotiateResponse)(nil),            // 71: pluginapi.v2.NegotiateResponse
+	(*CapabilitiesResponse)(nil),         // 72: pluginapi.v2.CapabilitiesResponse
+	(*ProtoMethodStats)(nil),             // 73: pluginapi.v2.ProtoMethodStats
+	(*StatsResponse)(nil),                // 74: pluginapi.v2.StatsResponse
+	(*LogSubscribeRequest)(nil),          // 75: pluginapi.v2.LogSubscribeRequest
+	(*ProtoLogEntry)(nil),                // 76: pluginapi.v2.ProtoLogEntry
+	(*HostServicesRequest)(nil),          // 77: pluginapi.v2.HostServicesRequest
+	(*HostLogRequest)(nil),               // 78: pluginapi.v2.HostLogRequest
+	(*ProtoCompletionMessage)(nil),       // 79: pluginapi.v2.ProtoCompletionMessage
+	(*HostCompleteRequest)(nil),          // 80: pluginapi.v2.HostCompleteRequest
+	(*HostCompleteResponse)(nil),         // 81: pluginapi.v2.HostCompleteResponse
+	(*HostCallToolRequest)(nil),          // 82: pluginapi.v2.HostCallToolRequest
+	(*HostConversationRequest)(nil),      // 83: pluginapi.v2.HostConversationRequest
+	(*ProtoConversationMessage)(nil),     // 84: pluginapi.v2.ProtoConversationMessage
+	(*HostConversationResponse)(nil),     // 85: pluginapi.v2.HostConversationResponse
+	(*HostRememberRequest)(nil),          // 86: pluginapi.v2.HostRememberRequest
+	(*HostRecallRequest)(nil),            // 87: pluginapi.v2.HostRecallRequest
+	(*ProtoMemoryRecord)(nil),            // 88: pluginapi.v2.ProtoMemoryRecord
+	(*HostRecallResponse)(nil),           // 89: pluginapi.v2.HostRecallResponse
+	(*ProtoNotification)(nil),            // 90: pluginapi.v2.ProtoNotification
+	(*HostSubscribeEventsRequest)(nil),   // 91: pluginapi.v2.HostSubscribeEventsRequest
+	(*ProtoPluginMessage)(nil),           // 92: pluginapi.v2.ProtoPluginMessage
+	(*HostSubscribeMessagesRequest)(nil), // 93: pluginapi.v2.HostSubscribeMessagesRequest
+	(*DefinitionChangedRequest)(nil),     // 94: pluginapi.v2.DefinitionChangedRequest
+	(*HostSecretRequest)(nil),            // 95: pluginapi.v2.HostSecretRequest
+	(*HostSecretResponse)(nil),           // 96: pluginapi.v2.HostSecretResponse
+	(*HostAuthorizationRequest)(nil),     // 97: pluginapi.v2.HostAuthorizationRequest
+	(*HostAuthorizationResponse)(nil),    // 98: pluginapi.v2.HostAuthorizationResponse
+	(*HostUsageRequest)(nil),             // 99: pluginapi.v2.HostUsageRequest
+	(*ProtoAgentEvent)(nil),              // 100: pluginapi.v2.ProtoAgentEvent
+	(*StdioFrame)(nil),                   // 101: pluginapi.v2.StdioFrame
+	(*StdioMetadata)(nil),                // 102: pluginapi.v2.StdioMetadata
+	nil,                                  // 103: pluginapi.v2.CallRequest.MetadataEntry
+	nil,                                  // 104: pluginapi.v2.StartTaskRequest.MetadataEntry
+	nil,                                  // 105: pluginapi.v2.WebPageRequest.QueryEntry
+	nil,                                  // 106: pluginapi.v2.CallWithFilesRequest.MetadataEntry
+	nil,                                  // 107: pluginapi.v2.ProtoLogEntry.FieldsEntry
+	nil,                                  // 108: pluginapi.v2.HostLogRequest.FieldsEntry
+	nil,                                  // 109: pluginapi.v2.HostCallToolRequest.MetadataEntry
+	nil,                                  // 110: pluginapi.v2.HostConversationRequest.MetadataEntry
+}
+var file_pluginapi_rpc_v2_tool_proto_depIdxs = []int32{
+	4,   // 0: pluginapi.v2.ToolDefinition.annotations:type_name -> pluginapi.v2.ProtoToolAnnotations
+	3,   // 1: pluginapi.v2.ToolDefinition.examples:type_name -> pluginapi.v2.ProtoToolExample
+	2,   // 2: pluginapi.v2.ToolDefinition.deprecation:type_name -> pluginapi.v2.ProtoDeprecation
+	1,   // 3: pluginapi.v2.ToolSetResponse.tools:type_name -> pluginapi.v2.ToolDefinition
+	103, // 4: pluginapi.v2.CallRequest.metadata:type_name -> pluginapi.v2.CallRequest.MetadataEntry
+	7,   // 5: pluginapi.v2.CallRequest.call_meta:type_name -> pluginapi.v2.ProtoCallMeta
+	9,   // 6: pluginapi.v2.CallResponse.structured_error:type_name -> pluginapi.v2.ProtoPluginError
+	38,  // 7: pluginapi.v2.CallResponse.files:type_name -> pluginapi.v2.ProtoFileAttachment
+	10,  // 8: pluginapi.v2.ProtoPluginError.field_errors:type_name -> pluginapi.v2.ProtoFieldError
+	104, // 9: pluginapi.v2.StartTaskRequest.metadata:type_name -> pluginapi.v2.StartTaskRequest.MetadataEntry
+	7,   // 10: pluginapi.v2.StartTaskRequest.call_meta:type_name -> pluginapi.v2.ProtoCallMeta
+	9,   // 11: pluginapi.v2.TaskStatusResponse.structured_error:type_name -> pluginapi.v2.ProtoPluginError
+	9,   // 12: pluginapi.v2.CallStreamChunk.structured_error:type_name -> pluginapi.v2.ProtoPluginError
+	20,  // 13: pluginapi.v2.ConfigVariablesResponse.config_vars:type_name -> pluginapi.v2.ProtoConfigVariable
+	29,  // 14: pluginapi.v2.Requirements.plugins:type_name -> pluginapi.v2.ProtoPluginDependency
+	26,  // 15: pluginapi.v2.PluginMetadata.maintainers:type_name -> pluginapi.v2.Maintainer
+	27,  // 16: pluginapi.v2.PluginMetadata.platforms:type_name -> pluginapi.v2.Platform
+	28,  // 17: pluginapi.v2.PluginMetadata.requirements:type_name -> pluginapi.v2.Requirements
+	30,  // 18: pluginapi.v2.MetadataResponse.metadata:type_name -> pluginapi.v2.PluginMetadata
+	29,  // 19: pluginapi.v2.CompatibilityInfoResponse.plugin_dependencies:type_name -> pluginapi.v2.ProtoPluginDependency
+	105, // 20: pluginapi.v2.WebPageRequest.query:type_name -> pluginapi.v2.WebPageRequest.QueryEntry
+	36,  // 21: pluginapi.v2.WebPageInfoResponse.pages:type_name -> pluginapi.v2.ProtoWebPageInfo
+	38,  // 22: pluginapi.v2.CallWithFilesRequest.files:type_name -> pluginapi.v2.ProtoFileAttachment
+	106, // 23: pluginapi.v2.CallWithFilesRequest.metadata:type_name -> pluginapi.v2.CallWithFilesRequest.MetadataEntry
+	7,   // 24: pluginapi.v2.CallWithFilesRequest.call_meta:type_name -> pluginapi.v2.ProtoCallMeta
+	40,  // 25: pluginapi.v2.FileUploadChunk.call:type_name -> pluginapi.v2.CallWithFilesRequest
+	38,  // 26: pluginapi.v2.FileUploadChunk.file:type_name -> pluginapi.v2.ProtoFileAttachment
+	3,   // 27: pluginapi.v2.ProtoOperationInfo.examples:type_name -> pluginapi.v2.ProtoToolExample
+	2,   // 28: pluginapi.v2.ProtoOperationInfo.deprecation:type_name -> pluginapi.v2.ProtoDeprecation
+	42,  // 29: pluginapi.v2.OperationsResponse.operations:type_name -> pluginapi.v2.ProtoOperationInfo
+	52,  // 30: pluginapi.v2.EnrichContextResponse.items:type_name -> pluginapi.v2.ProtoContextItem
+	55,  // 31: pluginapi.v2.EmbedResponse.embeddings:type_name -> pluginapi.v2.Embedding
+	57,  // 32: pluginapi.v2.FileWatchesResponse.watches:type_name -> pluginapi.v2.ProtoFileWatch
+	59,  // 33: pluginapi.v2.FileChangesRequest.events:type_name -> pluginapi.v2.ProtoFileChangeEvent
+	61,  // 34: pluginapi.v2.ScheduledTasksResponse.tasks:type_name -> pluginapi.v2.ProtoScheduledTask
+	66,  // 35: pluginapi.v2.RateLimitsResponse.limits:type_name -> pluginapi.v2.ProtoRateLimit
+	9,   // 36: pluginapi.v2.InitializeResponse.structured_error:type_name -> pluginapi.v2.ProtoPluginError
+	17,  // 37: pluginapi.v2.CapabilitiesResponse.version:type_name -> pluginapi.v2.VersionResponse
+	32,  // 38: pluginapi.v2.CapabilitiesResponse.compatibility:type_name -> pluginapi.v2.CompatibilityInfoResponse
+	31,  // 39: pluginapi.v2.CapabilitiesResponse.metadata:type_name -> pluginapi.v2.MetadataResponse
+	37,  // 40: pluginapi.v2.CapabilitiesResponse.web_pages:type_name -> pluginapi.v2.WebPageInfoResponse
+	39,  // 41: pluginapi.v2.CapabilitiesResponse.files:type_name -> pluginapi.v2.AcceptsFilesResponse
+	43,  // 42: pluginapi.v2.CapabilitiesResponse.operations:type_name -> pluginapi.v2.OperationsResponse
+	48,  // 43: pluginapi.v2.CapabilitiesResponse.system_prompt:type_name -> pluginapi.v2.SystemPromptResponse
+	58,  // 44: pluginapi.v2.CapabilitiesResponse.file_watches:type_name -> pluginapi.v2.FileWatchesResponse
+	65,  // 45: pluginapi.v2.CapabilitiesResponse.permissions:type_name -> pluginapi.v2.PermissionsResponse
+	68,  // 46: pluginapi.v2.CapabilitiesResponse.category:type_name -> pluginapi.v2.CategoryResponse
+	62,  // 47: pluginapi.v2.CapabilitiesResponse.scheduled_tasks:type_name -> pluginapi.v2.ScheduledTasksResponse
+	67,  // 48: pluginapi.v2.CapabilitiesResponse.rate_limits:type_name -> pluginapi.v2.RateLimitsResponse
+	73,  // 49: pluginapi.v2.StatsResponse.methods:type_name -> pluginapi.v2.ProtoMethodStats
+	107, // 50: pluginapi.v2.ProtoLogEntry.fields:type_name -> pluginapi.v2.ProtoLogEntry.FieldsEntry
+	108, // 51: pluginapi.v2.HostLogRequest.fields:type_name -> pluginapi.v2.HostLogRequest.FieldsEntry
+	79,  // 52: pluginapi.v2.HostCompleteRequest.messages:type_name -> pluginapi.v2.ProtoCompletionMessage
+	109, // 53: pluginapi.v2.HostCallToolRequest.metadata:type_name -> pluginapi.v2.HostCallToolRequest.MetadataEntry
+	110, // 54: pluginapi.v2.HostConversationRequest.metadata:type_name -> pluginapi.v2.HostConversationRequest.MetadataEntry
+	84,  // 55: pluginapi.v2.HostConversationResponse.messages:type_name -> pluginapi.v2.ProtoConversationMessage
+	88,  // 56: pluginapi.v2.HostRecallResponse.records:type_name -> pluginapi.v2.ProtoMemoryRecord
+	102, // 57: pluginapi.v2.StdioFrame.metadata:type_name -> pluginapi.v2.StdioMetadata
+	0,   // 58: pluginapi.v2.ToolService.GetDefinition:input_type -> pluginapi.v2.Empty
+	0,   // 59: pluginapi.v2.ToolService.GetTools:input_type -> pluginapi.v2.Empty
+	6,   // 60: pluginapi.v2.ToolService.Call:input_type -> pluginapi.v2.CallRequest
+	6,   // 61: pluginapi.v2.ToolService.CallStream:input_type -> pluginapi.v2.CallRequest
+	11,  // 62: pluginapi.v2.ToolService.CancelCall:input_type -> pluginapi.v2.CancelCallRequest
+	12,  // 63: pluginapi.v2.ToolService.StartTask:input_type -> pluginapi.v2.StartTaskRequest
+	14,  // 64: pluginapi.v2.ToolService.GetTaskStatus:input_type -> pluginapi.v2.TaskRequest
+	14,  // 65: pluginapi.v2.ToolService.CancelTask:input_type -> pluginapi.v2.TaskRequest
+	0,   // 66: pluginapi.v2.ToolService.GetVersion:input_type -> pluginapi.v2.Empty
+	18,  // 67: pluginapi.v2.ToolService.SetAgentContext:input_type -> pluginapi.v2.AgentContextRequest
+	18,  // 68: pluginapi.v2.ToolService.UpdateAgentContext:input_type -> pluginapi.v2.AgentContextRequest
+	0,   // 69: pluginapi.v2.ToolService.GetDefaultSettings:input_type -> pluginapi.v2.Empty
+	0,   // 70: pluginapi.v2.ToolService.GetRequiredConfig:input_type -> pluginapi.v2.Empty
+	22,  // 71: pluginapi.v2.ToolService.ValidateConfig:input_type -> pluginapi.v2.ValidateConfigRequest
+	23,  // 72: pluginapi.v2.ToolService.InitializeWithConfig:input_type -> pluginapi.v2.InitializeConfigRequest
+	24,  // 73: pluginapi.v2.ToolService.NotifyConfigChanged:input_type -> pluginapi.v2.ConfigChangedRequest
+	22,  // 74: pluginapi.v2.ToolService.TestConfig:input_type -> pluginapi.v2.ValidateConfigRequest
+	0,   // 75: pluginapi.v2.ToolService.GetMetadata:input_type -> pluginapi.v2.Empty
+	0,   // 76: pluginapi.v2.ToolService.GetCompatibilityInfo:input_type -> pluginapi.v2.Empty
+	0,   // 77: pluginapi.v2.ToolService.GetWebPages:input_type -> pluginapi.v2.Empty
+	34,  // 78: pluginapi.v2.ToolService.ServeWebPage:input_type -> pluginapi.v2.WebPageRequest
+	0,   // 79: pluginapi.v2.ToolService.GetWebPageInfo:input_type -> pluginapi.v2.Empty
+	0,   // 80: pluginapi.v2.ToolService.AcceptsFiles:input_type -> pluginapi.v2.Empty
+	40,  // 81: pluginapi.v2.ToolService.CallWithFiles:input_type -> pluginapi.v2.CallWithFilesRequest
+	41,  // 82: pluginapi.v2.ToolService.CallWithFilesStream:input_type -> pluginapi.v2.FileUploadChunk
+	0,   // 83: pluginapi.v2.ToolService.GetOperations:input_type -> pluginapi.v2.Empty
+	0,   // 84: pluginapi.v2.ToolService.SnapshotState:input_type -> pluginapi.v2.Empty
+	45,  // 85: pluginapi.v2.ToolService.RestoreState:input_type -> pluginapi.v2.RestoreStateRequest
+	0,   // 86: pluginapi.v2.ToolService.ExportState:input_type -> pluginapi.v2.Empty
+	45,  // 87: pluginapi.v2.ToolService.ImportState:input_type -> pluginapi.v2.RestoreStateRequest
+	0,   // 88: pluginapi.v2.ToolService.PrepareHandoff:input_type -> pluginapi.v2.Empty
+	47,  // 89: pluginapi.v2.ToolService.ReceiveHandoff:input_type -> pluginapi.v2.HandoffRequest
+	0,   // 90: pluginapi.v2.ToolService.GetSystemPromptFragment:input_type -> pluginapi.v2.Empty
+	49,  // 91: pluginapi.v2.ToolService.ContributePrompt:input_type -> pluginapi.v2.PromptContributionRequest
+	51,  // 92: pluginapi.v2.ToolService.EnrichContext:input_type -> pluginapi.v2.EnrichContextRequest
+	54,  // 93: pluginapi.v2.ToolService.Embed:input_type -> pluginapi.v2.EmbedRequest
+	0,   // 94: pluginapi.v2.ToolService.GetFileWatches:input_type -> pluginapi.v2.Empty
+	60,  // 95: pluginapi.v2.ToolService.WatchFileChanges:input_type -> pluginapi.v2.FileChangesRequest
+	0,   // 96: pluginapi.v2.ToolService.GetScheduledTasks:input_type -> pluginapi.v2.Empty
+	63,  // 97: pluginapi.v2.ToolService.ExecuteScheduledTask:input_type -> pluginapi.v2.ExecuteScheduledTaskRequest
+	0,   // 98: pluginapi.v2.ToolService.HealthCheck:input_type -> pluginapi.v2.Empty
+	0,   // 99: pluginapi.v2.ToolService.GetRequiredPermissions:input_type -> pluginapi.v2.Empty
+	0,   // 100: pluginapi.v2.ToolService.GetCategory:input_type -> pluginapi.v2.Empty
+	0,   // 101: pluginapi.v2.ToolService.GetRateLimits:input_type -> pluginapi.v2.Empty
+	0,   // 102: pluginapi.v2.ToolService.Shutdown:input_type -> pluginapi.v2.Empty
+	0,   // 103: pluginapi.v2.ToolService.Initialize:input_type -> pluginapi.v2.Empty
+	77,  // 104: pluginapi.v2.ToolService.SetHostServices:input_type -> pluginapi.v2.HostServicesRequest
+	70,  // 105: pluginapi.v2.ToolService.Negotiate:input_type -> pluginapi.v2.NegotiateRequest
+	0,   // 106: pluginapi.v2.ToolService.GetCapabilities:input_type -> pluginapi.v2.Empty
+	0,   // 107: pluginapi.v2.ToolService.GetStats:input_type -> pluginapi.v2.Empty
+	75,  // 108: pluginapi.v2.ToolService.SubscribeLogs:input_type -> pluginapi.v2.LogSubscribeRequest
+	78,  // 109: pluginapi.v2.HostService.Log:input_type -> pluginapi.v2.HostLogRequest
+	80,  // 110: pluginapi.v2.HostService.Complete:input_type -> pluginapi.v2.HostCompleteRequest
+	82,  // 111: pluginapi.v2.HostService.CallTool:input_type -> pluginapi.v2.HostCallToolRequest
+	83,  // 112: pluginapi.v2.HostService.GetConversationHistory:input_type -> pluginapi.v2.HostConversationRequest
+	86,  // 113: pluginapi.v2.HostService.Remember:input_type -> pluginapi.v2.HostRememberRequest
+	87,  // 114: pluginapi.v2.HostService.Recall:input_type -> pluginapi.v2.HostRecallRequest
+	54,  // 115: pluginapi.v2.HostService.Embeddings:input_type -> pluginapi.v2.EmbedRequest
+	90,  // 116: pluginapi.v2.HostService.Notify:input_type -> pluginapi.v2.ProtoNotification
+	91,  // 117: pluginapi.v2.HostService.SubscribeEvents:input_type -> pluginapi.v2.HostSubscribeEventsRequest
+	92,  // 118: pluginapi.v2.HostService.SendToPlugin:input_type -> pluginapi.v2.ProtoPluginMessage
+	93,  // 119: pluginapi.v2.HostService.SubscribeMessages:input_type -> pluginapi.v2.HostSubscribeMessagesRequest
+	94,  // 120: pluginapi.v2.HostService.DefinitionChanged:input_type -> pluginapi.v2.DefinitionChangedRequest
+	95,  // 121: pluginapi.v2.HostService.GetSecret:input_type -> pluginapi.v2.HostSecretRequest
+	95,  // 122: pluginapi.v2.HostService.SetSecret:input_type -> pluginapi.v2.HostSecretRequest
+	97,  // 123: pluginapi.v2.HostService.RequestAuthorization:input_type -> pluginapi.v2.HostAuthorizationRequest
+	99,  // 124: pluginapi.v2.HostService.ReportUsage:input_type -> pluginapi.v2.HostUsageRequest
+	1,   // 125: pluginapi.v2.ToolService.GetDefinition:output_type -> pluginapi.v2.ToolDefinition
+	5,   // 126: pluginapi.v2.ToolService.GetTools:output_type -> pluginapi.v2.ToolSetResponse
+	8,   // 127: pluginapi.v2.ToolService.Call:output_type -> pluginapi.v2.CallResponse
+	16,  // 128: pluginapi.v2.ToolService.CallStream:output_type -> pluginapi.v2.CallStreamChunk
+	25,  // 129: pluginapi.v2.ToolService.CancelCall:output_type -> pluginapi.v2.ConfigResponse
+	13,  // 130: pluginapi.v2.ToolService.StartTask:output_type -> pluginapi.v2.StartTaskResponse
+	15,  // 131: pluginapi.v2.ToolService.GetTaskStatus:output_type -> pluginapi.v2.TaskStatusResponse
+	25,  // 132: pluginapi.v2.ToolService.CancelTask:output_type -> pluginapi.v2.ConfigResponse
+	17,  // 133: pluginapi.v2.ToolService.GetVersion:output_type -> pluginapi.v2.VersionResponse
+	0,   // 134: pluginapi.v2.ToolService.SetAgentContext:output_type -> pluginapi.v2.Empty
+	0,   // 135: pluginapi.v2.ToolService.UpdateAgentContext:output_type -> pluginapi.v2.Empty
+	19,  // 136: pluginapi.v2.ToolService.GetDefaultSettings:output_type -> pluginapi.v2.SettingsResponse
+	21,  // 137: pluginapi.v2.ToolService.GetRequiredConfig:output_type -> pluginapi.v2.ConfigVariablesResponse
+	25,  // 138: pluginapi.v2.ToolService.ValidateConfig:output_type -> pluginapi.v2.ConfigResponse
+	25,  // 139: pluginapi.v2.ToolService.InitializeWithConfig:output_type -> pluginapi.v2.ConfigResponse
+	25,  // 140: pluginapi.v2.ToolService.NotifyConfigChanged:output_type -> pluginapi.v2.ConfigResponse
+	25,  // 141: pluginapi.v2.ToolService.TestConfig:output_type -> pluginapi.v2.ConfigResponse
+	31,  // 142: pluginapi.v2.ToolService.GetMetadata:output_type -> pluginapi.v2.MetadataResponse
+	32,  // 143: pluginapi.v2.ToolService.GetCompatibilityInfo:output_type -> pluginapi.v2.CompatibilityInfoResponse
+	33,  // 144: pluginapi.v2.ToolService.GetWebPages:output_type -> pluginapi.v2.WebPagesResponse
+	35,  // 145: pluginapi.v2.ToolService.ServeWebPage:output_type -> pluginapi.v2.WebPageResponse
+	37,  // 146: pluginapi.v2.ToolService.GetWebPageInfo:output_type -> pluginapi.v2.WebPageInfoResponse
+	39,  // 147: pluginapi.v2.ToolService.AcceptsFiles:output_type -> pluginapi.v2.AcceptsFilesResponse
+	8,   // 148: pluginapi.v2.ToolService.CallWithFiles:output_type -> pluginapi.v2.CallResponse
+	8,   // 149: pluginapi.v2.ToolService.CallWithFilesStream:output_type -> pluginapi.v2.CallResponse
+	43,  // 150: pluginapi.v2.ToolService.GetOperations:output_type -> pluginapi.v2.OperationsResponse
+	44,  // 151: pluginapi.v2.ToolService.SnapshotState:output_type -> pluginapi.v2.StateSnapshotResponse
+	25,  // 152: pluginapi.v2.ToolService.RestoreState:output_type -> pluginapi.v2.ConfigResponse
+	44,  // 153: pluginapi.v2.ToolService.ExportState:output_type -> pluginapi.v2.StateSnapshotResponse
+	25,  // 154: pluginapi.v2.ToolService.ImportState:output_type -> pluginapi.v2.ConfigResponse
+	46,  // 155: pluginapi.v2.ToolService.PrepareHandoff:output_type -> pluginapi.v2.HandoffResponse
+	25,  // 156: pluginapi.v2.ToolService.ReceiveHandoff:output_type -> pluginapi.v2.ConfigResponse
+	48,  // 157: pluginapi.v2.ToolService.GetSystemPromptFragment:output_type -> pluginapi.v2.SystemPromptResponse
+	50,  // 158: pluginapi.v2.ToolService.ContributePrompt:output_type -> pluginapi.v2.PromptContributionResponse
+	53,  // 159: pluginapi.v2.ToolService.EnrichContext:output_type -> pluginapi.v2.EnrichContextResponse
+	56,  // 160: pluginapi.v2.ToolService.Embed:output_type -> pluginapi.v2.EmbedResponse
+	58,  // 161: pluginapi.v2.ToolService.GetFileWatches:output_type -> pluginapi.v2.FileWatchesResponse
+	25,  // 162: pluginapi.v2.ToolService.WatchFileChanges:output_type -> pluginapi.v2.ConfigResponse
+	62,  // 163: pluginapi.v2.ToolService.GetScheduledTasks:output_type -> pluginapi.v2.ScheduledTasksResponse
+	25,  // 164: pluginapi.v2.ToolService.ExecuteScheduledTask:output_type -> pluginapi.v2.ConfigResponse
+	64,  // 165: pluginapi.v2.ToolService.HealthCheck:output_type -> pluginapi.v2.HealthCheckResponse
+	65,  // 166: pluginapi.v2.ToolService.GetRequiredPermissions:output_type -> pluginapi.v2.PermissionsResponse
+	68,  // 167: pluginapi.v2.ToolService.GetCategory:output_type -> pluginapi.v2.CategoryResponse
+	67,  // 168: pluginapi.v2.ToolService.GetRateLimits:output_type -> pluginapi.v2.RateLimitsResponse
+	25,  // 169: pluginapi.v2.ToolService.Shutdown:output_type -> pluginapi.v2.ConfigResponse
+	69,  // 170: pluginapi.v2.ToolService.Initialize:output_type -> pluginapi.v2.InitializeResponse
+	25,  // 171: pluginapi.v2.ToolService.SetHostServices:output_type -> pluginapi.v2.ConfigResponse
+	71,  // 172: pluginapi.v2.ToolService.Negotiate:output_type -> pluginapi.v2.NegotiateResponse
+	72,  // 173: pluginapi.v2.ToolService.GetCapabilities:output_type -> pluginapi.v2.CapabilitiesResponse
+	74,  // 174: pluginapi.v2.ToolService.GetStats:output_type -> pluginapi.v2.StatsResponse
+	76,  // 175: pluginapi.v2.ToolService.SubscribeLogs:output_type -> pluginapi.v2.ProtoLogEntry
+	25,  // 176: pluginapi.v2.HostService.Log:output_type -> pluginapi.v2.ConfigResponse
+	81,  // 177: pluginapi.v2.HostService.Complete:output_type -> pluginapi.v2.HostCompleteResponse
+	8,   // 178: pluginapi.v2.HostService.CallTool:output_type -> pluginapi.v2.CallResponse
+	85,  // 179: pluginapi.v2.HostService.GetConversationHistory:output_type -> pluginapi.v2.HostConversationResponse
+	25,  // 180: pluginapi.v2.HostService.Remember:output_type -> pluginapi.v2.ConfigResponse
+	89,  // 181: pluginapi.v2.HostService.Recall:output_type -> pluginapi.v2.HostRecallResponse
+	56,  // 182: pluginapi.v2.HostService.Embeddings:output_type -> pluginapi.v2.EmbedResponse
+	25,  // 183: pluginapi.v2.HostService.Notify:output_type -> pluginapi.v2.ConfigResponse
+	100, // 184: pluginapi.v2.HostService.SubscribeEvents:output_type -> pluginapi.v2.ProtoAgentEvent
+	25,  // 185: pluginapi.v2.HostService.SendToPlugin:output_type -> pluginapi.v2.ConfigResponse
+	92,  // 186: pluginapi.v2.HostService.SubscribeMessages:output_type -> pluginapi.v2.ProtoPluginMessage
+	25,  // 187: pluginapi.v2.HostService.DefinitionChanged:output_type -> pluginapi.v2.ConfigResponse
+	96,  // 188: pluginapi.v2.HostService.GetSecret:output_type -> pluginapi.v2.HostSecretResponse
+	25,  // 189: pluginapi.v2.HostService.SetSecret:output_type -> pluginapi.v2.ConfigResponse
+	98,  // 190: pluginapi.v2.HostService.RequestAuthorization:output_type -> pluginapi.v2.HostAuthorizationResponse
+	25,  // 191: pluginapi.v2.HostService.ReportUsage:output_type -> pluginapi.v2.ConfigResponse
+	125, // [125:192] is the sub-list for method output_type
+	58,  // [58:125] is the sub-list for method input_type
+	58,  // [58:58] is the sub-list for extension type_name
+	58,  // [58:58] is the sub-list for extension extendee
+	0,   // [0:58] is the sub-list for field type_name
+}
+
+func init() { file_pluginapi_rpc_v2_tool_proto_init() }
+func file_pluginapi_rpc_v2_tool_proto_init() {
+	if File_pluginapi_rpc_v2_tool_proto != nil {
+		return
+	}
+	file_pluginapi_rpc_v2_tool_proto_msgTypes[41].OneofWrappers = []any{
+		(*FileUploadChunk_Call)(nil),
+		(*FileUploadChunk_File)(nil),
+		(*FileUploadChunk_Data)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pluginapi_rpc_v2_tool_proto_rawDesc), len(file_pluginapi_rpc_v2_tool_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   111,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_pluginapi_rpc_v2_tool_proto_goTypes,
+		DependencyIndexes: file_pluginapi_rpc_v2_tool_proto_depIdxs,
+		MessageInfos:      file_pluginapi_rpc_v2_tool_proto_msgTypes,
+	}.Build()
+	File_pluginapi_rpc_v2_tool_proto = out.File
+	file_pluginapi_rpc_v2_tool_proto_goTypes = nil
+	file_pluginapi_rpc_v2_tool_proto_depIdxs = nil
+}
@@ -0,0 +1,2858 @@
+// Version 2 of the plugin wire protocol.
+//
+// Every message keeps the field numbers of the unversioned v1 protocol
+// (proto/tool.proto), so v1 and v2 messages can be converted by re-encoding.
+// Changes that would break compiled plugins belong in a new version, not here.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.33.0
+// source: pluginapi/rpc/v2/tool.proto
+
+package rpcv2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ToolService_GetDefinition_FullMethodName           = "/pluginapi.v2.ToolService/GetDefinition"
+	ToolService_GetTools_FullMethodName                = "/pluginapi.v2.ToolService/GetTools"
+	ToolService_Call_FullMethodName                    = "/pluginapi.v2.ToolService/Call"
+	ToolService_CallStream_FullMethodName              = "/pluginapi.v2.ToolService/CallStream"
+	ToolService_CancelCall_FullMethodName              = "/pluginapi.v2.ToolService/CancelCall"
+	ToolService_StartTask_FullMethodName               = "/pluginapi.v2.ToolService/StartTask"
+	ToolService_GetTaskStatus_FullMethodName           = "/pluginapi.v2.ToolService/GetTaskStatus"
+	ToolService_CancelTask_FullMethodName              = "/pluginapi.v2.ToolService/CancelTask"
+	ToolService_GetVersion_FullMethodName              = "/pluginapi.v2.ToolService/GetVersion"
+	ToolService_SetAgentContext_FullMethodName         = "/pluginapi.v2.ToolService/SetAgentContext"
+	ToolService_UpdateAgentContext_FullMethodName      = "/pluginapi.v2.ToolService/UpdateAgentContext"
+	ToolService_GetDefaultSettings_FullMethodName      = "/pluginapi.v2.ToolService/GetDefaultSettings"
+	ToolService_GetRequiredConfig_FullMethodName       = "/pluginapi.v2.ToolService/GetRequiredConfig"
+	ToolService_ValidateConfig_FullMethodName          = "/pluginapi.v2.ToolService/ValidateConfig"
+	ToolService_InitializeWithConfig_FullMethodName    = "/pluginapi.v2.ToolService/InitializeWithConfig"
+	ToolService_NotifyConfigChanged_FullMethodName     = "/pluginapi.v2.ToolService/NotifyConfigChanged"
+	ToolService_TestConfig_FullMethodName              = "/pluginapi.v2.ToolService/TestConfig"
+	ToolService_GetMetadata_FullMethodName             = "/pluginapi.v2.ToolService/GetMetadata"
+	ToolService_GetCompatibilityInfo_FullMethodName    = "/pluginapi.v2.ToolService/GetCompatibilityInfo"
+	ToolService_GetWebPages_FullMethodName             = "/pluginapi.v2.ToolService/GetWebPages"
+	ToolService_ServeWebPage_FullMethodName            = "/pluginapi.v2.ToolService/ServeWebPage"
+	ToolService_GetWebPageInfo_FullMethodName          = "/pluginapi.v2.ToolService/GetWebPageInfo"
+	ToolService_AcceptsFiles_FullMethodName            = "/pluginapi.v2.ToolService/AcceptsFiles"
+	ToolService_CallWithFiles_FullMethodName           = "/pluginapi.v2.ToolService/CallWithFiles"
+	ToolService_CallWithFilesStream_FullMethodName     = "/pluginapi.v2.ToolService/CallWithFilesStream"
+	ToolService_GetOperations_FullMethodName           = "/pluginapi.v2.ToolService/GetOperations"
+	ToolService_SnapshotState_FullMethodName           = "/pluginapi.v2.ToolService/SnapshotState"
+	ToolService_RestoreState_FullMethodName            = "/pluginapi.v2.ToolService/RestoreState"
+	ToolService_ExportState_FullMethodName             = "/pluginapi.v2.ToolService/ExportState"
+	ToolService_ImportState_FullMethodName             = "/pluginapi.v2.ToolService/ImportState"
+	ToolService_PrepareHandoff_FullMethodName          = "/pluginapi.v2.ToolService/PrepareHandoff"
+	ToolService_ReceiveHandoff_FullMethodName          = "/pluginapi.v2.ToolService/ReceiveHandoff"
+	ToolService_GetSystemPromptFragment_FullMethodName = "/pluginapi.v2.ToolService/GetSystemPromptFragment"
+	ToolService_ContributePrompt_FullMethodName        = "/pluginapi.v2.ToolService/ContributePrompt"
+	ToolService_EnrichContext_FullMethodName           = "/pluginapi.v2.ToolService/EnrichContext"
+	ToolService_Embed_FullMethodName                   = "/pluginapi.v2.ToolService/Embed"
+	ToolService_GetFileWatches_FullMethodName          = "/pluginapi.v2.ToolService/GetFileWatches"
+	ToolService_WatchFileChanges_FullMethodName        = "/pluginapi.v2.ToolService/WatchFileChanges"
+	ToolService_GetScheduledTasks_FullMethodName       = "/pluginapi.v2.ToolService/GetScheduledTasks"
+	ToolService_ExecuteScheduledTask_FullMethodName    = "/pluginapi.v2.ToolService/ExecuteScheduledTask"
+	ToolService_HealthCheck_FullMethodName             = "/pluginapi.v2.ToolService/HealthCheck"
+	ToolService_GetRequiredPermissions_FullMethodName  = "/pluginapi.v2.ToolService/GetRequiredPermissions"
+	ToolService_GetCategory_FullMethodName             = "/pluginapi.v2.ToolService/GetCategory"
+	ToolService_GetRateLimits_FullMethodName           = "/pluginapi.v2.ToolService/GetRateLimits"
+	ToolService_Shutdown_FullMethodName                = "/pluginapi.v2.ToolService/Shutdown"
+	ToolService_Initialize_FullMethodName              = "/pluginapi.v2.ToolService/Initialize"
+	ToolService_SetHostServices_FullMethodName         = "/pluginapi.v2.ToolService/SetHostServices"
+	ToolService_Negotiate_FullMethodName               = "/pluginapi.v2.ToolService/Negotiate"
+	ToolService_GetCapabilities_FullMethodName         = "/pluginapi.v2.ToolService/GetCapabilities"
+	ToolService_GetStats_FullMethodName                = "/pluginapi.v2.ToolService/GetStats"
+	ToolService_SubscribeLogs_FullMethodName           = "/pluginapi.v2.ToolService/SubscribeLogs"
+)
+
+// ToolServiceClient is the client API for ToolService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ToolService defines the RPC service for plugin tools
+type ToolServiceClient interface {
+	// GetDefinition returns the generic, provider-agnostic tool definition
+	GetDefinition(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ToolDefinition, error)
+	// GetTools returns the definitions of every tool the plugin serves (optional)
+	GetTools(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ToolSetResponse, error)
+	// Call executes the tool with the given arguments
+	Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error)
+	// CallStream executes the tool, streaming progress and partial results before the final chunk
+	CallStream(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CallStreamChunk], error)
+	// CancelCall cancels the context of a running Call, CallWithFiles, or CallStream by call ID
+	CancelCall(ctx context.Context, in *CancelCallRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// StartTask starts a long-running task in the background and returns its ID (optional)
+	StartTask(ctx context.Context, in *StartTaskRequest, opts ...grpc.CallOption) (*StartTaskResponse, error)
+	// GetTaskStatus reports the progress or outcome of a background task (optional)
+	GetTaskStatus(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*TaskStatusResponse, error)
+	// CancelTask cancels a running background task (optional)
+	CancelTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// GetVersion returns the plugin version (optional)
+	GetVersion(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VersionResponse, error)
+	// SetAgentContext provides agent information to the plugin (optional)
+	SetAgentContext(ctx context.Context, in *AgentContextRequest, opts ...grpc.CallOption) (*Empty, error)
+	// UpdateAgentContext delivers a change to the agent information during the plugin's lifetime (optional)
+	UpdateAgentContext(ctx context.Context, in *AgentContextRequest, opts ...grpc.CallOption) (*Empty, error)
+	// GetDefaultSettings returns default settings as JSON (optional)
+	GetDefaultSettings(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SettingsResponse, error)
+	// InitializationProvider methods
+	// GetRequiredConfig returns configuration variables needed for initialization
+	GetRequiredConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ConfigVariablesResponse, error)
+	// ValidateConfig validates the provided configuration
+	ValidateConfig(ctx context.Context, in *ValidateConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// InitializeWithConfig initializes the plugin with the provided configuration
+	InitializeWithConfig(ctx context.Context, in *InitializeConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// NotifyConfigChanged tells the plugin the user edited its configuration (optional)
+	NotifyConfigChanged(ctx context.Context, in *ConfigChangedRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// TestConfig checks a configuration against the real service without saving it (optional)
+	TestConfig(ctx context.Context, in *ValidateConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// GetMetadata returns plugin metadata (optional)
+	GetMetadata(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MetadataResponse, error)
+	// GetCompatibilityInfo returns plugin compatibility information (optional)
+	GetCompatibilityInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CompatibilityInfoResponse, error)
+	// WebPageProvider methods
+	// GetWebPages returns a list of available web pages this plugin provides
+	GetWebPages(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WebPagesResponse, error)
+	// ServeWebPage handles a web page request and returns HTML/JSON content
+	ServeWebPage(ctx context.Context, in *WebPageRequest, opts ...grpc.CallOption) (*WebPageResponse, error)
+	// GetWebPageInfo returns menu metadata for each web page
+	GetWebPageInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WebPageInfoResponse, error)
+	// File attachment support
+	// AcceptsFiles returns the list of file types this plugin accepts
+	AcceptsFiles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AcceptsFilesResponse, error)
+	// CallWithFiles executes the tool with arguments and file attachments
+	CallWithFiles(ctx context.Context, in *CallWithFilesRequest, opts ...grpc.CallOption) (*CallResponse, error)
+	// CallWithFilesStream is CallWithFiles with the attachments uploaded in chunks, for files too large for one message
+	CallWithFilesStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileUploadChunk, CallResponse], error)
+	// GetOperations returns operation-specific parameter information
+	GetOperations(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OperationsResponse, error)
+	// State snapshot support
+	// SnapshotState returns an opaque serialization of the plugin's state (optional)
+	SnapshotState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StateSnapshotResponse, error)
+	// RestoreState replaces the plugin's state with a previous snapshot (optional)
+	RestoreState(ctx context.Context, in *RestoreStateRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// State export support
+	// ExportState returns the plugin's settings and private data for migration (optional)
+	ExportState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StateSnapshotResponse, error)
+	// ImportState replaces the plugin's settings and private data with an export (optional)
+	ImportState(ctx context.Context, in *RestoreStateRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// Upgrade handoff support
+	// PrepareHandoff asks the outgoing plugin version to serialize transferable state (optional)
+	PrepareHandoff(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HandoffResponse, error)
+	// ReceiveHandoff passes handoff state to the incoming plugin version (optional)
+	ReceiveHandoff(ctx context.Context, in *HandoffRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// GetSystemPromptFragment returns text the agent adds to its system prompt (optional)
+	GetSystemPromptFragment(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SystemPromptResponse, error)
+	// ContributePrompt returns the system prompt fragment for one conversation (optional)
+	ContributePrompt(ctx context.Context, in *PromptContributionRequest, opts ...grpc.CallOption) (*PromptContributionResponse, error)
+	// EnrichContext returns structured context the agent attaches to the next LLM turn (optional)
+	EnrichContext(ctx context.Context, in *EnrichContextRequest, opts ...grpc.CallOption) (*EnrichContextResponse, error)
+	// Embed returns one embedding vector per input text (optional)
+	Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error)
+	// File watch support
+	// GetFileWatches returns the directories the plugin wants watched (optional)
+	GetFileWatches(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FileWatchesResponse, error)
+	// WatchFileChanges streams batches of file change events to the plugin, one ack per batch (optional)
+	WatchFileChanges(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[FileChangesRequest, ConfigResponse], error)
+	// GetScheduledTasks returns the periodic jobs the host should run (optional)
+	GetScheduledTasks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ScheduledTasksResponse, error)
+	// ExecuteScheduledTask runs a scheduled task that is due (optional)
+	ExecuteScheduledTask(ctx context.Context, in *ExecuteScheduledTaskRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// HealthCheck runs the plugin's own health check (optional)
+	HealthCheck(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HealthCheckResponse, error)
+	// GetRequiredPermissions returns the system permissions the plugin requires (optional)
+	GetRequiredPermissions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PermissionsResponse, error)
+	// GetCategory returns the plugin's category for organizing plugins in the UI (optional)
+	GetCategory(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CategoryResponse, error)
+	// GetRateLimits returns the rate limits the host enforces on calls to the plugin (optional)
+	GetRateLimits(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RateLimitsResponse, error)
+	// Shutdown asks the plugin to release its resources before the host stops it (optional)
+	Shutdown(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// Initialize prepares the plugin after launch and SetAgentContext (optional)
+	Initialize(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*InitializeResponse, error)
+	// SetHostServices tells the plugin where to reach the agent's HostService
+	SetHostServices(ctx context.Context, in *HostServicesRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// Negotiate agrees on the highest common API version and shared features
+	Negotiate(ctx context.Context, in *NegotiateRequest, opts ...grpc.CallOption) (*NegotiateResponse, error)
+	// GetCapabilities answers all the discovery RPCs above in one round trip
+	GetCapabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
+	// GetStats reports per-RPC metrics since the plugin started
+	GetStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatsResponse, error)
+	// SubscribeLogs streams the plugin's log entries, starting with recent ones
+	SubscribeLogs(ctx context.Context, in *LogSubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProtoLogEntry], error)
+}
+
+type toolServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewToolServiceClient(cc grpc.ClientConnInterface) ToolServiceClient {
+	return &toolServiceClient{cc}
+}
+
+func (c *toolServiceClient) GetDefinition(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ToolDefinition, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ToolDefinition)
+	err := c.cc.Invoke(ctx, ToolService_GetDefinition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetTools(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ToolSetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ToolSetResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetTools_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) Call(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CallResponse)
+	err := c.cc.Invoke(ctx, ToolService_Call_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) CallStream(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CallStreamChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ToolService_ServiceDesc.Streams[0], ToolService_CallStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CallRequest, CallStreamChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolService_CallStreamClient = grpc.ServerStreamingClient[CallStreamChunk]
+
+func (c *toolServiceClient) CancelCall(ctx context.Context, in *CancelCallRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_CancelCall_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) StartTask(ctx context.Context, in *StartTaskRequest, opts ...grpc.CallOption) (*StartTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartTaskResponse)
+	err := c.cc.Invoke(ctx, ToolService_StartTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetTaskStatus(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*TaskStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskStatusResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetTaskStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) CancelTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_CancelTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetVersion(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) SetAgentContext(ctx context.Context, in *AgentContextRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ToolService_SetAgentContext_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) UpdateAgentContext(ctx context.Context, in *AgentContextRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ToolService_UpdateAgentContext_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetDefaultSettings(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SettingsResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetDefaultSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetRequiredConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ConfigVariablesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigVariablesResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetRequiredConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) ValidateConfig(ctx context.Context, in *ValidateConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_ValidateConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) InitializeWithConfig(ctx context.Context, in *InitializeConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_InitializeWithConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) NotifyConfigChanged(ctx context.Context, in *ConfigChangedRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_NotifyConfigChanged_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) TestConfig(ctx context.Context, in *ValidateConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_TestConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetMetadata(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MetadataResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetCompatibilityInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CompatibilityInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompatibilityInfoResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetCompatibilityInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetWebPages(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WebPagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebPagesResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetWebPages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) ServeWebPage(ctx context.Context, in *WebPageRequest, opts ...grpc.CallOption) (*WebPageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebPageResponse)
+	err := c.cc.Invoke(ctx, ToolService_ServeWebPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetWebPageInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WebPageInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebPageInfoResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetWebPageInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) AcceptsFiles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AcceptsFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptsFilesResponse)
+	err := c.cc.Invoke(ctx, ToolService_AcceptsFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) CallWithFiles(ctx context.Context, in *CallWithFilesRequest, opts ...grpc.CallOption) (*CallResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CallResponse)
+	err := c.cc.Invoke(ctx, ToolService_CallWithFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) CallWithFilesStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileUploadChunk, CallResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ToolService_ServiceDesc.Streams[1], ToolService_CallWithFilesStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileUploadChunk, CallResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolService_CallWithFilesStreamClient = grpc.ClientStreamingClient[FileUploadChunk, CallResponse]
+
+func (c *toolServiceClient) GetOperations(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OperationsResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) SnapshotState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StateSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StateSnapshotResponse)
+	err := c.cc.Invoke(ctx, ToolService_SnapshotState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) RestoreState(ctx context.Context, in *RestoreStateRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_RestoreState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) ExportState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StateSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StateSnapshotResponse)
+	err := c.cc.Invoke(ctx, ToolService_ExportState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) ImportState(ctx context.Context, in *RestoreStateRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_ImportState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) PrepareHandoff(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HandoffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HandoffResponse)
+	err := c.cc.Invoke(ctx, ToolService_PrepareHandoff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) ReceiveHandoff(ctx context.Context, in *HandoffRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_ReceiveHandoff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetSystemPromptFragment(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SystemPromptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemPromptResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetSystemPromptFragment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) ContributePrompt(ctx context.Context, in *PromptContributionRequest, opts ...grpc.CallOption) (*PromptContributionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptContributionResponse)
+	err := c.cc.Invoke(ctx, ToolService_ContributePrompt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) EnrichContext(ctx context.Context, in *EnrichContextRequest, opts ...grpc.CallOption) (*EnrichContextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrichContextResponse)
+	err := c.cc.Invoke(ctx, ToolService_EnrichContext_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmbedResponse)
+	err := c.cc.Invoke(ctx, ToolService_Embed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetFileWatches(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FileWatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileWatchesResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetFileWatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) WatchFileChanges(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[FileChangesRequest, ConfigResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ToolService_ServiceDesc.Streams[2], ToolService_WatchFileChanges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileChangesRequest, ConfigResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolService_WatchFileChangesClient = grpc.BidiStreamingClient[FileChangesRequest, ConfigResponse]
+
+func (c *toolServiceClient) GetScheduledTasks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ScheduledTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledTasksResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetScheduledTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) ExecuteScheduledTask(ctx context.Context, in *ExecuteScheduledTaskRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_ExecuteScheduledTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) HealthCheck(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthCheckResponse)
+	err := c.cc.Invoke(ctx, ToolService_HealthCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetRequiredPermissions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PermissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PermissionsResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetRequiredPermissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetCategory(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategoryResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetRateLimits(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RateLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateLimitsResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetRateLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) Shutdown(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_Shutdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) Initialize(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*InitializeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InitializeResponse)
+	err := c.cc.Invoke(ctx, ToolService_Initialize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) SetHostServices(ctx context.Context, in *HostServicesRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ToolService_SetHostServices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) Negotiate(ctx context.Context, in *NegotiateRequest, opts ...grpc.CallOption) (*NegotiateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NegotiateResponse)
+	err := c.cc.Invoke(ctx, ToolService_Negotiate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetCapabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CapabilitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CapabilitiesResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) GetStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, ToolService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServiceClient) SubscribeLogs(ctx context.Context, in *LogSubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProtoLogEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ToolService_ServiceDesc.Streams[3], ToolService_SubscribeLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LogSubscribeRequest, ProtoLogEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolService_SubscribeLogsClient = grpc.ServerStreamingClient[ProtoLogEntry]
+
+// ToolServiceServer is the server API for ToolService service.
+// All implementations must embed UnimplementedToolServiceServer
+// for forward compatibility.
+//
+// ToolService defines the RPC service for plugin tools
+type ToolServiceServer interface {
+	// GetDefinition returns the generic, provider-agnostic tool definition
+	GetDefinition(context.Context, *Empty) (*ToolDefinition, error)
+	// GetTools returns the definitions of every tool the plugin serves (optional)
+	GetTools(context.Context, *Empty) (*ToolSetResponse, error)
+	// Call executes the tool with the given arguments
+	Call(context.Context, *CallRequest) (*CallResponse, error)
+	// CallStream executes the tool, streaming progress and partial results before the final chunk
+	CallStream(*CallRequest, grpc.ServerStreamingServer[CallStreamChunk]) error
+	// CancelCall cancels the context of a running Call, CallWithFiles, or CallStream by call ID
+	CancelCall(context.Context, *CancelCallRequest) (*ConfigResponse, error)
+	// StartTask starts a long-running task in the background and returns its ID (optional)
+	StartTask(context.Context, *StartTaskRequest) (*StartTaskResponse, error)
+	// GetTaskStatus reports the progress or outcome of a background task (optional)
+	GetTaskStatus(context.Context, *TaskRequest) (*TaskStatusResponse, error)
+	// CancelTask cancels a running background task (optional)
+	CancelTask(context.Context, *TaskRequest) (*ConfigResponse, error)
+	// GetVersion returns the plugin version (optional)
+	GetVersion(context.Context, *Empty) (*VersionResponse, error)
+	// SetAgentContext provides agent information to the plugin (optional)
+	SetAgentContext(context.Context, *AgentContextRequest) (*Empty, error)
+	// UpdateAgentContext delivers a change to the agent information during the plugin's lifetime (optional)
+	UpdateAgentContext(context.Context, *AgentContextRequest) (*Empty, error)
+	// GetDefaultSettings returns default settings as JSON (optional)
+	GetDefaultSettings(context.Context, *Empty) (*SettingsResponse, error)
+	// InitializationProvider methods
+	// GetRequiredConfig returns configuration variables needed for initialization
+	GetRequiredConfig(context.Context, *Empty) (*ConfigVariablesResponse, error)
+	// ValidateConfig validates the provided configuration
+	ValidateConfig(context.Context, *ValidateConfigRequest) (*ConfigResponse, error)
+	// InitializeWithConfig initializes the plugin with the provided configuration
+	InitializeWithConfig(context.Context, *InitializeConfigRequest) (*ConfigResponse, error)
+	// NotifyConfigChanged tells the plugin the user edited its configuration (optional)
+	NotifyConfigChanged(context.Context, *ConfigChangedRequest) (*ConfigResponse, error)
+	// TestConfig checks a configuration against the real service without saving it (optional)
+	TestConfig(context.Context, *ValidateConfigRequest) (*ConfigResponse, error)
+	// GetMetadata returns plugin metadata (optional)
+	GetMetadata(context.Context, *Empty) (*MetadataResponse, error)
+	// GetCompatibilityInfo returns plugin compatibility information (optional)
+	GetCompatibilityInfo(context.Context, *Empty) (*CompatibilityInfoResponse, error)
+	// WebPageProvider methods
+	// GetWebPages returns a list of available web pages this plugin provides
+	GetWebPages(context.Context, *Empty) (*WebPagesResponse, error)
+	// ServeWebPage handles a web page request and returns HTML/JSON content
+	ServeWebPage(context.Context, *WebPageRequest) (*WebPageResponse, error)
+	// GetWebPageInfo returns menu metadata for each web page
+	GetWebPageInfo(context.Context, *Empty) (*WebPageInfoResponse, error)
+	// File attachment support
+	// AcceptsFiles returns the list of file types this plugin accepts
+	AcceptsFiles(context.Context, *Empty) (*AcceptsFilesResponse, error)
+	// CallWithFiles executes the tool with arguments and file attachments
+	CallWithFiles(context.Context, *CallWithFilesRequest) (*CallResponse, error)
+	// CallWithFilesStream is CallWithFiles with the attachments uploaded in chunks, for files too large for one message
+	CallWithFilesStream(grpc.ClientStreamingServer[FileUploadChunk, CallResponse]) error
+	// GetOperations returns operation-specific parameter information
+	GetOperations(context.Context, *Empty) (*OperationsResponse, error)
+	// State snapshot support
+	// SnapshotState returns an opaque serialization of the plugin's state (optional)
+	SnapshotState(context.Context, *Empty) (*StateSnapshotResponse, error)
+	// RestoreState replaces the plugin's state with a previous snapshot (optional)
+	RestoreState(context.Context, *RestoreStateRequest) (*ConfigResponse, error)
+	// State export support
+	// ExportState returns the plugin's settings and private data for migration (optional)
+	ExportState(context.Context, *Empty) (*StateSnapshotResponse, error)
+	// ImportState replaces the plugin's settings and private data with an export (optional)
+	ImportState(context.Context, *RestoreStateRequest) (*ConfigResponse, error)
+	// Upgrade handoff support
+	// PrepareHandoff asks the outgoing plugin version to serialize transferable state (optional)
+	PrepareHandoff(context.Context, *Empty) (*HandoffResponse, error)
+	// ReceiveHandoff passes handoff state to the incoming plugin version (optional)
+	ReceiveHandoff(context.Context, *HandoffRequest) (*ConfigResponse, error)
+	// GetSystemPromptFragment returns text the agent adds to its system prompt (optional)
+	GetSystemPromptFragment(context.Context, *Empty) (*SystemPromptResponse, error)
+	// ContributePrompt returns the system prompt fragment for one conversation (optional)
+	ContributePrompt(context.Context, *PromptContributionRequest) (*PromptContributionResponse, error)
+	// EnrichContext returns structured context the agent attaches to the next LLM turn (optional)
+	EnrichContext(context.Context, *EnrichContextRequest) (*EnrichContextResponse, error)
+	// Embed returns one embedding vector per input text (optional)
+	Embed(context.Context, *EmbedRequest) (*EmbedResponse, error)
+	// File watch support
+	// GetFileWatches returns the directories the plugin wants watched (optional)
+	GetFileWatches(context.Context, *Empty) (*FileWatchesResponse, error)
+	// WatchFileChanges streams batches of file change events to the plugin, one ack per batch (optional)
+	WatchFileChanges(grpc.BidiStreamingServer[FileChangesRequest, ConfigResponse]) error
+	// GetScheduledTasks returns the periodic jobs the host should run (optional)
+	GetScheduledTasks(context.Context, *Empty) (*ScheduledTasksResponse, error)
+	// ExecuteScheduledTask runs a scheduled task that is due (optional)
+	ExecuteScheduledTask(context.Context, *ExecuteScheduledTaskRequest) (*ConfigResponse, error)
+	// HealthCheck runs the plugin's own health check (optional)
+	HealthCheck(context.Context, *Empty) (*HealthCheckResponse, error)
+	// GetRequiredPermissions returns the system permissions the plugin requires (optional)
+	GetRequiredPermissions(context.Context, *Empty) (*PermissionsResponse, error)
+	// GetCategory returns the plugin's category for organizing plugins in the UI (optional)
+	GetCategory(context.Context, *Empty) (*CategoryResponse, error)
+	// GetRateLimits returns the rate limits the host enforces on calls to the plugin (optional)
+	GetRateLimits(context.Context, *Empty) (*RateLimitsResponse, error)
+	// Shutdown asks the plugin to release its resources before the host stops it (optional)
+	Shutdown(context.Context, *Empty) (*ConfigResponse, error)
+	// Initialize prepares the plugin after launch and SetAgentContext (optional)
+	Initialize(context.Context, *Empty) (*InitializeResponse, error)
+	// SetHostServices tells the plugin where to reach the agent's HostService
+	SetHostServices(context.Context, *HostServicesRequest) (*ConfigResponse, error)
+	// Negotiate agrees on the highest common API version and shared features
+	Negotiate(context.Context, *NegotiateRequest) (*NegotiateResponse, error)
+	// GetCapabilities answers all the discovery RPCs above in one round trip
+	GetCapabilities(context.Context, *Empty) (*CapabilitiesResponse, error)
+	// GetStats reports per-RPC metrics since the plugin started
+	GetStats(context.Context, *Empty) (*StatsResponse, error)
+	// SubscribeLogs streams the plugin's log entries, starting with recent ones
+	SubscribeLogs(*LogSubscribeRequest, grpc.ServerStreamingServer[ProtoLogEntry]) error
+	mustEmbedUnimplementedToolServiceServer()
+}
+
+// UnimplementedToolServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedToolServiceServer struct{}
+
+func (UnimplementedToolServiceServer) GetDefinition(context.Context, *Empty) (*ToolDefinition, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDefinition not implemented")
+}
+func (UnimplementedToolServiceServer) GetTools(context.Context, *Empty) (*ToolSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTools not implemented")
+}
+func (UnimplementedToolServiceServer) Call(context.Context, *CallRequest) (*CallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Call not implemented")
+}
+func (UnimplementedToolServiceServer) CallStream(*CallRequest, grpc.ServerStreamingServer[CallStreamChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method CallStream not implemented")
+}
+func (UnimplementedToolServiceServer) CancelCall(context.Context, *CancelCallRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCall not implemented")
+}
+func (UnimplementedToolServiceServer) StartTask(context.Context, *StartTaskRequest) (*StartTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTask not implemented")
+}
+func (UnimplementedToolServiceServer) GetTaskStatus(context.Context, *TaskRequest) (*TaskStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskStatus not implemented")
+}
+func (UnimplementedToolServiceServer) CancelTask(context.Context, *TaskRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTask not implemented")
+}
+func (UnimplementedToolServiceServer) GetVersion(context.Context, *Empty) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (UnimplementedToolServiceServer) SetAgentContext(context.Context, *AgentContextRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAgentContext not implemented")
+}
+func (UnimplementedToolServiceServer) UpdateAgentContext(context.Context, *AgentContextRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAgentContext not implemented")
+}
+func (UnimplementedToolServiceServer) GetDefaultSettings(context.Context, *Empty) (*SettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDefaultSettings not implemented")
+}
+func (UnimplementedToolServiceServer) GetRequiredConfig(context.Context, *Empty) (*ConfigVariablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRequiredConfig not implemented")
+}
+func (UnimplementedToolServiceServer) ValidateConfig(context.Context, *ValidateConfigRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateConfig not implemented")
+}
+func (UnimplementedToolServiceServer) InitializeWithConfig(context.Context, *InitializeConfigRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitializeWithConfig not implemented")
+}
+func (UnimplementedToolServiceServer) NotifyConfigChanged(context.Context, *ConfigChangedRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NotifyConfigChanged not implemented")
+}
+func (UnimplementedToolServiceServer) TestConfig(context.Context, *ValidateConfigRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestConfig not implemented")
+}
+func (UnimplementedToolServiceServer) GetMetadata(context.Context, *Empty) (*MetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetadata not implemented")
+}
+func (UnimplementedToolServiceServer) GetCompatibilityInfo(context.Context, *Empty) (*CompatibilityInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompatibilityInfo not implemented")
+}
+func (UnimplementedToolServiceServer) GetWebPages(context.Context, *Empty) (*WebPagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebPages not implemented")
+}
+func (UnimplementedToolServiceServer) ServeWebPage(context.Context, *WebPageRequest) (*WebPageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServeWebPage not implemented")
+}
+func (UnimplementedToolServiceServer) GetWebPageInfo(context.Context, *Empty) (*WebPageInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebPageInfo not implemented")
+}
+func (UnimplementedToolServiceServer) AcceptsFiles(context.Context, *Empty) (*AcceptsFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptsFiles not implemented")
+}
+func (UnimplementedToolServiceServer) CallWithFiles(context.Context, *CallWithFilesRequest) (*CallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CallWithFiles not implemented")
+}
+func (UnimplementedToolServiceServer) CallWithFilesStream(grpc.ClientStreamingServer[FileUploadChunk, CallResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CallWithFilesStream not implemented")
+}
+func (UnimplementedToolServiceServer) GetOperations(context.Context, *Empty) (*OperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperations not implemented")
+}
+func (UnimplementedToolServiceServer) SnapshotState(context.Context, *Empty) (*StateSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotState not implemented")
+}
+func (UnimplementedToolServiceServer) RestoreState(context.Context, *RestoreStateRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreState not implemented")
+}
+func (UnimplementedToolServiceServer) ExportState(context.Context, *Empty) (*StateSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportState not implemented")
+}
+func (UnimplementedToolServiceServer) ImportState(context.Context, *RestoreStateRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportState not implemented")
+}
+func (UnimplementedToolServiceServer) PrepareHandoff(context.Context, *Empty) (*HandoffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareHandoff not implemented")
+}
+func (UnimplementedToolServiceServer) ReceiveHandoff(context.Context, *HandoffRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveHandoff not implemented")
+}
+func (UnimplementedToolServiceServer) GetSystemPromptFragment(context.Context, *Empty) (*SystemPromptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSystemPromptFragment not implemented")
+}
+func (UnimplementedToolServiceServer) ContributePrompt(context.Context, *PromptContributionRequest) (*PromptContributionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ContributePrompt not implemented")
+}
+func (UnimplementedToolServiceServer) EnrichContext(context.Context, *EnrichContextRequest) (*EnrichContextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrichContext not implemented")
+}
+func (UnimplementedToolServiceServer) Embed(context.Context, *EmbedRequest) (*EmbedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Embed not implemented")
+}
+func (UnimplementedToolServiceServer) GetFileWatches(context.Context, *Empty) (*FileWatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileWatches not implemented")
+}
+func (UnimplementedToolServiceServer) WatchFileChanges(grpc.BidiStreamingServer[FileChangesRequest, ConfigResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchFileChanges not implemented")
+}
+func (UnimplementedToolServiceServer) GetScheduledTasks(context.Context, *Empty) (*ScheduledTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduledTasks not implemented")
+}
+func (UnimplementedToolServiceServer) ExecuteScheduledTask(context.Context, *ExecuteScheduledTaskRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteScheduledTask not implemented")
+}
+func (UnimplementedToolServiceServer) HealthCheck(context.Context, *Empty) (*HealthCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
+}
+func (UnimplementedToolServiceServer) GetRequiredPermissions(context.Context, *Empty) (*PermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRequiredPermissions not implemented")
+}
+func (UnimplementedToolServiceServer) GetCategory(context.Context, *Empty) (*CategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategory not implemented")
+}
+func (UnimplementedToolServiceServer) GetRateLimits(context.Context, *Empty) (*RateLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateLimits not implemented")
+}
+func (UnimplementedToolServiceServer) Shutdown(context.Context, *Empty) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedToolServiceServer) Initialize(context.Context, *Empty) (*InitializeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Initialize not implemented")
+}
+func (UnimplementedToolServiceServer) SetHostServices(context.Context, *HostServicesRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHostServices not implemented")
+}
+func (UnimplementedToolServiceServer) Negotiate(context.Context, *NegotiateRequest) (*NegotiateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Negotiate not implemented")
+}
+func (UnimplementedToolServiceServer) GetCapabilities(context.Context, *Empty) (*CapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedToolServiceServer) GetStats(context.Context, *Empty) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedToolServiceServer) SubscribeLogs(*LogSubscribeRequest, grpc.ServerStreamingServer[ProtoLogEntry]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeLogs not implemented")
+}
+func (UnimplementedToolServiceServer) mustEmbedUnimplementedToolServiceServer() {}
+func (UnimplementedToolServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeToolServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ToolServiceServer will
+// result in compilation errors.
+type UnsafeToolServiceServer interface {
+	mustEmbedUnimplementedToolServiceServer()
+}
+
+func RegisterToolServiceServer(s grpc.ServiceRegistrar, srv ToolServiceServer) {
+	// If the following call pancis, it indicates UnimplementedToolServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ToolService_ServiceDesc, srv)
+}
+
+func _ToolService_GetDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetDefinition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetDefinition(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetTools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetTools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetTools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetTools(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_Call_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).Call(ctx, req.(*CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_CallStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CallRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ToolServiceServer).CallStream(m, &grpc.GenericServerStream[CallRequest, CallStreamChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolService_CallStreamServer = grpc.ServerStreamingServer[CallStreamChunk]
+
+func _ToolService_CancelCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).CancelCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_CancelCall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).CancelCall(ctx, req.(*CancelCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_StartTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).StartTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_StartTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).StartTask(ctx, req.(*StartTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetTaskStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetTaskStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetTaskStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetTaskStatus(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_CancelTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).CancelTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_CancelTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).CancelTask(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetVersion(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_SetAgentContext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentContextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).SetAgentContext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_SetAgentContext_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).SetAgentContext(ctx, req.(*AgentContextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_UpdateAgentContext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentContextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).UpdateAgentContext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_UpdateAgentContext_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).UpdateAgentContext(ctx, req.(*AgentContextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetDefaultSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetDefaultSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetDefaultSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetDefaultSettings(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetRequiredConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetRequiredConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetRequiredConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetRequiredConfig(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_ValidateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).ValidateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_ValidateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).ValidateConfig(ctx, req.(*ValidateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_InitializeWithConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitializeConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).InitializeWithConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_InitializeWithConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).InitializeWithConfig(ctx, req.(*InitializeConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_NotifyConfigChanged_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigChangedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).NotifyConfigChanged(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_NotifyConfigChanged_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).NotifyConfigChanged(ctx, req.(*ConfigChangedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_TestConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).TestConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_TestConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).TestConfig(ctx, req.(*ValidateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetMetadata(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetCompatibilityInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetCompatibilityInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetCompatibilityInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetCompatibilityInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetWebPages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetWebPages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetWebPages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetWebPages(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_ServeWebPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).ServeWebPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_ServeWebPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).ServeWebPage(ctx, req.(*WebPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetWebPageInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetWebPageInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetWebPageInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetWebPageInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_AcceptsFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).AcceptsFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_AcceptsFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).AcceptsFiles(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_CallWithFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallWithFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).CallWithFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_CallWithFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).CallWithFiles(ctx, req.(*CallWithFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_CallWithFilesStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ToolServiceServer).CallWithFilesStream(&grpc.GenericServerStream[FileUploadChunk, CallResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolService_CallWithFilesStreamServer = grpc.ClientStreamingServer[FileUploadChunk, CallResponse]
+
+func _ToolService_GetOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetOperations(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_SnapshotState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).SnapshotState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_SnapshotState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).SnapshotState(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_RestoreState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).RestoreState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_RestoreState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).RestoreState(ctx, req.(*RestoreStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_ExportState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).ExportState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_ExportState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).ExportState(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_ImportState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).ImportState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_ImportState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).ImportState(ctx, req.(*RestoreStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_PrepareHandoff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).PrepareHandoff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_PrepareHandoff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).PrepareHandoff(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_ReceiveHandoff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandoffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).ReceiveHandoff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_ReceiveHandoff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).ReceiveHandoff(ctx, req.(*HandoffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetSystemPromptFragment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetSystemPromptFragment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetSystemPromptFragment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetSystemPromptFragment(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_ContributePrompt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptContributionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).ContributePrompt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_ContributePrompt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).ContributePrompt(ctx, req.(*PromptContributionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_EnrichContext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrichContextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).EnrichContext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_EnrichContext_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).EnrichContext(ctx, req.(*EnrichContextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_Embed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).Embed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_Embed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).Embed(ctx, req.(*EmbedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetFileWatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetFileWatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetFileWatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetFileWatches(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_WatchFileChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ToolServiceServer).WatchFileChanges(&grpc.GenericServerStream[FileChangesRequest, ConfigResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolService_WatchFileChangesServer = grpc.BidiStreamingServer[FileChangesRequest, ConfigResponse]
+
+func _ToolService_GetScheduledTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetScheduledTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetScheduledTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetScheduledTasks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_ExecuteScheduledTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteScheduledTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).ExecuteScheduledTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_ExecuteScheduledTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).ExecuteScheduledTask(ctx, req.(*ExecuteScheduledTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).HealthCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_HealthCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).HealthCheck(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetRequiredPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetRequiredPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetRequiredPermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetRequiredPermissions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetCategory(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetRateLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetRateLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetRateLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetRateLimits(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_Shutdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).Shutdown(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_Initialize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).Initialize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_Initialize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).Initialize(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_SetHostServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).SetHostServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_SetHostServices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).SetHostServices(ctx, req.(*HostServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_Negotiate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NegotiateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).Negotiate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_Negotiate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).Negotiate(ctx, req.(*NegotiateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetCapabilities(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolServiceServer).GetStats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolService_SubscribeLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogSubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ToolServiceServer).SubscribeLogs(m, &grpc.GenericServerStream[LogSubscribeRequest, ProtoLogEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolService_SubscribeLogsServer = grpc.ServerStreamingServer[ProtoLogEntry]
+
+// ToolService_ServiceDesc is the grpc.ServiceDesc for ToolService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ToolService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pluginapi.v2.ToolService",
+	HandlerType: (*ToolServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDefinition",
+			Handler:    _ToolService_GetDefinition_Handler,
+		},
+		{
+			MethodName: "GetTools",
+			Handler:    _ToolService_GetTools_Handler,
+		},
+		{
+			MethodName: "Call",
+			Handler:    _ToolService_Call_Handler,
+		},
+		{
+			MethodName: "CancelCall",
+			Handler:    _ToolService_CancelCall_Handler,
+		},
+		{
+			MethodName: "StartTask",
+			Handler:    _ToolService_StartTask_Handler,
+		},
+		{
+			MethodName: "GetTaskStatus",
+			Handler:    _ToolService_GetTaskStatus_Handler,
+		},
+		{
+			MethodName: "CancelTask",
+			Handler:    _ToolService_CancelTask_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _ToolService_GetVersion_Handler,
+		},
+		{
+			MethodName: "SetAgentContext",
+			Handler:    _ToolService_SetAgentContext_Handler,
+		},
+		{
+			MethodName: "UpdateAgentContext",
+			Handler:    _ToolService_UpdateAgentContext_Handler,
+		},
+		{
+			MethodName: "GetDefaultSettings",
+			Handler:    _ToolService_GetDefaultSettings_Handler,
+		},
+		{
+			MethodName: "GetRequiredConfig",
+			Handler:    _ToolService_GetRequiredConfig_Handler,
+		},
+		{
+			MethodName: "ValidateConfig",
+			Handler:    _ToolService_ValidateConfig_Handler,
+		},
+		{
+			MethodName: "InitializeWithConfig",
+			Handler:    _ToolService_InitializeWithConfig_Handler,
+		},
+		{
+			MethodName: "NotifyConfigChanged",
+			Handler:    _ToolService_NotifyConfigChanged_Handler,
+		},
+		{
+			MethodName: "TestConfig",
+			Handler:    _ToolService_TestConfig_Handler,
+		},
+		{
+			MethodName: "GetMetadata",
+			Handler:    _ToolService_GetMetadata_Handler,
+		},
+		{
+			MethodName: "GetCompatibilityInfo",
+			Handler:    _ToolService_GetCompatibilityInfo_Handler,
+		},
+		{
+			MethodName: "GetWebPages",
+			Handler:    _ToolService_GetWebPages_Handler,
+		},
+		{
+			MethodName: "ServeWebPage",
+			Handler:    _ToolService_ServeWebPage_Handler,
+		},
+		{
+			MethodName: "GetWebPageInfo",
+			Handler:    _ToolService_GetWebPageInfo_Handler,
+		},
+		{
+			MethodName: "AcceptsFiles",
+			Handler:    _ToolService_AcceptsFiles_Handler,
+		},
+		{
+			MethodName: "CallWithFiles",
+			Handler:    _ToolService_CallWithFiles_Handler,
+		},
+		{
+			MethodName: "GetOperations",
+			Handler:    _ToolService_GetOperations_Handler,
+		},
+		{
+			MethodName: "SnapshotState",
+			Handler:    _ToolService_SnapshotState_Handler,
+		},
+		{
+			MethodName: "RestoreState",
+			Handler:    _ToolService_RestoreState_Handler,
+		},
+		{
+			MethodName: "ExportState",
+			Handler:    _ToolService_ExportState_Handler,
+		},
+		{
+			MethodName: "ImportState",
+			Handler:    _ToolService_ImportState_Handler,
+		},
+		{
+			MethodName: "PrepareHandoff",
+			Handler:    _ToolService_PrepareHandoff_Handler,
+		},
+		{
+			MethodName: "ReceiveHandoff",
+			Handler:    _ToolService_ReceiveHandoff_Handler,
+		},
+		{
+			MethodName: "GetSystemPromptFragment",
+			Handler:    _ToolService_GetSystemPromptFragment_Handler,
+		},
+		{
+			MethodName: "ContributePrompt",
+			Handler:    _ToolService_ContributePrompt_Handler,
+		},
+		{
+			MethodName: "EnrichContext",
+			Handler:    _ToolService_EnrichContext_Handler,
+		},
+		{
+			MethodName: "Embed",
+			Handler:    _ToolService_Embed_Handler,
+		},
+		{
+			MethodName: "GetFileWatches",
+			Handler:    _ToolService_GetFileWatches_Handler,
+		},
+		{
+			MethodName: "GetScheduledTasks",
+			Handler:    _ToolService_GetScheduledTasks_Handler,
+		},
+		{
+			MethodName: "ExecuteScheduledTask",
+			Handler:    _ToolService_ExecuteScheduledTask_Handler,
+		},
+		{
+			MethodName: "HealthCheck",
+			Handler:    _ToolService_HealthCheck_Handler,
+		},
+		{
+			MethodName: "GetRequiredPermissions",
+			Handler:    _ToolService_GetRequiredPermissions_Handler,
+		},
+		{
+			MethodName: "GetCategory",
+			Handler:    _ToolService_GetCategory_Handler,
+		},
+		{
+			MethodName: "GetRateLimits",
+			Handler:    _ToolService_GetRateLimits_Handler,
+		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _ToolService_Shutdown_Handler,
+		},
+		{
+			MethodName: "Initialize",
+			Handler:    _ToolService_Initialize_Handler,
+		},
+		{
+			MethodName: "SetHostServices",
+			Handler:    _ToolService_SetHostServices_Handler,
+		},
+		{
+			MethodName: "Negotiate",
+			Handler:    _ToolService_Negotiate_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _ToolService_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _ToolService_GetStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CallStream",
+			Handler:       _ToolService_CallStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CallWithFilesStream",
+			Handler:       _ToolService_CallWithFilesStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchFileChanges",
+			Handler:       _ToolService_WatchFileChanges_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeLogs",
+			Handler:       _ToolService_SubscribeLogs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pluginapi/rpc/v2/tool.proto",
+}
+
+const (
+	HostService_Log_FullMethodName                    = "/pluginapi.v2.HostService/Log"
+	HostService_Complete_FullMethodName               = "/pluginapi.v2.HostService/Complete"
+	HostService_CallTool_FullMethodName               = "/pluginapi.v2.HostService/CallTool"
+	HostService_GetConversationHistory_FullMethodName = "/pluginapi.v2.HostService/GetConversationHistory"
+	HostService_Remember_FullMethodName               = "/pluginapi.v2.HostService/Remember"
+	HostService_Recall_FullMethodName                 = "/pluginapi.v2.HostService/Recall"
+	HostService_Embeddings_FullMethodName             = "/pluginapi.v2.HostService/Embeddings"
+	HostService_Notify_FullMethodName                 = "/pluginapi.v2.HostService/Notify"
+	HostService_SubscribeEvents_FullMethodName        = "/pluginapi.v2.HostService/SubscribeEvents"
+	HostService_SendToPlugin_FullMethodName           = "/pluginapi.v2.HostService/SendToPlugin"
+	HostService_SubscribeMessages_FullMethodName      = "/pluginapi.v2.HostService/SubscribeMessages"
+	HostService_DefinitionChanged_FullMethodName      = "/pluginapi.v2.HostService/DefinitionChanged"
+	HostService_GetSecret_FullMethodName              = "/pluginapi.v2.HostService/GetSecret"
+	HostService_SetSecret_FullMethodName              = "/pluginapi.v2.HostService/SetSecret"
+	HostService_RequestAuthorization_FullMethodName   = "/pluginapi.v2.HostService/RequestAuthorization"
+	HostService_ReportUsage_FullMethodName            = "/pluginapi.v2.HostService/ReportUsage"
+)
+
+// HostServiceClient is the client API for HostService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HostService is served by the agent so plugins can call back into it.
+// Every request carries the token from HostServicesRequest as "ori-host-token" metadata.
+type HostServiceClient interface {
+	// Log writes a message to the agent's log
+	Log(ctx context.Context, in *HostLogRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// Complete asks the agent's active model for a completion
+	Complete(ctx context.Context, in *HostCompleteRequest, opts ...grpc.CallOption) (*HostCompleteResponse, error)
+	// CallTool invokes another tool registered with the agent
+	CallTool(ctx context.Context, in *HostCallToolRequest, opts ...grpc.CallOption) (*CallResponse, error)
+	// GetConversationHistory returns recent messages of the conversation that made the call
+	GetConversationHistory(ctx context.Context, in *HostConversationRequest, opts ...grpc.CallOption) (*HostConversationResponse, error)
+	// Remember stores a fact in the agent's memory
+	Remember(ctx context.Context, in *HostRememberRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// Recall searches the agent's memory
+	Recall(ctx context.Context, in *HostRecallRequest, opts ...grpc.CallOption) (*HostRecallResponse, error)
+	// Embeddings embeds texts with the agent's embedding model
+	Embeddings(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error)
+	// Notify shows a notification to the user in the agent UI
+	Notify(ctx context.Context, in *ProtoNotification, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// SubscribeEvents streams agent events to the plugin until it disconnects
+	SubscribeEvents(ctx context.Context, in *HostSubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProtoAgentEvent], error)
+	// SendToPlugin sends a message to another plugin (or, with no target, to all subscribers)
+	SendToPlugin(ctx context.Context, in *ProtoPluginMessage, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// SubscribeMessages streams messages from other plugins until the plugin disconnects
+	SubscribeMessages(ctx context.Context, in *HostSubscribeMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProtoPluginMessage], error)
+	// DefinitionChanged tells the agent to fetch the plugin's tool definitions again
+	DefinitionChanged(ctx context.Context, in *DefinitionChangedRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// GetSecret reads a secret from the agent's keychain
+	GetSecret(ctx context.Context, in *HostSecretRequest, opts ...grpc.CallOption) (*HostSecretResponse, error)
+	// SetSecret stores a secret in the agent's keychain (an empty value deletes it)
+	SetSecret(ctx context.Context, in *HostSecretRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	// RequestAuthorization has the user authorize the plugin with an OAuth provider in a browser
+	RequestAuthorization(ctx context.Context, in *HostAuthorizationRequest, opts ...grpc.CallOption) (*HostAuthorizationResponse, error)
+	// ReportUsage records upstream API consumption for the current call
+	ReportUsage(ctx context.Context, in *HostUsageRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+}
+
+type hostServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHostServiceClient(cc grpc.ClientConnInterface) HostServiceClient {
+	return &hostServiceClient{cc}
+}
+
+func (c *hostServiceClient) Log(ctx context.Context, in *HostLogRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, HostService_Log_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) Complete(ctx context.Context, in *HostCompleteRequest, opts ...grpc.CallOption) (*HostCompleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HostCompleteResponse)
+	err := c.cc.Invoke(ctx, HostService_Complete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) CallTool(ctx context.Context, in *HostCallToolRequest, opts ...grpc.CallOption) (*CallResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CallResponse)
+	err := c.cc.Invoke(ctx, HostService_CallTool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) GetConversationHistory(ctx context.Context, in *HostConversationRequest, opts ...grpc.CallOption) (*HostConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HostConversationResponse)
+	err := c.cc.Invoke(ctx, HostService_GetConversationHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) Remember(ctx context.Context, in *HostRememberRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, HostService_Remember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) Recall(ctx context.Context, in *HostRecallRequest, opts ...grpc.CallOption) (*HostRecallResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HostRecallResponse)
+	err := c.cc.Invoke(ctx, HostService_Recall_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) Embeddings(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmbedResponse)
+	err := c.cc.Invoke(ctx, HostService_Embeddings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) Notify(ctx context.Context, in *ProtoNotification, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, HostService_Notify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) SubscribeEvents(ctx context.Context, in *HostSubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProtoAgentEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HostService_ServiceDesc.Streams[0], HostService_SubscribeEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HostSubscribeEventsRequest, ProtoAgentEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HostService_SubscribeEventsClient = grpc.ServerStreamingClient[ProtoAgentEvent]
+
+func (c *hostServiceClient) SendToPlugin(ctx context.Context, in *ProtoPluginMessage, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, HostService_SendToPlugin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) SubscribeMessages(ctx context.Context, in *HostSubscribeMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProtoPluginMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HostService_ServiceDesc.Streams[1], HostService_SubscribeMessages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HostSubscribeMessagesRequest, ProtoPluginMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HostService_SubscribeMessagesClient = grpc.ServerStreamingClient[ProtoPluginMessage]
+
+func (c *hostServiceClient) DefinitionChanged(ctx context.Context, in *DefinitionChangedRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, HostService_DefinitionChanged_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) GetSecret(ctx context.Context, in *HostSecretRequest, opts ...grpc.CallOption) (*HostSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HostSecretResponse)
+	err := c.cc.Invoke(ctx, HostService_GetSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) SetSecret(ctx context.Context, in *HostSecretRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, HostService_SetSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) RequestAuthorization(ctx context.Context, in *HostAuthorizationRequest, opts ...grpc.CallOption) (*HostAuthorizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HostAuthorizationResponse)
+	err := c.cc.Invoke(ctx, HostService_RequestAuthorization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) ReportUsage(ctx context.Context, in *HostUsageRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, HostService_ReportUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HostServiceServer is the server API for HostService service.
+// All implementations must embed UnimplementedHostServiceServer
+// for forward compatibility.
+//
+// HostService is served by the agent so plugins can call back into it.
+// Every request carries the token from HostServicesRequest as "ori-host-token" metadata.
+type HostServiceServer interface {
+	// Log writes a message to the agent's log
+	Log(context.Context, *HostLogRequest) (*ConfigResponse, error)
+	// Complete asks the agent's active model for a completion
+	Complete(context.Context, *HostCompleteRequest) (*HostCompleteResponse, error)
+	// CallTool invokes another tool registered with the agent
+	CallTool(context.Context, *HostCallToolRequest) (*CallResponse, error)
+	// GetConversationHistory returns recent messages of the conversation that made the call
+	GetConversationHistory(context.Context, *HostConversationRequest) (*HostConversationResponse, error)
+	// Remember stores a fact in the agent's memory
+	Remember(context.Context, *HostRememberRequest) (*ConfigResponse, error)
+	// Recall searches the agent's memory
+	Recall(context.Context, *HostRecallRequest) (*HostRecallResponse, error)
+	// Embeddings embeds texts with the agent's embedding model
+	Embeddings(context.Context, *EmbedRequest) (*EmbedResponse, error)
+	// Notify shows a notification to the user in the agent UI
+	Notify(context.Context, *ProtoNotification) (*ConfigResponse, error)
+	// SubscribeEvents streams agent events to the plugin until it disconnects
+	SubscribeEvents(*HostSubscribeEventsRequest, grpc.ServerStreamingServer[ProtoAgentEvent]) error
+	// SendToPlugin sends a message to another plugin (or, with no target, to all subscribers)
+	SendToPlugin(context.Context, *ProtoPluginMessage) (*ConfigResponse, error)
+	// SubscribeMessages streams messages from other plugins until the plugin disconnects
+	SubscribeMessages(*HostSubscribeMessagesRequest, grpc.ServerStreamingServer[ProtoPluginMessage]) error
+	// DefinitionChanged tells the agent to fetch the plugin's tool definitions again
+	DefinitionChanged(context.Context, *DefinitionChangedRequest) (*ConfigResponse, error)
+	// GetSecret reads a secret from the agent's keychain
+	GetSecret(context.Context, *HostSecretRequest) (*HostSecretResponse, error)
+	// SetSecret stores a secret in the agent's keychain (an empty value deletes it)
+	SetSecret(context.Context, *HostSecretRequest) (*ConfigResponse, error)
+	// RequestAuthorization has the user authorize the plugin with an OAuth provider in a browser
+	RequestAuthorization(context.Context, *HostAuthorizationRequest) (*HostAuthorizationResponse, error)
+	// ReportUsage records upstream API consumption for the current call
+	ReportUsage(context.Context, *HostUsageRequest) (*ConfigResponse, error)
+	mustEmbedUnimplementedHostServiceServer()
+}
+
+// UnimplementedHostServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHostServiceServer struct{}
+
+func (UnimplementedHostServiceServer) Log(context.Context, *HostLogRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Log not implemented")
+}
+func (UnimplementedHostServiceServer) Complete(context.Context, *HostCompleteRequest) (*HostCompleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Complete not implemented")
+}
+func (UnimplementedHostServiceServer) CallTool(context.Context, *HostCallToolRequest) (*CallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CallTool not implemented")
+}
+func (UnimplementedHostServiceServer) GetConversationHistory(context.Context, *HostConversationRequest) (*HostConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConversationHistory not implemented")
+}
+func (UnimplementedHostServiceServer) Remember(context.Context, *HostRememberRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remember not implemented")
+}
+func (UnimplementedHostServiceServer) Recall(context.Context, *HostRecallRequest) (*HostRecallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recall not implemented")
+}
+func (UnimplementedHostServiceServer) Embeddings(context.Context, *EmbedRequest) (*EmbedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Embeddings not implemented")
+}
+func (UnimplementedHostServiceServer) Notify(context.Context, *ProtoNotification) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Notify not implemented")
+}
+func (UnimplementedHostServiceServer) SubscribeEvents(*HostSubscribeEventsRequest, grpc.ServerStreamingServer[ProtoAgentEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedHostServiceServer) SendToPlugin(context.Context, *ProtoPluginMessage) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendToPlugin not implemented")
+}
+func (UnimplementedHostServiceServer) SubscribeMessages(*HostSubscribeMessagesRequest, grpc.ServerStreamingServer[ProtoPluginMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMessages not implemented")
+}
+func (UnimplementedHostServiceServer) DefinitionChanged(context.Context, *DefinitionChangedRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DefinitionChanged not implemented")
+}
+func (UnimplementedHostServiceServer) GetSecret(context.Context, *HostSecretRequest) (*HostSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecret not implemented")
+}
+func (UnimplementedHostServiceServer) SetSecret(context.Context, *HostSecretRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSecret not implemented")
+}
+func (UnimplementedHostServiceServer) RequestAuthorization(context.Context, *HostAuthorizationRequest) (*HostAuthorizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestAuthorization not implemented")
+}
+func (UnimplementedHostServiceServer) ReportUsage(context.Context, *HostUsageRequest) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportUsage not implemented")
+}
+func (UnimplementedHostServiceServer) mustEmbedUnimplementedHostServiceServer() {}
+func (UnimplementedHostServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeHostServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HostServiceServer will
+// result in compilation errors.
+type UnsafeHostServiceServer interface {
+	mustEmbedUnimplementedHostServiceServer()
+}
+
+func RegisterHostServiceServer(s grpc.ServiceRegistrar, srv HostServiceServer) {
+	// If the following call pancis, it indicates UnimplementedHostServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HostService_ServiceDesc, srv)
+}
+
+func _HostService_Log_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).Log(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_Log_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).Log(ctx, req.(*HostLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostCompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_Complete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).Complete(ctx, req.(*HostCompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_CallTool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostCallToolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).CallTool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_CallTool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).CallTool(ctx, req.(*HostCallToolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_GetConversationHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).GetConversationHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_GetConversationHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).GetConversationHistory(ctx, req.(*HostConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_Remember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostRememberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).Remember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_Remember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).Remember(ctx, req.(*HostRememberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_Recall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostRecallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).Recall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_Recall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).Recall(ctx, req.(*HostRecallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_Embeddings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).Embeddings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_Embeddings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).Embeddings(ctx, req.(*EmbedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_Notify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProtoNotification)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).Notify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_Notify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).Notify(ctx, req.(*ProtoNotification))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HostSubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HostServiceServer).SubscribeEvents(m, &grpc.GenericServerStream[HostSubscribeEventsRequest, ProtoAgentEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HostService_SubscribeEventsServer = grpc.ServerStreamingServer[ProtoAgentEvent]
+
+func _HostService_SendToPlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProtoPluginMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).SendToPlugin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_SendToPlugin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).SendToPlugin(ctx, req.(*ProtoPluginMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_SubscribeMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HostSubscribeMessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HostServiceServer).SubscribeMessages(m, &grpc.GenericServerStream[HostSubscribeMessagesRequest, ProtoPluginMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HostService_SubscribeMessagesServer = grpc.ServerStreamingServer[ProtoPluginMessage]
+
+func _HostService_DefinitionChanged_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DefinitionChangedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).DefinitionChanged(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_DefinitionChanged_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).DefinitionChanged(ctx, req.(*DefinitionChangedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).GetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_GetSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).GetSecret(ctx, req.(*HostSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_SetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).SetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_SetSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).SetSecret(ctx, req.(*HostSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_RequestAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).RequestAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_RequestAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).RequestAuthorization(ctx, req.(*HostAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_ReportUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).ReportUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_ReportUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).ReportUsage(ctx, req.(*HostUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HostService_ServiceDesc is the grpc.ServiceDesc for HostService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HostService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pluginapi.v2.HostService",
+	HandlerType: (*HostServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Log",
+			Handler:    _HostService_Log_Handler,
+		},
+		{
+			MethodName: "Complete",
+			Handler:    _HostService_Complete_Handler,
+		},
+		{
+			MethodName: "CallTool",
+			Handler:    _HostService_CallTool_Handler,
+		},
+		{
+			MethodName: "GetConversationHistory",
+			Handler:    _HostService_GetConversationHistory_Handler,
+		},
+		{
+			MethodName: "Remember",
+			Handler:    _HostService_Remember_Handler,
+		},
+		{
+			MethodName: "Recall",
+			Handler:    _HostService_Recall_Handler,
+		},
+		{
+			MethodName: "Embeddings",
+			Handler:    _HostService_Embeddings_Handler,
+		},
+		{
+			MethodName: "Notify",
+			Handler:    _HostService_Notify_Handler,
+		},
+		{
+			MethodName: "SendToPlugin",
+			Handler:    _HostService_SendToPlugin_Handler,
+		},
+		{
+			MethodName: "DefinitionChanged",
+			Handler:    _HostService_DefinitionChanged_Handler,
+		},
+		{
+			MethodName: "GetSecret",
+			Handler:    _HostService_GetSecret_Handler,
+		},
+		{
+			MethodName: "SetSecret",
+			Handler:    _HostService_SetSecret_Handler,
+		},
+		{
+			MethodName: "RequestAuthorization",
+			Handler:    _HostService_RequestAuthorization_Handler,
+		},
+		{
+			MethodName: "ReportUsage",
+			Handler:    _HostService_ReportUsage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _HostService_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeMessages",
+			Handler:       _HostService_SubscribeMessages_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pluginapi/rpc/v2/tool.proto",
+}
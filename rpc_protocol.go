@@ -4,38 +4,105 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // grpcServer is a local wrapper for the server implementation
 type grpcServer struct {
 	UnimplementedToolServiceServer
-	Impl PluginTool
+	Impl       PluginTool
+	middleware Middleware // Added by WrapTool; runs around every call of Impl
+
+	calls    callRegistry      // Running calls, for CancelCall
+	shutdown shutdownOnce      // Shared by the Shutdown RPC and signal handling
+	host     hostConnection    // Connection to the agent's HostService, if any
+	agent    agentContextState // Last context delivered, so updates can report what changed
+	panics   panicCounter      // Handler panics recovered by recoverUnary and recoverStream
+	stats    callStats         // Served RPCs, recorded by measureUnary and measureStream
+	draining drainSignal       // Ends log subscriptions when the plugin shuts down
+	tasks    taskRegistry      // Background tasks started with StartTask
 }
 
 func (s *grpcServer) GetDefinition(ctx context.Context, _ *Empty) (*ToolDefinition, error) {
-	def := s.Impl.Definition()
+	return toolDefinitionToProto(s.Impl)
+}
 
-	// Convert generic Tool definition to protobuf message
-	paramsJSON, err := json.Marshal(def.Parameters)
-	if err != nil {
-		return nil, err
+// GetTools lists the tools of a ToolSetProvider, or just the plugin's own tool.
+func (s *grpcServer) GetTools(ctx context.Context, _ *Empty) (*ToolSetResponse, error) {
+	provider, ok := s.Impl.(ToolSetProvider)
+	if !ok {
+		def, err := toolDefinitionToProto(s.Impl)
+		if err != nil {
+			return nil, err
+		}
+		return &ToolSetResponse{Tools: []*ToolDefinition{def}}, nil
 	}
-
-	return &ToolDefinition{
-		Name:           def.Name,
-		Description:    def.Description,
-		ParametersJson: string(paramsJSON),
-	}, nil
+	resp := &ToolSetResponse{SupportsToolSet: true}
+	for _, tool := range provider.Tools() {
+		tool, _ = unwrapTool(tool)
+		def, err := toolDefinitionToProto(tool)
+		if err != nil {
+			return nil, err
+		}
+		resp.Tools = append(resp.Tools, def)
+	}
+	return resp, nil
 }
 
 func (s *grpcServer) Call(ctx context.Context, req *CallRequest) (*CallResponse, error) {
-	result, err := s.Impl.Call(ctx, req.ArgsJson)
+	tool, middleware, err := s.resolveTool(req.ToolName)
+	if err != nil {
+		return callErrorResponse(err), nil
+	}
+	ctx, done := s.calls.start(ctx, req.CallId)
+	defer done()
+	ctx, cancel := withOperationTimeout(ctx, tool, req.ArgsJson)
+	defer cancel()
+	ctx = WithCallMetadata(ctx, req.Metadata)
+	ctx = withProtoCallMeta(ctx, req.CallMeta)
+	ctx = WithIdempotencyKey(ctx, req.IdempotencyKey)
+	ctx = WithToolName(ctx, req.ToolName)
+	if outputProvider, ok := tool.(FileOutputProvider); ok {
+		return callWithOutputFiles(ctx, middleware, outputProvider, req.ArgsJson, nil), nil
+	}
+	result, err := middleware(tool.Call)(ctx, req.ArgsJson)
 	if err != nil {
-		return &CallResponse{Error: err.Error()}, nil
+		return callErrorResponse(err), nil
 	}
 	return &CallResponse{ResultJson: result}, nil
 }
 
+// callWithOutputFiles runs a FileOutputProvider's call and returns its files with the result.
+func callWithOutputFiles(ctx context.Context, middleware Middleware, provider FileOutputProvider, args string, files []FileAttachment) *CallResponse {
+	var outputs []FileAttachment
+	result, err := middleware(func(ctx context.Context, args string) (result string, err error) {
+		result, outputs, err = provider.CallWithOutputFiles(ctx, args, files)
+		return result, err
+	})(ctx, args)
+	if err != nil {
+		return callErrorResponse(err)
+	}
+	for i := range outputs {
+		if outputs[i].Size == 0 && outputs[i].Encoding == "" {
+			outputs[i].Size = int64(len(outputs[i].Content))
+		}
+	}
+	return &CallResponse{ResultJson: result, Files: fileAttachmentsToProto(outputs)}
+}
+
+// callErrorResponse reports a failed call with both the plain message and its classification.
+func callErrorResponse(err error) *CallResponse {
+	return &CallResponse{Error: err.Error(), StructuredError: pluginErrorToProto(err)}
+}
+
 func (s *grpcServer) GetVersion(ctx context.Context, _ *Empty) (*VersionResponse, error) {
 	if versionedTool, ok := s.Impl.(VersionedTool); ok {
 		return &VersionResponse{Version: versionedTool.Version()}, nil
@@ -44,13 +111,24 @@ func (s *grpcServer) GetVersion(ctx context.Context, _ *Empty) (*VersionResponse
 }
 
 func (s *grpcServer) SetAgentContext(ctx context.Context, req *AgentContextRequest) (*Empty, error) {
+	agentCtx := agentContextFromProto(req)
+	s.agent.swap(agentCtx)
 	if agentAware, ok := s.Impl.(AgentAwareTool); ok {
-		agentAware.SetAgentContext(AgentContext{
-			Name:         req.Name,
-			ConfigPath:   req.ConfigPath,
-			SettingsPath: req.SettingsPath,
-			AgentDir:     req.AgentDir,
-		})
+		agentAware.SetAgentContext(agentCtx)
+	}
+	return &Empty{}, nil
+}
+
+// UpdateAgentContext stores the new context like SetAgentContext, then tells
+// listeners what changed.
+func (s *grpcServer) UpdateAgentContext(ctx context.Context, req *AgentContextRequest) (*Empty, error) {
+	agentCtx := agentContextFromProto(req)
+	previous := s.agent.swap(agentCtx)
+	if agentAware, ok := s.Impl.(AgentAwareTool); ok {
+		agentAware.SetAgentContext(agentCtx)
+	}
+	if listener, ok := s.Impl.(AgentContextUpdateListener); ok && previous != agentCtx {
+		listener.OnAgentContextUpdate(previous, agentCtx)
 	}
 	return &Empty{}, nil
 }
@@ -118,6 +196,13 @@ func (s *grpcServer) InitializeWithConfig(ctx context.Context, req *InitializeCo
 			return &ConfigResponse{Success: false, Error: err.Error()}, nil
 		}
 
+		if config == nil {
+			config = make(map[string]interface{})
+		}
+		if err := routeSecrets(ctx, s.host.current(), initProvider.GetRequiredConfig(), config); err != nil {
+			return &ConfigResponse{Success: false, Error: err.Error()}, nil
+		}
+
 		if err := initProvider.InitializeWithConfig(config); err != nil {
 			return &ConfigResponse{Success: false, Error: err.Error()}, nil
 		}
@@ -148,53 +233,104 @@ func (s *grpcServer) GetMetadata(ctx context.Context, _ *Empty) (*MetadataRespon
 
 func (s *grpcServer) GetCompatibilityInfo(ctx context.Context, _ *Empty) (*CompatibilityInfoResponse, error) {
 	// Check if plugin implements PluginCompatibility
+	resp := &CompatibilityInfoResponse{}
 	if compatTool, ok := s.Impl.(PluginCompatibility); ok {
-		return &CompatibilityInfoResponse{
-			MinAgentVersion: compatTool.MinAgentVersion(),
-			MaxAgentVersion: compatTool.MaxAgentVersion(),
-			ApiVersion:      compatTool.APIVersion(),
-		}, nil
+		resp.MinAgentVersion = compatTool.MinAgentVersion()
+		resp.MaxAgentVersion = compatTool.MaxAgentVersion()
+		resp.ApiVersion = compatTool.APIVersion()
+	}
+	if depProvider, ok := s.Impl.(PluginDependencyProvider); ok {
+		resp.PluginDependencies = pluginDependenciesToProto(depProvider.GetPluginDependencies())
 	}
-	// Plugin doesn't implement PluginCompatibility, return empty
-	return &CompatibilityInfoResponse{}, nil
+	// Empty if the plugin implements neither
+	return resp, nil
 }
 
-// grpcClient is a local wrapper for the client implementation
+// grpcClient is a local wrapper for the client implementation.
+// Methods whose PluginTool signatures take no context have a ...Ctx variant
+// (e.g., DefinitionCtx) so hosts can apply timeouts; the plain methods use context.Background().
+// The definition, metadata and compatibility info are fetched once and cached until Invalidate.
 type grpcClient struct {
 	client ToolServiceClient
+	cache  responseCache
+}
+
+// Invalidate drops the cached definition, metadata and compatibility info, so the
+// next lookups ask the plugin again. Call it after the plugin restarts or reloads,
+// or reports a new definition with DefinitionChanged.
+func (c *grpcClient) Invalidate() {
+	c.cache.clear()
 }
 
 func (c *grpcClient) Definition() Tool {
-	resp, err := c.client.GetDefinition(context.Background(), &Empty{})
+	return c.DefinitionCtx(context.Background())
+}
+
+// DefinitionCtx is like Definition but uses ctx for the RPC.
+func (c *grpcClient) DefinitionCtx(ctx context.Context) Tool {
+	resp, err := cachedResponse(&c.cache, &c.cache.definition, func() (*ToolDefinition, error) {
+		return c.client.GetDefinition(ctx, &Empty{})
+	})
 	if err != nil {
 		return Tool{}
 	}
 
-	var params map[string]interface{}
-	if err := json.Unmarshal([]byte(resp.ParametersJson), &params); err != nil {
-		params = map[string]interface{}{}
-	}
+	return toolFromProto(resp)
+}
 
-	return Tool{
-		Name:        resp.Name,
-		Description: resp.Description,
-		Parameters:  params,
+// GetTools returns the definitions of the tools the plugin serves. Plugins without
+// ToolSetProvider, or built before it, serve only their own tool.
+// Call one of them by passing WithToolName(ctx, tool.Name) to Call.
+func (c *grpcClient) GetTools(ctx context.Context) ([]Tool, error) {
+	resp, err := c.client.GetTools(ctx, &Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		def, err := c.client.GetDefinition(ctx, &Empty{})
+		if err != nil {
+			return nil, err
+		}
+		return []Tool{toolFromProto(def)}, nil
+	}
+	if err != nil {
+		return nil, err
 	}
+	tools := make([]Tool, 0, len(resp.Tools))
+	for _, def := range resp.Tools {
+		tools = append(tools, toolFromProto(def))
+	}
+	return tools, nil
 }
 
+// Call executes the tool. ctx's deadline is forwarded as the gRPC deadline, so the
+// plugin's context expires at the same time, and cancelling ctx cancels the plugin's context.
+// Failures are returned as a *PluginError carrying the plugin's classification.
 func (c *grpcClient) Call(ctx context.Context, args string) (string, error) {
-	resp, err := c.client.Call(ctx, &CallRequest{ArgsJson: args})
+	ctx, callID, finish := c.startCall(ctx)
+	defer finish()
+
+	resp, err := c.client.Call(ctx, &CallRequest{
+		ArgsJson:       args,
+		Metadata:       CallMetadata(ctx),
+		CallMeta:       callMetaToProto(ctx),
+		IdempotencyKey: IdempotencyKey(ctx),
+		CallId:         callID,
+		ToolName:       ToolName(ctx),
+	})
 	if err != nil {
 		return "", err
 	}
-	if err := resp.Error; err != "" {
-		return "", fmt.Errorf("%s", err)
+	if resp.Error != "" {
+		return "", callError(resp.Error, resp.StructuredError)
 	}
 	return resp.ResultJson, nil
 }
 
 func (c *grpcClient) Version() string {
-	resp, err := c.client.GetVersion(context.Background(), &Empty{})
+	return c.VersionCtx(context.Background())
+}
+
+// VersionCtx is like Version but uses ctx for the RPC.
+func (c *grpcClient) VersionCtx(ctx context.Context) string {
+	resp, err := c.client.GetVersion(ctx, &Empty{})
 	if err != nil {
 		return "unknown"
 	}
@@ -202,7 +338,12 @@ func (c *grpcClient) Version() string {
 }
 
 func (c *grpcClient) GetDefaultSettings() (string, error) {
-	resp, err := c.client.GetDefaultSettings(context.Background(), &Empty{})
+	return c.GetDefaultSettingsCtx(context.Background())
+}
+
+// GetDefaultSettingsCtx is like GetDefaultSettings but uses ctx for the RPC.
+func (c *grpcClient) GetDefaultSettingsCtx(ctx context.Context) (string, error) {
+	resp, err := c.client.GetDefaultSettings(ctx, &Empty{})
 	if err != nil {
 		return "", err
 	}
@@ -213,16 +354,30 @@ func (c *grpcClient) GetDefaultSettings() (string, error) {
 }
 
 func (c *grpcClient) SetAgentContext(ctx AgentContext) {
-	_, _ = c.client.SetAgentContext(context.Background(), &AgentContextRequest{
-		Name:         ctx.Name,
-		ConfigPath:   ctx.ConfigPath,
-		SettingsPath: ctx.SettingsPath,
-		AgentDir:     ctx.AgentDir,
-	})
+	_ = c.SetAgentContextCtx(context.Background(), ctx)
+}
+
+// SetAgentContextCtx is like SetAgentContext but uses ctx for the RPC and reports delivery errors.
+func (c *grpcClient) SetAgentContextCtx(ctx context.Context, agentCtx AgentContext) error {
+	_, err := c.client.SetAgentContext(ctx, agentContextToProto(agentCtx))
+	return err
+}
+
+// UpdateAgentContext pushes a changed agent context (e.g., a new CurrentLocation)
+// to a running plugin. Unlike SetAgentContext, plugins implementing
+// AgentContextUpdateListener are told what changed.
+func (c *grpcClient) UpdateAgentContext(ctx context.Context, agentCtx AgentContext) error {
+	_, err := c.client.UpdateAgentContext(ctx, agentContextToProto(agentCtx))
+	return err
 }
 
 func (c *grpcClient) GetRequiredConfig() []ConfigVariable {
-	resp, err := c.client.GetRequiredConfig(context.Background(), &Empty{})
+	return c.GetRequiredConfigCtx(context.Background())
+}
+
+// GetRequiredConfigCtx is like GetRequiredConfig but uses ctx for the RPC.
+func (c *grpcClient) GetRequiredConfigCtx(ctx context.Context) []ConfigVariable {
+	resp, err := c.client.GetRequiredConfig(ctx, &Empty{})
 	if err != nil || resp == nil {
 		return []ConfigVariable{}
 	}
@@ -252,12 +407,17 @@ func (c *grpcClient) GetRequiredConfig() []ConfigVariable {
 }
 
 func (c *grpcClient) ValidateConfig(config map[string]interface{}) error {
+	return c.ValidateConfigCtx(context.Background(), config)
+}
+
+// ValidateConfigCtx is like ValidateConfig but uses ctx for the RPC.
+func (c *grpcClient) ValidateConfigCtx(ctx context.Context, config map[string]interface{}) error {
 	configJSON, err := json.Marshal(config)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.client.ValidateConfig(context.Background(), &ValidateConfigRequest{
+	resp, err := c.client.ValidateConfig(ctx, &ValidateConfigRequest{
 		ConfigJson: string(configJSON),
 	})
 	if err != nil {
@@ -272,12 +432,17 @@ func (c *grpcClient) ValidateConfig(config map[string]interface{}) error {
 }
 
 func (c *grpcClient) InitializeWithConfig(config map[string]interface{}) error {
+	return c.InitializeWithConfigCtx(context.Background(), config)
+}
+
+// InitializeWithConfigCtx is like InitializeWithConfig but uses ctx for the RPC.
+func (c *grpcClient) InitializeWithConfigCtx(ctx context.Context, config map[string]interface{}) error {
 	configJSON, err := json.Marshal(config)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.client.InitializeWithConfig(context.Background(), &InitializeConfigRequest{
+	resp, err := c.client.InitializeWithConfig(ctx, &InitializeConfigRequest{
 		ConfigJson: string(configJSON),
 	})
 	if err != nil {
@@ -292,20 +457,46 @@ func (c *grpcClient) InitializeWithConfig(config map[string]interface{}) error {
 }
 
 func (c *grpcClient) GetMetadata() (*PluginMetadata, error) {
-	resp, err := c.client.GetMetadata(context.Background(), &Empty{})
+	return c.GetMetadataCtx(context.Background())
+}
+
+// GetMetadataCtx is like GetMetadata but uses ctx for the RPC.
+func (c *grpcClient) GetMetadataCtx(ctx context.Context) (*PluginMetadata, error) {
+	resp, err := cachedResponse(&c.cache, &c.cache.metadata, func() (*MetadataResponse, error) {
+		resp, err := c.client.GetMetadata(ctx, &Empty{})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("%s", resp.Error)
+		}
+		return resp, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if resp.Error != "" {
-		return nil, fmt.Errorf("%s", resp.Error)
+
+	// Copy so callers can't modify the cached metadata
+	if resp.Metadata == nil {
+		return nil, nil
 	}
+	return proto.Clone(resp.Metadata).(*PluginMetadata), nil
+}
 
-	// Return the proto-generated metadata directly
-	return resp.Metadata, nil
+// compatibilityInfo returns the plugin's cached GetCompatibilityInfo response.
+func (c *grpcClient) compatibilityInfo(ctx context.Context) (*CompatibilityInfoResponse, error) {
+	return cachedResponse(&c.cache, &c.cache.compatibility, func() (*CompatibilityInfoResponse, error) {
+		return c.client.GetCompatibilityInfo(ctx, &Empty{})
+	})
 }
 
 func (c *grpcClient) GetTags() []string {
-	metadata, err := c.GetMetadata()
+	return c.GetTagsCtx(context.Background())
+}
+
+// GetTagsCtx is like GetTags but uses ctx for the RPC.
+func (c *grpcClient) GetTagsCtx(ctx context.Context) []string {
+	metadata, err := c.GetMetadataCtx(ctx)
 	if err != nil || metadata == nil {
 		return nil
 	}
@@ -313,7 +504,12 @@ func (c *grpcClient) GetTags() []string {
 }
 
 func (c *grpcClient) MinAgentVersion() string {
-	resp, err := c.client.GetCompatibilityInfo(context.Background(), &Empty{})
+	return c.MinAgentVersionCtx(context.Background())
+}
+
+// MinAgentVersionCtx is like MinAgentVersion but uses ctx for the RPC.
+func (c *grpcClient) MinAgentVersionCtx(ctx context.Context) string {
+	resp, err := c.compatibilityInfo(ctx)
 	if err != nil {
 		return ""
 	}
@@ -321,7 +517,12 @@ func (c *grpcClient) MinAgentVersion() string {
 }
 
 func (c *grpcClient) MaxAgentVersion() string {
-	resp, err := c.client.GetCompatibilityInfo(context.Background(), &Empty{})
+	return c.MaxAgentVersionCtx(context.Background())
+}
+
+// MaxAgentVersionCtx is like MaxAgentVersion but uses ctx for the RPC.
+func (c *grpcClient) MaxAgentVersionCtx(ctx context.Context) string {
+	resp, err := c.compatibilityInfo(ctx)
 	if err != nil {
 		return ""
 	}
@@ -329,7 +530,12 @@ func (c *grpcClient) MaxAgentVersion() string {
 }
 
 func (c *grpcClient) APIVersion() string {
-	resp, err := c.client.GetCompatibilityInfo(context.Background(), &Empty{})
+	return c.APIVersionCtx(context.Background())
+}
+
+// APIVersionCtx is like APIVersion but uses ctx for the RPC.
+func (c *grpcClient) APIVersionCtx(ctx context.Context) string {
+	resp, err := c.compatibilityInfo(ctx)
 	if err != nil {
 		return ""
 	}
@@ -350,16 +556,57 @@ func (s *grpcServer) ServeWebPage(ctx context.Context, req *WebPageRequest) (*We
 		if err != nil {
 			return &WebPageResponse{Error: err.Error()}, nil
 		}
-		return &WebPageResponse{
-			Content:     content,
+
+		resp := &WebPageResponse{
 			ContentType: contentType,
-		}, nil
+			Etag:        webContentETag(content),
+		}
+		switch {
+		case req.IfNoneMatch != "" && req.IfNoneMatch == resp.Etag:
+			resp.NotModified = true
+		case utf8.ValidString(content):
+			resp.Content = content
+		default:
+			// Proto strings must be UTF-8, so binary assets travel as bytes
+			resp.Body = []byte(content)
+		}
+		return resp, nil
 	}
 	return &WebPageResponse{Error: "plugin does not implement WebPageProvider"}, nil
 }
 
+func (s *grpcServer) GetWebPageInfo(ctx context.Context, _ *Empty) (*WebPageInfoResponse, error) {
+	var pages []WebPageInfo
+	if infoProvider, ok := s.Impl.(WebPageInfoProvider); ok {
+		pages = infoProvider.GetWebPageInfo()
+	} else if webProvider, ok := s.Impl.(WebPageProvider); ok {
+		// Fall back to bare paths so hosts can always use GetWebPageInfo
+		for _, path := range webProvider.GetWebPages() {
+			pages = append(pages, WebPageInfo{Path: path, Title: path, ShowInMenu: !IsWebRoutePattern(path)})
+		}
+	}
+
+	protoPages := make([]*ProtoWebPageInfo, len(pages))
+	for i, p := range pages {
+		protoPages[i] = &ProtoWebPageInfo{
+			Path:         p.Path,
+			Title:        p.Title,
+			Icon:         p.Icon,
+			Description:  p.Description,
+			RequiresAuth: p.RequiresAuth,
+			ShowInMenu:   p.ShowInMenu,
+		}
+	}
+	return &WebPageInfoResponse{Pages: protoPages}, nil
+}
+
 func (c *grpcClient) GetWebPages() []string {
-	resp, err := c.client.GetWebPages(context.Background(), &Empty{})
+	return c.GetWebPagesCtx(context.Background())
+}
+
+// GetWebPagesCtx is like GetWebPages but uses ctx for the RPC.
+func (c *grpcClient) GetWebPagesCtx(ctx context.Context) []string {
+	resp, err := c.client.GetWebPages(ctx, &Empty{})
 	if err != nil || resp == nil {
 		return []string{}
 	}
@@ -367,7 +614,12 @@ func (c *grpcClient) GetWebPages() []string {
 }
 
 func (c *grpcClient) ServeWebPage(path string, query map[string]string) (string, string, error) {
-	resp, err := c.client.ServeWebPage(context.Background(), &WebPageRequest{
+	return c.ServeWebPageCtx(context.Background(), path, query)
+}
+
+// ServeWebPageCtx is like ServeWebPage but uses ctx for the RPC.
+func (c *grpcClient) ServeWebPageCtx(ctx context.Context, path string, query map[string]string) (string, string, error) {
+	resp, err := c.client.ServeWebPage(ctx, &WebPageRequest{
 		Path:  path,
 		Query: query,
 	})
@@ -377,9 +629,182 @@ func (c *grpcClient) ServeWebPage(path string, query map[string]string) (string,
 	if resp.Error != "" {
 		return "", "", fmt.Errorf("%s", resp.Error)
 	}
+	if len(resp.Body) > 0 {
+		return string(resp.Body), resp.ContentType, nil
+	}
 	return resp.Content, resp.ContentType, nil
 }
 
+// FetchWebPage serves a web page with caching information.
+// If ifNoneMatch equals the current ETag, the result has NotModified set and no content.
+func (c *grpcClient) FetchWebPage(ctx context.Context, path string, query map[string]string, ifNoneMatch string) (*WebPageContent, error) {
+	resp, err := c.client.ServeWebPage(ctx, &WebPageRequest{
+		Path:        path,
+		Query:       query,
+		IfNoneMatch: ifNoneMatch,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+
+	content := resp.Body
+	if len(content) == 0 && resp.Content != "" {
+		content = []byte(resp.Content)
+	}
+	return &WebPageContent{
+		Content:     content,
+		ContentType: resp.ContentType,
+		ETag:        resp.Etag,
+		NotModified: resp.NotModified,
+	}, nil
+}
+
+// GetWebPageInfo returns menu metadata for each web page.
+// Plugins without WebPageInfoProvider report their bare paths as titles.
+func (c *grpcClient) GetWebPageInfo() []WebPageInfo {
+	return c.GetWebPageInfoCtx(context.Background())
+}
+
+// GetWebPageInfoCtx is like GetWebPageInfo but uses ctx for the RPC.
+func (c *grpcClient) GetWebPageInfoCtx(ctx context.Context) []WebPageInfo {
+	resp, err := c.client.GetWebPageInfo(ctx, &Empty{})
+	if err != nil || resp == nil {
+		return []WebPageInfo{}
+	}
+	return webPageInfoFromProto(resp)
+}
+
+func webPageInfoFromProto(resp *WebPageInfoResponse) []WebPageInfo {
+	pages := make([]WebPageInfo, len(resp.GetPages()))
+	for i, p := range resp.GetPages() {
+		pages[i] = WebPageInfo{
+			Path:         p.Path,
+			Title:        p.Title,
+			Icon:         p.Icon,
+			Description:  p.Description,
+			RequiresAuth: p.RequiresAuth,
+			ShowInMenu:   p.ShowInMenu,
+		}
+	}
+	return pages
+}
+
+// =============================================================================
+// Config Change Support
+// =============================================================================
+
+func (s *grpcServer) NotifyConfigChanged(ctx context.Context, req *ConfigChangedRequest) (*ConfigResponse, error) {
+	listener, ok := s.Impl.(ConfigChangeListener)
+	if !ok {
+		return &ConfigResponse{Success: false, Error: "plugin does not implement ConfigChangeListener"}, nil
+	}
+
+	var config map[string]interface{}
+	if req.ConfigJson != "" {
+		if err := json.Unmarshal([]byte(req.ConfigJson), &config); err != nil {
+			return &ConfigResponse{Success: false, Error: err.Error()}, nil
+		}
+	}
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+	if initProvider, ok := s.Impl.(InitializationProvider); ok {
+		if err := routeSecrets(ctx, s.host.current(), initProvider.GetRequiredConfig(), config); err != nil {
+			return &ConfigResponse{Success: false, Error: err.Error()}, nil
+		}
+	}
+	if reloader, ok := s.Impl.(settingsReloader); ok {
+		if err := reloader.reloadSettings(); err != nil {
+			return &ConfigResponse{Success: false, Error: err.Error()}, nil
+		}
+	}
+
+	if err := listener.OnConfigChanged(ctx, req.ChangedKeys, config); err != nil {
+		return &ConfigResponse{Success: false, Error: err.Error()}, nil
+	}
+	return &ConfigResponse{Success: true}, nil
+}
+
+// NotifyConfigChanged tells the plugin that the keys in changed were edited and
+// config is its new configuration. Returns an error if the plugin doesn't implement
+// ConfigChangeListener, in which case hosts should restart it to apply the change.
+func (c *grpcClient) NotifyConfigChanged(ctx context.Context, changed []string, config map[string]interface{}) error {
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.NotifyConfigChanged(ctx, &ConfigChangedRequest{
+		ChangedKeys: changed,
+		ConfigJson:  string(configJSON),
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
+// =============================================================================
+// Connectivity Test Support
+// =============================================================================
+
+func (s *grpcServer) TestConfig(ctx context.Context, req *ValidateConfigRequest) (*ConfigResponse, error) {
+	tester, ok := s.Impl.(ConnectivityTester)
+	if !ok {
+		return &ConfigResponse{Success: false, Error: "plugin does not implement ConnectivityTester"}, nil
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(req.ConfigJson), &config); err != nil {
+		return &ConfigResponse{Success: false, Error: err.Error()}, nil
+	}
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+	if initProvider, ok := s.Impl.(InitializationProvider); ok {
+		if err := fillSecrets(ctx, s.host.current(), initProvider.GetRequiredConfig(), config); err != nil {
+			return &ConfigResponse{Success: false, Error: err.Error()}, nil
+		}
+	}
+
+	if err := tester.TestConfig(config); err != nil {
+		return &ConfigResponse{Success: false, Error: err.Error()}, nil
+	}
+	return &ConfigResponse{Success: true}, nil
+}
+
+func (c *grpcClient) TestConfig(config map[string]interface{}) error {
+	return c.TestConfigCtx(context.Background(), config)
+}
+
+// TestConfigCtx is like TestConfig but uses ctx for the RPC. If ctx has no deadline,
+// DefaultConnectivityTestTimeout applies.
+func (c *grpcClient) TestConfigCtx(ctx context.Context, config map[string]interface{}) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultConnectivityTestTimeout)
+		defer cancel()
+	}
+
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.TestConfig(ctx, &ValidateConfigRequest{ConfigJson: string(configJSON)})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
 // =============================================================================
 // Operations Provider Support - Server Side
 // =============================================================================
@@ -395,10 +820,21 @@ func (s *grpcServer) GetOperations(ctx context.Context, _ *Empty) (*OperationsRe
 		// Convert OperationInfo to proto
 		protoOps := make([]*ProtoOperationInfo, len(operations))
 		for i, op := range operations {
+			examples, err := toolExamplesToProto(op.Examples)
+			if err != nil {
+				return nil, err
+			}
 			protoOps[i] = &ProtoOperationInfo{
-				Name:               op.Name,
-				Parameters:         op.Parameters,
-				RequiredParameters: op.RequiredParameters,
+				Name:                op.Name,
+				Parameters:          op.Parameters,
+				RequiredParameters:  op.RequiredParameters,
+				RequiredPermissions: permissionTypesToStrings(op.RequiredPermissions),
+				SupportsDryRun:      op.SupportsDryRun,
+				TimeoutMs:           op.Timeout.Milliseconds(),
+				Examples:            examples,
+				Deprecation:         deprecationToProto(op.Deprecation),
+				Latency:             string(op.CostHint.Latency),
+				Cost:                op.CostHint.Cost,
 			}
 		}
 
@@ -432,34 +868,111 @@ func (s *grpcServer) AcceptsFiles(ctx context.Context, _ *Empty) (*AcceptsFilesR
 }
 
 func (s *grpcServer) CallWithFiles(ctx context.Context, req *CallWithFilesRequest) (*CallResponse, error) {
-	// Check if plugin implements FileAttachmentHandler
-	if fileHandler, ok := s.Impl.(FileAttachmentHandler); ok {
-		// Convert proto ProtoFileAttachment to pluginapi FileAttachment
-		files := make([]FileAttachment, len(req.Files))
-		for i, pf := range req.Files {
-			files[i] = FileAttachment{
-				Name:    pf.Name,
-				Type:    pf.Type,
-				Size:    pf.Size,
-				Content: pf.Content,
+	tool, middleware, err := s.resolveTool(req.ToolName)
+	if err != nil {
+		return callErrorResponse(err), nil
+	}
+	ctx, done := s.calls.start(ctx, req.CallId)
+	defer done()
+	ctx, cancel := withOperationTimeout(ctx, tool, req.ArgsJson)
+	defer cancel()
+	ctx = WithCallMetadata(ctx, req.Metadata)
+	ctx = withProtoCallMeta(ctx, req.CallMeta)
+	ctx = WithIdempotencyKey(ctx, req.IdempotencyKey)
+	ctx = WithToolName(ctx, req.ToolName)
+
+	outputProvider, producesFiles := tool.(FileOutputProvider)
+	fileHandler, acceptsFiles := tool.(FileAttachmentHandler)
+	if producesFiles || acceptsFiles {
+		files := fileAttachmentsFromProto(req.Files)
+		for i := range files {
+			file, err := files[i].Decompress()
+			if err != nil {
+				return callErrorResponse(WrapPluginError(ErrorCodeInvalidArgs, err)), nil
 			}
+			files[i] = file
 		}
 
-		result, err := fileHandler.CallWithFiles(ctx, req.ArgsJson, files)
+		if producesFiles {
+			return callWithOutputFiles(ctx, middleware, outputProvider, req.ArgsJson, files), nil
+		}
+		result, err := middleware(func(ctx context.Context, args string) (string, error) {
+			return fileHandler.CallWithFiles(ctx, args, files)
+		})(ctx, req.ArgsJson)
 		if err != nil {
-			return &CallResponse{Error: err.Error()}, nil
+			return callErrorResponse(err), nil
 		}
 		return &CallResponse{ResultJson: result}, nil
 	}
 
 	// Fallback to regular Call if plugin doesn't support files
-	result, err := s.Impl.Call(ctx, req.ArgsJson)
+	result, err := middleware(tool.Call)(ctx, req.ArgsJson)
 	if err != nil {
-		return &CallResponse{Error: err.Error()}, nil
+		return callErrorResponse(err), nil
 	}
 	return &CallResponse{ResultJson: result}, nil
 }
 
+// CallWithFilesStream reassembles the uploaded files and handles the call like CallWithFiles.
+func (s *grpcServer) CallWithFilesStream(stream ToolService_CallWithFilesStreamServer) error {
+	req, err := receiveFileUpload(stream)
+	if err != nil {
+		return err
+	}
+	resp, err := s.CallWithFiles(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
+
+// receiveFileUpload reads a CallWithFilesStream upload into a CallWithFilesRequest.
+func receiveFileUpload(stream ToolService_CallWithFilesStreamServer) (*CallWithFilesRequest, error) {
+	first, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	req := first.GetCall()
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "file upload must start with the call")
+	}
+
+	var current *ProtoFileAttachment
+	var total int
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return req, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch part := chunk.Part.(type) {
+		case *FileUploadChunk_File:
+			current = part.File
+			if current.Encoding == "" && current.Uri == "" && current.Size > int64(len(current.Content)) && current.Size <= maxUploadSize {
+				content := make([]byte, len(current.Content), current.Size)
+				copy(content, current.Content)
+				current.Content = content
+			}
+			req.Files = append(req.Files, current)
+			total += len(current.Content)
+		case *FileUploadChunk_Data:
+			if current == nil {
+				return nil, status.Error(codes.InvalidArgument, "file upload sent data before a file")
+			}
+			current.Content = append(current.Content, part.Data...)
+			total += len(part.Data)
+		default:
+			return nil, status.Error(codes.InvalidArgument, "file upload sent an empty chunk")
+		}
+		if total > maxUploadSize {
+			return nil, status.Errorf(codes.ResourceExhausted, "file upload exceeds %d bytes", maxUploadSize)
+		}
+	}
+}
+
 // =============================================================================
 // File Attachment Support - Client Side
 // =============================================================================
@@ -467,7 +980,12 @@ func (s *grpcServer) CallWithFiles(ctx context.Context, req *CallWithFilesReques
 // AcceptsFiles returns the list of file types this plugin accepts.
 // Returns nil and false if the plugin doesn't implement FileAttachmentHandler.
 func (c *grpcClient) AcceptsFiles() []string {
-	resp, err := c.client.AcceptsFiles(context.Background(), &Empty{})
+	return c.AcceptsFilesCtx(context.Background())
+}
+
+// AcceptsFilesCtx is like AcceptsFiles but uses ctx for the RPC.
+func (c *grpcClient) AcceptsFilesCtx(ctx context.Context) []string {
+	resp, err := c.client.AcceptsFiles(ctx, &Empty{})
 	if err != nil || resp == nil || !resp.SupportsFiles {
 		return nil
 	}
@@ -476,7 +994,12 @@ func (c *grpcClient) AcceptsFiles() []string {
 
 // SupportsFiles returns true if the plugin implements FileAttachmentHandler.
 func (c *grpcClient) SupportsFiles() bool {
-	resp, err := c.client.AcceptsFiles(context.Background(), &Empty{})
+	return c.SupportsFilesCtx(context.Background())
+}
+
+// SupportsFilesCtx is like SupportsFiles but uses ctx for the RPC.
+func (c *grpcClient) SupportsFilesCtx(ctx context.Context) bool {
+	resp, err := c.client.AcceptsFiles(ctx, &Empty{})
 	if err != nil || resp == nil {
 		return false
 	}
@@ -485,54 +1008,1715 @@ func (c *grpcClient) SupportsFiles() bool {
 
 // CallWithFiles executes the tool with arguments and file attachments.
 // If the plugin doesn't support files, it falls back to regular Call.
+// Attachments totalling more than uploadStreamThreshold are sent with CallWithFilesStream.
 func (c *grpcClient) CallWithFiles(ctx context.Context, args string, files []FileAttachment) (string, error) {
-	// Convert pluginapi FileAttachment to proto ProtoFileAttachment
-	protoFiles := make([]*ProtoFileAttachment, len(files))
-	for i, f := range files {
-		protoFiles[i] = &ProtoFileAttachment{
-			Name:    f.Name,
-			Type:    f.Type,
-			Size:    f.Size,
-			Content: f.Content,
-		}
+	resp, err := c.callWithFiles(ctx, args, files)
+	if err != nil {
+		return "", err
 	}
+	return resp.ResultJson, nil
+}
 
-	resp, err := c.client.CallWithFiles(ctx, &CallWithFilesRequest{
-		ArgsJson: args,
-		Files:    protoFiles,
-	})
+// CallWithFilesStream is like CallWithFiles but uploads the files in chunks, so
+// their size is not limited by MaxMessageSize. The plugin receives the same
+// []FileAttachment either way.
+func (c *grpcClient) CallWithFilesStream(ctx context.Context, args string, files []FileAttachment) (string, error) {
+	resp, err := c.callWithFilesStream(ctx, args, files)
 	if err != nil {
 		return "", err
 	}
-	if resp.Error != "" {
-		return "", fmt.Errorf("%s", resp.Error)
-	}
 	return resp.ResultJson, nil
 }
 
-// =============================================================================
-// Operations Provider Support - Client Side
-// =============================================================================
-
-// GetOperations returns operation-specific parameter information.
-// Returns nil if the plugin doesn't implement OperationsProvider.
-func (c *grpcClient) GetOperations() []OperationInfo {
-	resp, err := c.client.GetOperations(context.Background(), &Empty{})
-	if err != nil || resp == nil || !resp.SupportsOperations {
-		return nil
+// CallWithOutputFiles is like CallWithFiles but also returns the files the plugin
+// generated (see FileOutputProvider), decompressed. files may be nil.
+func (c *grpcClient) CallWithOutputFiles(ctx context.Context, args string, files []FileAttachment) (string, []FileAttachment, error) {
+	resp, err := c.callWithFiles(ctx, args, files)
+	if err != nil {
+		return "", nil, err
+	}
+	outputs := fileAttachmentsFromProto(resp.Files)
+	for i := range outputs {
+		if outputs[i], err = outputs[i].Decompress(); err != nil {
+			return "", nil, err
+		}
 	}
+	return resp.ResultJson, outputs, nil
+}
 
-	// Convert proto to OperationInfo
-	operations := make([]OperationInfo, len(resp.Operations))
-	for i, op := range resp.Operations {
-		operations[i] = OperationInfo{
-			Name:               op.Name,
-			Parameters:         op.Parameters,
-			RequiredParameters: op.RequiredParameters,
+// callWithFiles makes a CallWithFiles call, choosing how to send the files by their
+// size. Failed calls are returned as errors.
+func (c *grpcClient) callWithFiles(ctx context.Context, args string, files []FileAttachment) (*CallResponse, error) {
+	var contentSize int
+	for _, f := range files {
+		contentSize += len(f.Content)
+	}
+	if contentSize > uploadStreamThreshold {
+		resp, err := c.callWithFilesStream(ctx, args, files)
+		if status.Code(err) != codes.Unimplemented {
+			return resp, err
 		}
+		// Plugins built before chunked uploads only accept a single message
 	}
 
-	return operations
+	ctx, callID, finish := c.startCall(ctx)
+	defer finish()
+
+	req := &CallWithFilesRequest{
+		ArgsJson:       args,
+		Files:          fileAttachmentsToProto(files),
+		Metadata:       CallMetadata(ctx),
+		CallMeta:       callMetaToProto(ctx),
+		IdempotencyKey: IdempotencyKey(ctx),
+		CallId:         callID,
+		ToolName:       ToolName(ctx),
+	}
+	var resp *CallResponse
+	var err error
+	if contentSize > compressCallThreshold {
+		resp, err = c.client.CallWithFiles(ctx, req, grpc.UseCompressor(gzip.Name))
+		if status.Code(err) == codes.Unimplemented {
+			// Plugins built before compression support can't decompress the request
+			resp, err = c.client.CallWithFiles(ctx, req)
+		}
+	} else {
+		resp, err = c.client.CallWithFiles(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, callError(resp.Error, resp.StructuredError)
+	}
+	return resp, nil
+}
+
+func (c *grpcClient) callWithFilesStream(ctx context.Context, args string, files []FileAttachment) (*CallResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx, callID, finish := c.startCall(ctx)
+	defer finish()
+
+	stream, err := c.client.CallWithFilesStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	send := func(chunk *FileUploadChunk) error {
+		err := stream.Send(chunk)
+		if err == io.EOF {
+			// The plugin ended the upload; the reason comes with the response
+			_, err = stream.CloseAndRecv()
+		}
+		return err
+	}
+
+	err = send(&FileUploadChunk{Part: &FileUploadChunk_Call{Call: &CallWithFilesRequest{
+		ArgsJson:       args,
+		Metadata:       CallMetadata(ctx),
+		CallMeta:       callMetaToProto(ctx),
+		IdempotencyKey: IdempotencyKey(ctx),
+		CallId:         callID,
+		ToolName:       ToolName(ctx),
+	}}})
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		first := min(len(f.Content), uploadChunkSize)
+		err := send(&FileUploadChunk{Part: &FileUploadChunk_File{File: &ProtoFileAttachment{
+			Name:     f.Name,
+			Type:     f.Type,
+			Size:     f.Size,
+			Content:  f.Content[:first],
+			Encoding: f.Encoding,
+			Uri:      f.URI,
+		}}})
+		if err != nil {
+			return nil, err
+		}
+		for offset := first; offset < len(f.Content); offset += uploadChunkSize {
+			data := f.Content[offset:min(offset+uploadChunkSize, len(f.Content))]
+			if err := send(&FileUploadChunk{Part: &FileUploadChunk_Data{Data: data}}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, callError(resp.Error, resp.StructuredError)
+	}
+	return resp, nil
+}
+
+func fileAttachmentsToProto(files []FileAttachment) []*ProtoFileAttachment {
+	protoFiles := make([]*ProtoFileAttachment, len(files))
+	for i, f := range files {
+		protoFiles[i] = &ProtoFileAttachment{
+			Name:     f.Name,
+			Type:     f.Type,
+			Size:     f.Size,
+			Content:  f.Content,
+			Encoding: f.Encoding,
+			Uri:      f.URI,
+		}
+	}
+	return protoFiles
+}
+
+func fileAttachmentsFromProto(protoFiles []*ProtoFileAttachment) []FileAttachment {
+	files := make([]FileAttachment, len(protoFiles))
+	for i, pf := range protoFiles {
+		files[i] = FileAttachment{
+			Name:     pf.Name,
+			Type:     pf.Type,
+			Size:     pf.Size,
+			Content:  pf.Content,
+			Encoding: pf.Encoding,
+			URI:      pf.Uri,
+		}
+	}
+	return files
+}
+
+// =============================================================================
+// Operations Provider Support - Client Side
+// =============================================================================
+
+// GetOperations returns operation-specific parameter information.
+// Returns nil if the plugin doesn't implement OperationsProvider.
+func (c *grpcClient) GetOperations() []OperationInfo {
+	return c.GetOperationsCtx(context.Background())
+}
+
+// GetOperationsCtx is like GetOperations but uses ctx for the RPC.
+func (c *grpcClient) GetOperationsCtx(ctx context.Context) []OperationInfo {
+	resp, err := c.client.GetOperations(ctx, &Empty{})
+	if err != nil {
+		return nil
+	}
+	return operationsFromProto(resp)
+}
+
+// operationsFromProto converts a GetOperations response, returning nil if the
+// plugin doesn't implement OperationsProvider.
+func operationsFromProto(resp *OperationsResponse) []OperationInfo {
+	if resp == nil || !resp.SupportsOperations {
+		return nil
+	}
+
+	// Convert proto to OperationInfo
+	operations := make([]OperationInfo, len(resp.Operations))
+	for i, op := range resp.Operations {
+		operations[i] = OperationInfo{
+			Name:                op.Name,
+			Parameters:          op.Parameters,
+			RequiredParameters:  op.RequiredParameters,
+			RequiredPermissions: permissionTypesFromStrings(op.RequiredPermissions),
+			SupportsDryRun:      op.SupportsDryRun,
+			Timeout:             time.Duration(op.TimeoutMs) * time.Millisecond,
+			Examples:            toolExamplesFromProto(op.Examples),
+			Deprecation:         deprecationFromProto(op.Deprecation),
+			CostHint:            CostHint{Latency: LatencyClass(op.Latency), Cost: op.Cost},
+		}
+	}
+
+	return operations
+}
+
+func permissionTypesToStrings(perms []PermissionType) []string {
+	if len(perms) == 0 {
+		return nil
+	}
+	out := make([]string, len(perms))
+	for i, perm := range perms {
+		out[i] = string(perm)
+	}
+	return out
+}
+
+func permissionTypesFromStrings(perms []string) []PermissionType {
+	if len(perms) == 0 {
+		return nil
+	}
+	out := make([]PermissionType, len(perms))
+	for i, perm := range perms {
+		out[i] = PermissionType(perm)
+	}
+	return out
+}
+
+// =============================================================================
+// State Snapshot Support - Server Side
+// =============================================================================
+
+func (s *grpcServer) SnapshotState(ctx context.Context, _ *Empty) (*StateSnapshotResponse, error) {
+	// Check if plugin implements StatefulPlugin
+	if stateful, ok := s.Impl.(StatefulPlugin); ok {
+		state, err := stateful.SnapshotState(ctx)
+		if err != nil {
+			return &StateSnapshotResponse{SupportsState: true, Error: err.Error()}, nil
+		}
+		return &StateSnapshotResponse{State: state, SupportsState: true}, nil
+	}
+	// Plugin doesn't implement StatefulPlugin
+	return &StateSnapshotResponse{SupportsState: false}, nil
+}
+
+func (s *grpcServer) RestoreState(ctx context.Context, req *RestoreStateRequest) (*ConfigResponse, error) {
+	if stateful, ok := s.Impl.(StatefulPlugin); ok {
+		if err := stateful.RestoreState(ctx, req.State); err != nil {
+			return &ConfigResponse{Success: false, Error: err.Error()}, nil
+		}
+		return &ConfigResponse{Success: true}, nil
+	}
+	return &ConfigResponse{Success: false, Error: "plugin does not implement StatefulPlugin"}, nil
+}
+
+// =============================================================================
+// State Snapshot Support - Client Side
+// =============================================================================
+
+// SnapshotState returns the plugin's serialized state.
+// Returns an error if the plugin doesn't implement StatefulPlugin.
+func (c *grpcClient) SnapshotState(ctx context.Context) ([]byte, error) {
+	resp, err := c.client.SnapshotState(ctx, &Empty{})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.SupportsState {
+		return nil, fmt.Errorf("plugin does not implement StatefulPlugin")
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+	return resp.State, nil
+}
+
+// RestoreState restores a snapshot previously returned by SnapshotState.
+func (c *grpcClient) RestoreState(ctx context.Context, state []byte) error {
+	resp, err := c.client.RestoreState(ctx, &RestoreStateRequest{State: state})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
+// =============================================================================
+// State Export Support - Server Side
+// =============================================================================
+
+func (s *grpcServer) ExportState(_ context.Context, _ *Empty) (*StateSnapshotResponse, error) {
+	// Check if plugin implements StatePorter
+	if porter, ok := s.Impl.(StatePorter); ok {
+		state, err := porter.ExportState()
+		if err != nil {
+			return &StateSnapshotResponse{SupportsState: true, Error: err.Error()}, nil
+		}
+		return &StateSnapshotResponse{State: state, SupportsState: true}, nil
+	}
+	// Plugin doesn't implement StatePorter
+	return &StateSnapshotResponse{SupportsState: false}, nil
+}
+
+func (s *grpcServer) ImportState(_ context.Context, req *RestoreStateRequest) (*ConfigResponse, error) {
+	if porter, ok := s.Impl.(StatePorter); ok {
+		if err := porter.ImportState(req.State); err != nil {
+			return &ConfigResponse{Success: false, Error: err.Error()}, nil
+		}
+		return &ConfigResponse{Success: true}, nil
+	}
+	return &ConfigResponse{Success: false, Error: "plugin does not implement StatePorter"}, nil
+}
+
+// =============================================================================
+// State Export Support - Client Side
+// =============================================================================
+
+func (c *grpcClient) ExportState() ([]byte, error) {
+	return c.ExportStateCtx(context.Background())
+}
+
+// ExportStateCtx is like ExportState but uses ctx for the RPC.
+// Returns an error if the plugin doesn't implement StatePorter.
+func (c *grpcClient) ExportStateCtx(ctx context.Context) ([]byte, error) {
+	resp, err := c.client.ExportState(ctx, &Empty{})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.SupportsState {
+		return nil, fmt.Errorf("plugin does not implement StatePorter")
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+	return resp.State, nil
+}
+
+func (c *grpcClient) ImportState(state []byte) error {
+	return c.ImportStateCtx(context.Background(), state)
+}
+
+// ImportStateCtx is like ImportState but uses ctx for the RPC.
+func (c *grpcClient) ImportStateCtx(ctx context.Context, state []byte) error {
+	resp, err := c.client.ImportState(ctx, &RestoreStateRequest{State: state})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
+// =============================================================================
+// Upgrade Handoff Support - Server Side
+// =============================================================================
+
+func (s *grpcServer) PrepareHandoff(ctx context.Context, _ *Empty) (*HandoffResponse, error) {
+	// Check if plugin implements HandoffProvider
+	if handoff, ok := s.Impl.(HandoffProvider); ok {
+		state, err := handoff.PrepareHandoff(ctx)
+		if err != nil {
+			return &HandoffResponse{SupportsHandoff: true, Error: err.Error()}, nil
+		}
+
+		version := state.FromVersion
+		if version == "" {
+			if versionedTool, ok := s.Impl.(VersionedTool); ok {
+				version = versionedTool.Version()
+			}
+		}
+
+		return &HandoffResponse{
+			State:           state.Data,
+			Version:         version,
+			SupportsHandoff: true,
+		}, nil
+	}
+	// Plugin doesn't implement HandoffProvider
+	return &HandoffResponse{SupportsHandoff: false}, nil
+}
+
+func (s *grpcServer) ReceiveHandoff(ctx context.Context, req *HandoffRequest) (*ConfigResponse, error) {
+	if handoff, ok := s.Impl.(HandoffProvider); ok {
+		err := handoff.ReceiveHandoff(ctx, HandoffState{
+			FromVersion: req.FromVersion,
+			Data:        req.State,
+		})
+		if err != nil {
+			return &ConfigResponse{Success: false, Error: err.Error()}, nil
+		}
+		return &ConfigResponse{Success: true}, nil
+	}
+	return &ConfigResponse{Success: false, Error: "plugin does not implement HandoffProvider"}, nil
+}
+
+// =============================================================================
+// Upgrade Handoff Support - Client Side
+// =============================================================================
+
+// PrepareHandoff asks the outgoing plugin version for its transferable state.
+// Returns an error if the plugin doesn't implement HandoffProvider.
+func (c *grpcClient) PrepareHandoff(ctx context.Context) (HandoffState, error) {
+	resp, err := c.client.PrepareHandoff(ctx, &Empty{})
+	if err != nil {
+		return HandoffState{}, err
+	}
+	if !resp.SupportsHandoff {
+		return HandoffState{}, fmt.Errorf("plugin does not implement HandoffProvider")
+	}
+	if resp.Error != "" {
+		return HandoffState{}, fmt.Errorf("%s", resp.Error)
+	}
+	return HandoffState{FromVersion: resp.Version, Data: resp.State}, nil
+}
+
+// ReceiveHandoff passes state from the previous plugin version to this one.
+func (c *grpcClient) ReceiveHandoff(ctx context.Context, state HandoffState) error {
+	resp, err := c.client.ReceiveHandoff(ctx, &HandoffRequest{
+		State:       state.Data,
+		FromVersion: state.FromVersion,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
+// =============================================================================
+// System Prompt Support
+// =============================================================================
+
+func (s *grpcServer) GetSystemPromptFragment(ctx context.Context, _ *Empty) (*SystemPromptResponse, error) {
+	if promptProvider, ok := s.Impl.(SystemPromptProvider); ok {
+		return &SystemPromptResponse{
+			Fragment:             promptProvider.GetSystemPromptFragment(),
+			SupportsSystemPrompt: true,
+		}, nil
+	}
+	return &SystemPromptResponse{SupportsSystemPrompt: false}, nil
+}
+
+// GetSystemPromptFragment returns the plugin's system prompt fragment.
+// Returns an empty string if the plugin doesn't implement SystemPromptProvider.
+func (c *grpcClient) GetSystemPromptFragment() string {
+	return c.GetSystemPromptFragmentCtx(context.Background())
+}
+
+// GetSystemPromptFragmentCtx is like GetSystemPromptFragment but uses ctx for the RPC.
+func (c *grpcClient) GetSystemPromptFragmentCtx(ctx context.Context) string {
+	resp, err := c.client.GetSystemPromptFragment(ctx, &Empty{})
+	if err != nil || resp == nil || !resp.SupportsSystemPrompt {
+		return ""
+	}
+	return resp.Fragment
+}
+
+func (s *grpcServer) ContributePrompt(ctx context.Context, req *PromptContributionRequest) (*PromptContributionResponse, error) {
+	contributor, ok := s.Impl.(PromptContributor)
+	if !ok {
+		static, err := s.GetSystemPromptFragment(ctx, &Empty{})
+		if err != nil {
+			return nil, err
+		}
+		return &PromptContributionResponse{Fragment: static.Fragment}, nil
+	}
+	fragment, err := contributor.ContributePrompt(ctx, PromptRequest{
+		ConversationID: req.ConversationId,
+		AgentName:      req.AgentName,
+	})
+	if err != nil {
+		return &PromptContributionResponse{Error: err.Error()}, nil
+	}
+	return &PromptContributionResponse{Fragment: fragment}, nil
+}
+
+// ContributePrompt returns the plugin's system prompt fragment for a conversation.
+// Plugins without PromptContributor, or built before it, answer with their static
+// GetSystemPromptFragment, so hosts calling this per conversation need nothing else.
+func (c *grpcClient) ContributePrompt(ctx context.Context, req PromptRequest) (string, error) {
+	resp, err := c.client.ContributePrompt(ctx, &PromptContributionRequest{
+		ConversationId: req.ConversationID,
+		AgentName:      req.AgentName,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return c.GetSystemPromptFragmentCtx(ctx), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if resp.Error != "" {
+		return "", fmt.Errorf("%s", resp.Error)
+	}
+	return resp.Fragment, nil
+}
+
+// =============================================================================
+// Context Enrichment Support
+// =============================================================================
+
+func (s *grpcServer) EnrichContext(ctx context.Context, req *EnrichContextRequest) (*EnrichContextResponse, error) {
+	enricher, ok := s.Impl.(ContextEnricher)
+	if !ok {
+		return &EnrichContextResponse{SupportsEnrichment: false}, nil
+	}
+	items, err := enricher.EnrichContext(ctx, EnrichRequest{
+		ConversationID: req.ConversationId,
+		AgentName:      req.AgentName,
+		UserMessage:    req.UserMessage,
+	})
+	if err == nil {
+		var protoItems []*ProtoContextItem
+		if protoItems, err = contextItemsToProto(items); err == nil {
+			return &EnrichContextResponse{Items: protoItems, SupportsEnrichment: true}, nil
+		}
+	}
+	return &EnrichContextResponse{SupportsEnrichment: true, Error: err.Error()}, nil
+}
+
+// EnrichContext returns the context the plugin attaches to the next LLM turn.
+// Returns nothing if the plugin doesn't implement ContextEnricher. If ctx has no
+// deadline, the call is bounded by DefaultEnrichmentTimeout.
+func (c *grpcClient) EnrichContext(ctx context.Context, req EnrichRequest) ([]ContextItem, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultEnrichmentTimeout)
+		defer cancel()
+	}
+	resp, err := c.client.EnrichContext(ctx, &EnrichContextRequest{
+		ConversationId: req.ConversationID,
+		AgentName:      req.AgentName,
+		UserMessage:    req.UserMessage,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+	if !resp.SupportsEnrichment {
+		return nil, nil
+	}
+	return contextItemsFromProto(resp.Items)
+}
+
+// =============================================================================
+// Embedding Provider Support
+// =============================================================================
+
+func (s *grpcServer) Embed(ctx context.Context, req *EmbedRequest) (*EmbedResponse, error) {
+	// Check if plugin implements EmbeddingProvider
+	if embedder, ok := s.Impl.(EmbeddingProvider); ok {
+		vectors, err := embedder.Embed(ctx, req.Texts)
+		if err != nil {
+			return &EmbedResponse{SupportsEmbeddings: true, Error: err.Error()}, nil
+		}
+		if len(vectors) != len(req.Texts) {
+			return &EmbedResponse{
+				SupportsEmbeddings: true,
+				Error:              fmt.Sprintf("plugin returned %d embeddings for %d texts", len(vectors), len(req.Texts)),
+			}, nil
+		}
+
+		embeddings := make([]*Embedding, len(vectors))
+		for i, v := range vectors {
+			embeddings[i] = &Embedding{Values: v}
+		}
+		return &EmbedResponse{Embeddings: embeddings, SupportsEmbeddings: true}, nil
+	}
+	// Plugin doesn't implement EmbeddingProvider
+	return &EmbedResponse{SupportsEmbeddings: false}, nil
+}
+
+// Embed returns one embedding vector per input text.
+// Returns an error if the plugin doesn't implement EmbeddingProvider.
+func (c *grpcClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	resp, err := c.client.Embed(ctx, &EmbedRequest{Texts: texts})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.SupportsEmbeddings {
+		return nil, fmt.Errorf("plugin does not implement EmbeddingProvider")
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+
+	vectors := make([][]float32, len(resp.Embeddings))
+	for i, e := range resp.Embeddings {
+		vectors[i] = e.Values
+	}
+	return vectors, nil
+}
+
+// =============================================================================
+// File Watch Support - Server Side
+// =============================================================================
+
+func (s *grpcServer) GetFileWatches(ctx context.Context, _ *Empty) (*FileWatchesResponse, error) {
+	// Check if plugin implements FileWatchProvider
+	watcher, ok := s.Impl.(FileWatchProvider)
+	if !ok {
+		return &FileWatchesResponse{SupportsFileWatch: false}, nil
+	}
+
+	watches := watcher.GetFileWatches()
+	protoWatches := make([]*ProtoFileWatch, len(watches))
+	for i, w := range watches {
+		protoWatches[i] = &ProtoFileWatch{
+			Path:      w.Path,
+			Glob:      w.Glob,
+			Recursive: w.Recursive,
+		}
+	}
+
+	// Watches are only honored if the plugin declares file access
+	var permissions PluginPermissions
+	if permProvider, ok := s.Impl.(PermissionProvider); ok {
+		permissions = permProvider.GetRequiredPermissions()
+	}
+	resp := &FileWatchesResponse{Watches: protoWatches, SupportsFileWatch: true}
+	if err := ValidateFileWatches(watches, permissions); err != nil {
+		resp.Error = err.Error()
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) WatchFileChanges(stream grpc.BidiStreamingServer[FileChangesRequest, ConfigResponse]) error {
+	watcher, ok := s.Impl.(FileWatchProvider)
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp := &ConfigResponse{Success: true}
+		if !ok {
+			resp = &ConfigResponse{Success: false, Error: "plugin does not implement FileWatchProvider"}
+		} else if err := watcher.OnFileChanges(stream.Context(), fileChangeEventsFromProto(req.Events)); err != nil {
+			resp = &ConfigResponse{Success: false, Error: err.Error()}
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// =============================================================================
+// File Watch Support - Client Side
+// =============================================================================
+
+// GetFileWatches returns the plugin's watch declarations.
+// Returns nil if the plugin doesn't implement FileWatchProvider or its watches
+// fail validation against its declared permissions.
+func (c *grpcClient) GetFileWatches() []FileWatch {
+	return c.GetFileWatchesCtx(context.Background())
+}
+
+// GetFileWatchesCtx is like GetFileWatches but uses ctx for the RPC.
+func (c *grpcClient) GetFileWatchesCtx(ctx context.Context) []FileWatch {
+	resp, err := c.client.GetFileWatches(ctx, &Empty{})
+	if err != nil {
+		return nil
+	}
+	return fileWatchesFromProto(resp)
+}
+
+// fileWatchesFromProto converts a GetFileWatches response, returning nil if the
+// plugin doesn't implement FileWatchProvider or its watches are invalid.
+func fileWatchesFromProto(resp *FileWatchesResponse) []FileWatch {
+	if resp == nil || !resp.SupportsFileWatch || resp.Error != "" {
+		return nil
+	}
+
+	watches := make([]FileWatch, len(resp.Watches))
+	for i, w := range resp.Watches {
+		watches[i] = FileWatch{
+			Path:      w.Path,
+			Glob:      w.Glob,
+			Recursive: w.Recursive,
+		}
+	}
+	return watches
+}
+
+// OnFileChanges delivers a single batch of events.
+// Use OpenFileChangeStream to deliver a continuous feed over one stream.
+func (c *grpcClient) OnFileChanges(ctx context.Context, events []FileChangeEvent) error {
+	stream, err := c.OpenFileChangeStream(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(events); err != nil {
+		_ = stream.Close()
+		return err
+	}
+	return stream.Close()
+}
+
+// OpenFileChangeStream opens a stream for delivering batches of file change events.
+func (c *grpcClient) OpenFileChangeStream(ctx context.Context) (FileChangeStream, error) {
+	stream, err := c.client.WatchFileChanges(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &fileChangeStream{stream: stream}, nil
+}
+
+// fileChangeStream adapts the WatchFileChanges client stream to FileChangeStream
+type fileChangeStream struct {
+	stream grpc.BidiStreamingClient[FileChangesRequest, ConfigResponse]
+}
+
+func (s *fileChangeStream) Send(events []FileChangeEvent) error {
+	protoEvents := make([]*ProtoFileChangeEvent, len(events))
+	for i, e := range events {
+		protoEvents[i] = &ProtoFileChangeEvent{
+			Path:    e.Path,
+			Op:      string(e.Op),
+			OldPath: e.OldPath,
+		}
+	}
+
+	if err := s.stream.Send(&FileChangesRequest{Events: protoEvents}); err != nil {
+		return err
+	}
+	resp, err := s.stream.Recv()
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
+func (s *fileChangeStream) Close() error {
+	if err := s.stream.CloseSend(); err != nil {
+		return err
+	}
+	// Wait for the server to finish so the last batch is fully handled
+	if _, err := s.stream.Recv(); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+func fileChangeEventsFromProto(protoEvents []*ProtoFileChangeEvent) []FileChangeEvent {
+	events := make([]FileChangeEvent, len(protoEvents))
+	for i, e := range protoEvents {
+		events[i] = FileChangeEvent{
+			Path:    e.Path,
+			Op:      FileChangeOp(e.Op),
+			OldPath: e.OldPath,
+		}
+	}
+	return events
+}
+
+// =============================================================================
+// Scheduled Task Support - Server Side
+// =============================================================================
+
+func (s *grpcServer) GetScheduledTasks(ctx context.Context, _ *Empty) (*ScheduledTasksResponse, error) {
+	provider, ok := s.Impl.(ScheduledTaskProvider)
+	if !ok {
+		return &ScheduledTasksResponse{SupportsScheduledTasks: false}, nil
+	}
+
+	tasks := provider.GetScheduledTasks()
+	protoTasks := make([]*ProtoScheduledTask, len(tasks))
+	for i, task := range tasks {
+		protoTasks[i] = &ProtoScheduledTask{
+			Name:        task.Name,
+			Schedule:    task.Schedule,
+			Description: task.Description,
+			TimeoutMs:   task.Timeout.Milliseconds(),
+		}
+	}
+	resp := &ScheduledTasksResponse{Tasks: protoTasks, SupportsScheduledTasks: true}
+	if err := ValidateScheduledTasks(tasks); err != nil {
+		resp.Error = err.Error()
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) ExecuteScheduledTask(ctx context.Context, req *ExecuteScheduledTaskRequest) (*ConfigResponse, error) {
+	provider, ok := s.Impl.(ScheduledTaskProvider)
+	if !ok {
+		return &ConfigResponse{Success: false, Error: "plugin does not implement ScheduledTaskProvider"}, nil
+	}
+	var task *ScheduledTask
+	for _, t := range provider.GetScheduledTasks() {
+		if t.Name == req.Name {
+			task = &t
+			break
+		}
+	}
+	if task == nil {
+		return &ConfigResponse{Success: false, Error: fmt.Sprintf("unknown scheduled task %q", req.Name)}, nil
+	}
+	if task.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, task.Timeout)
+		defer cancel()
+	}
+	if err := provider.ExecuteScheduledTask(ctx, req.Name, time.UnixMilli(req.ScheduledAtUnixMs)); err != nil {
+		return &ConfigResponse{Success: false, Error: err.Error()}, nil
+	}
+	return &ConfigResponse{Success: true}, nil
+}
+
+// =============================================================================
+// Scheduled Task Support - Client Side
+// =============================================================================
+
+// GetScheduledTasks returns the plugin's scheduled tasks.
+// Returns nil if the plugin doesn't implement ScheduledTaskProvider or its tasks
+// fail validation.
+func (c *grpcClient) GetScheduledTasks() []ScheduledTask {
+	return c.GetScheduledTasksCtx(context.Background())
+}
+
+// GetScheduledTasksCtx is like GetScheduledTasks but uses ctx for the RPC.
+func (c *grpcClient) GetScheduledTasksCtx(ctx context.Context) []ScheduledTask {
+	resp, err := c.client.GetScheduledTasks(ctx, &Empty{})
+	if err != nil {
+		return nil
+	}
+	return scheduledTasksFromProto(resp)
+}
+
+// scheduledTasksFromProto converts a GetScheduledTasks response, returning nil if
+// the plugin doesn't implement ScheduledTaskProvider or its tasks are invalid.
+func scheduledTasksFromProto(resp *ScheduledTasksResponse) []ScheduledTask {
+	if resp == nil || !resp.SupportsScheduledTasks || resp.Error != "" {
+		return nil
+	}
+
+	tasks := make([]ScheduledTask, len(resp.Tasks))
+	for i, t := range resp.Tasks {
+		tasks[i] = ScheduledTask{
+			Name:        t.Name,
+			Schedule:    t.Schedule,
+			Description: t.Description,
+			Timeout:     time.Duration(t.TimeoutMs) * time.Millisecond,
+		}
+	}
+	return tasks
+}
+
+// ExecuteScheduledTask runs the named task, which was due at scheduledAt.
+// Hosts compute due times with ParseSchedule.
+func (c *grpcClient) ExecuteScheduledTask(ctx context.Context, name string, scheduledAt time.Time) error {
+	resp, err := c.client.ExecuteScheduledTask(ctx, &ExecuteScheduledTaskRequest{
+		Name:              name,
+		ScheduledAtUnixMs: scheduledAt.UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
+// =============================================================================
+// Streaming Call Support - Server Side
+// =============================================================================
+
+func (s *grpcServer) CallStream(req *CallRequest, stream grpc.ServerStreamingServer[CallStreamChunk]) error {
+	tool, middleware, err := s.resolveTool(req.ToolName)
+	if err != nil {
+		return stream.Send(finalCallStreamChunk("", err, false))
+	}
+	ctx, done := s.calls.start(stream.Context(), req.CallId)
+	defer done()
+	ctx, cancel := withOperationTimeout(ctx, tool, req.ArgsJson)
+	defer cancel()
+	ctx = WithCallMetadata(ctx, req.Metadata)
+	ctx = withProtoCallMeta(ctx, req.CallMeta)
+	ctx = WithIdempotencyKey(ctx, req.IdempotencyKey)
+	ctx = WithToolName(ctx, req.ToolName)
+
+	// Check if the tool implements StreamingTool
+	streamer, ok := tool.(StreamingTool)
+	if !ok {
+		// Answer with a single final chunk so hosts can always use CallStream
+		result, err := middleware(tool.Call)(ctx, req.ArgsJson)
+		return stream.Send(finalCallStreamChunk(result, err, false))
+	}
+
+	result, err := middleware(func(ctx context.Context, args string) (string, error) {
+		return streamer.CallStream(ctx, args, func(chunk CallChunk) error {
+			return stream.Send(&CallStreamChunk{
+				Message:           chunk.Message,
+				Progress:          chunk.Progress,
+				Partial:           chunk.Partial,
+				SupportsStreaming: true,
+			})
+		})
+	})(ctx, req.ArgsJson)
+	return stream.Send(finalCallStreamChunk(result, err, true))
+}
+
+func finalCallStreamChunk(result string, err error, streaming bool) *CallStreamChunk {
+	chunk := &CallStreamChunk{Done: true, Progress: 1, SupportsStreaming: streaming}
+	if err != nil {
+		chunk.Error = err.Error()
+		chunk.StructuredError = pluginErrorToProto(err)
+	} else {
+		chunk.ResultJson = result
+	}
+	return chunk
+}
+
+// =============================================================================
+// Streaming Call Support - Client Side
+// =============================================================================
+
+// CallStream executes the tool, calling emit for each progress update.
+// Plugins that don't implement StreamingTool produce no updates, only the result.
+// If emit returns an error, the call is cancelled and that error is returned.
+func (c *grpcClient) CallStream(ctx context.Context, args string, emit func(CallChunk) error) (string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx, callID, finish := c.startCall(ctx)
+	defer finish()
+
+	stream, err := c.client.CallStream(ctx, &CallRequest{
+		ArgsJson:       args,
+		Metadata:       CallMetadata(ctx),
+		CallMeta:       callMetaToProto(ctx),
+		IdempotencyKey: IdempotencyKey(ctx),
+		CallId:         callID,
+		ToolName:       ToolName(ctx),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return "", fmt.Errorf("call stream ended without a result")
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if chunk.Done {
+			if chunk.Error != "" {
+				return "", callError(chunk.Error, chunk.StructuredError)
+			}
+			return chunk.ResultJson, nil
+		}
+		if err := emit(CallChunk{
+			Message:  chunk.Message,
+			Progress: chunk.Progress,
+			Partial:  chunk.Partial,
+		}); err != nil {
+			return "", err
+		}
+	}
+}
+
+// =============================================================================
+// Call Cancellation Support - Server Side
+// =============================================================================
+
+func (s *grpcServer) CancelCall(ctx context.Context, req *CancelCallRequest) (*ConfigResponse, error) {
+	if !s.calls.cancel(req.CallId) {
+		return &ConfigResponse{Success: false, Error: fmt.Sprintf("no running call with ID %q", req.CallId)}, nil
+	}
+	return &ConfigResponse{Success: true}, nil
+}
+
+// =============================================================================
+// Call Cancellation Support - Client Side
+// =============================================================================
+
+// CancelCall cancels a running call by ID (see WithCallID).
+// Calls are also cancelled automatically when their own context ends.
+func (c *grpcClient) CancelCall(ctx context.Context, callID string) error {
+	resp, err := c.client.CancelCall(ctx, &CancelCallRequest{CallId: callID})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
+// startCall assigns the call an ID (from WithCallID, or a random one) and an
+// invocation ID (see WithInvocationID), and tells the plugin to cancel it if ctx
+// ends before the call returns. The call must be made with the returned context. gRPC stream teardown
+// alone is not enough, since it only reaches the plugin when the connection is healthy
+// and not behind a proxy that keeps upstream requests alive.
+// The returned function must be called when the call returns.
+func (c *grpcClient) startCall(ctx context.Context) (context.Context, string, func()) {
+	ctx = withInvocationMetadata(ctx)
+	callID := CallID(ctx)
+	if callID == "" {
+		callID = newCallID()
+	}
+
+	// finished receives whether ctx had ended by the time the call returned
+	finished := make(chan bool, 1)
+	go func() {
+		var cancelled bool
+		select {
+		case cancelled = <-finished:
+		case <-ctx.Done():
+			// Prefer the call's own outcome if it returned first
+			select {
+			case cancelled = <-finished:
+			default:
+				cancelled = true
+			}
+		}
+		if !cancelled {
+			return
+		}
+		cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelCallTimeout)
+		defer cancel()
+		_, _ = c.client.CancelCall(cancelCtx, &CancelCallRequest{CallId: callID})
+	}()
+	return ctx, callID, func() { finished <- ctx.Err() != nil }
+}
+
+// =============================================================================
+// Background Task Support - Server Side
+// =============================================================================
+
+func (s *grpcServer) StartTask(ctx context.Context, req *StartTaskRequest) (*StartTaskResponse, error) {
+	tool, middleware, err := s.resolveTool(req.ToolName)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	provider, ok := tool.(TaskProvider)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "plugin does not implement TaskProvider")
+	}
+	ctx = WithCallMetadata(ctx, req.Metadata)
+	ctx = withProtoCallMeta(ctx, req.CallMeta)
+	ctx = WithIdempotencyKey(ctx, req.IdempotencyKey)
+	ctx = WithToolName(ctx, req.ToolName)
+
+	id := s.tasks.start(ctx, req.ToolName, req.IdempotencyKey, func(ctx context.Context, report func(float64, string)) (result string, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recordPanic(s, "RunTask", r)
+			}
+		}()
+		return middleware(func(ctx context.Context, args string) (string, error) {
+			return provider.RunTask(ctx, args, report)
+		})(ctx, req.ArgsJson)
+	})
+	return &StartTaskResponse{TaskId: id}, nil
+}
+
+func (s *grpcServer) GetTaskStatus(ctx context.Context, req *TaskRequest) (*TaskStatusResponse, error) {
+	task, ok := s.tasks.status(req.TaskId)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no task with ID %q", req.TaskId)
+	}
+	resp := &TaskStatusResponse{
+		TaskId:        task.ID,
+		State:         string(task.State),
+		Percent:       task.Percent,
+		Message:       task.Message,
+		ResultJson:    task.Result,
+		StartedUnixMs: task.StartedAt.UnixMilli(),
+	}
+	if task.Done() {
+		resp.FinishedUnixMs = task.FinishedAt.UnixMilli()
+	}
+	if task.Err != nil {
+		resp.Error = task.Err.Error()
+		resp.StructuredError = pluginErrorToProto(task.Err)
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) CancelTask(ctx context.Context, req *TaskRequest) (*ConfigResponse, error) {
+	if !s.tasks.cancel(req.TaskId) {
+		return &ConfigResponse{Success: false, Error: fmt.Sprintf("no running task with ID %q", req.TaskId)}, nil
+	}
+	return &ConfigResponse{Success: true}, nil
+}
+
+// =============================================================================
+// Background Task Support - Client Side
+// =============================================================================
+
+// StartTask starts a background task with args and returns its ID right away.
+// Call metadata, the idempotency key, and the tool name in ctx are sent as for Call;
+// retrying with the same idempotency key returns the task already started.
+// Returns an Unimplemented status error if the plugin doesn't implement TaskProvider.
+func (c *grpcClient) StartTask(ctx context.Context, args string) (string, error) {
+	resp, err := c.client.StartTask(withInvocationMetadata(ctx), &StartTaskRequest{
+		ArgsJson:       args,
+		Metadata:       CallMetadata(ctx),
+		CallMeta:       callMetaToProto(ctx),
+		IdempotencyKey: IdempotencyKey(ctx),
+		ToolName:       ToolName(ctx),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.TaskId, nil
+}
+
+// GetTaskStatus returns a snapshot of a background task. Tasks can be queried
+// for an hour after they finish; after that a NotFound status error is returned.
+func (c *grpcClient) GetTaskStatus(ctx context.Context, taskID string) (TaskStatus, error) {
+	resp, err := c.client.GetTaskStatus(ctx, &TaskRequest{TaskId: taskID})
+	if err != nil {
+		return TaskStatus{}, err
+	}
+	task := TaskStatus{
+		ID:        resp.TaskId,
+		State:     TaskState(resp.State),
+		Percent:   resp.Percent,
+		Message:   resp.Message,
+		Result:    resp.ResultJson,
+		StartedAt: time.UnixMilli(resp.StartedUnixMs),
+	}
+	if resp.FinishedUnixMs != 0 {
+		task.FinishedAt = time.UnixMilli(resp.FinishedUnixMs)
+	}
+	if resp.Error != "" {
+		task.Err = callError(resp.Error, resp.StructuredError)
+	}
+	return task, nil
+}
+
+// CancelTask cancels a running background task. The task ends in TaskCancelled
+// once the plugin's RunTask returns.
+func (c *grpcClient) CancelTask(ctx context.Context, taskID string) error {
+	resp, err := c.client.CancelTask(ctx, &TaskRequest{TaskId: taskID})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
+// =============================================================================
+// Health Check Support
+// =============================================================================
+
+func (s *grpcServer) HealthCheck(ctx context.Context, _ *Empty) (*HealthCheckResponse, error) {
+	checker, ok := s.Impl.(HealthCheckProvider)
+	if !ok {
+		resp := &HealthCheckResponse{SupportsHealthCheck: false}
+		resp.RecoveredPanics, resp.LastPanic = s.panics.snapshot()
+		return resp, nil
+	}
+	resp := &HealthCheckResponse{SupportsHealthCheck: true}
+	if err := checker.HealthCheck(); err != nil {
+		resp.Error = err.Error()
+	}
+	resp.RecoveredPanics, resp.LastPanic = s.panics.snapshot()
+	return resp, nil
+}
+
+// HealthCheck runs the plugin's health check. Plugins that don't implement
+// HealthCheckProvider are healthy as long as they answer the RPC.
+func (c *grpcClient) HealthCheck() error {
+	return c.HealthCheckCtx(context.Background())
+}
+
+// HealthCheckCtx is like HealthCheck but uses ctx for the RPC.
+func (c *grpcClient) HealthCheckCtx(ctx context.Context) error {
+	health, err := c.HealthStatus(ctx)
+	if err != nil {
+		return err
+	}
+	return health.Err
+}
+
+// HealthStatus runs the plugin's health check and also reports the panics its
+// server has recovered from, which a plain HealthCheck does not treat as unhealthy.
+func (c *grpcClient) HealthStatus(ctx context.Context) (HealthStatus, error) {
+	resp, err := c.client.HealthCheck(ctx, &Empty{})
+	if err != nil {
+		return HealthStatus{}, err
+	}
+	health := HealthStatus{RecoveredPanics: resp.RecoveredPanics, LastPanic: resp.LastPanic}
+	if resp.Error != "" {
+		health.Err = fmt.Errorf("%s", resp.Error)
+	}
+	return health, nil
+}
+
+// =============================================================================
+// Permission Provider Support
+// =============================================================================
+
+func (s *grpcServer) GetRequiredPermissions(ctx context.Context, _ *Empty) (*PermissionsResponse, error) {
+	permProvider, ok := s.Impl.(PermissionProvider)
+	if !ok {
+		return &PermissionsResponse{SupportsPermissions: false}, nil
+	}
+	perms := permProvider.GetRequiredPermissions()
+	return &PermissionsResponse{
+		FileAccess:          perms.FileAccess,
+		NetworkAccess:       perms.NetworkAccess,
+		SystemCommands:      perms.SystemCommands,
+		Description:         perms.Description,
+		SupportsPermissions: true,
+		ReadPaths:           perms.ReadPaths,
+		WritePaths:          perms.WritePaths,
+		AllowedHosts:        perms.AllowedHosts,
+	}, nil
+}
+
+// GetRequiredPermissions returns the permissions the plugin requires.
+// Returns no permissions if the plugin doesn't implement PermissionProvider.
+func (c *grpcClient) GetRequiredPermissions() PluginPermissions {
+	return c.GetRequiredPermissionsCtx(context.Background())
+}
+
+// GetRequiredPermissionsCtx is like GetRequiredPermissions but uses ctx for the RPC.
+func (c *grpcClient) GetRequiredPermissionsCtx(ctx context.Context) PluginPermissions {
+	resp, err := c.client.GetRequiredPermissions(ctx, &Empty{})
+	if err != nil {
+		return PluginPermissions{}
+	}
+	return permissionsFromProto(resp)
+}
+
+func permissionsFromProto(resp *PermissionsResponse) PluginPermissions {
+	if resp == nil || !resp.SupportsPermissions {
+		return PluginPermissions{}
+	}
+	return PluginPermissions{
+		FileAccess:     resp.FileAccess,
+		NetworkAccess:  resp.NetworkAccess,
+		SystemCommands: resp.SystemCommands,
+		Description:    resp.Description,
+		ReadPaths:      resp.ReadPaths,
+		WritePaths:     resp.WritePaths,
+		AllowedHosts:   resp.AllowedHosts,
+	}
+}
+
+// =============================================================================
+// Rate Limit Support
+// =============================================================================
+
+func (s *grpcServer) GetRateLimits(ctx context.Context, _ *Empty) (*RateLimitsResponse, error) {
+	provider, ok := s.Impl.(RateLimitProvider)
+	if !ok {
+		return &RateLimitsResponse{SupportsRateLimits: false}, nil
+	}
+	limits := provider.GetRateLimits()
+	resp := &RateLimitsResponse{Limits: make([]*ProtoRateLimit, len(limits)), SupportsRateLimits: true}
+	for i, limit := range limits {
+		resp.Limits[i] = &ProtoRateLimit{
+			Operation: limit.Operation,
+			Requests:  int32(limit.Requests),
+			PerMs:     limit.Per.Milliseconds(),
+			Burst:     int32(limit.Burst),
+		}
+	}
+	return resp, nil
+}
+
+// GetRateLimits returns the rate limits the host should enforce (see RateLimiter).
+// Returns nil if the plugin doesn't implement RateLimitProvider.
+func (c *grpcClient) GetRateLimits() []RateLimit {
+	return c.GetRateLimitsCtx(context.Background())
+}
+
+// GetRateLimitsCtx is like GetRateLimits but uses ctx for the RPC.
+func (c *grpcClient) GetRateLimitsCtx(ctx context.Context) []RateLimit {
+	resp, err := c.client.GetRateLimits(ctx, &Empty{})
+	if err != nil {
+		return nil
+	}
+	return rateLimitsFromProto(resp)
+}
+
+func rateLimitsFromProto(resp *RateLimitsResponse) []RateLimit {
+	if resp == nil || !resp.SupportsRateLimits || len(resp.Limits) == 0 {
+		return nil
+	}
+	limits := make([]RateLimit, len(resp.Limits))
+	for i, l := range resp.Limits {
+		limits[i] = RateLimit{
+			Operation: l.Operation,
+			Requests:  int(l.Requests),
+			Per:       time.Duration(l.PerMs) * time.Millisecond,
+			Burst:     int(l.Burst),
+		}
+	}
+	return limits
+}
+
+// =============================================================================
+// Category Provider Support
+// =============================================================================
+
+func (s *grpcServer) GetCategory(ctx context.Context, _ *Empty) (*CategoryResponse, error) {
+	if categoryProvider, ok := s.Impl.(CategoryProvider); ok {
+		return &CategoryResponse{
+			Category:         categoryProvider.GetCategory(),
+			SupportsCategory: true,
+		}, nil
+	}
+	return &CategoryResponse{SupportsCategory: false}, nil
+}
+
+// GetCategory returns the plugin's category.
+// Returns an empty string if the plugin doesn't implement CategoryProvider.
+func (c *grpcClient) GetCategory() string {
+	return c.GetCategoryCtx(context.Background())
+}
+
+// GetCategoryCtx is like GetCategory but uses ctx for the RPC.
+func (c *grpcClient) GetCategoryCtx(ctx context.Context) string {
+	resp, err := c.client.GetCategory(ctx, &Empty{})
+	if err != nil || resp == nil || !resp.SupportsCategory {
+		return ""
+	}
+	return resp.Category
+}
+
+// =============================================================================
+// Startup Support
+// =============================================================================
+
+func (s *grpcServer) Initialize(ctx context.Context, _ *Empty) (*InitializeResponse, error) {
+	initializer, ok := s.Impl.(Initializer)
+	if !ok {
+		return &InitializeResponse{SupportsInitialize: false}, nil
+	}
+	resp := &InitializeResponse{SupportsInitialize: true}
+	if err := initializer.Initialize(ctx); err != nil {
+		resp.Error = err.Error()
+		resp.StructuredError = pluginErrorToProto(err)
+	}
+	return resp, nil
+}
+
+// Initialize asks the plugin to get ready for calls. Readiness failures are
+// returned as a *PluginError. Plugins that don't implement Initializer are ready
+// as soon as they answer the RPC.
+func (c *grpcClient) Initialize(ctx context.Context) error {
+	resp, err := c.client.Initialize(ctx, &Empty{})
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return callError(resp.Error, resp.StructuredError)
+	}
+	return nil
+}
+
+// =============================================================================
+// Version Negotiation Support
+// =============================================================================
+
+func (s *grpcServer) Negotiate(ctx context.Context, req *NegotiateRequest) (*NegotiateResponse, error) {
+	version := highestCommonVersion(req.ApiVersions, supportedAPIVersions)
+	if version == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "no common API version: host speaks %v, plugin speaks %v", req.ApiVersions, supportedAPIVersions)
+	}
+	return &NegotiateResponse{
+		ApiVersion:  version,
+		ApiVersions: supportedAPIVersions,
+		Features:    commonFeatures(req.Features),
+	}, nil
+}
+
+// Negotiate agrees with the plugin on the highest API version and the features
+// both support, so hosts can use newer protocol versions only with plugins that
+// speak them. Plugins built before negotiation speak v1 and no features.
+func (c *grpcClient) Negotiate(ctx context.Context) (Negotiation, error) {
+	resp, err := c.client.Negotiate(ctx, &NegotiateRequest{
+		ApiVersions: supportedAPIVersions,
+		Features:    supportedFeatures,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return Negotiation{APIVersion: "v1", PluginAPIVersions: []string{"v1"}}, nil
+	}
+	if err != nil {
+		return Negotiation{}, err
+	}
+	return Negotiation{
+		APIVersion:        resp.ApiVersion,
+		PluginAPIVersions: resp.ApiVersions,
+		Features:          resp.Features,
+	}, nil
+}
+
+// =============================================================================
+// Capabilities Support
+// =============================================================================
+
+func (s *grpcServer) GetCapabilities(ctx context.Context, _ *Empty) (*CapabilitiesResponse, error) {
+	resp := &CapabilitiesResponse{Interfaces: implementedInterfaces(s.Impl)}
+	var err error
+	if resp.Version, err = s.GetVersion(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	if resp.Compatibility, err = s.GetCompatibilityInfo(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	if resp.Metadata, err = s.GetMetadata(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	if resp.WebPages, err = s.GetWebPageInfo(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	if resp.Files, err = s.AcceptsFiles(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	if resp.Operations, err = s.GetOperations(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	if resp.SystemPrompt, err = s.GetSystemPromptFragment(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	if resp.FileWatches, err = s.GetFileWatches(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	if resp.Permissions, err = s.GetRequiredPermissions(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	if resp.Category, err = s.GetCategory(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	if resp.ScheduledTasks, err = s.GetScheduledTasks(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	if resp.RateLimits, err = s.GetRateLimits(ctx, &Empty{}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetCapabilities discovers the plugin's optional features in one round trip.
+// Plugins built before GetCapabilities are probed with the individual RPCs
+// instead, and report no Interfaces.
+func (c *grpcClient) GetCapabilities(ctx context.Context) (Capabilities, error) {
+	resp, err := c.client.GetCapabilities(ctx, &Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		return c.probeCapabilities(ctx), nil
+	}
+	if err != nil {
+		return Capabilities{}, err
+	}
+
+	caps := Capabilities{
+		Interfaces:           resp.Interfaces,
+		Version:              resp.GetVersion().GetVersion(),
+		MinAgentVersion:      resp.GetCompatibility().GetMinAgentVersion(),
+		MaxAgentVersion:      resp.GetCompatibility().GetMaxAgentVersion(),
+		APIVersion:           resp.GetCompatibility().GetApiVersion(),
+		WebPages:             webPageInfoFromProto(resp.GetWebPages()),
+		Operations:           operationsFromProto(resp.Operations),
+		FileWatches:          fileWatchesFromProto(resp.FileWatches),
+		Permissions:          permissionsFromProto(resp.Permissions),
+		Category:             resp.GetCategory().GetCategory(),
+		SystemPromptFragment: resp.GetSystemPrompt().GetFragment(),
+		ScheduledTasks:       scheduledTasksFromProto(resp.ScheduledTasks),
+		RateLimits:           rateLimitsFromProto(resp.RateLimits),
+	}
+	if resp.GetMetadata().GetError() == "" {
+		caps.Metadata = resp.GetMetadata().GetMetadata()
+	}
+	if resp.GetFiles().GetSupportsFiles() {
+		caps.AcceptedFileTypes = resp.Files.AcceptedTypes
+	}
+	return caps, nil
+}
+
+func (c *grpcClient) probeCapabilities(ctx context.Context) Capabilities {
+	caps := Capabilities{
+		Version:              c.VersionCtx(ctx),
+		MinAgentVersion:      c.MinAgentVersionCtx(ctx),
+		MaxAgentVersion:      c.MaxAgentVersionCtx(ctx),
+		APIVersion:           c.APIVersionCtx(ctx),
+		WebPages:             c.GetWebPageInfoCtx(ctx),
+		AcceptedFileTypes:    c.AcceptsFilesCtx(ctx),
+		Operations:           c.GetOperationsCtx(ctx),
+		SystemPromptFragment: c.GetSystemPromptFragmentCtx(ctx),
+		FileWatches:          c.GetFileWatchesCtx(ctx),
+		Permissions:          c.GetRequiredPermissionsCtx(ctx),
+		Category:             c.GetCategoryCtx(ctx),
+		ScheduledTasks:       c.GetScheduledTasksCtx(ctx),
+		RateLimits:           c.GetRateLimitsCtx(ctx),
+	}
+	caps.Metadata, _ = c.GetMetadataCtx(ctx)
+	return caps
+}
+
+// =============================================================================
+// Stats Support
+// =============================================================================
+
+func (s *grpcServer) GetStats(ctx context.Context, _ *Empty) (*StatsResponse, error) {
+	stats := s.stats.snapshot()
+	resp := &StatsResponse{Methods: make([]*ProtoMethodStats, len(stats))}
+	for i, m := range stats {
+		resp.Methods[i] = &ProtoMethodStats{
+			Method:          m.Method,
+			Calls:           m.Calls,
+			Errors:          m.Errors,
+			TotalDurationUs: m.TotalDuration.Microseconds(),
+			MaxDurationUs:   m.MaxDuration.Microseconds(),
+			RequestBytes:    m.RequestBytes,
+			ResponseBytes:   m.ResponseBytes,
+		}
+	}
+	return resp, nil
+}
+
+// GetStats returns per-RPC call counts, durations, errors and payload sizes since
+// the plugin started, sorted by method, to spot slow or failing plugins.
+func (c *grpcClient) GetStats(ctx context.Context) ([]MethodStats, error) {
+	resp, err := c.client.GetStats(ctx, &Empty{})
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]MethodStats, len(resp.Methods))
+	for i, m := range resp.Methods {
+		stats[i] = MethodStats{
+			Method:        m.Method,
+			Calls:         m.Calls,
+			Errors:        m.Errors,
+			TotalDuration: time.Duration(m.TotalDurationUs) * time.Microsecond,
+			MaxDuration:   time.Duration(m.MaxDurationUs) * time.Microsecond,
+			RequestBytes:  m.RequestBytes,
+			ResponseBytes: m.ResponseBytes,
+		}
+	}
+	return stats, nil
+}
+
+// =============================================================================
+// Logging Support
+// =============================================================================
+
+func (s *grpcServer) SubscribeLogs(req *LogSubscribeRequest, stream ToolService_SubscribeLogsServer) error {
+	source, ok := s.Impl.(logSource)
+	if !ok {
+		return status.Error(codes.Unimplemented, "plugin does not embed BasePlugin")
+	}
+	minRank := logLevelRank(LogDebug)
+	if req.MinLevel != "" {
+		minRank = logLevelRank(LogLevel(req.MinLevel))
+	}
+	send := func(entry LogEntry) error {
+		if logLevelRank(entry.Level) < minRank {
+			return nil
+		}
+		return stream.Send(&ProtoLogEntry{
+			TimeUnixNano: entry.Time.UnixNano(),
+			Level:        string(entry.Level),
+			Message:      entry.Message,
+			Fields:       entry.Fields,
+		})
+	}
+
+	recent, entries, unsubscribe := source.logBroker().subscribe()
+	defer unsubscribe()
+	for _, entry := range recent {
+		if err := send(entry); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case entry := <-entries:
+			if err := send(entry); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-s.draining.done():
+			return nil
+		}
+	}
+}
+
+// SubscribeLogs passes the plugin's log entries at minLevel or above (see
+// BasePlugin.Logger) to emit, starting with recent ones, until ctx is done or
+// emit returns an error.
+func (c *grpcClient) SubscribeLogs(ctx context.Context, minLevel LogLevel, emit func(LogEntry) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.client.SubscribeLogs(ctx, &LogSubscribeRequest{MinLevel: string(minLevel)})
+	if err != nil {
+		return err
+	}
+	for {
+		entry, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF || ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if err := emit(LogEntry{
+			Time:    time.Unix(0, entry.TimeUnixNano),
+			Level:   LogLevel(entry.Level),
+			Message: entry.Message,
+			Fields:  entry.Fields,
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// =============================================================================
+// Host Services Support
+// =============================================================================
+
+func (s *grpcServer) SetHostServices(ctx context.Context, req *HostServicesRequest) (*ConfigResponse, error) {
+	hostAware, isHostAware := s.Impl.(HostAwareTool)
+	listener, isListener := s.Impl.(EventListener)
+	messageListener, isMessageListener := s.Impl.(MessageListener)
+	if !isHostAware && !isListener && !isMessageListener {
+		return &ConfigResponse{Success: false, Error: "plugin does not implement HostAwareTool"}, nil
+	}
+	host, hostCtx, err := s.host.connect(req.Address, req.Token)
+	if err != nil {
+		return &ConfigResponse{Success: false, Error: err.Error()}, nil
+	}
+	if isHostAware {
+		hostAware.SetHostServices(host)
+	}
+	if isListener {
+		go listenForEvents(hostCtx, host, listener)
+	}
+	if isMessageListener {
+		go listenForMessages(hostCtx, host, messageListener)
+	}
+	return &ConfigResponse{Success: true}, nil
+}
+
+// ConnectHostServices tells the plugin to call back into the HostService at address
+// (see RegisterHostServices). token is sent with every callback so the host can
+// tell which plugin is calling (see HostCallerToken). address is host:port or, for
+// a unix socket, unix:///path/to/socket.
+func (c *grpcClient) ConnectHostServices(ctx context.Context, address, token string) error {
+	resp, err := c.client.SetHostServices(ctx, &HostServicesRequest{Address: address, Token: token})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
+// =============================================================================
+// Shutdown Support
+// =============================================================================
+
+func (s *grpcServer) Shutdown(ctx context.Context, _ *Empty) (*ConfigResponse, error) {
+	if err := s.shutdown.run(ctx, s.Impl); err != nil {
+		return &ConfigResponse{Success: false, Error: err.Error()}, nil
+	}
+	return &ConfigResponse{Success: true}, nil
+}
+
+// Shutdown asks the plugin to release its resources before the host stops it.
+// The plugin keeps serving afterwards; the host is expected to terminate it.
+func (c *grpcClient) Shutdown(ctx context.Context) error {
+	resp, err := c.client.Shutdown(ctx, &Empty{})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
 }
 
 // Compile-time interface checks
@@ -544,7 +2728,23 @@ var (
 	_ DefaultSettingsProvider = (*grpcClient)(nil)
 	_ AgentAwareTool          = (*grpcClient)(nil)
 	_ InitializationProvider  = (*grpcClient)(nil)
+	_ ConnectivityTester      = (*grpcClient)(nil)
 	_ WebPageProvider         = (*grpcClient)(nil)
+	_ WebPageInfoProvider     = (*grpcClient)(nil)
 	_ FileAttachmentHandler   = (*grpcClient)(nil)
 	_ OperationsProvider      = (*grpcClient)(nil)
+	_ StatefulPlugin          = (*grpcClient)(nil)
+	_ StatePorter             = (*grpcClient)(nil)
+	_ HandoffProvider         = (*grpcClient)(nil)
+	_ SystemPromptProvider    = (*grpcClient)(nil)
+	_ PromptContributor       = (*grpcClient)(nil)
+	_ ContextEnricher         = (*grpcClient)(nil)
+	_ EmbeddingProvider       = (*grpcClient)(nil)
+	_ FileWatchProvider       = (*grpcClient)(nil)
+	_ StreamingTool           = (*grpcClient)(nil)
+	_ HealthCheckProvider     = (*grpcClient)(nil)
+	_ PermissionProvider      = (*grpcClient)(nil)
+	_ CategoryProvider        = (*grpcClient)(nil)
+	_ Initializer             = (*grpcClient)(nil)
+	_ ShutdownHandler         = (*grpcClient)(nil)
 )
@@ -0,0 +1,343 @@
+package pluginapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves impl over an in-memory gRPC connection and returns a client for it.
+func newTestClient(t *testing.T, impl PluginTool) *grpcClient {
+	t.Helper()
+	return &grpcClient{client: NewToolServiceClient(newTestConn(t, impl))}
+}
+
+// newTestConn serves impl over an in-memory gRPC connection.
+func newTestConn(t *testing.T, impl PluginTool, opts ...grpc.ServerOption) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(opts...)
+	registerToolServices(server, impl)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial test server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+type promptTestTool struct {
+	BasePlugin
+}
+
+func (t *promptTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *promptTestTool) GetSystemPromptFragment() string {
+	return "Always pass project IDs, never project names."
+}
+
+func TestGRPCClient_SystemPromptFragment(t *testing.T) {
+	client := newTestClient(t, &promptTestTool{})
+	if got := client.GetSystemPromptFragment(); got != "Always pass project IDs, never project names." {
+		t.Errorf("unexpected fragment: %q", got)
+	}
+
+	plain := newTestClient(t, &plainTestTool{})
+	if got := plain.GetSystemPromptFragment(); got != "" {
+		t.Errorf("expected empty fragment for plugin without SystemPromptProvider, got %q", got)
+	}
+}
+
+type projectPromptTestTool struct {
+	promptTestTool
+}
+
+func (t *projectPromptTestTool) ContributePrompt(ctx context.Context, req PromptRequest) (string, error) {
+	if req.ConversationID == "" {
+		return "", errors.New("no conversation")
+	}
+	return "Project for " + req.AgentName + "/" + req.ConversationID + " is live-set.rpp.", nil
+}
+
+func TestGRPCClient_ContributePrompt(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, &projectPromptTestTool{})
+	got, err := client.ContributePrompt(ctx, PromptRequest{ConversationID: "c1", AgentName: "daw"})
+	if err != nil || got != "Project for daw/c1 is live-set.rpp." {
+		t.Errorf("ContributePrompt = %q, %v", got, err)
+	}
+	if _, err := client.ContributePrompt(ctx, PromptRequest{}); err == nil || err.Error() != "no conversation" {
+		t.Errorf("expected the plugin's error, got %v", err)
+	}
+
+	// Plugins with only a static fragment contribute it to every conversation
+	static := newTestClient(t, &promptTestTool{})
+	got, err = static.ContributePrompt(ctx, PromptRequest{ConversationID: "c1"})
+	if err != nil || got != "Always pass project IDs, never project names." {
+		t.Errorf("ContributePrompt = %q, %v, want the static fragment", got, err)
+	}
+	plain := newTestClient(t, &plainTestTool{})
+	if got, err := plain.ContributePrompt(ctx, PromptRequest{ConversationID: "c1"}); err != nil || got != "" {
+		t.Errorf("ContributePrompt = %q, %v, want nothing", got, err)
+	}
+}
+
+type embeddingTestTool struct {
+	BasePlugin
+}
+
+func (t *embeddingTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *embeddingTestTool) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float32{float32(len(text)), 0.5}
+	}
+	return vectors, nil
+}
+
+func TestGRPCClient_Embed(t *testing.T) {
+	client := newTestClient(t, &embeddingTestTool{})
+	vectors, err := client.Embed(context.Background(), []string{"a", "abc"})
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(vectors) != 2 || vectors[0][0] != 1 || vectors[1][0] != 3 || vectors[1][1] != 0.5 {
+		t.Errorf("unexpected vectors: %v", vectors)
+	}
+
+	plain := newTestClient(t, &plainTestTool{})
+	if _, err := plain.Embed(context.Background(), []string{"a"}); err == nil {
+		t.Error("expected error for plugin without EmbeddingProvider")
+	}
+}
+
+type webPagesTestTool struct {
+	BasePlugin
+}
+
+func (t *webPagesTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *webPagesTestTool) GetWebPages() []string {
+	return []string{"marketplace", "projects/detail"}
+}
+
+func (t *webPagesTestTool) ServeWebPage(path string, query map[string]string) (string, string, error) {
+	return "<h1>" + path + "</h1>", "text/html", nil
+}
+
+type webPageInfoTestTool struct {
+	webPagesTestTool
+}
+
+func (t *webPageInfoTestTool) GetWebPageInfo() []WebPageInfo {
+	return []WebPageInfo{
+		{Path: "marketplace", Title: "Script Marketplace", Icon: "store", ShowInMenu: true},
+		{Path: "projects/detail", Title: "Project", RequiresAuth: true},
+	}
+}
+
+func TestGRPCClient_WebPageInfo(t *testing.T) {
+	pages := newTestClient(t, &webPageInfoTestTool{}).GetWebPageInfo()
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 pages, got %+v", pages)
+	}
+	if pages[0].Title != "Script Marketplace" || pages[0].Icon != "store" || !pages[0].ShowInMenu {
+		t.Errorf("unexpected first page: %+v", pages[0])
+	}
+	if !pages[1].RequiresAuth || pages[1].ShowInMenu {
+		t.Errorf("unexpected second page: %+v", pages[1])
+	}
+
+	// Plugins with only WebPageProvider fall back to bare paths
+	pages = newTestClient(t, &webPagesTestTool{}).GetWebPageInfo()
+	if len(pages) != 2 || pages[0].Title != "marketplace" || !pages[0].ShowInMenu {
+		t.Errorf("unexpected fallback pages: %+v", pages)
+	}
+
+	if pages := newTestClient(t, &plainTestTool{}).GetWebPageInfo(); len(pages) != 0 {
+		t.Errorf("expected no pages, got %+v", pages)
+	}
+}
+
+type slowDefinitionTestTool struct {
+	BasePlugin
+	release chan struct{}
+}
+
+func (t *slowDefinitionTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *slowDefinitionTestTool) Definition() Tool {
+	<-t.release
+	return Tool{Name: "slow"}
+}
+
+func TestGRPCClient_CtxVariantsHonorDeadlines(t *testing.T) {
+	tool := &slowDefinitionTestTool{release: make(chan struct{})}
+	defer close(tool.release)
+	client := newTestClient(t, tool)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if def := client.DefinitionCtx(ctx); def.Name != "" {
+		t.Errorf("expected empty definition after timeout, got %+v", def)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("DefinitionCtx ignored the deadline (took %v)", elapsed)
+	}
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if _, err := client.GetMetadataCtx(cancelled); err == nil {
+		t.Error("expected error from GetMetadataCtx with a cancelled context")
+	}
+	if err := client.SetAgentContextCtx(cancelled, AgentContext{Name: "a"}); err == nil {
+		t.Error("expected error from SetAgentContextCtx with a cancelled context")
+	}
+}
+
+type healthCheckTestTool struct {
+	BasePlugin
+	err error
+}
+
+func (t *healthCheckTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *healthCheckTestTool) HealthCheck() error {
+	return t.err
+}
+
+func TestGRPCClient_HealthCheck(t *testing.T) {
+	if err := newTestClient(t, &healthCheckTestTool{}).HealthCheck(); err != nil {
+		t.Errorf("expected healthy plugin, got %v", err)
+	}
+
+	unhealthy := newTestClient(t, &healthCheckTestTool{err: fmt.Errorf("database unreachable")})
+	if err := unhealthy.HealthCheck(); err == nil || err.Error() != "database unreachable" {
+		t.Errorf("expected database error, got %v", err)
+	}
+
+	if err := newTestClient(t, &plainTestTool{}).HealthCheck(); err != nil {
+		t.Errorf("plugins without HealthCheckProvider should be healthy, got %v", err)
+	}
+}
+
+type initializerTestTool struct {
+	BasePlugin
+	err error
+}
+
+func (t *initializerTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *initializerTestTool) Initialize(ctx context.Context) error {
+	return t.err
+}
+
+func TestGRPCClient_Initialize(t *testing.T) {
+	if err := newTestClient(t, &initializerTestTool{}).Initialize(context.Background()); err != nil {
+		t.Errorf("expected ready plugin, got %v", err)
+	}
+
+	notReady := newTestClient(t, &initializerTestTool{
+		err: NewPluginError(ErrorCodeAuth, "API key rejected").WithSuggestion("Update the API key in Settings"),
+	})
+	err := notReady.Initialize(context.Background())
+	var pluginErr *PluginError
+	if !errors.As(err, &pluginErr) || pluginErr.Code != ErrorCodeAuth || pluginErr.Suggestion != "Update the API key in Settings" {
+		t.Errorf("expected auth readiness error, got %#v", err)
+	}
+
+	if err := newTestClient(t, &plainTestTool{}).Initialize(context.Background()); err != nil {
+		t.Errorf("plugins without Initializer should be ready, got %v", err)
+	}
+}
+
+type exportTestTool struct {
+	BasePlugin
+}
+
+func (t *exportTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", errors.New("Call should not be used for a FileOutputProvider")
+}
+
+func (t *exportTestTool) CallWithFiles(ctx context.Context, args string, files []FileAttachment) (string, error) {
+	return "", errors.New("CallWithFiles should not be used for a FileOutputProvider")
+}
+
+func (t *exportTestTool) CallWithOutputFiles(ctx context.Context, args string, files []FileAttachment) (string, []FileAttachment, error) {
+	if args == "fail" {
+		return "", nil, NewPluginError(ErrorCodeInvalidArgs, "nothing to export")
+	}
+	report, err := FileAttachment{Name: "report.txt", Type: "text/plain", Content: bytes.Repeat([]byte("row "), 1000)}.Compress()
+	if err != nil {
+		return "", nil, err
+	}
+	outputs := []FileAttachment{{Name: "export.csv", Type: "text/csv", Content: []byte("a,b\n1,2\n")}, report}
+	return fmt.Sprintf("exported %d inputs", len(files)), outputs, nil
+}
+
+func TestGRPCClient_CallWithOutputFiles(t *testing.T) {
+	client := newTestClient(t, &exportTestTool{})
+	ctx := context.Background()
+
+	result, outputs, err := client.CallWithOutputFiles(ctx, "{}", []FileAttachment{{Name: "in.txt", Content: []byte("x")}})
+	if err != nil {
+		t.Fatalf("CallWithOutputFiles failed: %v", err)
+	}
+	if result != "exported 1 inputs" || len(outputs) != 2 {
+		t.Fatalf("unexpected result %q with outputs %+v", result, outputs)
+	}
+	if outputs[0].Name != "export.csv" || string(outputs[0].Content) != "a,b\n1,2\n" || outputs[0].Size != 8 {
+		t.Errorf("unexpected CSV output: %+v", outputs[0])
+	}
+	if outputs[1].Encoding != "" || len(outputs[1].Content) != 4000 {
+		t.Errorf("expected decompressed report, got encoding %q and %d bytes", outputs[1].Encoding, len(outputs[1].Content))
+	}
+
+	// Plain calls reach the provider too, with no input files
+	if result, err := client.Call(ctx, "{}"); err != nil || result != "exported 0 inputs" {
+		t.Errorf("unexpected Call result %q, %v", result, err)
+	}
+
+	var pluginErr *PluginError
+	if _, _, err := client.CallWithOutputFiles(ctx, "fail", nil); !errors.As(err, &pluginErr) || pluginErr.Code != ErrorCodeInvalidArgs {
+		t.Errorf("expected invalid args error, got %v", err)
+	}
+
+	// Plugins without FileOutputProvider return no files
+	result, outputs, err = newTestClient(t, &plainTestTool{}).CallWithOutputFiles(ctx, "{}", nil)
+	if err != nil || len(outputs) != 0 {
+		t.Errorf("unexpected outputs %+v, %v (result %q)", outputs, err, result)
+	}
+}
@@ -0,0 +1,76 @@
+package pluginapi
+
+import (
+	"fmt"
+
+	rpcv2 "github.com/oriagent/ori-pluginapi/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// registerToolServices registers every supported wire protocol version for impl.
+// Hosts built against any of these versions can talk to the plugin.
+// All versions share the returned server, and with it the plugin's running calls.
+func registerToolServices(server grpc.ServiceRegistrar, impl PluginTool) *grpcServer {
+	impl, middleware := unwrapTool(impl)
+	srv := &grpcServer{Impl: impl, middleware: middleware}
+	RegisterToolServiceServer(server, srv)
+	server.RegisterService(toolServiceV2Desc(), srv)
+	return srv
+}
+
+// toolServiceV2Desc serves the v2 protocol with the v1 handlers.
+// This works because v2 messages are wire-compatible with v1: the handlers decode
+// v2 requests into v1 types and their v1 responses encode to identical v2 bytes.
+// When a v2 RPC diverges from v1, it needs its own handler here instead.
+func toolServiceV2Desc() *grpc.ServiceDesc {
+	v1Methods := make(map[string]grpc.MethodDesc, len(ToolService_ServiceDesc.Methods))
+	for _, m := range ToolService_ServiceDesc.Methods {
+		v1Methods[m.MethodName] = m
+	}
+	v1Streams := make(map[string]grpc.StreamDesc, len(ToolService_ServiceDesc.Streams))
+	for _, s := range ToolService_ServiceDesc.Streams {
+		v1Streams[s.StreamName] = s
+	}
+
+	desc := &grpc.ServiceDesc{
+		ServiceName: rpcv2.ToolService_ServiceDesc.ServiceName,
+		HandlerType: ToolService_ServiceDesc.HandlerType,
+		Metadata:    rpcv2.ToolService_ServiceDesc.Metadata,
+	}
+	for _, m := range rpcv2.ToolService_ServiceDesc.Methods {
+		v1, ok := v1Methods[m.MethodName]
+		if !ok {
+			panic(fmt.Sprintf("pluginapi: v2 method %s has no v1 handler", m.MethodName))
+		}
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{MethodName: m.MethodName, Handler: v1.Handler})
+	}
+	for _, s := range rpcv2.ToolService_ServiceDesc.Streams {
+		v1, ok := v1Streams[s.StreamName]
+		if !ok {
+			panic(fmt.Sprintf("pluginapi: v2 stream %s has no v1 handler", s.StreamName))
+		}
+		desc.Streams = append(desc.Streams, grpc.StreamDesc{
+			StreamName:    s.StreamName,
+			Handler:       v1.Handler,
+			ServerStreams: s.ServerStreams,
+			ClientStreams: s.ClientStreams,
+		})
+	}
+	return desc
+}
+
+// ConvertRPCMessage copies src into dst, where both are the same message in
+// different wire protocol versions (e.g., *pluginapi.CallRequest and *rpcv2.CallRequest).
+// Fields unknown to dst's version are preserved as unknown fields.
+func ConvertRPCMessage(dst, src proto.Message) error {
+	data, err := proto.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", src.ProtoReflect().Descriptor().FullName(), err)
+	}
+	proto.Reset(dst)
+	if err := proto.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", dst.ProtoReflect().Descriptor().FullName(), err)
+	}
+	return nil
+}
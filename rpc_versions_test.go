@@ -0,0 +1,59 @@
+package pluginapi
+
+import (
+	"context"
+	"testing"
+
+	rpcv2 "github.com/oriagent/ori-pluginapi/rpc/v2"
+)
+
+func TestToolServiceV2Desc_MatchesV1(t *testing.T) {
+	// Every v2 RPC must be served; toolServiceV2Desc panics if one has no handler
+	desc := toolServiceV2Desc()
+	if len(desc.Methods) != len(ToolService_ServiceDesc.Methods) || len(desc.Streams) != len(ToolService_ServiceDesc.Streams) {
+		t.Errorf("v2 serves %d methods and %d streams, v1 has %d and %d",
+			len(desc.Methods), len(desc.Streams), len(ToolService_ServiceDesc.Methods), len(ToolService_ServiceDesc.Streams))
+	}
+}
+
+func TestGRPCServer_ServesV2(t *testing.T) {
+	conn := newTestConn(t, &plainTestTool{BasePlugin: newBasePlugin("echo", "1.2.0", "", "", "v1")})
+	client := rpcv2.NewToolServiceClient(conn)
+
+	resp, err := client.Call(context.Background(), &rpcv2.CallRequest{ArgsJson: `{"x":1}`})
+	if err != nil {
+		t.Fatalf("v2 Call failed: %v", err)
+	}
+	if resp.ResultJson != `{"x":1}` {
+		t.Errorf("unexpected v2 result: %q", resp.ResultJson)
+	}
+
+	version, err := client.GetVersion(context.Background(), &rpcv2.Empty{})
+	if err != nil || version.Version != "1.2.0" {
+		t.Errorf("unexpected v2 version %q (err: %v)", version.GetVersion(), err)
+	}
+
+	// v1 hosts keep working against the same server
+	if got := (&grpcClient{client: NewToolServiceClient(conn)}).Version(); got != "1.2.0" {
+		t.Errorf("unexpected v1 version: %q", got)
+	}
+}
+
+func TestConvertRPCMessage(t *testing.T) {
+	v1 := &WebPageRequest{Path: "stats", Query: map[string]string{"range": "7d"}}
+	var v2 rpcv2.WebPageRequest
+	if err := ConvertRPCMessage(&v2, v1); err != nil {
+		t.Fatalf("ConvertRPCMessage failed: %v", err)
+	}
+	if v2.Path != "stats" || v2.Query["range"] != "7d" {
+		t.Errorf("unexpected v2 message: %v", &v2)
+	}
+
+	var back WebPageRequest
+	if err := ConvertRPCMessage(&back, &v2); err != nil {
+		t.Fatalf("ConvertRPCMessage back failed: %v", err)
+	}
+	if back.Path != v1.Path || back.Query["range"] != "7d" {
+		t.Errorf("unexpected v1 message after round trip: %v", &back)
+	}
+}
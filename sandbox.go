@@ -0,0 +1,145 @@
+package pluginapi
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ErrOutsideSandbox is returned by SafeOpen and SafeWrite for paths the plugin's
+// permissions don't allow.
+var ErrOutsideSandbox = errors.New("path outside plugin sandbox")
+
+// SafeOpen opens path for reading if perms allow it: the plugin must declare file
+// access, and if it lists ReadPaths or WritePaths, path must be inside one of them.
+// Paths are resolved with os.Root, so symlinks cannot lead outside the allowed directory.
+//
+// Example:
+//
+//	f, err := pluginapi.SafeOpen(t.GetRequiredPermissions(), args.Path)
+//	if errors.Is(err, pluginapi.ErrOutsideSandbox) {
+//	    return "", fmt.Errorf("%s is not in a folder this plugin may read", args.Path)
+//	}
+func SafeOpen(perms PluginPermissions, path string) (*os.File, error) {
+	root, rel, err := sandboxRoot(perms, path, slices.Concat(perms.ReadPaths, perms.WritePaths))
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return os.Open(path)
+	}
+	defer root.Close()
+	return root.Open(rel)
+}
+
+// SafeWrite writes data to path, like os.WriteFile, if perms allow it: the plugin
+// must declare file access, and if it lists ReadPaths or WritePaths, path must be
+// inside one of the WritePaths.
+func SafeWrite(perms PluginPermissions, path string, data []byte, perm os.FileMode) error {
+	root, rel, err := sandboxRoot(perms, path, perms.WritePaths)
+	if err != nil {
+		return err
+	}
+	if root == nil {
+		return os.WriteFile(path, data, perm)
+	}
+	defer root.Close()
+	return root.WriteFile(rel, data, perm)
+}
+
+// sandboxRoot opens the directory in allowed that contains path and returns it
+// with path relative to it. It returns a nil root if perms don't restrict paths.
+func sandboxRoot(perms PluginPermissions, path string, allowed []string) (*os.Root, string, error) {
+	if !perms.FileAccess {
+		return nil, "", fmt.Errorf("%w: %s (the plugin does not declare %s)", ErrOutsideSandbox, path, PermissionFileAccess)
+	}
+	if !perms.HasPathAllowlist() {
+		return nil, "", nil
+	}
+
+	dir, rel, ok := sandboxDir(allowed, path)
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %s", ErrOutsideSandbox, path)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	return root, rel, nil
+}
+
+// sandboxDir returns the directory in allowed that lexically contains path, and
+// path relative to it.
+func sandboxDir(allowed []string, path string) (dir, rel string, ok bool) {
+	target, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", false
+	}
+	for _, dir := range allowed {
+		dir, err := ExpandSandboxPath(dir)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(dir, target)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return dir, rel, true
+	}
+	return "", "", false
+}
+
+// AllowsPath reports whether p allows reading path (or writing it, if write is set).
+// The check is lexical; SafeOpen and SafeWrite also refuse symlinks leading outside.
+func (p PluginPermissions) AllowsPath(path string, write bool) bool {
+	if !p.FileAccess {
+		return false
+	}
+	if !p.HasPathAllowlist() {
+		return true
+	}
+	allowed := p.WritePaths
+	if !write {
+		allowed = slices.Concat(p.ReadPaths, p.WritePaths)
+	}
+	_, _, ok := sandboxDir(allowed, path)
+	return ok
+}
+
+// HasPathAllowlist reports whether p limits file access to ReadPaths and WritePaths.
+// Plugins that declare file access without paths may use any path.
+func (p PluginPermissions) HasPathAllowlist() bool {
+	return len(p.ReadPaths) > 0 || len(p.WritePaths) > 0
+}
+
+// ExpandSandboxPath returns the absolute, cleaned form of an allowlisted path,
+// expanding a leading "~" to the user's home directory.
+func ExpandSandboxPath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("sandbox path must be absolute or start with ~/ (got %q)", path)
+	}
+	return filepath.Clean(path), nil
+}
+
+// validatePaths checks that the allowlisted paths are well-formed and come with file access.
+func (p PluginPermissions) validatePaths() error {
+	if p.HasPathAllowlist() && !p.FileAccess {
+		return fmt.Errorf("read_paths and write_paths require the %s permission", PermissionFileAccess)
+	}
+	for _, path := range slices.Concat(p.ReadPaths, p.WritePaths) {
+		if _, err := ExpandSandboxPath(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
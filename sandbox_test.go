@@ -0,0 +1,87 @@
+package pluginapi
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSafeOpenAndWrite(t *testing.T) {
+	dir := t.TempDir()
+	library, exports, outside := filepath.Join(dir, "library"), filepath.Join(dir, "exports"), filepath.Join(dir, "outside")
+	for _, d := range []string{library, exports, outside} {
+		_ = os.MkdirAll(d, 0755)
+	}
+	_ = os.WriteFile(filepath.Join(library, "song.mid"), []byte("MThd"), 0644)
+	_ = os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("no"), 0644)
+	_ = os.Symlink(outside, filepath.Join(library, "escape"))
+
+	perms := PluginPermissions{FileAccess: true, ReadPaths: []string{library}, WritePaths: []string{exports}}
+
+	f, err := SafeOpen(perms, filepath.Join(library, "song.mid"))
+	if err != nil {
+		t.Fatalf("SafeOpen failed: %v", err)
+	}
+	content, _ := io.ReadAll(f)
+	_ = f.Close()
+	if string(content) != "MThd" {
+		t.Errorf("unexpected content %q", content)
+	}
+
+	if err := SafeWrite(perms, filepath.Join(exports, "song.wav"), []byte("RIFF"), 0644); err != nil {
+		t.Fatalf("SafeWrite failed: %v", err)
+	}
+	if f, err := SafeOpen(perms, filepath.Join(exports, "song.wav")); err != nil {
+		t.Errorf("write paths should be readable: %v", err)
+	} else {
+		_ = f.Close()
+	}
+
+	if err := SafeWrite(perms, filepath.Join(library, "song.mid"), nil, 0644); !errors.Is(err, ErrOutsideSandbox) {
+		t.Errorf("expected read paths to be read-only, got %v", err)
+	}
+	if _, err := SafeOpen(perms, filepath.Join(library, "..", "outside", "secret.txt")); !errors.Is(err, ErrOutsideSandbox) {
+		t.Errorf("expected ErrOutsideSandbox for a path outside the allowlist, got %v", err)
+	}
+	if _, err := SafeOpen(perms, filepath.Join(library, "escape", "secret.txt")); err == nil {
+		t.Error("expected a symlink out of the sandbox to be refused")
+	}
+	if _, err := SafeOpen(PluginPermissions{}, filepath.Join(library, "song.mid")); !errors.Is(err, ErrOutsideSandbox) {
+		t.Errorf("expected plugins without file access to be refused, got %v", err)
+	}
+	if f, err := SafeOpen(PluginPermissions{FileAccess: true}, filepath.Join(outside, "secret.txt")); err != nil {
+		t.Errorf("file access without an allowlist should cover every path: %v", err)
+	} else {
+		_ = f.Close()
+	}
+}
+
+func TestSandboxPaths_FromYAML(t *testing.T) {
+	config, err := readPluginConfig(permissionsPluginYAML + "  read_paths: [\"~/Music\"]\n  write_paths: [/tmp/exports]\n")
+	if err != nil {
+		t.Fatalf("readPluginConfig error: %v", err)
+	}
+	tool := &plainTestTool{}
+	tool.SetPluginConfig(&config)
+	got := newTestClient(t, tool).GetRequiredPermissions()
+	if !reflect.DeepEqual(got.ReadPaths, []string{"~/Music"}) || !reflect.DeepEqual(got.WritePaths, []string{"/tmp/exports"}) {
+		t.Errorf("unexpected permissions over RPC: %+v", got)
+	}
+
+	if _, err := readPluginConfig(permissionsPluginYAML + "  read_paths: [Music]\n"); err == nil {
+		t.Error("expected a relative sandbox path to be rejected")
+	}
+}
+
+func TestValidateFileWatches_Sandbox(t *testing.T) {
+	perms := PluginPermissions{FileAccess: true, ReadPaths: []string{"/projects"}}
+	if err := ValidateFileWatches([]FileWatch{{Path: "/projects/songs"}}, perms); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateFileWatches([]FileWatch{{Path: "/etc"}}, perms); !errors.Is(err, ErrOutsideSandbox) {
+		t.Errorf("expected ErrOutsideSandbox, got %v", err)
+	}
+}
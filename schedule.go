@@ -0,0 +1,228 @@
+package pluginapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScheduledTask declares a periodic job the host runs on the plugin's behalf.
+type ScheduledTask struct {
+	// Name identifies the task in ExecuteScheduledTask
+	Name string `json:"name"`
+	// Schedule is a cron spec (see ParseSchedule), e.g. "*/15 * * * *" or "@daily"
+	Schedule string `json:"schedule"`
+	// Description tells users what the task does
+	Description string `json:"description,omitempty"`
+	// Timeout bounds each run (0 = none)
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// ScheduledTaskProvider allows plugins to run periodic jobs, such as syncing or
+// cleaning up, without keeping their own timers. The host runs the schedule and
+// calls ExecuteScheduledTask when a task is due; runs missed while the agent was
+// not running are not made up.
+// Plugins can optionally implement this interface.
+//
+// Example:
+//
+//	func (p *syncPlugin) GetScheduledTasks() []pluginapi.ScheduledTask {
+//	    return []pluginapi.ScheduledTask{{Name: "sync", Schedule: "*/15 * * * *", Timeout: time.Minute}}
+//	}
+//
+//	func (p *syncPlugin) ExecuteScheduledTask(ctx context.Context, name string, scheduledAt time.Time) error {
+//	    return p.syncChangesSince(ctx, p.lastSync)
+//	}
+type ScheduledTaskProvider interface {
+	// GetScheduledTasks returns the tasks to schedule
+	GetScheduledTasks() []ScheduledTask
+
+	// ExecuteScheduledTask runs the named task, which was due at scheduledAt.
+	// ctx ends when the task's Timeout elapses.
+	ExecuteScheduledTask(ctx context.Context, name string, scheduledAt time.Time) error
+}
+
+// ValidateScheduledTasks checks that every task has a unique name and a valid schedule.
+func ValidateScheduledTasks(tasks []ScheduledTask) error {
+	seen := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		if task.Name == "" {
+			return fmt.Errorf("scheduled task name is required")
+		}
+		if seen[task.Name] {
+			return fmt.Errorf("duplicate scheduled task %q", task.Name)
+		}
+		seen[task.Name] = true
+		if _, err := ParseSchedule(task.Schedule); err != nil {
+			return fmt.Errorf("scheduled task %q: %w", task.Name, err)
+		}
+		if task.Timeout < 0 {
+			return fmt.Errorf("scheduled task %q: timeout cannot be negative", task.Name)
+		}
+	}
+	return nil
+}
+
+// Schedule computes when a scheduled task runs.
+type Schedule interface {
+	// Next returns the first run time after t, or the zero time if there is none
+	Next(t time.Time) time.Time
+}
+
+// scheduleDescriptors are the cron shorthands ParseSchedule accepts.
+var scheduleDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a standard five-field cron spec: minute, hour, day of month,
+// month, and day of week (0 or 7 is Sunday). Fields accept "*", values, ranges
+// ("1-5"), lists ("1,15") and steps ("*/10", "0-30/5"). The shorthands @hourly,
+// @daily, @weekly, @monthly and @yearly, and "@every <duration>" (e.g., "@every 90s")
+// are accepted too. Times are computed in the location of the time passed to Next.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if every, ok := strings.CutPrefix(spec, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(every))
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid schedule %q: @every needs a positive duration", spec)
+		}
+		return intervalSchedule(interval), nil
+	}
+	if expanded, ok := scheduleDescriptors[spec]; ok {
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields (minute hour day month weekday)", spec)
+	}
+	var s cronSchedule
+	var err error
+	for i, field := range []struct {
+		bits     *uint64
+		min, max int
+	}{
+		{&s.minute, 0, 59},
+		{&s.hour, 0, 23},
+		{&s.dayOfMonth, 1, 31},
+		{&s.month, 1, 12},
+		{&s.dayOfWeek, 0, 7},
+	} {
+		if *field.bits, err = parseCronField(fields[i], field.min, field.max); err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+	}
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek |= 1 << 0
+	}
+	s.anyDayOfMonth = fields[2] == "*"
+	s.anyDayOfWeek = fields[4] == "*"
+	return &s, nil
+}
+
+// parseCronField returns the values a cron field matches as a bit set.
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = min, max
+		case strings.Contains(rangePart, "-"):
+			loPart, hiPart, _ := strings.Cut(rangePart, "-")
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(loPart)
+			hi, err2 = strconv.Atoi(hiPart)
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rangePart)
+			}
+			lo, hi = n, n
+			if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// cronSchedule is a parsed five-field cron spec.
+type cronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	anyDayOfMonth, anyDayOfWeek                bool
+}
+
+// cronSearchYears bounds the search for specs that never match, like "0 0 30 2 *".
+const cronSearchYears = 5
+
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		if s.month&(1<<int(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<t.Hour()) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<t.Minute()) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay follows cron: if both day fields are restricted, either may match.
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	dom := s.dayOfMonth&(1<<t.Day()) != 0
+	dow := s.dayOfWeek&(1<<int(t.Weekday())) != 0
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// intervalSchedule runs at a fixed interval from the previous run.
+type intervalSchedule time.Duration
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(s))
+}
@@ -0,0 +1,130 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule_Next(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2026, time.March, 4, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, time.March, 4, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.March, 4, 10, 15, 0, 0, time.UTC)},
+		{"5 * * * *", time.Date(2026, time.March, 4, 11, 5, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2026, time.March, 4, 13, 0, 0, 0, time.UTC)},
+		{"30 2 * * 1-5", time.Date(2026, time.March, 5, 2, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either matches (the 10th, or the coming Friday)
+		{"0 0 10 * 5", time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 90s", from.Add(90 * time.Second)},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.spec)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q) failed: %v", tt.spec, err)
+			continue
+		}
+		if got := schedule.Next(from); !got.Equal(tt.want) {
+			t.Errorf("ParseSchedule(%q).Next = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+
+	never, err := ParseSchedule("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("ParseSchedule failed: %v", err)
+	}
+	if next := never.Next(from); !next.IsZero() {
+		t.Errorf("expected no run for February 30, got %v", next)
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *", "@every", "@every -1m", "@often"} {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("ParseSchedule(%q) should fail", spec)
+		}
+	}
+}
+
+type scheduledTestTool struct {
+	plainTestTool
+	runs chan string
+}
+
+func (t *scheduledTestTool) GetScheduledTasks() []ScheduledTask {
+	return []ScheduledTask{
+		{Name: "sync", Schedule: "*/15 * * * *", Description: "Syncs projects", Timeout: time.Minute},
+		{Name: "cleanup", Schedule: "@daily"},
+	}
+}
+
+func (t *scheduledTestTool) ExecuteScheduledTask(ctx context.Context, name string, scheduledAt time.Time) error {
+	if name == "cleanup" {
+		return errors.New("disk is read-only")
+	}
+	deadline, _ := ctx.Deadline()
+	t.runs <- name + "@" + scheduledAt.UTC().Format(time.RFC3339) + " deadline=" + time.Until(deadline).Round(time.Minute).String()
+	return nil
+}
+
+func TestScheduledTasks(t *testing.T) {
+	tool := &scheduledTestTool{runs: make(chan string, 1)}
+	client := newTestClient(t, tool)
+	ctx := context.Background()
+
+	tasks := client.GetScheduledTasksCtx(ctx)
+	if len(tasks) != 2 || tasks[0].Name != "sync" || tasks[0].Timeout != time.Minute || tasks[1].Schedule != "@daily" {
+		t.Fatalf("unexpected tasks: %+v", tasks)
+	}
+
+	due := time.Date(2026, time.March, 4, 10, 15, 0, 0, time.UTC)
+	if err := client.ExecuteScheduledTask(ctx, "sync", due); err != nil {
+		t.Fatalf("ExecuteScheduledTask failed: %v", err)
+	}
+	if run := <-tool.runs; run != "sync@2026-03-04T10:15:00Z deadline=1m0s" {
+		t.Errorf("unexpected run %q", run)
+	}
+
+	if err := client.ExecuteScheduledTask(ctx, "cleanup", due); err == nil || err.Error() != "disk is read-only" {
+		t.Errorf("expected the task's error, got %v", err)
+	}
+	if err := client.ExecuteScheduledTask(ctx, "backup", due); err == nil || !strings.Contains(err.Error(), "unknown scheduled task") {
+		t.Errorf("expected unknown task error, got %v", err)
+	}
+
+	plain := newTestClient(t, &plainTestTool{})
+	if tasks := plain.GetScheduledTasksCtx(ctx); tasks != nil {
+		t.Errorf("expected no tasks without ScheduledTaskProvider, got %+v", tasks)
+	}
+	if err := plain.ExecuteScheduledTask(ctx, "sync", due); err == nil {
+		t.Error("expected error without ScheduledTaskProvider")
+	}
+}
+
+func TestValidateScheduledTasks(t *testing.T) {
+	tests := []struct {
+		tasks []ScheduledTask
+		want  string
+	}{
+		{[]ScheduledTask{{Schedule: "@hourly"}}, "name is required"},
+		{[]ScheduledTask{{Name: "a", Schedule: "@hourly"}, {Name: "a", Schedule: "@daily"}}, "duplicate"},
+		{[]ScheduledTask{{Name: "a", Schedule: "every hour"}}, "invalid schedule"},
+		{[]ScheduledTask{{Name: "a", Schedule: "@hourly", Timeout: -time.Second}}, "negative"},
+	}
+	for _, tt := range tests {
+		if err := ValidateScheduledTasks(tt.tasks); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ValidateScheduledTasks(%+v) = %v, want error containing %q", tt.tasks, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrSecretNotFound is returned by HostServices.GetSecret when no secret is stored
+// under the key.
+var ErrSecretNotFound = errors.New("secret not found")
+
+func (UnimplementedHostServices) GetSecret(ctx context.Context, key string) (string, error) {
+	return "", ErrHostServiceUnavailable
+}
+
+func (UnimplementedHostServices) SetSecret(ctx context.Context, key, value string) error {
+	return ErrHostServiceUnavailable
+}
+
+func (s *hostServer) GetSecret(ctx context.Context, req *HostSecretRequest) (*HostSecretResponse, error) {
+	value, err := s.Impl.GetSecret(ctx, req.Key)
+	if st := hostStatus(err); st != nil {
+		return nil, st
+	}
+	if err != nil {
+		return &HostSecretResponse{Error: err.Error()}, nil
+	}
+	return &HostSecretResponse{Value: value}, nil
+}
+
+func (s *hostServer) SetSecret(ctx context.Context, req *HostSecretRequest) (*ConfigResponse, error) {
+	return hostResponse(s.Impl.SetSecret(ctx, req.Key, req.Value))
+}
+
+func (h *hostClient) GetSecret(ctx context.Context, key string) (string, error) {
+	resp, err := h.client.GetSecret(h.outgoing(ctx), &HostSecretRequest{Key: key})
+	if err != nil {
+		return "", hostSentinel(err)
+	}
+	if resp.Error != "" {
+		return "", fmt.Errorf("%s", resp.Error)
+	}
+	return resp.Value, nil
+}
+
+func (h *hostClient) SetSecret(ctx context.Context, key, value string) error {
+	return hostError(h.client.SetSecret(h.outgoing(ctx), &HostSecretRequest{Key: key, Value: value}))
+}
+
+// routeSecrets moves the values of ConfigTypeSecret variables between config and
+// the host's keychain before InitializeWithConfig: values the user entered are
+// stored with SetSecret, and missing ones are filled in with GetSecret, so plugins
+// receive their secrets like any other config while the settings file never holds them.
+func routeSecrets(ctx context.Context, host HostServices, vars []ConfigVariable, config map[string]interface{}) error {
+	for _, v := range vars {
+		if v.Type != ConfigTypeSecret {
+			continue
+		}
+		if value, ok := config[v.Key].(string); ok && value != "" {
+			if err := host.SetSecret(ctx, v.Key, value); err != nil {
+				return fmt.Errorf("failed to store secret %q: %w", v.Key, err)
+			}
+		}
+	}
+	return fillSecrets(ctx, host, vars, config)
+}
+
+// fillSecrets sets ConfigTypeSecret variables missing from config to the values
+// stored in the host's keychain, without storing anything.
+func fillSecrets(ctx context.Context, host HostServices, vars []ConfigVariable, config map[string]interface{}) error {
+	for _, v := range vars {
+		if v.Type != ConfigTypeSecret {
+			continue
+		}
+		if value, ok := config[v.Key].(string); ok && value != "" {
+			continue
+		}
+		value, err := host.GetSecret(ctx, v.Key)
+		switch {
+		case err == nil:
+			config[v.Key] = value
+		case errors.Is(err, ErrSecretNotFound), errors.Is(err, ErrHostServiceUnavailable):
+			// Nothing stored; InitializeWithConfig sees the variable as unset
+		default:
+			return fmt.Errorf("failed to read secret %q: %w", v.Key, err)
+		}
+	}
+	return nil
+}
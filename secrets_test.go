@@ -0,0 +1,102 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// keychainHost stores secrets per plugin, like the agent's keychain integration.
+type keychainHost struct {
+	UnimplementedHostServices
+	secrets map[string]string
+}
+
+func (h *keychainHost) GetSecret(ctx context.Context, key string) (string, error) {
+	value, ok := h.secrets[HostCallerToken(ctx)+"/"+key]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+func (h *keychainHost) SetSecret(ctx context.Context, key, value string) error {
+	if value == "" {
+		delete(h.secrets, HostCallerToken(ctx)+"/"+key)
+		return nil
+	}
+	h.secrets[HostCallerToken(ctx)+"/"+key] = value
+	return nil
+}
+
+func TestHostServices_Secrets(t *testing.T) {
+	impl := &keychainHost{secrets: make(map[string]string)}
+	weather := connectTestHost(t, impl, "weather")
+	ctx := context.Background()
+
+	if _, err := weather.GetSecret(ctx, "api_key"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("expected ErrSecretNotFound, got %v", err)
+	}
+	if err := weather.SetSecret(ctx, "api_key", "s3cret"); err != nil {
+		t.Fatalf("SetSecret failed: %v", err)
+	}
+	if value, err := weather.GetSecret(ctx, "api_key"); err != nil || value != "s3cret" {
+		t.Errorf("GetSecret = %q, %v", value, err)
+	}
+	if _, err := connectTestHost(t, impl, "music").GetSecret(ctx, "api_key"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("expected secrets to be separate per plugin, got %v", err)
+	}
+	if err := weather.SetSecret(ctx, "api_key", ""); err != nil {
+		t.Fatalf("SetSecret failed: %v", err)
+	}
+	if _, err := weather.GetSecret(ctx, "api_key"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("expected an empty value to delete the secret, got %v", err)
+	}
+}
+
+type secretConfigTestTool struct {
+	plainTestTool
+	config map[string]interface{}
+}
+
+func (t *secretConfigTestTool) GetRequiredConfig() []ConfigVariable {
+	return []ConfigVariable{
+		{Key: "api_key", Type: ConfigTypeSecret, Required: true},
+		{Key: "units", Type: ConfigTypeString},
+	}
+}
+
+func (t *secretConfigTestTool) ValidateConfig(config map[string]interface{}) error {
+	return nil
+}
+
+func (t *secretConfigTestTool) InitializeWithConfig(config map[string]interface{}) error {
+	t.config = config
+	return nil
+}
+
+func TestInitializeWithConfig_RoutesSecrets(t *testing.T) {
+	impl := &keychainHost{secrets: make(map[string]string)}
+	tool := &secretConfigTestTool{}
+	client := newTestClient(t, tool)
+	if err := client.ConnectHostServices(context.Background(), newTestHost(t, impl), "weather"); err != nil {
+		t.Fatalf("ConnectHostServices failed: %v", err)
+	}
+
+	if err := client.InitializeWithConfig(map[string]interface{}{"api_key": "s3cret", "units": "metric"}); err != nil {
+		t.Fatalf("InitializeWithConfig failed: %v", err)
+	}
+	if impl.secrets["weather/api_key"] != "s3cret" {
+		t.Errorf("expected the secret in the keychain, got %v", impl.secrets)
+	}
+	if _, ok := impl.secrets["weather/units"]; ok {
+		t.Error("only secret variables belong in the keychain")
+	}
+
+	if err := client.InitializeWithConfig(map[string]interface{}{"units": "metric"}); err != nil {
+		t.Fatalf("InitializeWithConfig failed: %v", err)
+	}
+	if tool.config["api_key"] != "s3cret" {
+		t.Errorf("expected the stored secret to be filled in, got %v", tool.config)
+	}
+}
@@ -2,13 +2,11 @@ package pluginapi
 
 import (
 	"fmt"
-	"net"
 	"os"
 	"reflect"
-	"strconv"
-	"strings"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // ServePlugin is a helper function that dramatically simplifies plugin main() functions.
@@ -30,8 +28,8 @@ import (
 // The function automatically:
 // - Parses plugin.yaml configuration
 // - Creates and initializes BasePlugin with all metadata
-// - Injects BasePlugin into your tool struct (via reflection)
-// - Starts the gRPC plugin server on ORI_PLUGIN_GRPC_PORT
+// - Injects BasePlugin into your tool struct (via BaseSetter, falling back to reflection)
+// - Starts the gRPC plugin server on ORI_PLUGIN_GRPC_PORT, or over stdio where listeners aren't allowed
 //
 // Requirements:
 // - tool must be a pointer to a struct
@@ -42,7 +40,17 @@ func ServePlugin(tool PluginTool, configYAML string) {
 }
 
 // ServeGRPCPlugin starts a direct gRPC server (no go-plugin handshake).
-// It listens on the port provided via ORI_PLUGIN_GRPC_PORT.
+// It listens on the unix socket at ORI_PLUGIN_SOCKET if set, otherwise on the port
+// provided via ORI_PLUGIN_GRPC_PORT, on 127.0.0.1 unless ORI_PLUGIN_GRPC_HOST says
+// otherwise. It serves TLS or mutual TLS when the ORI_PLUGIN_TLS_* variables are
+// set (see EnvTLSCert), and gRPC reflection when EnvGRPCReflection is.
+// With ORI_PLUGIN_TRANSPORT=stdio, or when neither a port nor a socket is given,
+// it serves over stdin and stdout instead (see StdioClientConn), unless
+// ORI_PLUGIN_DEV picks a free port for standalone testing.
+// Once listening it registers the standard gRPC health service and prints a
+// readiness line to stdout (see ReadyInfo).
+// On SIGTERM or interrupt it stops accepting calls, waits for running ones (up to
+// 15 seconds), runs the plugin's ShutdownHandler, if any, and returns.
 func ServeGRPCPlugin(tool PluginTool, configYAML string) {
 	// Parse plugin config from embedded YAML
 	config, err := readPluginConfig(configYAML)
@@ -73,36 +81,63 @@ func ServeGRPCPlugin(tool PluginTool, configYAML string) {
 		base.SetMetadata(metadata)
 	}
 
-	// Use reflection to inject BasePlugin into the tool struct
+	// Inject BasePlugin into the tool struct
 	if err := injectBasePlugin(tool, &base); err != nil {
 		panic(fmt.Sprintf("ServeGRPCPlugin failed: %v", err))
 	}
 
-	portStr := strings.TrimSpace(os.Getenv("ORI_PLUGIN_GRPC_PORT"))
-	if portStr == "" {
-		panic("ServeGRPCPlugin requires ORI_PLUGIN_GRPC_PORT to be set")
+	transport, err := transportFromEnv()
+	if err != nil {
+		panic(fmt.Sprintf("ServeGRPCPlugin %v", err))
+	}
+	if transport == TransportStdio {
+		serveStdio(tool)
+		return
+	}
+
+	tlsConfig, err := serverTLSConfigFromEnv()
+	if err != nil {
+		panic(fmt.Sprintf("ServeGRPCPlugin invalid TLS configuration: %v", err))
 	}
-	port, err := strconv.Atoi(portStr)
-	if err != nil || port <= 0 || port > 65535 {
-		panic(fmt.Sprintf("ServeGRPCPlugin invalid ORI_PLUGIN_GRPC_PORT: %q", portStr))
+	opts := serverOptions(tool)
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
 
-	addr := fmt.Sprintf("127.0.0.1:%d", port)
-	lis, err := net.Listen("tcp", addr)
+	lis, err := listenFromEnv()
 	if err != nil {
-		panic(fmt.Sprintf("ServeGRPCPlugin failed to listen on %s: %v", addr, err))
+		panic(fmt.Sprintf("ServeGRPCPlugin %v", err))
 	}
 
-	server := grpc.NewServer()
-	RegisterToolServiceServer(server, &grpcServer{Impl: tool})
+	server := grpc.NewServer(opts...)
+	srv := registerToolServices(server, tool)
+	healthServer := registerHealth(server)
+	registerReflection(server)
+	stopped := stopOnSignal(server, srv, healthServer.Shutdown)
 
+	if err := writeReadyLine(os.Stdout, newReadyInfo(lis, apiVersion, tlsConfig != nil)); err != nil {
+		panic(fmt.Sprintf("ServeGRPCPlugin failed to announce readiness: %v", err))
+	}
+	if devMode() {
+		fmt.Fprintf(os.Stderr, "%s %s listening on %s\n", config.Name, config.Version, lis.Addr())
+	}
 	if err := server.Serve(lis); err != nil {
 		panic(fmt.Sprintf("ServeGRPCPlugin gRPC server error: %v", err))
 	}
+	// Serve only returns cleanly once a signal started the shutdown
+	<-stopped
 }
 
-// injectBasePlugin uses reflection to find and set the embedded BasePlugin field
+// injectBasePlugin sets the embedded BasePlugin field.
+// Tools embedding BasePlugin implement BaseSetter through method promotion, so
+// reflection is only needed for unusual layouts (e.g., BasePlugin behind an embedded pointer).
 func injectBasePlugin(tool PluginTool, base *BasePlugin) error {
+	tool, _ = unwrapTool(tool)
+	if setter, ok := tool.(BaseSetter); ok {
+		setter.setBase(base)
+		return nil
+	}
+
 	toolValue := reflect.ValueOf(tool)
 
 	// Ensure tool is a pointer
@@ -0,0 +1,33 @@
+package pluginapi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInjectBasePlugin(t *testing.T) {
+	base := newBasePlugin("test", "2.0.0", "0.1.0", "", "v1")
+
+	tool := &plainTestTool{}
+	var _ BaseSetter = tool
+	if err := injectBasePlugin(tool, &base); err != nil {
+		t.Fatalf("injectBasePlugin failed: %v", err)
+	}
+	if tool.Version() != "2.0.0" || tool.MinAgentVersion() != "0.1.0" {
+		t.Errorf("BasePlugin not injected: version %q, min %q", tool.Version(), tool.MinAgentVersion())
+	}
+
+	if err := injectBasePlugin(&notEmbeddingTool{}, &base); err == nil {
+		t.Error("expected error for tool without BasePlugin")
+	}
+}
+
+type notEmbeddingTool struct{}
+
+func (t *notEmbeddingTool) Definition() Tool {
+	return Tool{}
+}
+
+func (t *notEmbeddingTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
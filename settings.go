@@ -1,21 +1,37 @@
 package pluginapi
 
 import (
+	"crypto/cipher"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 // SettingsManager provides thread-safe access to plugin settings.
 // Settings are stored as JSON files in the agent's directory and cached in memory.
+//
+// Keys may be dot paths (e.g., "smtp.host") that address nested JSON objects, so
+// related settings can be grouped and read together with GetSection. A top-level
+// key that contains dots itself takes precedence over the nested path.
 type SettingsManager interface {
 	// Get retrieves a setting value by key.
 	// Returns nil if the key doesn't exist.
 	Get(key string) (interface{}, error)
 
+	// GetSection returns a copy of the nested settings under prefix (e.g., "smtp").
+	// Returns nil if there are none, or an error if prefix is not an object.
+	GetSection(prefix string) (map[string]interface{}, error)
+
+	// Bind decodes the settings under prefix (all settings for "") into target,
+	// a pointer to a struct or map. See also SettingAs.
+	Bind(prefix string, target interface{}) error
+
 	// GetString retrieves a string setting. Returns empty string if not found.
 	GetString(key string) (string, error)
 
@@ -31,12 +47,52 @@ type SettingsManager interface {
 	// Set stores a setting value. Value will be serialized to JSON.
 	Set(key string, value interface{}) error
 
+	// SetWithTTL stores a setting that reads as absent once ttl has passed.
+	SetWithTTL(key string, value interface{}, ttl time.Duration) error
+
 	// Delete removes a setting by key.
 	Delete(key string) error
 
 	// GetAll returns all settings as a map.
 	GetAll() (map[string]interface{}, error)
 
+	// Update atomically replaces a setting with the value returned by fn.
+	// fn receives the current value (nil if the key doesn't exist) and runs under
+	// the write lock, so concurrent updates to the same key are never lost.
+	// If fn returns an error, the setting is left unchanged.
+	Update(key string, fn func(current interface{}) (interface{}, error)) error
+
+	// UpdateAll atomically modifies multiple settings in a single save.
+	// fn receives a copy of all settings and may add, change, or delete entries.
+	// If fn returns an error, no changes are applied.
+	UpdateAll(fn func(settings map[string]interface{}) error) error
+
+	// SetMany stores several settings with a single save. Either all of them are
+	// applied or, if one is rejected, none.
+	SetMany(values map[string]interface{}) error
+
+	// Transaction runs fn under the write lock and saves the changes it makes
+	// through tx once, atomically. If fn returns an error, no changes are applied.
+	//
+	// Example:
+	//
+	//	err := settings.Transaction(func(tx pluginapi.SettingsTx) error {
+	//	    count, _ := tx.Get("sync.count").(float64)
+	//	    if err := tx.Set("sync.count", count+1); err != nil {
+	//	        return err
+	//	    }
+	//	    return tx.Set("sync.last", time.Now().Format(time.RFC3339))
+	//	})
+	Transaction(fn func(tx SettingsTx) error) error
+
+	// OversizedEntries reports settings whose JSON-encoded value exceeds maxBytes,
+	// sorted from largest to smallest.
+	OversizedEntries(maxBytes int) ([]SettingsEntrySize, error)
+
+	// PruneOversized deletes settings whose JSON-encoded value exceeds maxBytes
+	// and returns the removed keys.
+	PruneOversized(maxBytes int) ([]string, error)
+
 	// Save persists settings to disk atomically.
 	Save() error
 
@@ -44,17 +100,54 @@ type SettingsManager interface {
 	Load() error
 }
 
+// ErrSettingsLimitExceeded is returned when a change would push the settings
+// past the configured SettingsLimits. The change is not applied.
+var ErrSettingsLimitExceeded = errors.New("settings limit exceeded")
+
+// SettingsLimits bounds the size of a plugin's settings file.
+// A zero value for any field disables that limit.
+type SettingsLimits struct {
+	// MaxFileSize is the maximum size of the serialized settings file in bytes
+	MaxFileSize int64
+	// MaxKeys is the maximum number of top-level settings keys
+	MaxKeys int
+}
+
+// DefaultSettingsLimits are the limits used by NewSettingsManager.
+var DefaultSettingsLimits = SettingsLimits{
+	MaxFileSize: 10 * 1024 * 1024, // 10 MiB
+	MaxKeys:     10000,
+}
+
+// SettingsEntrySize describes the serialized size of a single setting.
+type SettingsEntrySize struct {
+	Key  string
+	Size int // Size of the JSON-encoded value in bytes
+}
+
 // settingsManager is the default implementation of SettingsManager.
 type settingsManager struct {
 	mu       sync.RWMutex
 	cache    map[string]interface{}
 	filePath string
 	dirty    bool // Track if cache has unsaved changes
+	limits   SettingsLimits
+	aead     cipher.AEAD // Encrypts the file when set (see WithSettingsEncryption)
 }
 
 // NewSettingsManager creates a new settings manager for a plugin.
 // The settings file is stored at: agentDir/{plugin}_settings.json (UI-consistent path).
-func NewSettingsManager(agentDir, pluginName string) (SettingsManager, error) {
+// DefaultSettingsLimits are applied; use NewSettingsManagerWithLimits to override them.
+// opts can, for example, encrypt the file (see WithSettingsEncryption).
+func NewSettingsManager(agentDir, pluginName string, opts ...SettingsOption) (SettingsManager, error) {
+	return NewSettingsManagerWithLimits(agentDir, pluginName, DefaultSettingsLimits, opts...)
+}
+
+// NewSettingsManagerWithLimits creates a new settings manager that enforces the given limits.
+//
+// If the settings file cannot be parsed, the last good copy ({plugin}_settings.json.bak)
+// is restored automatically. An error is returned only when no usable backup exists.
+func NewSettingsManagerWithLimits(agentDir, pluginName string, limits SettingsLimits, opts ...SettingsOption) (SettingsManager, error) {
 	if agentDir == "" {
 		return nil, fmt.Errorf("agentDir cannot be empty")
 	}
@@ -68,6 +161,12 @@ func NewSettingsManager(agentDir, pluginName string) (SettingsManager, error) {
 		cache:    make(map[string]interface{}),
 		filePath: filePath,
 		dirty:    false,
+		limits:   limits,
+	}
+	for _, opt := range opts {
+		if err := opt(sm); err != nil {
+			return nil, err
+		}
 	}
 
 	// Load existing settings if file exists
@@ -102,7 +201,7 @@ func (sm *settingsManager) Get(key string) (interface{}, error) {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
-	value, exists := sm.cache[key]
+	value, exists := getSetting(sm.cache, key)
 	if !exists {
 		return nil, nil
 	}
@@ -195,11 +294,13 @@ func (sm *settingsManager) Set(key string, value interface{}) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
-	sm.cache[key] = value
-	sm.dirty = true
+	updated := sm.copyUnlocked()
+	if err := storeSetting(updated, key, value); err != nil {
+		return err
+	}
 
 	// Auto-save on set for durability
-	return sm.saveUnlocked()
+	return sm.applyUnlocked(updated)
 }
 
 // Delete removes a setting by key.
@@ -207,11 +308,11 @@ func (sm *settingsManager) Delete(key string) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
-	delete(sm.cache, key)
-	sm.dirty = true
+	updated := sm.copyUnlocked()
+	deleteSetting(updated, key)
 
 	// Auto-save on delete for durability
-	return sm.saveUnlocked()
+	return sm.applyUnlocked(updated)
 }
 
 // GetAll returns all settings as a map.
@@ -220,11 +321,157 @@ func (sm *settingsManager) GetAll() (map[string]interface{}, error) {
 	defer sm.mu.RUnlock()
 
 	// Return a copy to prevent external modifications
+	return copySettingsSection(sm.cache), nil
+}
+
+// Update atomically replaces a setting with the value returned by fn.
+func (sm *settingsManager) Update(key string, fn func(current interface{}) (interface{}, error)) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	// fn gets its own copy, so changing it in place and failing leaves the cache untouched
+	current, _ := getSetting(sm.cache, key)
+	value, err := fn(cloneSettingValue(current))
+	if err != nil {
+		return err
+	}
+
+	updated := sm.copyUnlocked()
+	if err := storeSetting(updated, key, value); err != nil {
+		return err
+	}
+
+	return sm.applyUnlocked(updated)
+}
+
+// UpdateAll atomically modifies multiple settings in a single save.
+func (sm *settingsManager) UpdateAll(fn func(settings map[string]interface{}) error) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	// Work on a deep copy so a failing fn leaves the cache untouched,
+	// even if it changed nested objects in place
+	updated := sm.deepCopyUnlocked()
+	if err := fn(updated); err != nil {
+		return err
+	}
+
+	return sm.applyUnlocked(updated)
+}
+
+// OversizedEntries reports settings whose JSON-encoded value exceeds maxBytes.
+func (sm *settingsManager) OversizedEntries(maxBytes int) ([]SettingsEntrySize, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.oversizedUnlocked(maxBytes)
+}
+
+// PruneOversized deletes settings whose JSON-encoded value exceeds maxBytes.
+func (sm *settingsManager) PruneOversized(maxBytes int) ([]string, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	entries, err := sm.oversizedUnlocked(maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	updated := sm.copyUnlocked()
+	keys := make([]string, len(entries))
+	for i, entry := range entries {
+		delete(updated, entry.Key)
+		keys[i] = entry.Key
+	}
+
+	if err := sm.applyUnlocked(updated); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// oversizedUnlocked measures each setting and returns those larger than maxBytes.
+// Caller must hold at least the read lock.
+func (sm *settingsManager) oversizedUnlocked(maxBytes int) ([]SettingsEntrySize, error) {
+	var entries []SettingsEntrySize
+	for key, value := range sm.cache {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal setting %q: %w", key, err)
+		}
+		if len(data) > maxBytes {
+			entries = append(entries, SettingsEntrySize{Key: key, Size: len(data)})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Size != entries[j].Size {
+			return entries[i].Size > entries[j].Size
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries, nil
+}
+
+// copyUnlocked returns a shallow copy of the cache.
+// Caller must hold at least the read lock.
+func (sm *settingsManager) copyUnlocked() map[string]interface{} {
 	result := make(map[string]interface{}, len(sm.cache))
 	for k, v := range sm.cache {
 		result[k] = v
 	}
-	return result, nil
+	return result
+}
+
+// deepCopyUnlocked returns a copy of the cache that shares no objects or arrays with it.
+// Caller must hold at least the read lock.
+func (sm *settingsManager) deepCopyUnlocked() map[string]interface{} {
+	return cloneSettingValue(sm.cache).(map[string]interface{})
+}
+
+// cloneSettingValue deep-copies the objects and arrays in a setting value.
+func cloneSettingValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			result[k] = cloneSettingValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = cloneSettingValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+// applyUnlocked replaces the cache with updated and persists it.
+// The change is rejected, leaving the cache untouched, if it would exceed the limits.
+// Caller must hold the write lock.
+func (sm *settingsManager) applyUnlocked(updated map[string]interface{}) error {
+	pruneExpiredSettings(updated, time.Now())
+	if sm.limits.MaxKeys > 0 && len(updated) > sm.limits.MaxKeys && len(updated) > len(sm.cache) {
+		return fmt.Errorf("%w: %d keys (max %d)", ErrSettingsLimitExceeded, len(updated), sm.limits.MaxKeys)
+	}
+
+	if sm.limits.MaxFileSize > 0 {
+		data, err := json.MarshalIndent(updated, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal settings: %w", err)
+		}
+		if int64(len(data)) > sm.limits.MaxFileSize {
+			return fmt.Errorf("%w: %d bytes (max %d)", ErrSettingsLimitExceeded, len(data), sm.limits.MaxFileSize)
+		}
+	}
+
+	sm.cache = updated
+	sm.dirty = true
+	return sm.saveUnlocked()
 }
 
 // Save persists settings to disk atomically using temp file + rename pattern.
@@ -246,6 +493,9 @@ func (sm *settingsManager) saveUnlocked() error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal settings: %w", err)
 	}
+	if data, err = sm.seal(data); err != nil {
+		return fmt.Errorf("failed to encrypt settings: %w", err)
+	}
 
 	// Atomic write: write to temp file, then rename
 	// This ensures we never corrupt the settings file
@@ -262,10 +512,24 @@ func (sm *settingsManager) saveUnlocked() error {
 		return fmt.Errorf("failed to rename settings file: %w", err)
 	}
 
+	// Keep a copy of the last good settings for corruption recovery.
+	// This is best-effort: the primary file has already been written.
+	backupPath := sm.backupPath()
+	if err := os.WriteFile(backupPath+".tmp", data, 0644); err == nil {
+		if err := os.Rename(backupPath+".tmp", backupPath); err != nil {
+			_ = os.Remove(backupPath + ".tmp")
+		}
+	}
+
 	sm.dirty = false
 	return nil
 }
 
+// backupPath returns the path of the last-known-good settings copy.
+func (sm *settingsManager) backupPath() string {
+	return sm.filePath + ".bak"
+}
+
 // Load reloads settings from disk.
 func (sm *settingsManager) Load() error {
 	sm.mu.Lock()
@@ -283,13 +547,79 @@ func (sm *settingsManager) Load() error {
 		return fmt.Errorf("failed to read settings file: %w", err)
 	}
 
-	// Parse JSON
-	var settings map[string]interface{}
-	if err := json.Unmarshal(data, &settings); err != nil {
-		return fmt.Errorf("failed to parse settings file: %w", err)
+	settings, parseErr := sm.parseSettings(data)
+	if parseErr == nil {
+		sm.cache = settings
+		sm.dirty = false
+		return nil
+	}
+
+	// Main file is corrupt or oversized; fall back to the last good copy
+	backup, err := os.ReadFile(sm.backupPath())
+	if err != nil {
+		return parseErr
+	}
+	settings, err = sm.parseSettings(backup)
+	if err != nil {
+		return parseErr
 	}
 
+	// Rewrite the main file from the recovered copy
 	sm.cache = settings
-	sm.dirty = false
+	sm.dirty = true
+	if err := sm.saveUnlocked(); err != nil {
+		return fmt.Errorf("failed to restore settings from backup: %w", err)
+	}
 	return nil
 }
+
+// parseSettings decrypts and decodes a settings file, enforcing the file size limit.
+func (sm *settingsManager) parseSettings(data []byte) (map[string]interface{}, error) {
+	data, err := sm.open(data)
+	if err != nil {
+		return nil, err
+	}
+	if sm.limits.MaxFileSize > 0 && int64(len(data)) > sm.limits.MaxFileSize {
+		return nil, fmt.Errorf("%w: settings file is %d bytes (max %d)", ErrSettingsLimitExceeded, len(data), sm.limits.MaxFileSize)
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings file: %w", err)
+	}
+	if settings == nil {
+		settings = make(map[string]interface{})
+	}
+	return settings, nil
+}
+
+// failedSettings is a SettingsManager whose every method fails with err.
+// BasePlugin.Settings returns it when the settings can't be opened.
+type failedSettings struct {
+	err error
+}
+
+func (f failedSettings) Get(string) (interface{}, error)                     { return nil, f.err }
+func (f failedSettings) GetSection(string) (map[string]interface{}, error)   { return nil, f.err }
+func (f failedSettings) Bind(string, interface{}) error                      { return f.err }
+func (f failedSettings) GetString(string) (string, error)                    { return "", f.err }
+func (f failedSettings) GetInt(string) (int, error)                          { return 0, f.err }
+func (f failedSettings) GetBool(string) (bool, error)                        { return false, f.err }
+func (f failedSettings) GetFloat(string) (float64, error)                    { return 0, f.err }
+func (f failedSettings) Set(string, interface{}) error                       { return f.err }
+func (f failedSettings) SetWithTTL(string, interface{}, time.Duration) error { return f.err }
+func (f failedSettings) Delete(string) error                                 { return f.err }
+func (f failedSettings) GetAll() (map[string]interface{}, error)             { return nil, f.err }
+func (f failedSettings) Save() error                                         { return f.err }
+func (f failedSettings) Load() error                                         { return f.err }
+
+func (f failedSettings) Update(string, func(interface{}) (interface{}, error)) error {
+	return f.err
+}
+
+func (f failedSettings) UpdateAll(func(map[string]interface{}) error) error { return f.err }
+func (f failedSettings) SetMany(map[string]interface{}) error               { return f.err }
+func (f failedSettings) Transaction(func(SettingsTx) error) error           { return f.err }
+
+func (f failedSettings) OversizedEntries(int) ([]SettingsEntrySize, error) { return nil, f.err }
+func (f failedSettings) PruneOversized(int) ([]string, error)              { return nil, f.err }
@@ -0,0 +1,105 @@
+package pluginapi
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvSettingsKey is the base64-encoded AES key (16, 24, or 32 bytes) the agent
+// starts plugins with to have BasePlugin.Settings encrypt the settings file.
+// Without it, settings are stored as plain JSON.
+const EnvSettingsKey = "ORI_PLUGIN_SETTINGS_KEY"
+
+// ErrSettingsEncrypted is returned when loading an encrypted settings file
+// without the key (or with the wrong one).
+var ErrSettingsEncrypted = errors.New("settings file is encrypted")
+
+// settingsCipher marks an encrypted settings file and names its algorithm.
+const settingsCipher = "aes-gcm"
+
+// encryptedSettingsFile is the on-disk form of encrypted settings.
+type encryptedSettingsFile struct {
+	Cipher string `json:"ori_encrypted"`
+	Nonce  []byte `json:"nonce"`
+	Data   []byte `json:"data"`
+}
+
+// SettingsOption configures a settings manager created by NewSettingsManager.
+type SettingsOption func(*settingsManager) error
+
+// WithSettingsEncryption encrypts the settings file (and its backup) with AES-GCM
+// under key, which must be 16, 24, or 32 bytes long. Existing plain settings files
+// are still read and are encrypted the next time settings are saved.
+//
+// Example:
+//
+//	sm, err := pluginapi.NewSettingsManager(agentDir, "weather", pluginapi.WithSettingsEncryption(key))
+func WithSettingsEncryption(key []byte) SettingsOption {
+	return func(sm *settingsManager) error {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return fmt.Errorf("invalid settings key: %w", err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return fmt.Errorf("invalid settings key: %w", err)
+		}
+		sm.aead = aead
+		return nil
+	}
+}
+
+// settingsOptionsFromEnv returns the options EnvSettingsKey asks for.
+func settingsOptionsFromEnv() ([]SettingsOption, error) {
+	encoded := strings.TrimSpace(os.Getenv(EnvSettingsKey))
+	if encoded == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not valid base64: %w", EnvSettingsKey, err)
+	}
+	return []SettingsOption{WithSettingsEncryption(key)}, nil
+}
+
+// seal encrypts serialized settings for writing, if encryption is enabled.
+func (sm *settingsManager) seal(data []byte) ([]byte, error) {
+	if sm.aead == nil {
+		return data, nil
+	}
+	nonce := make([]byte, sm.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return json.MarshalIndent(encryptedSettingsFile{
+		Cipher: settingsCipher,
+		Nonce:  nonce,
+		Data:   sm.aead.Seal(nil, nonce, data, nil),
+	}, "", "  ")
+}
+
+// open decrypts a settings file read from disk. Plain files are returned as-is.
+func (sm *settingsManager) open(data []byte) ([]byte, error) {
+	var file encryptedSettingsFile
+	if json.Unmarshal(data, &file) != nil || file.Cipher != settingsCipher {
+		return data, nil
+	}
+	if sm.aead == nil {
+		return nil, fmt.Errorf("%w; start the plugin with %s", ErrSettingsEncrypted, EnvSettingsKey)
+	}
+	if len(file.Nonce) != sm.aead.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid nonce", ErrSettingsEncrypted)
+	}
+	plain, err := sm.aead.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: wrong key or corrupted file", ErrSettingsEncrypted)
+	}
+	return plain, nil
+}
@@ -0,0 +1,105 @@
+package pluginapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSettingsEncryption(t *testing.T) {
+	dir := t.TempDir()
+	key := bytes.Repeat([]byte{7}, 32)
+
+	// A plain file from before encryption was enabled is still readable
+	plain, err := NewSettingsManager(dir, "weather")
+	if err != nil {
+		t.Fatalf("NewSettingsManager failed: %v", err)
+	}
+	if err := plain.Set("units", "metric"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	sm, err := NewSettingsManager(dir, "weather", WithSettingsEncryption(key))
+	if err != nil {
+		t.Fatalf("NewSettingsManager with key failed: %v", err)
+	}
+	if units, _ := sm.GetString("units"); units != "metric" {
+		t.Errorf("units = %q, want metric", units)
+	}
+	if err := sm.Set("api_key", "sk-secret"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	for _, name := range []string{"weather_settings.json", "weather_settings.json.bak"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		if bytes.Contains(data, []byte("sk-secret")) || bytes.Contains(data, []byte("metric")) {
+			t.Errorf("%s contains plaintext settings: %s", name, data)
+		}
+	}
+
+	reopened, err := NewSettingsManager(dir, "weather", WithSettingsEncryption(key))
+	if err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	if apiKey, _ := reopened.GetString("api_key"); apiKey != "sk-secret" {
+		t.Errorf("api_key = %q, want sk-secret", apiKey)
+	}
+
+	if _, err := NewSettingsManager(dir, "weather"); !errors.Is(err, ErrSettingsEncrypted) {
+		t.Errorf("opening without the key: got %v, want ErrSettingsEncrypted", err)
+	}
+	if _, err := NewSettingsManager(dir, "weather", WithSettingsEncryption(bytes.Repeat([]byte{8}, 32))); !errors.Is(err, ErrSettingsEncrypted) {
+		t.Errorf("opening with the wrong key: got %v, want ErrSettingsEncrypted", err)
+	}
+	if _, err := NewSettingsManager(dir, "weather", WithSettingsEncryption([]byte("short"))); err == nil {
+		t.Error("expected an error for an invalid key length")
+	}
+}
+
+func TestBasePluginSettings_EncryptedFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(EnvSettingsKey, base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 16)))
+
+	var b BasePlugin
+	b.SetAgentContext(AgentContext{AgentDir: dir})
+	if err := b.Settings().Set("token", "abc123"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "unknown_settings.json"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if bytes.Contains(data, []byte("abc123")) {
+		t.Errorf("settings file is not encrypted: %s", data)
+	}
+}
+
+func TestBasePluginSettings_MalformedKey(t *testing.T) {
+	t.Setenv(EnvSettingsKey, "not base64!")
+
+	var b BasePlugin
+	b.SetAgentContext(AgentContext{AgentDir: t.TempDir()})
+	settings := b.Settings()
+	if settings == nil {
+		t.Fatal("Settings returned nil for a malformed key")
+	}
+	if err := settings.Set("token", "abc123"); err == nil || !strings.Contains(err.Error(), EnvSettingsKey) {
+		t.Errorf("Set = %v, want an error naming %s", err, EnvSettingsKey)
+	}
+	if _, err := settings.GetString("token"); err == nil {
+		t.Error("expected GetString to fail")
+	}
+
+	recent, _, unsubscribe := b.logBroker().subscribe()
+	defer unsubscribe()
+	if len(recent) != 1 || recent[0].Level != LogError {
+		t.Errorf("expected one logged error, got %+v", recent)
+	}
+}
@@ -0,0 +1,119 @@
+package pluginapi
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+)
+
+// settingsPathSeparator separates the parts of a nested settings key (e.g., "smtp.host").
+const settingsPathSeparator = "."
+
+// lookupSetting returns the value of a settings key. A key naming a top-level
+// setting as-is wins, so settings stored flat with dots in their names keep working;
+// otherwise a dotted key walks nested objects.
+func lookupSetting(settings map[string]interface{}, key string) (interface{}, bool) {
+	if value, ok := settings[key]; ok {
+		return value, true
+	}
+	parts := strings.Split(key, settingsPathSeparator)
+	var current interface{} = settings
+	for _, part := range parts {
+		section, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = section[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// storeSetting sets a settings key in settings, creating the nested objects a
+// dotted key walks through. The objects on the path are copied, not modified, so
+// maps shared with an earlier copy of the settings stay unchanged.
+func storeSetting(settings map[string]interface{}, key string, value interface{}) error {
+	if _, ok := settings[key]; ok || !strings.Contains(key, settingsPathSeparator) {
+		settings[key] = value
+		return nil
+	}
+	parts := strings.Split(key, settingsPathSeparator)
+	section := settings
+	for i, part := range parts[:len(parts)-1] {
+		var next map[string]interface{}
+		switch existing := section[part].(type) {
+		case nil:
+			next = make(map[string]interface{})
+		case map[string]interface{}:
+			next = maps.Clone(existing)
+		default:
+			return fmt.Errorf("setting %q is not an object (type: %T)", strings.Join(parts[:i+1], settingsPathSeparator), existing)
+		}
+		section[part] = next
+		section = next
+	}
+	section[parts[len(parts)-1]] = value
+	return nil
+}
+
+// deleteSetting removes a settings key from settings, copying the nested objects
+// on its path like storeSetting. Objects left empty are kept.
+func deleteSetting(settings map[string]interface{}, key string) {
+	if _, ok := settings[key]; ok {
+		delete(settings, key)
+		return
+	}
+	if _, ok := lookupSetting(settings, key); ok {
+		deleteSettingPath(settings, strings.Split(key, settingsPathSeparator))
+	}
+}
+
+// deleteSettingPath removes the setting at path, which must exist, copying the
+// nested objects on the way.
+func deleteSettingPath(settings map[string]interface{}, path []string) {
+	section := settings
+	for _, part := range path[:len(path)-1] {
+		next := maps.Clone(section[part].(map[string]interface{}))
+		section[part] = next
+		section = next
+	}
+	delete(section, path[len(path)-1])
+}
+
+// GetSection returns the nested settings under prefix (e.g., "smtp" for
+// "smtp.host" and "smtp.port") as a copy. Returns nil if there are none.
+func (sm *settingsManager) GetSection(prefix string) (map[string]interface{}, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	value, ok := getSetting(sm.cache, prefix)
+	if !ok || value == nil {
+		return nil, nil
+	}
+	section, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("setting %q is not an object (type: %T)", prefix, value)
+	}
+	return copySettingsSection(section), nil
+}
+
+// copySettingsSection deep-copies nested settings objects, so callers can't
+// modify the cache through the returned map. Expiring settings are replaced by
+// the values they hold, and expired ones are left out.
+func copySettingsSection(section map[string]interface{}) map[string]interface{} {
+	now := time.Now()
+	result := make(map[string]interface{}, len(section))
+	for k, v := range section {
+		v, live := liveSetting(v, now)
+		if !live {
+			continue
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			v = copySettingsSection(nested)
+		}
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package pluginapi
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSettingsManager_NestedKeys(t *testing.T) {
+	dir := t.TempDir()
+	sm, err := NewSettingsManager(dir, "mailer")
+	if err != nil {
+		t.Fatalf("NewSettingsManager failed: %v", err)
+	}
+
+	if err := sm.Set("smtp.host", "mail.example.com"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := sm.Set("smtp.port", 587); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if host, _ := sm.GetString("smtp.host"); host != "mail.example.com" {
+		t.Errorf("smtp.host = %q", host)
+	}
+
+	// Nested on disk
+	data, err := os.ReadFile(filepath.Join(dir, "mailer_settings.json"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	var onDisk map[string]map[string]interface{}
+	if err := json.Unmarshal(data, &onDisk); err != nil || onDisk["smtp"]["host"] != "mail.example.com" {
+		t.Errorf("settings file is not nested (%v): %s", err, data)
+	}
+
+	section, err := sm.GetSection("smtp")
+	if err != nil || len(section) != 2 || section["host"] != "mail.example.com" {
+		t.Errorf("GetSection = %v, %v", section, err)
+	}
+	section["host"] = "changed"
+	if host, _ := sm.GetString("smtp.host"); host != "mail.example.com" {
+		t.Error("modifying the section changed the settings")
+	}
+	if section, err := sm.GetSection("imap"); section != nil || err != nil {
+		t.Errorf("missing section = %v, %v", section, err)
+	}
+
+	// A snapshot taken earlier is not affected by later nested changes
+	before, _ := sm.GetAll()
+	if err := sm.Update("smtp.port", func(current interface{}) (interface{}, error) {
+		return current.(int) + 1, nil
+	}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if port, _ := sm.GetInt("smtp.port"); port != 588 {
+		t.Errorf("smtp.port = %d, want 588", port)
+	}
+	if before["smtp"].(map[string]interface{})["port"] != 587 {
+		t.Error("Update modified a previously returned map")
+	}
+
+	if err := sm.Delete("smtp.host"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if value, _ := sm.Get("smtp.host"); value != nil {
+		t.Errorf("smtp.host = %v after Delete", value)
+	}
+	if _, err := sm.GetSection("smtp.port"); err == nil {
+		t.Error("expected an error for a section that is not an object")
+	}
+	if err := sm.Set("smtp.port.number", 1); err == nil {
+		t.Error("expected an error when a path runs through a non-object")
+	}
+
+	// Flat keys containing dots still work
+	if err := sm.Set("legacy.key", "flat"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	all, _ := sm.GetAll()
+	if all["legacy"].(map[string]interface{})["key"] != "flat" {
+		t.Errorf("legacy.key was not nested: %v", all)
+	}
+	if err := sm.UpdateAll(func(settings map[string]interface{}) error {
+		settings["dotted.name"] = "kept"
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if value, _ := sm.GetString("dotted.name"); value != "kept" {
+		t.Errorf("dotted.name = %q, want kept", value)
+	}
+}
@@ -1,8 +1,10 @@
 package pluginapi
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 )
@@ -423,3 +425,236 @@ func TestSettingsManager_DefaultValues(t *testing.T) {
 		t.Errorf("expected 0.0 for non-existent key, got %f", floatVal)
 	}
 }
+
+func TestSettingsManager_Update(t *testing.T) {
+	tempDir := t.TempDir()
+	sm, err := NewSettingsManager(tempDir, "test-plugin")
+	if err != nil {
+		t.Fatalf("failed to create settings manager: %v", err)
+	}
+
+	increment := func(current interface{}) (interface{}, error) {
+		n, _ := current.(float64)
+		return n + 1, nil
+	}
+
+	// Concurrent increments must not lose updates
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sm.Update("counter", increment); err != nil {
+				t.Errorf("update failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	count, err := sm.GetInt("counter")
+	if err != nil {
+		t.Fatalf("failed to get counter: %v", err)
+	}
+	if count != 50 {
+		t.Errorf("expected counter=50, got %d", count)
+	}
+
+	// A failing update leaves the value unchanged
+	err = sm.Update("counter", func(current interface{}) (interface{}, error) {
+		return nil, os.ErrInvalid
+	})
+	if err == nil {
+		t.Error("expected error from failing update")
+	}
+	if count, _ := sm.GetInt("counter"); count != 50 {
+		t.Errorf("expected counter to remain 50, got %d", count)
+	}
+}
+
+func TestSettingsManager_FailedUpdateKeepsNestedValues(t *testing.T) {
+	sm, err := NewSettingsManagerWithLimits(t.TempDir(), "test-plugin", SettingsLimits{MaxKeys: 2})
+	if err != nil {
+		t.Fatalf("failed to create settings manager: %v", err)
+	}
+	_ = sm.Set("smtp.host", "mail.example.com")
+	_ = sm.Set("tags", []interface{}{"a", "b"})
+
+	err = sm.UpdateAll(func(settings map[string]interface{}) error {
+		settings["smtp"].(map[string]interface{})["host"] = "MUTATED"
+		return os.ErrInvalid
+	})
+	if err == nil {
+		t.Error("expected error from failing UpdateAll")
+	}
+	if host, _ := sm.GetString("smtp.host"); host != "mail.example.com" {
+		t.Errorf("smtp.host = %q, a failed UpdateAll changed a nested value", host)
+	}
+
+	// Rejected for exceeding MaxKeys after changing a nested value
+	err = sm.UpdateAll(func(settings map[string]interface{}) error {
+		settings["smtp"].(map[string]interface{})["host"] = "MUTATED"
+		settings["extra"] = true
+		return nil
+	})
+	if !errors.Is(err, ErrSettingsLimitExceeded) {
+		t.Errorf("UpdateAll = %v, want ErrSettingsLimitExceeded", err)
+	}
+	if host, _ := sm.GetString("smtp.host"); host != "mail.example.com" {
+		t.Errorf("smtp.host = %q, a rejected UpdateAll changed a nested value", host)
+	}
+
+	err = sm.Update("tags", func(current interface{}) (interface{}, error) {
+		current.([]interface{})[0] = "MUTATED"
+		return nil, os.ErrInvalid
+	})
+	if err == nil {
+		t.Error("expected error from failing Update")
+	}
+	if tags, _ := sm.Get("tags"); tags.([]interface{})[0] != "a" {
+		t.Errorf("tags = %v, a failed Update changed the value", tags)
+	}
+}
+
+func TestSettingsManager_UpdateAll(t *testing.T) {
+	tempDir := t.TempDir()
+	sm, err := NewSettingsManager(tempDir, "test-plugin")
+	if err != nil {
+		t.Fatalf("failed to create settings manager: %v", err)
+	}
+
+	_ = sm.Set("keep", "value")
+	_ = sm.Set("remove", "value")
+
+	err = sm.UpdateAll(func(settings map[string]interface{}) error {
+		settings["added"] = true
+		delete(settings, "remove")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+
+	all, _ := sm.GetAll()
+	if len(all) != 2 || all["added"] != true || all["keep"] != "value" {
+		t.Errorf("unexpected settings after UpdateAll: %v", all)
+	}
+
+	// A failing UpdateAll applies nothing
+	err = sm.UpdateAll(func(settings map[string]interface{}) error {
+		settings["keep"] = "changed"
+		return os.ErrInvalid
+	})
+	if err == nil {
+		t.Error("expected error from failing UpdateAll")
+	}
+	if val, _ := sm.GetString("keep"); val != "value" {
+		t.Errorf("expected keep='value', got '%s'", val)
+	}
+
+	// Changes are persisted
+	sm2, err := NewSettingsManager(tempDir, "test-plugin")
+	if err != nil {
+		t.Fatalf("failed to create second settings manager: %v", err)
+	}
+	if val, _ := sm2.GetBool("added"); !val {
+		t.Error("expected added=true to be persisted")
+	}
+}
+
+func TestSettingsManager_RecoverFromBackup(t *testing.T) {
+	tempDir := t.TempDir()
+	sm, err := NewSettingsManager(tempDir, "test-plugin")
+	if err != nil {
+		t.Fatalf("failed to create settings manager: %v", err)
+	}
+	_ = sm.Set("key1", "value1")
+
+	// Corrupt the main settings file; the backup still holds the last good copy
+	filePath := filepath.Join(tempDir, "test-plugin_settings.json")
+	if _, err := os.Stat(filePath + ".bak"); err != nil {
+		t.Fatalf("expected backup file after save: %v", err)
+	}
+	_ = os.WriteFile(filePath, []byte("invalid json{{{"), 0644)
+
+	sm2, err := NewSettingsManager(tempDir, "test-plugin")
+	if err != nil {
+		t.Fatalf("expected recovery from backup, got error: %v", err)
+	}
+	if val, _ := sm2.GetString("key1"); val != "value1" {
+		t.Errorf("expected recovered key1='value1', got '%s'", val)
+	}
+
+	// The main file is rewritten from the backup
+	sm3, err := NewSettingsManager(tempDir, "test-plugin")
+	if err != nil {
+		t.Fatalf("failed to load restored settings: %v", err)
+	}
+	if val, _ := sm3.GetString("key1"); val != "value1" {
+		t.Errorf("expected restored key1='value1', got '%s'", val)
+	}
+}
+
+func TestSettingsManager_Limits(t *testing.T) {
+	tempDir := t.TempDir()
+	sm, err := NewSettingsManagerWithLimits(tempDir, "test-plugin", SettingsLimits{MaxKeys: 2, MaxFileSize: 128})
+	if err != nil {
+		t.Fatalf("failed to create settings manager: %v", err)
+	}
+
+	_ = sm.Set("key1", "a")
+	_ = sm.Set("key2", "b")
+
+	// Exceeding the key limit is rejected
+	if err := sm.Set("key3", "c"); !errors.Is(err, ErrSettingsLimitExceeded) {
+		t.Errorf("expected ErrSettingsLimitExceeded for key limit, got %v", err)
+	}
+	if val, _ := sm.Get("key3"); val != nil {
+		t.Errorf("expected key3 to be rejected, got %v", val)
+	}
+
+	// Updating an existing key is still allowed
+	if err := sm.Set("key1", "updated"); err != nil {
+		t.Errorf("unexpected error updating existing key: %v", err)
+	}
+
+	// Exceeding the file size limit is rejected
+	if err := sm.Set("key2", strings.Repeat("x", 200)); !errors.Is(err, ErrSettingsLimitExceeded) {
+		t.Errorf("expected ErrSettingsLimitExceeded for file size, got %v", err)
+	}
+	if val, _ := sm.GetString("key2"); val != "b" {
+		t.Errorf("expected key2 to remain 'b', got '%s'", val)
+	}
+}
+
+func TestSettingsManager_PruneOversized(t *testing.T) {
+	tempDir := t.TempDir()
+	sm, err := NewSettingsManager(tempDir, "test-plugin")
+	if err != nil {
+		t.Fatalf("failed to create settings manager: %v", err)
+	}
+
+	_ = sm.Set("small", "ok")
+	_ = sm.Set("large", strings.Repeat("x", 100))
+	_ = sm.Set("larger", strings.Repeat("x", 200))
+
+	entries, err := sm.OversizedEntries(50)
+	if err != nil {
+		t.Fatalf("OversizedEntries failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Key != "larger" || entries[1].Key != "large" {
+		t.Fatalf("unexpected oversized entries: %+v", entries)
+	}
+
+	removed, err := sm.PruneOversized(50)
+	if err != nil {
+		t.Fatalf("PruneOversized failed: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("expected 2 removed keys, got %v", removed)
+	}
+
+	all, _ := sm.GetAll()
+	if len(all) != 1 || all["small"] != "ok" {
+		t.Errorf("unexpected settings after prune: %v", all)
+	}
+}
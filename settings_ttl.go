@@ -0,0 +1,127 @@
+package pluginapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// Keys of the object an expiring setting is stored as.
+const (
+	settingsExpiresAtKey = "ori_expires_at"
+	settingsValueKey     = "value"
+)
+
+// SetWithTTL stores a setting that expires after ttl, such as a cached access
+// token or API lookup. Once expired, reads treat it as absent, and it is removed
+// from the file with the next change. Setting the key again without a TTL makes
+// it permanent.
+//
+// Example:
+//
+//	if cached, _ := settings.Get("geocode.berlin"); cached != nil {
+//	    return cached, nil
+//	}
+//	coords, err := geocode(ctx, "Berlin")
+//	...
+//	_ = settings.SetWithTTL("geocode.berlin", coords, 24*time.Hour)
+func (sm *settingsManager) SetWithTTL(key string, value interface{}, ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("ttl must be positive, got %v", ttl)
+	}
+	return sm.Set(key, map[string]interface{}{
+		settingsExpiresAtKey: time.Now().Add(ttl).UTC().Format(time.RFC3339Nano),
+		settingsValueKey:     value,
+	})
+}
+
+// expiringSetting reports whether value is an expiring setting and, if so,
+// returns its expiry and the value it holds.
+func expiringSetting(value interface{}) (expiresAt time.Time, inner interface{}, ok bool) {
+	entry, isMap := value.(map[string]interface{})
+	if !isMap || len(entry) != 2 {
+		return time.Time{}, nil, false
+	}
+	stamp, isString := entry[settingsExpiresAtKey].(string)
+	inner, hasValue := entry[settingsValueKey]
+	if !isString || !hasValue {
+		return time.Time{}, nil, false
+	}
+	expiresAt, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return time.Time{}, nil, false
+	}
+	return expiresAt, inner, true
+}
+
+// liveSetting returns the value a setting holds: the value itself for permanent
+// settings, the held value for expiring ones, or false if it has expired.
+func liveSetting(value interface{}, now time.Time) (interface{}, bool) {
+	expiresAt, inner, ok := expiringSetting(value)
+	if !ok {
+		return value, true
+	}
+	if !now.Before(expiresAt) {
+		return nil, false
+	}
+	return inner, true
+}
+
+// getSetting returns the live value of a settings key (see lookupSetting and liveSetting).
+func getSetting(settings map[string]interface{}, key string) (interface{}, bool) {
+	value, ok := lookupSetting(settings, key)
+	if !ok {
+		return nil, false
+	}
+	return liveSetting(value, time.Now())
+}
+
+// storedSettingsReader is implemented by settings managers that can return their
+// settings as stored, with expiring settings still wrapped, so ExportPluginState
+// keeps their expiry.
+type storedSettingsReader interface {
+	storedSettings() map[string]interface{}
+}
+
+// storedSettings returns a deep copy of the settings as stored. Implements storedSettingsReader.
+func (sm *settingsManager) storedSettings() map[string]interface{} {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return copyStoredSettings(sm.cache)
+}
+
+// copyStoredSettings deep-copies nested settings objects as they are.
+func copyStoredSettings(section map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(section))
+	for k, v := range section {
+		if nested, ok := v.(map[string]interface{}); ok {
+			v = copyStoredSettings(nested)
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// pruneExpiredSettings removes the expired settings, at any depth, from settings.
+func pruneExpiredSettings(settings map[string]interface{}, now time.Time) {
+	for _, key := range expiredSettingKeys(settings, nil, now) {
+		deleteSettingPath(settings, key)
+	}
+}
+
+// expiredSettingKeys returns the paths of the expired settings in section.
+func expiredSettingKeys(section map[string]interface{}, prefix []string, now time.Time) [][]string {
+	var expired [][]string
+	for key, value := range section {
+		path := append(prefix[:len(prefix):len(prefix)], key)
+		if _, _, ok := expiringSetting(value); ok {
+			if _, live := liveSetting(value, now); !live {
+				expired = append(expired, path)
+			}
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			expired = append(expired, expiredSettingKeys(nested, path, now)...)
+		}
+	}
+	return expired
+}
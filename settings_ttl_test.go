@@ -0,0 +1,66 @@
+package pluginapi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSettingsManager_SetWithTTL(t *testing.T) {
+	dir := t.TempDir()
+	sm, err := NewSettingsManager(dir, "test-plugin")
+	if err != nil {
+		t.Fatalf("NewSettingsManager failed: %v", err)
+	}
+
+	if err := sm.SetWithTTL("oauth.token", "short-lived", 50*time.Millisecond); err != nil {
+		t.Fatalf("SetWithTTL failed: %v", err)
+	}
+	if err := sm.SetWithTTL("lookup", []interface{}{"a", "b"}, time.Hour); err != nil {
+		t.Fatalf("SetWithTTL failed: %v", err)
+	}
+	if err := sm.SetWithTTL("bad", 1, 0); err == nil {
+		t.Error("expected an error for a zero TTL")
+	}
+
+	if token, _ := sm.GetString("oauth.token"); token != "short-lived" {
+		t.Errorf("oauth.token = %q before expiry", token)
+	}
+	reopened, err := NewSettingsManager(dir, "test-plugin")
+	if err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	if token, _ := reopened.GetString("oauth.token"); token != "short-lived" {
+		t.Errorf("oauth.token = %q after reload", token)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if value, _ := sm.Get("oauth.token"); value != nil {
+		t.Errorf("oauth.token = %v after expiry", value)
+	}
+	all, _ := sm.GetAll()
+	if len(all["oauth"].(map[string]interface{})) != 0 {
+		t.Errorf("GetAll includes the expired token: %v", all)
+	}
+	if lookup, ok := all["lookup"].([]interface{}); !ok || len(lookup) != 2 {
+		t.Errorf("GetAll lookup = %v, want the stored value", all["lookup"])
+	}
+
+	// The next change drops the expired entry from the file
+	if err := sm.Set("other", true); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "test-plugin_settings.json"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if strings.Contains(string(data), "short-lived") {
+		t.Errorf("expired setting is still on disk: %s", data)
+	}
+	if !strings.Contains(string(data), settingsExpiresAtKey) {
+		t.Errorf("live expiring setting lost its expiry: %s", data)
+	}
+}
@@ -0,0 +1,57 @@
+package pluginapi
+
+// SettingsTx reads and changes settings inside SettingsManager.Transaction.
+// Reads see the transaction's own changes. Keys may be dot paths, as for Get and Set.
+type SettingsTx interface {
+	// Get returns a setting value, or nil if the key doesn't exist
+	Get(key string) interface{}
+	// Set stores a setting value
+	Set(key string, value interface{}) error
+	// Delete removes a setting
+	Delete(key string)
+}
+
+// settingsTx is a SettingsTx over a deep working copy of the settings, so values
+// read through it can be changed in place without touching the cache.
+type settingsTx struct {
+	settings map[string]interface{}
+}
+
+func (tx *settingsTx) Get(key string) interface{} {
+	value, _ := getSetting(tx.settings, key)
+	return value
+}
+
+func (tx *settingsTx) Set(key string, value interface{}) error {
+	return storeSetting(tx.settings, key, value)
+}
+
+func (tx *settingsTx) Delete(key string) {
+	deleteSetting(tx.settings, key)
+}
+
+// Transaction applies the changes fn makes through tx with a single save.
+func (sm *settingsManager) Transaction(fn func(tx SettingsTx) error) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	// Work on a deep copy so a failing fn leaves the cache untouched,
+	// even if it changed values it read in place
+	tx := &settingsTx{settings: sm.deepCopyUnlocked()}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return sm.applyUnlocked(tx.settings)
+}
+
+// SetMany stores several settings with a single save.
+func (sm *settingsManager) SetMany(values map[string]interface{}) error {
+	return sm.Transaction(func(tx SettingsTx) error {
+		for key, value := range values {
+			if err := tx.Set(key, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
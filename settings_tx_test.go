@@ -0,0 +1,97 @@
+package pluginapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSettingsManager_SetMany(t *testing.T) {
+	dir := t.TempDir()
+	sm, err := NewSettingsManager(dir, "test-plugin")
+	if err != nil {
+		t.Fatalf("NewSettingsManager failed: %v", err)
+	}
+
+	if err := sm.SetMany(map[string]interface{}{"smtp.host": "mail.example.com", "smtp.port": 587, "enabled": true}); err != nil {
+		t.Fatalf("SetMany failed: %v", err)
+	}
+	reopened, err := NewSettingsManager(dir, "test-plugin")
+	if err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	if host, _ := reopened.GetString("smtp.host"); host != "mail.example.com" {
+		t.Errorf("smtp.host = %q after reload", host)
+	}
+	if enabled, _ := reopened.GetBool("enabled"); !enabled {
+		t.Error("enabled was not saved")
+	}
+
+	// enabled is not an object, so the whole batch is rejected
+	if err := sm.SetMany(map[string]interface{}{"smtp.host": "other", "enabled.since": 2024}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if host, _ := sm.GetString("smtp.host"); host != "mail.example.com" {
+		t.Errorf("smtp.host = %q, a failed SetMany was partially applied", host)
+	}
+}
+
+func TestSettingsManager_Transaction(t *testing.T) {
+	sm, err := NewSettingsManagerWithLimits(t.TempDir(), "test-plugin", SettingsLimits{MaxKeys: 3})
+	if err != nil {
+		t.Fatalf("NewSettingsManager failed: %v", err)
+	}
+
+	err = sm.Transaction(func(tx SettingsTx) error {
+		if err := tx.Set("a", 1); err != nil {
+			return err
+		}
+		if tx.Get("a") != 1 {
+			t.Error("transaction does not see its own changes")
+		}
+		tx.Delete("a")
+		return tx.Set("b", 2)
+	})
+	if err != nil {
+		t.Fatalf("Transaction failed: %v", err)
+	}
+	if all, _ := sm.GetAll(); len(all) != 1 || all["b"] != 2 {
+		t.Errorf("settings = %v, want only b", all)
+	}
+
+	errAbort := errors.New("abort")
+	if err := sm.Transaction(func(tx SettingsTx) error {
+		_ = tx.Set("b", 3)
+		return errAbort
+	}); !errors.Is(err, errAbort) {
+		t.Errorf("Transaction = %v, want errAbort", err)
+	}
+	if b, _ := sm.GetInt("b"); b != 2 {
+		t.Errorf("b = %d, an aborted transaction was applied", b)
+	}
+
+	// Changing a value read in the transaction in place is undone by aborting too
+	_ = sm.Set("smtp.host", "mail.example.com")
+	if err := sm.Transaction(func(tx SettingsTx) error {
+		tx.Get("smtp").(map[string]interface{})["host"] = "MUTATED"
+		return errAbort
+	}); !errors.Is(err, errAbort) {
+		t.Errorf("Transaction = %v, want errAbort", err)
+	}
+	if host, _ := sm.GetString("smtp.host"); host != "mail.example.com" {
+		t.Errorf("smtp.host = %q, an aborted transaction changed a nested value", host)
+	}
+	_ = sm.Delete("smtp")
+
+	err = sm.Transaction(func(tx SettingsTx) error {
+		for _, key := range []string{"c", "d", "e"} {
+			_ = tx.Set(key, true)
+		}
+		return nil
+	})
+	if !errors.Is(err, ErrSettingsLimitExceeded) {
+		t.Errorf("Transaction = %v, want ErrSettingsLimitExceeded", err)
+	}
+	if value, _ := sm.Get("c"); value != nil {
+		t.Error("a rejected transaction was partially applied")
+	}
+}
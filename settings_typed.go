@@ -0,0 +1,61 @@
+package pluginapi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SettingAs returns a setting converted to T by a JSON round trip, so settings
+// holding slices or nested objects decode into Go types without type assertions.
+// A missing key returns the zero T.
+//
+// Example:
+//
+//	recipients, err := pluginapi.SettingAs[[]string](settings, "smtp.recipients")
+func SettingAs[T any](sm SettingsManager, key string) (T, error) {
+	var result T
+	value, err := sm.Get(key)
+	if err != nil || value == nil {
+		return result, err
+	}
+	if err := convertSetting(value, &result); err != nil {
+		return result, fmt.Errorf("setting %q: %w", key, err)
+	}
+	return result, nil
+}
+
+// Bind decodes the settings under prefix (all settings for "") into target, a
+// pointer to a struct or map, by a JSON round trip. Fields without a setting keep
+// their values, so target can hold defaults.
+//
+// Example:
+//
+//	cfg := SMTPConfig{Port: 587}
+//	if err := settings.Bind("smtp", &cfg); err != nil {
+//	    return err
+//	}
+func (sm *settingsManager) Bind(prefix string, target interface{}) error {
+	var section map[string]interface{}
+	var err error
+	if prefix == "" {
+		section, err = sm.GetAll()
+	} else {
+		section, err = sm.GetSection(prefix)
+	}
+	if err != nil || section == nil {
+		return err
+	}
+	if err := convertSetting(section, target); err != nil {
+		return fmt.Errorf("settings %q: %w", prefix, err)
+	}
+	return nil
+}
+
+// convertSetting decodes a setting value into target through JSON.
+func convertSetting(value interface{}, target interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
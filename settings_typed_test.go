@@ -0,0 +1,58 @@
+package pluginapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSettingAs(t *testing.T) {
+	sm, err := NewSettingsManager(t.TempDir(), "test-plugin")
+	if err != nil {
+		t.Fatalf("NewSettingsManager failed: %v", err)
+	}
+	if err := sm.SetMany(map[string]interface{}{
+		"smtp.host":       "mail.example.com",
+		"smtp.recipients": []interface{}{"a@example.com", "b@example.com"},
+		"retries":         3.0,
+	}); err != nil {
+		t.Fatalf("SetMany failed: %v", err)
+	}
+
+	recipients, err := SettingAs[[]string](sm, "smtp.recipients")
+	if err != nil || !reflect.DeepEqual(recipients, []string{"a@example.com", "b@example.com"}) {
+		t.Errorf("recipients = %v, %v", recipients, err)
+	}
+	if retries, err := SettingAs[int](sm, "retries"); err != nil || retries != 3 {
+		t.Errorf("retries = %d, %v", retries, err)
+	}
+	if missing, err := SettingAs[[]string](sm, "missing"); err != nil || missing != nil {
+		t.Errorf("missing = %v, %v", missing, err)
+	}
+	if _, err := SettingAs[int](sm, "smtp.host"); err == nil {
+		t.Error("expected an error converting a string to int")
+	}
+
+	type smtpConfig struct {
+		Host       string   `json:"host"`
+		Port       int      `json:"port"`
+		Recipients []string `json:"recipients"`
+	}
+	cfg := smtpConfig{Port: 587}
+	if err := sm.Bind("smtp", &cfg); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	want := smtpConfig{Host: "mail.example.com", Port: 587, Recipients: []string{"a@example.com", "b@example.com"}}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Bind = %+v, want %+v", cfg, want)
+	}
+
+	var all struct {
+		Retries int `json:"retries"`
+	}
+	if err := sm.Bind("", &all); err != nil || all.Retries != 3 {
+		t.Errorf("Bind all = %+v, %v", all, err)
+	}
+	if err := sm.Bind("retries", &all); err == nil {
+		t.Error("expected an error binding a setting that is not an object")
+	}
+}
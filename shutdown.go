@@ -0,0 +1,119 @@
+package pluginapi
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// drainTimeout bounds how long a plugin that received SIGTERM waits for running calls
+	drainTimeout = 15 * time.Second
+	// signalShutdownTimeout bounds the ShutdownHandler run when the plugin receives SIGTERM
+	signalShutdownTimeout = 10 * time.Second
+)
+
+// shutdownOnce runs a plugin's ShutdownHandler at most once, whether the host
+// asked for it over RPC or the process was sent a signal.
+// The zero value is ready to use.
+type shutdownOnce struct {
+	once sync.Once
+	err  error
+}
+
+// run calls impl's Shutdown the first time it is called and returns its result on every call.
+// Plugins that don't implement ShutdownHandler shut down successfully.
+func (s *shutdownOnce) run(ctx context.Context, impl PluginTool) error {
+	s.once.Do(func() {
+		if handler, ok := impl.(ShutdownHandler); ok {
+			s.err = handler.Shutdown(ctx)
+		}
+	})
+	return s.err
+}
+
+// drainSignal tells streams that never end on their own, such as SubscribeLogs,
+// that the plugin is draining, so they don't hold up GracefulStop.
+// The zero value is ready to use.
+type drainSignal struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+// done returns a channel that is closed once draining starts.
+func (d *drainSignal) done() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.ch == nil {
+		d.ch = make(chan struct{})
+	}
+	return d.ch
+}
+
+func (d *drainSignal) start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.ch == nil {
+		d.ch = make(chan struct{})
+	}
+	select {
+	case <-d.ch:
+	default:
+		close(d.ch)
+	}
+}
+
+// drainableServer is a grpc.Server or stdioServer.
+type drainableServer interface {
+	// GracefulStop stops accepting new RPCs and waits for running ones to finish
+	GracefulStop()
+	// Stop closes the server without waiting
+	Stop()
+}
+
+// stopOnSignal shuts the plugin down gracefully (see shutdownGracefully) when the
+// process receives SIGTERM or an interrupt, so ServeGRPCPlugin returns instead of
+// the process being killed mid-write. The returned channel is closed once the
+// shutdown is complete; Serve returns before that, as soon as draining begins.
+// notServing, if not nil, is called first to fail health checks.
+func stopOnSignal(server drainableServer, srv *grpcServer, notServing func()) <-chan struct{} {
+	done := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	go func() {
+		<-signals
+		signal.Stop(signals)
+		if notServing != nil {
+			notServing()
+		}
+		shutdownGracefully(server, srv, drainTimeout)
+		close(done)
+	}()
+	return done
+}
+
+// shutdownGracefully stops server accepting calls, waits up to timeout for the
+// running ones, then runs the plugin's shutdown hook and closes server. Calls still
+// running after the timeout are cancelled once the hook returns; background tasks
+// are cancelled right away, since they may run for much longer.
+func shutdownGracefully(server drainableServer, srv *grpcServer, timeout time.Duration) {
+	srv.draining.start()
+	srv.tasks.cancelAll()
+	drained := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(timeout):
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), signalShutdownTimeout)
+	defer cancel()
+	_ = srv.shutdown.run(ctx, srv.Impl)
+	server.Stop()
+}
@@ -0,0 +1,178 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type shutdownTestTool struct {
+	BasePlugin
+	calls int
+	err   error
+}
+
+func (t *shutdownTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *shutdownTestTool) Shutdown(ctx context.Context) error {
+	t.calls++
+	return t.err
+}
+
+func TestGRPCClient_Shutdown(t *testing.T) {
+	tool := &shutdownTestTool{}
+	client := newTestClient(t, tool)
+
+	for i := 0; i < 2; i++ {
+		if err := client.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown failed: %v", err)
+		}
+	}
+	if tool.calls != 1 {
+		t.Errorf("expected Shutdown to run once, ran %d times", tool.calls)
+	}
+
+	failing := newTestClient(t, &shutdownTestTool{err: errors.New("flush failed")})
+	if err := failing.Shutdown(context.Background()); err == nil || err.Error() != "flush failed" {
+		t.Errorf("expected flush error, got %v", err)
+	}
+
+	if err := newTestClient(t, &plainTestTool{}).Shutdown(context.Background()); err != nil {
+		t.Errorf("plugins without ShutdownHandler should shut down cleanly, got %v", err)
+	}
+}
+
+type drainTestTool struct {
+	BasePlugin
+	started  chan struct{}
+	release  chan struct{}
+	finished bool
+	shutdown chan bool // Receives finished when Shutdown runs
+}
+
+func (t *drainTestTool) Call(ctx context.Context, args string) (string, error) {
+	if args == "block" {
+		t.started <- struct{}{}
+		<-t.release
+		t.finished = true
+	}
+	return "done", nil
+}
+
+func (t *drainTestTool) Shutdown(ctx context.Context) error {
+	t.shutdown <- t.finished
+	return nil
+}
+
+func TestShutdownGracefully_DrainsRunningCalls(t *testing.T) {
+	tool := &drainTestTool{started: make(chan struct{}), release: make(chan struct{}), shutdown: make(chan bool, 1)}
+	server := grpc.NewServer()
+	srv := registerToolServices(server, tool)
+	client := &grpcClient{client: NewToolServiceClient(dialTestServer(t, server))}
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := client.Call(context.Background(), "block")
+		result <- err
+	}()
+	<-tool.started
+
+	stopped := make(chan struct{})
+	go func() {
+		shutdownGracefully(server, srv, 5*time.Second)
+		close(stopped)
+	}()
+
+	// New calls are refused while the running one drains
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := client.Call(context.Background(), "{}"); err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("server kept accepting calls while draining")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	close(tool.release)
+	if err := <-result; err != nil {
+		t.Errorf("running call should complete, got %v", err)
+	}
+	waitClosed(t, stopped, "shutdown")
+	if !<-tool.shutdown {
+		t.Error("Shutdown hook ran before the running call finished")
+	}
+}
+
+func TestShutdownGracefully_TimesOut(t *testing.T) {
+	tool := newBlockingTestTool()
+	server := grpc.NewServer()
+	srv := registerToolServices(server, tool)
+	client := &grpcClient{client: NewToolServiceClient(dialTestServer(t, server))}
+
+	go func() { _, _ = client.Call(context.Background(), "{}") }()
+	<-tool.started
+
+	stopped := make(chan struct{})
+	go func() {
+		shutdownGracefully(server, srv, 50*time.Millisecond)
+		close(stopped)
+	}()
+	waitClosed(t, stopped, "shutdown after the drain timeout")
+	waitClosed(t, tool.cancelled, "stuck call cancellation")
+}
+
+func TestStdioServer_GracefulStop(t *testing.T) {
+	tool := &drainTestTool{started: make(chan struct{}), release: make(chan struct{}), shutdown: make(chan bool, 1)}
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+	server := newStdioServer()
+	registerToolServices(server, tool)
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.Serve(stdinR, stdoutW) }()
+	conn := NewStdioClientConn(stdoutR, stdinW)
+	t.Cleanup(func() { _ = conn.Close() })
+	client := &grpcClient{client: NewToolServiceClient(conn)}
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := client.Call(context.Background(), "block")
+		result <- err
+	}()
+	<-tool.started
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, err := client.Call(context.Background(), "{}")
+		if status.Code(err) == codes.Unavailable {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected Unavailable while draining, got %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	close(tool.release)
+	if err := <-result; err != nil {
+		t.Errorf("running call should complete, got %v", err)
+	}
+	waitClosed(t, stopped, "GracefulStop")
+	if err := <-serveErr; err != nil {
+		t.Errorf("Serve returned %v", err)
+	}
+}
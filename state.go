@@ -0,0 +1,417 @@
+package pluginapi
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveDirectory packs the contents of dir into a gzip-compressed tar archive.
+// This is a convenience for StatefulPlugin implementations whose state lives on disk.
+// Only regular files and directories are included; symlinks and other special files are skipped.
+//
+// Example usage in a plugin:
+//
+//	func (t *myTool) SnapshotState(ctx context.Context) ([]byte, error) {
+//	    return pluginapi.ArchiveDirectory(t.dataDir())
+//	}
+func ArchiveDirectory(dir string) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	if err := archiveDirectory(tw, dir, ""); err != nil {
+		return nil, err
+	}
+	return finishArchive(&buf, gz, tw)
+}
+
+// archiveDirectory writes the contents of dir to tw, naming entries prefix + their relative path.
+func archiveDirectory(tw *tar.Writer, dir, prefix string) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil // Skip symlinks, sockets, etc.
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = prefix + filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w", dir, err)
+	}
+	return nil
+}
+
+// finishArchive flushes tw and gz and returns the archive written to buf.
+func finishArchive(buf *bytes.Buffer, gz *gzip.Writer, tw *tar.Writer) ([]byte, error) {
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// maxExtractedArchiveSize caps the total size of the files extracted from an
+// archive, so a small compressed archive can't fill the disk.
+const maxExtractedArchiveSize = 1 << 30
+
+// ExtractArchive replaces the contents of dir with an archive created by
+// ArchiveDirectory, so files created after the snapshot are removed. Nothing is
+// changed unless the whole archive can be extracted: files are extracted next to
+// dir first and swapped in once complete. Entries that would escape dir (e.g.,
+// "../etc/passwd") are rejected, as are archives holding more than 1 GB of files.
+//
+// Example usage in a plugin:
+//
+//	func (t *myTool) RestoreState(ctx context.Context, state []byte) error {
+//	    return pluginapi.ExtractArchive(t.dataDir(), state)
+//	}
+func ExtractArchive(dir string, data []byte) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid archive: %w", err)
+	}
+	defer gz.Close()
+
+	staging, err := newStagingDir(dir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if staging != "" {
+			_ = os.RemoveAll(staging)
+		}
+	}()
+	x, err := newArchiveExtractor(staging)
+	if err != nil {
+		return err
+	}
+	defer x.close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid archive: %w", err)
+		}
+		if err := x.extract(header.Name, header, tr); err != nil {
+			return err
+		}
+	}
+
+	x.close()
+	if _, err := swapDirectory(dir, staging); err != nil {
+		return err
+	}
+	staging = ""
+	_ = os.RemoveAll(dir + stateReplacedSuffix)
+	return nil
+}
+
+// archiveExtractor writes archive entries into a directory. Entries are written
+// through an os.Root, so neither their names nor symlinks in the directory can
+// lead outside it.
+type archiveExtractor struct {
+	root      *os.Root
+	remaining int64 // Bytes left before maxExtractedArchiveSize is reached
+}
+
+func newArchiveExtractor(dir string) (*archiveExtractor, error) {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", dir, err)
+	}
+	return &archiveExtractor{root: root, remaining: maxExtractedArchiveSize}, nil
+}
+
+// extract writes the archive entry header, read from r, to name in the directory.
+func (x *archiveExtractor) extract(name string, header *tar.Header, r io.Reader) error {
+	rel := filepath.Clean(filepath.FromSlash(name))
+	if !filepath.IsLocal(rel) {
+		return fmt.Errorf("invalid archive entry %q: path escapes target directory", header.Name)
+	}
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		if err := x.root.MkdirAll(rel, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", rel, err)
+		}
+	case tar.TypeReg:
+		if header.Size > x.remaining {
+			return fmt.Errorf("invalid archive: files exceed %d bytes", int64(maxExtractedArchiveSize))
+		}
+		x.remaining -= header.Size
+		if err := x.root.MkdirAll(filepath.Dir(rel), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(rel), err)
+		}
+		return x.writeFile(rel, r, header.FileInfo().Mode().Perm())
+	}
+	return nil
+}
+
+// writeFile writes a single archive entry to disk.
+func (x *archiveExtractor) writeFile(rel string, r io.Reader, perm fs.FileMode) error {
+	f, err := x.root.OpenFile(rel, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", rel, err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s: %w", rel, err)
+	}
+	return f.Close()
+}
+
+// close releases the directory, which must happen before it is renamed.
+// Calling it again does nothing.
+func (x *archiveExtractor) close() {
+	if x.root != nil {
+		_ = x.root.Close()
+		x.root = nil
+	}
+}
+
+// Entry names used in archives created by ExportPluginState.
+const (
+	stateSettingsEntry = "settings.json"
+	stateFilesPrefix   = "files/"
+)
+
+// ExportPluginState bundles a plugin's settings and the files under dataDir into a
+// single gzip-compressed tar archive, for StatePorter implementations.
+// Either part may be omitted by passing a nil settings manager or an empty dataDir.
+//
+// Example usage in a plugin:
+//
+//	func (t *myTool) ExportState() ([]byte, error) {
+//	    return pluginapi.ExportPluginState(t.Settings(), t.dataDir())
+//	}
+//
+//	func (t *myTool) ImportState(state []byte) error {
+//	    return pluginapi.ImportPluginState(t.Settings(), t.dataDir(), state)
+//	}
+func ExportPluginState(settings SettingsManager, dataDir string) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	if settings != nil {
+		var all map[string]interface{}
+		var err error
+		if stored, ok := settings.(storedSettingsReader); ok {
+			// Keep expiring settings wrapped, so they still expire after import
+			all = stored.storedSettings()
+		} else if all, err = settings.GetAll(); err != nil {
+			return nil, fmt.Errorf("failed to read settings: %w", err)
+		}
+		data, err := json.Marshal(all)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode settings: %w", err)
+		}
+		header := &tar.Header{Name: stateSettingsEntry, Mode: 0600, Size: int64(len(data)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if dataDir != "" {
+		if _, err := os.Stat(dataDir); err == nil {
+			if err := archiveDirectory(tw, dataDir, stateFilesPrefix); err != nil {
+				return nil, err
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to archive %s: %w", dataDir, err)
+		}
+	}
+	return finishArchive(&buf, gz, tw)
+}
+
+// ImportPluginState restores an archive created by ExportPluginState.
+// The settings in the archive replace all current settings, and its files replace
+// the contents of dataDir. Nothing is changed unless the whole archive can be read:
+// files are extracted next to dataDir first and swapped in once complete, and
+// settings are applied last. Parts of the archive whose destination is nil or
+// empty are ignored, as is dataDir when the archive holds no files.
+func ImportPluginState(settings SettingsManager, dataDir string, data []byte) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid archive: %w", err)
+	}
+	defer gz.Close()
+
+	var imported map[string]interface{}
+	var staging string // Where files are extracted before replacing dataDir
+	var x *archiveExtractor
+	defer func() {
+		if x != nil {
+			x.close()
+		}
+		if staging != "" {
+			_ = os.RemoveAll(staging)
+		}
+	}()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid archive: %w", err)
+		}
+
+		switch {
+		case header.Name == stateSettingsEntry:
+			if settings == nil {
+				continue
+			}
+			if err := json.NewDecoder(tr).Decode(&imported); err != nil {
+				return fmt.Errorf("invalid settings in archive: %w", err)
+			}
+		case strings.HasPrefix(header.Name, stateFilesPrefix):
+			if dataDir == "" {
+				continue
+			}
+			if staging == "" {
+				if staging, err = newStagingDir(dataDir); err != nil {
+					return err
+				}
+				if x, err = newArchiveExtractor(staging); err != nil {
+					return err
+				}
+			}
+			if err := x.extract(strings.TrimPrefix(header.Name, stateFilesPrefix), header, tr); err != nil {
+				return err
+			}
+		}
+	}
+
+	var restoreFiles func() error
+	if staging != "" {
+		x.close()
+		if restoreFiles, err = swapDirectory(dataDir, staging); err != nil {
+			return err
+		}
+		staging = ""
+	}
+	if imported != nil {
+		err := settings.UpdateAll(func(current map[string]interface{}) error {
+			clear(current)
+			maps.Copy(current, imported)
+			return nil
+		})
+		if err != nil {
+			if restoreFiles != nil {
+				_ = restoreFiles()
+			}
+			return fmt.Errorf("failed to restore settings: %w", err)
+		}
+	}
+	if restoreFiles != nil {
+		_ = os.RemoveAll(dataDir + stateReplacedSuffix)
+	}
+	return nil
+}
+
+// stateReplacedSuffix names where ExtractArchive and ImportPluginState keep the
+// previous directory until the restore has succeeded.
+const stateReplacedSuffix = ".replaced"
+
+// newStagingDir creates an empty directory next to dir, on the same filesystem,
+// so it can be renamed into dir's place.
+func newStagingDir(dir string) (string, error) {
+	parent := filepath.Dir(filepath.Clean(dir))
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", parent, err)
+	}
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(dir)+".import-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	if err := os.Chmod(staging, 0755); err != nil {
+		_ = os.RemoveAll(staging)
+		return "", fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	return staging, nil
+}
+
+// swapDirectory moves staging into dir's place, keeping the previous dir at
+// dir + stateReplacedSuffix. The returned function moves the previous dir back.
+func swapDirectory(dir, staging string) (restore func() error, err error) {
+	replaced := dir + stateReplacedSuffix
+	if err := os.RemoveAll(replaced); err != nil {
+		return nil, fmt.Errorf("failed to remove %s: %w", replaced, err)
+	}
+	hadDir := true
+	if err := os.Rename(dir, replaced); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to replace %s: %w", dir, err)
+		}
+		hadDir = false
+	}
+	if err := os.Rename(staging, dir); err != nil {
+		if hadDir {
+			_ = os.Rename(replaced, dir)
+		}
+		return nil, fmt.Errorf("failed to replace %s: %w", dir, err)
+	}
+	return func() error {
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+		if !hadDir {
+			return nil
+		}
+		return os.Rename(replaced, dir)
+	}, nil
+}
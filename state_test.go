@@ -0,0 +1,368 @@
+package pluginapi
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArchiveDirectory_RoundTrip(t *testing.T) {
+	srcDir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(srcDir, "cache", "nested"), 0755)
+	_ = os.WriteFile(filepath.Join(srcDir, "state.json"), []byte(`{"jobs":3}`), 0644)
+	_ = os.WriteFile(filepath.Join(srcDir, "cache", "nested", "data.bin"), []byte{1, 2, 3}, 0600)
+
+	data, err := ArchiveDirectory(srcDir)
+	if err != nil {
+		t.Fatalf("ArchiveDirectory failed: %v", err)
+	}
+
+	dstDir := filepath.Join(t.TempDir(), "restored")
+	if err := ExtractArchive(dstDir, data); err != nil {
+		t.Fatalf("ExtractArchive failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dstDir, "state.json"))
+	if err != nil || string(content) != `{"jobs":3}` {
+		t.Errorf("expected state.json to be restored, got %q (err: %v)", content, err)
+	}
+	content, err = os.ReadFile(filepath.Join(dstDir, "cache", "nested", "data.bin"))
+	if err != nil || !bytes.Equal(content, []byte{1, 2, 3}) {
+		t.Errorf("expected nested file to be restored, got %v (err: %v)", content, err)
+	}
+}
+
+func TestExtractArchive_RejectsPathTraversal(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	_ = tw.WriteHeader(&tar.Header{Name: "../escape.txt", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	_, _ = tw.Write([]byte("x"))
+	_ = tw.Close()
+	_ = gz.Close()
+
+	dir := t.TempDir()
+	if err := ExtractArchive(filepath.Join(dir, "target"), buf.Bytes()); err == nil {
+		t.Error("expected error for path traversal entry")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escape.txt")); err == nil {
+		t.Error("path traversal entry should not be written")
+	}
+}
+
+func TestExtractArchive_ReplacesContents(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	_ = os.MkdirAll(dir, 0755)
+	_ = os.WriteFile(filepath.Join(dir, "state.json"), []byte("v1"), 0644)
+	snapshot, err := ArchiveDirectory(dir)
+	if err != nil {
+		t.Fatalf("ArchiveDirectory failed: %v", err)
+	}
+
+	_ = os.WriteFile(filepath.Join(dir, "state.json"), []byte("v2"), 0644)
+	_ = os.WriteFile(filepath.Join(dir, "later.json"), []byte("new"), 0644)
+	if err := ExtractArchive(dir, snapshot); err != nil {
+		t.Fatalf("ExtractArchive failed: %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "state.json")); string(content) != "v1" {
+		t.Errorf("state.json = %q, want v1", content)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "later.json")); !os.IsNotExist(err) {
+		t.Errorf("file created after the snapshot survived the restore (err: %v)", err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(dir)); len(entries) != 1 {
+		t.Errorf("expected only the data directory to be left, got %v", entries)
+	}
+}
+
+func TestExtractArchive_FailureChangesNothing(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	_ = tw.WriteHeader(&tar.Header{Name: "new.txt", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	_, _ = tw.Write([]byte("x"))
+	_ = tw.WriteHeader(&tar.Header{Name: "../escape.txt", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	_, _ = tw.Write([]byte("x"))
+	_ = tw.Close()
+	_ = gz.Close()
+
+	dir := filepath.Join(t.TempDir(), "data")
+	_ = os.MkdirAll(dir, 0755)
+	_ = os.WriteFile(filepath.Join(dir, "keep.txt"), []byte("kept"), 0644)
+	if err := ExtractArchive(dir, buf.Bytes()); err == nil {
+		t.Fatal("expected error for path traversal entry")
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "keep.txt")); string(content) != "kept" {
+		t.Errorf("keep.txt = %q after a failed extract", content)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.txt")); !os.IsNotExist(err) {
+		t.Error("a failed extract left a partially extracted file")
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(dir)); len(entries) != 1 {
+		t.Errorf("expected the staging directory to be removed, got %v", entries)
+	}
+}
+
+func TestExtractArchive_RejectsOversizedArchive(t *testing.T) {
+	// Only the header is needed: the size is checked before anything is written
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	_ = tw.WriteHeader(&tar.Header{Name: "huge.bin", Mode: 0644, Size: maxExtractedArchiveSize + 1, Typeflag: tar.TypeReg})
+	_ = tw.Flush()
+	_ = gz.Close()
+
+	dir := filepath.Join(t.TempDir(), "data")
+	err := ExtractArchive(dir, buf.Bytes())
+	if err == nil || !strings.Contains(err.Error(), "exceed") {
+		t.Errorf("expected size limit error, got %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Error("a rejected archive created the target directory")
+	}
+}
+
+type statefulTestTool struct {
+	BasePlugin
+	state []byte
+}
+
+func (t *statefulTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *statefulTestTool) SnapshotState(ctx context.Context) ([]byte, error) {
+	return t.state, nil
+}
+
+func (t *statefulTestTool) RestoreState(ctx context.Context, state []byte) error {
+	if len(state) == 0 {
+		return fmt.Errorf("empty state")
+	}
+	t.state = state
+	return nil
+}
+
+func TestGRPCServer_StateSnapshot(t *testing.T) {
+	tool := &statefulTestTool{state: []byte("initial")}
+	server := &grpcServer{Impl: tool}
+
+	resp, err := server.SnapshotState(context.Background(), &Empty{})
+	if err != nil {
+		t.Fatalf("SnapshotState failed: %v", err)
+	}
+	if !resp.SupportsState || string(resp.State) != "initial" {
+		t.Errorf("unexpected snapshot response: %+v", resp)
+	}
+
+	restoreResp, _ := server.RestoreState(context.Background(), &RestoreStateRequest{State: []byte("restored")})
+	if !restoreResp.Success || string(tool.state) != "restored" {
+		t.Errorf("expected state to be restored, got %q (resp: %+v)", tool.state, restoreResp)
+	}
+
+	restoreResp, _ = server.RestoreState(context.Background(), &RestoreStateRequest{})
+	if restoreResp.Success || restoreResp.Error == "" {
+		t.Error("expected restore error to be reported")
+	}
+
+	// Plugins without StatefulPlugin report no support
+	plain := &grpcServer{Impl: &plainTestTool{}}
+	resp, _ = plain.SnapshotState(context.Background(), &Empty{})
+	if resp.SupportsState {
+		t.Error("expected SupportsState=false for plugin without StatefulPlugin")
+	}
+}
+
+type plainTestTool struct {
+	BasePlugin
+}
+
+func (t *plainTestTool) Call(ctx context.Context, args string) (string, error) {
+	return args, nil
+}
+
+type handoffTestTool struct {
+	BasePlugin
+	jobs     []byte
+	received HandoffState
+}
+
+func (t *handoffTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *handoffTestTool) PrepareHandoff(ctx context.Context) (HandoffState, error) {
+	return HandoffState{Data: t.jobs}, nil
+}
+
+func (t *handoffTestTool) ReceiveHandoff(ctx context.Context, state HandoffState) error {
+	t.received = state
+	return nil
+}
+
+func TestGRPCServer_Handoff(t *testing.T) {
+	outgoing := &handoffTestTool{BasePlugin: newBasePlugin("test", "1.0.0", "", "", "v1"), jobs: []byte("job-1,job-2")}
+	incoming := &handoffTestTool{BasePlugin: newBasePlugin("test", "1.1.0", "", "", "v1")}
+
+	resp, err := (&grpcServer{Impl: outgoing}).PrepareHandoff(context.Background(), &Empty{})
+	if err != nil {
+		t.Fatalf("PrepareHandoff failed: %v", err)
+	}
+	if !resp.SupportsHandoff || resp.Version != "1.0.0" {
+		t.Errorf("unexpected handoff response: %+v", resp)
+	}
+
+	ack, _ := (&grpcServer{Impl: incoming}).ReceiveHandoff(context.Background(), &HandoffRequest{
+		State:       resp.State,
+		FromVersion: resp.Version,
+	})
+	if !ack.Success {
+		t.Fatalf("ReceiveHandoff failed: %s", ack.Error)
+	}
+	if incoming.received.FromVersion != "1.0.0" || string(incoming.received.Data) != "job-1,job-2" {
+		t.Errorf("unexpected received handoff: %+v", incoming.received)
+	}
+
+	plainResp, _ := (&grpcServer{Impl: &plainTestTool{}}).PrepareHandoff(context.Background(), &Empty{})
+	if plainResp.SupportsHandoff {
+		t.Error("expected SupportsHandoff=false for plugin without HandoffProvider")
+	}
+}
+
+type portingTestTool struct {
+	plainTestTool
+	settings SettingsManager
+	dataDir  string
+}
+
+func (t *portingTestTool) ExportState() ([]byte, error) {
+	return ExportPluginState(t.settings, t.dataDir)
+}
+
+func (t *portingTestTool) ImportState(state []byte) error {
+	return ImportPluginState(t.settings, t.dataDir, state)
+}
+
+func newPortingTestTool(t *testing.T) *portingTestTool {
+	agentDir := t.TempDir()
+	settings, err := NewSettingsManager(agentDir, "porter")
+	if err != nil {
+		t.Fatalf("NewSettingsManager failed: %v", err)
+	}
+	return &portingTestTool{settings: settings, dataDir: filepath.Join(agentDir, "porter")}
+}
+
+func TestStatePorter_MigratesSettingsAndFiles(t *testing.T) {
+	source := newPortingTestTool(t)
+	_ = source.settings.Set("api_url", "https://example.com")
+	_ = os.MkdirAll(filepath.Join(source.dataDir, "cache"), 0755)
+	_ = os.WriteFile(filepath.Join(source.dataDir, "cache", "index.db"), []byte("rows"), 0644)
+
+	state, err := newTestClient(t, source).ExportState()
+	if err != nil {
+		t.Fatalf("ExportState failed: %v", err)
+	}
+
+	target := newPortingTestTool(t)
+	_ = target.settings.Set("stale", true)
+	if err := newTestClient(t, target).ImportState(state); err != nil {
+		t.Fatalf("ImportState failed: %v", err)
+	}
+
+	all, _ := target.settings.GetAll()
+	if len(all) != 1 || all["api_url"] != "https://example.com" {
+		t.Errorf("expected settings to be replaced, got %v", all)
+	}
+	content, err := os.ReadFile(filepath.Join(target.dataDir, "cache", "index.db"))
+	if err != nil || string(content) != "rows" {
+		t.Errorf("expected private file to be restored, got %q (err: %v)", content, err)
+	}
+
+	if _, err := newTestClient(t, &plainTestTool{}).ExportState(); err == nil {
+		t.Error("expected an error for a plugin without StatePorter")
+	}
+}
+
+func TestExportPluginState_KeepsSettingExpiry(t *testing.T) {
+	source := newPortingTestTool(t)
+	_ = source.settings.SetWithTTL("oauth.token", "short-lived", 100*time.Millisecond)
+	_ = source.settings.Set("api_url", "https://example.com")
+
+	state, err := ExportPluginState(source.settings, "")
+	if err != nil {
+		t.Fatalf("ExportPluginState failed: %v", err)
+	}
+	target := newPortingTestTool(t)
+	if err := ImportPluginState(target.settings, "", state); err != nil {
+		t.Fatalf("ImportPluginState failed: %v", err)
+	}
+	if token, _ := target.settings.GetString("oauth.token"); token != "short-lived" {
+		t.Errorf("oauth.token = %q after import", token)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if value, _ := target.settings.Get("oauth.token"); value != nil {
+		t.Errorf("imported oauth.token = %v, want it expired", value)
+	}
+	if url, _ := target.settings.GetString("api_url"); url != "https://example.com" {
+		t.Errorf("api_url = %q after import", url)
+	}
+}
+
+func TestImportPluginState_TruncatedArchiveChangesNothing(t *testing.T) {
+	source := newPortingTestTool(t)
+	_ = source.settings.Set("api_url", "https://new.example.com")
+	_ = os.MkdirAll(source.dataDir, 0755)
+	_ = os.WriteFile(filepath.Join(source.dataDir, "a.txt"), []byte("new"), 0644)
+	noise := make([]byte, 64<<10)
+	_, _ = rand.Read(noise)
+	_ = os.WriteFile(filepath.Join(source.dataDir, "b.bin"), noise, 0644)
+
+	state, err := ExportPluginState(source.settings, source.dataDir)
+	if err != nil {
+		t.Fatalf("ExportPluginState failed: %v", err)
+	}
+
+	target := newPortingTestTool(t)
+	_ = target.settings.Set("api_url", "https://old.example.com")
+	_ = os.MkdirAll(target.dataDir, 0755)
+	_ = os.WriteFile(filepath.Join(target.dataDir, "a.txt"), []byte("old"), 0644)
+
+	if err := ImportPluginState(target.settings, target.dataDir, state[:len(state)/2]); err == nil {
+		t.Fatal("expected an error for a truncated archive")
+	}
+	if url, _ := target.settings.GetString("api_url"); url != "https://old.example.com" {
+		t.Errorf("api_url = %q, settings changed by a failed import", url)
+	}
+	if content, _ := os.ReadFile(filepath.Join(target.dataDir, "a.txt")); string(content) != "old" {
+		t.Errorf("a.txt = %q, files changed by a failed import", content)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(target.dataDir))
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".import-") {
+			t.Errorf("staging directory %s was left behind", entry.Name())
+		}
+	}
+
+	// The complete archive replaces both
+	if err := ImportPluginState(target.settings, target.dataDir, state); err != nil {
+		t.Fatalf("ImportPluginState failed: %v", err)
+	}
+	if url, _ := target.settings.GetString("api_url"); url != "https://new.example.com" {
+		t.Errorf("api_url = %q after import", url)
+	}
+	if content, _ := os.ReadFile(filepath.Join(target.dataDir, "b.bin")); !bytes.Equal(content, noise) {
+		t.Error("b.bin was not restored")
+	}
+	if _, err := os.Stat(target.dataDir + stateReplacedSuffix); !os.IsNotExist(err) {
+		t.Errorf("previous data directory was left behind: %v", err)
+	}
+}
@@ -0,0 +1,621 @@
+package pluginapi
+
+// Stdio transport
+//
+// Where a sandbox forbids opening listeners, ServeGRPCPlugin serves the same gRPC
+// services over the process's stdin and stdout. Each direction is a sequence of
+// StdioFrame messages, each preceded by its size as a 4-byte big-endian integer.
+// Deadlines and outgoing metadata travel with the frame that opens an RPC; headers
+// and trailers are not supported. Every RPC, unary or streaming, is a stream identified by a client-chosen ID:
+//
+//   - the client opens a stream with a frame naming the method, sends request
+//     messages as payload frames, and ends them with a close_send frame; a cancel
+//     frame abandons the stream
+//   - the server sends response messages as payload frames and ends the stream with
+//     a done frame carrying the gRPC status
+//
+// Agents talk to such plugins through a StdioClientConn.
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxStdioFrameSize bounds a frame so a corrupt size prefix can't exhaust memory.
+// It leaves room for a MaxMessageSize payload plus the frame's own fields.
+const maxStdioFrameSize = MaxMessageSize + 64<<10
+
+// serveStdio serves tool over stdin and stdout until the agent closes stdin.
+func serveStdio(tool PluginTool) {
+	// Anything the plugin prints would corrupt the frames, so send it to stderr
+	out := os.Stdout
+	os.Stdout = os.Stderr
+
+	server := newStdioServer()
+	unary, stream := serverInterceptors(tool)
+	server.unaryInterceptor, server.streamInterceptor = chainUnaryServer(unary), chainStreamServer(stream)
+	srv := registerToolServices(server, tool)
+	stopped := stopOnSignal(server, srv, nil)
+
+	if err := server.Serve(os.Stdin, out); err != nil {
+		panic(fmt.Sprintf("ServeGRPCPlugin stdio transport error: %v", err))
+	}
+	select {
+	case <-server.stopped:
+		// A signal stopped the server; wait for the shutdown hook
+		<-stopped
+	default:
+		// The agent closed stdin
+	}
+}
+
+// frameWriter writes frames from concurrent streams without interleaving them.
+type frameWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (fw *frameWriter) write(frame *StdioFrame) error {
+	data, err := proto.Marshal(frame)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(buf, uint32(len(data)))
+	copy(buf[4:], data)
+
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	_, err = fw.w.Write(buf)
+	return err
+}
+
+func readFrame(r io.Reader) (*StdioFrame, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxStdioFrameSize {
+		return nil, fmt.Errorf("stdio frame of %d bytes exceeds the %d byte limit", size, maxStdioFrameSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	frame := &StdioFrame{}
+	if err := proto.Unmarshal(data, frame); err != nil {
+		return nil, fmt.Errorf("invalid stdio frame: %w", err)
+	}
+	return frame, nil
+}
+
+// messageQueue buffers a stream's incoming messages so that reading frames never
+// blocks on a slow stream.
+type messageQueue struct {
+	mu    sync.Mutex
+	items [][]byte
+	err   error // Returned once items are drained; set by close
+	ready chan struct{}
+}
+
+func newMessageQueue() *messageQueue {
+	return &messageQueue{ready: make(chan struct{}, 1)}
+}
+
+func (q *messageQueue) push(msg []byte) {
+	q.mu.Lock()
+	if q.err == nil {
+		q.items = append(q.items, msg)
+	}
+	q.mu.Unlock()
+	q.notify()
+}
+
+// close ends the queue; pop returns err after the remaining messages.
+func (q *messageQueue) close(err error) {
+	q.mu.Lock()
+	if q.err == nil {
+		q.err = err
+	}
+	q.mu.Unlock()
+	q.notify()
+}
+
+func (q *messageQueue) notify() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (q *messageQueue) pop(ctx context.Context) ([]byte, error) {
+	for {
+		q.mu.Lock()
+		if len(q.items) > 0 {
+			msg := q.items[0]
+			q.items = q.items[1:]
+			q.mu.Unlock()
+			return msg, nil
+		}
+		err := q.err
+		q.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case <-q.ready:
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+func sendStdioMessage(out *frameWriter, id uint64, m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "stdio transport cannot send %T", m)
+	}
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to encode %T: %v", m, err)
+	}
+	if err := out.write(&StdioFrame{StreamId: id, Payload: payload}); err != nil {
+		return status.Errorf(codes.Unavailable, "stdio transport write failed: %v", err)
+	}
+	return nil
+}
+
+func recvStdioMessage(ctx context.Context, q *messageQueue, m any) error {
+	payload, err := q.pop(ctx)
+	if err != nil {
+		return err
+	}
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "stdio transport cannot receive %T", m)
+	}
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode %T: %v", m, err)
+	}
+	return nil
+}
+
+// ============================================================================
+// Server
+// ============================================================================
+
+// stdioServer serves gRPC services over the stdio transport. It implements
+// grpc.ServiceRegistrar, so services register exactly as on a grpc.Server.
+type stdioServer struct {
+	services map[string]stdioService
+	out      *frameWriter
+
+	// Optional, like the grpc.Server interceptor options
+	unaryInterceptor  grpc.UnaryServerInterceptor
+	streamInterceptor grpc.StreamServerInterceptor
+
+	mu       sync.Mutex
+	streams  map[uint64]*stdioServerStream
+	draining bool           // Set by GracefulStop; new streams are refused
+	running  sync.WaitGroup // Streams whose handlers haven't returned
+
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+type stdioService struct {
+	desc *grpc.ServiceDesc
+	impl any
+}
+
+func newStdioServer() *stdioServer {
+	return &stdioServer{
+		services: make(map[string]stdioService),
+		streams:  make(map[uint64]*stdioServerStream),
+		stopped:  make(chan struct{}),
+	}
+}
+
+func (s *stdioServer) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	s.services[desc.ServiceName] = stdioService{desc: desc, impl: impl}
+}
+
+// Serve reads frames from r and writes responses to w until r ends or Stop is called.
+// Running RPCs are cancelled when it returns.
+func (s *stdioServer) Serve(r io.Reader, w io.Writer) error {
+	s.out = &frameWriter{w: w}
+	defer s.cancelAll()
+
+	frames := make(chan *StdioFrame)
+	readErr := make(chan error, 1)
+	go func() {
+		br := bufio.NewReader(r)
+		for {
+			frame, err := readFrame(br)
+			if err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case frames <- frame:
+			case <-s.stopped:
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case frame := <-frames:
+			s.handleFrame(frame)
+		case err := <-readErr:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-s.stopped:
+			return nil
+		}
+	}
+}
+
+// Stop makes Serve return. It does not wait for running RPCs.
+func (s *stdioServer) Stop() {
+	s.stopOnce.Do(func() { close(s.stopped) })
+}
+
+// GracefulStop refuses new RPCs, waits for running ones to finish and then
+// makes Serve return.
+func (s *stdioServer) GracefulStop() {
+	s.mu.Lock()
+	s.draining = true
+	s.mu.Unlock()
+	s.running.Wait()
+	s.Stop()
+}
+
+func (s *stdioServer) cancelAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, stream := range s.streams {
+		stream.cancel()
+	}
+}
+
+func (s *stdioServer) handleFrame(frame *StdioFrame) {
+	if frame.Method != "" {
+		s.open(frame)
+		return
+	}
+
+	s.mu.Lock()
+	stream := s.streams[frame.StreamId]
+	s.mu.Unlock()
+	if stream == nil {
+		return // Already finished
+	}
+	switch {
+	case frame.Cancel:
+		stream.cancel()
+	case frame.CloseSend:
+		stream.recv.close(io.EOF)
+	default:
+		stream.recv.push(frame.Payload)
+	}
+}
+
+func (s *stdioServer) open(frame *StdioFrame) {
+	ctx, cancel := stdioStreamContext(frame.TimeoutMs)
+	if len(frame.Metadata) > 0 {
+		md := make(metadata.MD, len(frame.Metadata))
+		for _, entry := range frame.Metadata {
+			md[entry.Key] = entry.Values
+		}
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	stream := &stdioServerStream{
+		ctx:    ctx,
+		cancel: cancel,
+		id:     frame.StreamId,
+		out:    s.out,
+		recv:   newMessageQueue(),
+	}
+	s.mu.Lock()
+	if s.draining {
+		s.mu.Unlock()
+		cancel()
+		_ = s.out.write(&StdioFrame{StreamId: stream.id, Done: true, Code: int32(codes.Unavailable), Error: "plugin is shutting down"})
+		return
+	}
+	s.streams[stream.id] = stream
+	s.running.Add(1)
+	s.mu.Unlock()
+
+	go func() {
+		defer s.running.Done()
+		err := s.dispatch(frame.Method, stream)
+
+		s.mu.Lock()
+		delete(s.streams, stream.id)
+		s.mu.Unlock()
+		cancel()
+
+		st := status.Convert(err)
+		_ = s.out.write(&StdioFrame{StreamId: stream.id, Done: true, Code: int32(st.Code()), Error: st.Message()})
+	}()
+}
+
+func stdioStreamContext(timeoutMs int64) (context.Context, context.CancelFunc) {
+	if timeoutMs > 0 {
+		return context.WithTimeout(context.Background(), time.Duration(timeoutMs)*time.Millisecond)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// dispatch runs the handler for fullMethod ("/package.Service/Method").
+func (s *stdioServer) dispatch(fullMethod string, stream *stdioServerStream) error {
+	serviceName, methodName, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	service, found := s.services[serviceName]
+	if !ok || !found {
+		return status.Errorf(codes.Unimplemented, "unknown service %s", serviceName)
+	}
+
+	for _, method := range service.desc.Methods {
+		if method.MethodName == methodName {
+			reply, err := method.Handler(service.impl, stream.ctx, stream.RecvMsg, s.unaryInterceptor)
+			if err != nil {
+				return err
+			}
+			return stream.SendMsg(reply)
+		}
+	}
+	for _, desc := range service.desc.Streams {
+		if desc.StreamName == methodName {
+			if s.streamInterceptor == nil {
+				return desc.Handler(service.impl, stream)
+			}
+			info := &grpc.StreamServerInfo{FullMethod: fullMethod, IsClientStream: desc.ClientStreams, IsServerStream: desc.ServerStreams}
+			return s.streamInterceptor(service.impl, stream, info, desc.Handler)
+		}
+	}
+	return status.Errorf(codes.Unimplemented, "unknown method %s for service %s", methodName, serviceName)
+}
+
+// stdioServerStream implements grpc.ServerStream for one RPC. Headers and
+// trailers are dropped.
+type stdioServerStream struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	id     uint64
+	out    *frameWriter
+	recv   *messageQueue
+}
+
+func (s *stdioServerStream) Context() context.Context     { return s.ctx }
+func (s *stdioServerStream) SetHeader(metadata.MD) error  { return nil }
+func (s *stdioServerStream) SendHeader(metadata.MD) error { return nil }
+func (s *stdioServerStream) SetTrailer(metadata.MD)       {}
+func (s *stdioServerStream) SendMsg(m any) error          { return sendStdioMessage(s.out, s.id, m) }
+func (s *stdioServerStream) RecvMsg(m any) error          { return recvStdioMessage(s.ctx, s.recv, m) }
+
+// ============================================================================
+// Client
+// ============================================================================
+
+// StdioClientConn is the agent's side of the stdio transport: a gRPC client
+// connection to a plugin serving over its stdin and stdout. Pass it to
+// NewToolServiceClient like a *grpc.ClientConn.
+//
+// Example:
+//
+//	cmd := exec.Command(pluginPath)
+//	cmd.Env = append(os.Environ(), pluginapi.EnvTransport+"="+pluginapi.TransportStdio)
+//	stdin, _ := cmd.StdinPipe()
+//	stdout, _ := cmd.StdoutPipe()
+//	if err := cmd.Start(); err != nil { ... }
+//	conn := pluginapi.NewStdioClientConn(stdout, stdin)
+//	defer conn.Close()
+//	tool := pluginapi.NewToolServiceClient(conn)
+type StdioClientConn struct {
+	w      io.Writer
+	out    *frameWriter
+	nextID atomic.Uint64
+
+	mu      sync.Mutex
+	streams map[uint64]*messageQueue
+	err     error // Set once the connection is broken; new streams fail with it
+}
+
+// NewStdioClientConn starts a connection that reads the plugin's frames from r
+// (its stdout) and writes frames to w (its stdin).
+func NewStdioClientConn(r io.Reader, w io.Writer) *StdioClientConn {
+	c := &StdioClientConn{
+		w:       w,
+		out:     &frameWriter{w: w},
+		streams: make(map[uint64]*messageQueue),
+	}
+	go c.readLoop(r)
+	return c
+}
+
+// Close fails all running RPCs and closes the plugin's stdin if w is closeable,
+// which tells the plugin to stop serving.
+func (c *StdioClientConn) Close() error {
+	c.fail(status.Error(codes.Canceled, "stdio connection closed"))
+	if closer, ok := c.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (c *StdioClientConn) readLoop(r io.Reader) {
+	br := bufio.NewReader(r)
+	for {
+		frame, err := readFrame(br)
+		if err != nil {
+			c.fail(status.Errorf(codes.Unavailable, "stdio transport closed: %v", err))
+			return
+		}
+
+		if !frame.Done {
+			c.mu.Lock()
+			q := c.streams[frame.StreamId]
+			c.mu.Unlock()
+			if q != nil {
+				q.push(frame.Payload)
+			}
+			continue
+		}
+		if q := c.remove(frame.StreamId); q != nil {
+			if code := codes.Code(frame.Code); code != codes.OK {
+				q.close(status.Error(code, frame.Error))
+			} else {
+				q.close(io.EOF)
+			}
+		}
+	}
+}
+
+func (c *StdioClientConn) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.err = err
+	}
+	for id, q := range c.streams {
+		q.close(err)
+		delete(c.streams, id)
+	}
+}
+
+// remove unregisters a stream, returning its queue if it was still registered.
+func (c *StdioClientConn) remove(id uint64) *messageQueue {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	q := c.streams[id]
+	delete(c.streams, id)
+	return q
+}
+
+// Invoke performs a unary RPC.
+func (c *StdioClientConn) Invoke(ctx context.Context, method string, args, reply any, _ ...grpc.CallOption) error {
+	stream, err := c.newStream(ctx, method)
+	if err != nil {
+		return err
+	}
+	defer stream.stop()
+
+	if err := stream.SendMsg(args); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	if err := stream.RecvMsg(reply); err != nil {
+		if err == io.EOF {
+			return status.Errorf(codes.Internal, "%s finished without a response", method)
+		}
+		return err
+	}
+	// Wait for the status so the RPC is complete when Invoke returns
+	if _, err := stream.recv.pop(ctx); err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// NewStream starts a streaming RPC.
+func (c *StdioClientConn) NewStream(ctx context.Context, _ *grpc.StreamDesc, method string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+	return c.newStream(ctx, method)
+}
+
+func (c *StdioClientConn) newStream(ctx context.Context, method string) (*stdioClientStream, error) {
+	id := c.nextID.Add(1)
+	q := newMessageQueue()
+	c.mu.Lock()
+	if c.err != nil {
+		err := c.err
+		c.mu.Unlock()
+		return nil, err
+	}
+	c.streams[id] = q
+	c.mu.Unlock()
+
+	open := &StdioFrame{StreamId: id, Method: method}
+	if deadline, ok := ctx.Deadline(); ok {
+		open.TimeoutMs = max(time.Until(deadline).Milliseconds(), 1)
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		for key, values := range md {
+			open.Metadata = append(open.Metadata, &StdioMetadata{Key: key, Values: values})
+		}
+	}
+	if err := c.out.write(open); err != nil {
+		c.remove(id)
+		return nil, status.Errorf(codes.Unavailable, "stdio transport write failed: %v", err)
+	}
+
+	// Tell the plugin when the caller gives up, as gRPC would
+	stop := context.AfterFunc(ctx, func() {
+		if c.remove(id) != nil {
+			_ = c.out.write(&StdioFrame{StreamId: id, Cancel: true})
+			q.close(status.FromContextError(ctx.Err()).Err())
+		}
+	})
+	return &stdioClientStream{ctx: ctx, id: id, conn: c, recv: q, stop: stop}, nil
+}
+
+// stdioClientStream implements grpc.ClientStream for one RPC.
+type stdioClientStream struct {
+	ctx  context.Context
+	id   uint64
+	conn *StdioClientConn
+	recv *messageQueue
+	stop func() bool // Unregisters the cancellation watch
+}
+
+func (s *stdioClientStream) Header() (metadata.MD, error) { return nil, nil }
+func (s *stdioClientStream) Trailer() metadata.MD         { return nil }
+func (s *stdioClientStream) Context() context.Context     { return s.ctx }
+
+func (s *stdioClientStream) CloseSend() error {
+	if err := s.conn.out.write(&StdioFrame{StreamId: s.id, CloseSend: true}); err != nil {
+		return status.Errorf(codes.Unavailable, "stdio transport write failed: %v", err)
+	}
+	return nil
+}
+
+func (s *stdioClientStream) SendMsg(m any) error {
+	return sendStdioMessage(s.conn.out, s.id, m)
+}
+
+func (s *stdioClientStream) RecvMsg(m any) error {
+	err := recvStdioMessage(s.ctx, s.recv, m)
+	if err != nil {
+		s.stop()
+	}
+	return err
+}
+
+var (
+	_ grpc.ServiceRegistrar    = (*stdioServer)(nil)
+	_ grpc.ServerStream        = (*stdioServerStream)(nil)
+	_ grpc.ClientConnInterface = (*StdioClientConn)(nil)
+	_ grpc.ClientStream        = (*stdioClientStream)(nil)
+)
@@ -0,0 +1,130 @@
+package pluginapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newStdioTestClient serves impl over a pair of pipes standing in for the plugin's
+// stdin and stdout. The returned channel receives Serve's result.
+func newStdioTestClient(t *testing.T, impl PluginTool) (*grpcClient, *StdioClientConn, <-chan error) {
+	t.Helper()
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+
+	server := newStdioServer()
+	unary, stream := serverInterceptors(impl)
+	server.unaryInterceptor, server.streamInterceptor = chainUnaryServer(unary), chainStreamServer(stream)
+	registerToolServices(server, impl)
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(stdinR, stdoutW)
+		_ = stdoutW.Close()
+	}()
+
+	conn := NewStdioClientConn(stdoutR, stdinW)
+	t.Cleanup(func() { _ = conn.Close() })
+	return &grpcClient{client: NewToolServiceClient(conn)}, conn, served
+}
+
+func TestStdioTransport_Unary(t *testing.T) {
+	client, _, _ := newStdioTestClient(t, &streamingTestTool{})
+	ctx := context.Background()
+
+	result, err := client.Call(ctx, "{}")
+	if err != nil || result != `{"done":true}` {
+		t.Errorf("Call = %q, %v", result, err)
+	}
+	if _, err := client.Call(ctx, "fail"); err == nil || err.Error() != "render failed" {
+		t.Errorf("expected render error, got %v", err)
+	}
+	if err := client.HealthCheckCtx(ctx); err != nil {
+		t.Errorf("HealthCheck failed: %v", err)
+	}
+}
+
+func TestStdioTransport_Streaming(t *testing.T) {
+	client, _, _ := newStdioTestClient(t, &streamingTestTool{})
+
+	var chunks []CallChunk
+	result, err := client.CallStream(context.Background(), "{}", func(chunk CallChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil || result != `{"done":true}` {
+		t.Fatalf("CallStream = %q, %v", result, err)
+	}
+	if len(chunks) != 4 || chunks[3].Partial != "partial" {
+		t.Errorf("unexpected chunks: %+v", chunks)
+	}
+}
+
+func TestStdioTransport_CancellationReachesPlugin(t *testing.T) {
+	tool := newBlockingTestTool()
+	client, _, _ := newStdioTestClient(t, tool)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := client.Call(ctx, `{}`)
+		errCh <- err
+	}()
+	<-tool.started
+	cancel()
+
+	waitClosed(t, tool.cancelled, "plugin context cancellation")
+	if err := <-errCh; status.Code(err) != codes.Canceled {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+}
+
+func TestStdioTransport_UnknownMethod(t *testing.T) {
+	_, conn, _ := newStdioTestClient(t, &plainTestTool{})
+
+	err := conn.Invoke(context.Background(), "/pluginapi.ToolService/NoSuchMethod", &Empty{}, &Empty{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected Unimplemented, got %v", err)
+	}
+}
+
+func TestStdioTransport_CloseStopsServer(t *testing.T) {
+	client, conn, served := newStdioTestClient(t, &plainTestTool{})
+	if err := client.HealthCheckCtx(context.Background()); err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+
+	if err := conn.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("Serve should return nil when stdin closes, got %v", err)
+	}
+	if err := client.HealthCheckCtx(context.Background()); err == nil {
+		t.Error("expected error after Close")
+	}
+}
+
+func TestReadFrame_RejectsOversizedFrames(t *testing.T) {
+	header := []byte{0xff, 0xff, 0xff, 0xff}
+	if _, err := readFrame(bytes.NewReader(header)); err == nil {
+		t.Error("expected error for oversized frame")
+	}
+}
+
+func TestStdioTransport_ForwardsMetadata(t *testing.T) {
+	client, _, _ := newStdioTestClient(t, &interceptedTestTool{})
+
+	if _, err := client.Call(context.Background(), "{}"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without token, got %v", err)
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-token", "secret")
+	if _, err := client.Call(ctx, "{}"); err != nil {
+		t.Errorf("Call with token failed: %v", err)
+	}
+}
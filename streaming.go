@@ -0,0 +1,92 @@
+package pluginapi
+
+import (
+	"context"
+	"iter"
+)
+
+// CallChunk is an incremental update from a streaming tool call.
+type CallChunk struct {
+	// Message is a human-readable progress message (e.g., "Rendering track 2 of 5")
+	Message string
+	// Progress is the completed fraction between 0 and 1, or negative if unknown
+	Progress float64
+	// Partial is a partial result (e.g., text generated so far or one batch of rows).
+	// Its format is tool-defined; hosts typically append or display it as-is.
+	Partial string
+	// Done is set on the last chunk, which carries Result or Err
+	Done bool
+	// Result is the final result JSON; only set when Done
+	Result string
+	// Err is the call's error; only set when Done
+	Err error
+}
+
+// StreamingTool allows long-running tools to report progress and partial results
+// while they run instead of returning everything at the end.
+// Plugins can optionally implement this interface; hosts that don't stream keep using Call.
+type StreamingTool interface {
+	PluginTool
+	// CallStream executes the tool like Call, calling emit for each update.
+	// emit returns an error when the host has gone away; the tool should stop and return it.
+	// The returned result is delivered to the host as the final chunk.
+	CallStream(ctx context.Context, args string, emit func(CallChunk) error) (string, error)
+}
+
+// ProgressChunk is a convenience for emitting a progress update.
+//
+// Example:
+//
+//	for i, track := range tracks {
+//	    if err := emit(pluginapi.ProgressChunk(float64(i)/float64(len(tracks)), "Rendering "+track)); err != nil {
+//	        return "", err
+//	    }
+//	    render(track)
+//	}
+func ProgressChunk(progress float64, message string) CallChunk {
+	return CallChunk{Progress: progress, Message: message}
+}
+
+// PartialChunk is a convenience for emitting a partial result without progress information.
+func PartialChunk(partial string) CallChunk {
+	return CallChunk{Progress: -1, Partial: partial}
+}
+
+// CallChunks adapts a StreamingTool to an iterator. Each update is yielded with a nil
+// error; the final chunk has Done set and its error, if any, is yielded alongside it.
+// Breaking out of the loop cancels the call.
+//
+// Example:
+//
+//	for chunk, err := range pluginapi.CallChunks(ctx, tool, args) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if chunk.Done {
+//	        return show(chunk.Result)
+//	    }
+//	    showProgress(chunk.Progress, chunk.Message)
+//	}
+func CallChunks(ctx context.Context, tool StreamingTool, args string) iter.Seq2[CallChunk, error] {
+	return func(yield func(CallChunk, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stopped := false
+		result, err := tool.CallStream(ctx, args, func(chunk CallChunk) error {
+			if stopped {
+				return context.Canceled
+			}
+			if !yield(chunk, nil) {
+				stopped = true
+				cancel()
+				return context.Canceled
+			}
+			return nil
+		})
+		if stopped {
+			return
+		}
+		yield(CallChunk{Done: true, Result: result, Err: err}, err)
+	}
+}
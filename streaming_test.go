@@ -0,0 +1,137 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type streamingTestTool struct {
+	BasePlugin
+	cancelled chan struct{}
+}
+
+func (t *streamingTestTool) Call(ctx context.Context, args string) (string, error) {
+	return t.CallStream(ctx, args, func(CallChunk) error { return nil })
+}
+
+func (t *streamingTestTool) CallStream(ctx context.Context, args string, emit func(CallChunk) error) (string, error) {
+	switch args {
+	case "fail":
+		return "", errors.New("render failed")
+	case "forever":
+		for i := 0; ; i++ {
+			if err := emit(ProgressChunk(-1, fmt.Sprintf("tick %d", i))); err != nil {
+				close(t.cancelled)
+				return "", err
+			}
+			if ctx.Err() != nil {
+				close(t.cancelled)
+				return "", ctx.Err()
+			}
+		}
+	}
+	for i := 1; i <= 3; i++ {
+		if err := emit(ProgressChunk(float64(i)/4, fmt.Sprintf("step %d", i))); err != nil {
+			return "", err
+		}
+	}
+	if err := emit(PartialChunk("partial")); err != nil {
+		return "", err
+	}
+	return `{"done":true}`, nil
+}
+
+func TestGRPCClient_CallStream(t *testing.T) {
+	client := newTestClient(t, &streamingTestTool{})
+
+	var chunks []CallChunk
+	result, err := client.CallStream(context.Background(), "{}", func(chunk CallChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("CallStream failed: %v", err)
+	}
+	if result != `{"done":true}` {
+		t.Errorf("result = %q", result)
+	}
+	if len(chunks) != 4 {
+		t.Fatalf("expected 4 chunks, got %+v", chunks)
+	}
+	if chunks[0].Message != "step 1" || chunks[0].Progress != 0.25 {
+		t.Errorf("unexpected first chunk: %+v", chunks[0])
+	}
+	if chunks[3].Partial != "partial" || chunks[3].Progress >= 0 {
+		t.Errorf("unexpected partial chunk: %+v", chunks[3])
+	}
+
+	_, err = client.CallStream(context.Background(), "fail", func(CallChunk) error { return nil })
+	if err == nil || err.Error() != "render failed" {
+		t.Errorf("expected render error, got %v", err)
+	}
+}
+
+func TestGRPCClient_CallStream_NonStreamingPlugin(t *testing.T) {
+	client := newTestClient(t, &plainTestTool{})
+
+	emitted := 0
+	result, err := client.CallStream(context.Background(), `{"x":1}`, func(CallChunk) error {
+		emitted++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("CallStream failed: %v", err)
+	}
+	if emitted != 0 {
+		t.Errorf("expected no updates from a non-streaming plugin, got %d", emitted)
+	}
+	want, _ := (&plainTestTool{}).Call(context.Background(), `{"x":1}`)
+	if result != want {
+		t.Errorf("result = %q, want %q", result, want)
+	}
+}
+
+func TestCallChunks(t *testing.T) {
+	client := newTestClient(t, &streamingTestTool{})
+
+	var messages []string
+	var final CallChunk
+	for chunk, err := range CallChunks(context.Background(), client, "{}") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if chunk.Done {
+			final = chunk
+			break
+		}
+		messages = append(messages, chunk.Message)
+	}
+	if len(messages) != 4 || messages[0] != "step 1" {
+		t.Errorf("messages = %v", messages)
+	}
+	if !final.Done || final.Result != `{"done":true}` {
+		t.Errorf("final chunk = %+v", final)
+	}
+
+	for chunk, err := range CallChunks(context.Background(), client, "fail") {
+		if err == nil || !chunk.Done || chunk.Err == nil {
+			t.Errorf("expected final error chunk, got %+v, %v", chunk, err)
+		}
+	}
+}
+
+func TestCallChunks_BreakCancels(t *testing.T) {
+	tool := &streamingTestTool{cancelled: make(chan struct{})}
+	client := newTestClient(t, tool)
+
+	count := 0
+	for range CallChunks(context.Background(), client, "forever") {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	<-tool.cancelled
+}
@@ -0,0 +1,362 @@
+package pluginapi
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Export formats for table results
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// ExportFormatArg is the argument name used by export actions.
+// When a table result carries export actions (see AddExportActions), the host calls the
+// plugin with the original arguments plus export_format set to "csv" or "xlsx".
+// The plugin re-runs the query and returns the table via ExportFile instead of rendering it.
+const ExportFormatArg = "export_format"
+
+// MIME types for exported tables
+const (
+	MIMETypeCSV  = "text/csv"
+	MIMETypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// ToCSV renders a table result as CSV with a header row.
+// Text cells starting with =, +, -, @, tab or carriage return get a leading ' so
+// spreadsheets opening the file don't run them as formulas (CSV injection);
+// plain numbers written as text, such as "-5", are left as they are.
+// Returns an error if the result is not a table.
+func (sr *StructuredResult) ToCSV() ([]byte, error) {
+	columns, rows, err := sr.tableCells()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = csvSafeText(col)
+	}
+	if err := w.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			record[i] = tableCellString(cell)
+			if _, isText := cell.(string); isText {
+				record[i] = csvSafeText(record[i])
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ToXLSX renders a table result as a single-sheet Excel workbook.
+// Numeric cells, including text holding a plain number such as the cells of
+// NewTableResultFromStructs tables, are written as numbers; everything else is
+// written as text.
+// Returns an error if the result is not a table.
+func (sr *StructuredResult) ToXLSX() ([]byte, error) {
+	columns, rows, err := sr.tableCells()
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]interface{}, len(columns))
+	for i, col := range columns {
+		header[i] = col
+	}
+
+	var sheet bytes.Buffer
+	sheet.WriteString(xml.Header)
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for r, row := range append([][]interface{}{header}, rows...) {
+		fmt.Fprintf(&sheet, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := xlsxColumnName(c) + strconv.Itoa(r+1)
+			if n, ok := tableCellNumber(cell); ok {
+				fmt.Fprintf(&sheet, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(n, 'f', -1, 64))
+				continue
+			}
+			fmt.Fprintf(&sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			if err := xml.EscapeText(&sheet, []byte(tableCellString(cell))); err != nil {
+				return nil, fmt.Errorf("failed to write XLSX: %w", err)
+			}
+			sheet.WriteString(`</t></is></c>`)
+		}
+		sheet.WriteString(`</row>`)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	var sheetName bytes.Buffer
+	if err := xml.EscapeText(&sheetName, []byte(xlsxSheetName(sr.Title))); err != nil {
+		return nil, fmt.Errorf("failed to write XLSX: %w", err)
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="` + sheetName.String() + `" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`},
+		{"xl/worksheets/sheet1.xml", sheet.String()},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write XLSX: %w", err)
+		}
+		if _, err := w.Write([]byte(f.content)); err != nil {
+			return nil, fmt.Errorf("failed to write XLSX: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write XLSX: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportFile renders a table result in the given format ("csv" or "xlsx") as a file
+// attachment named after the result title, ready to be returned to the user.
+func (sr *StructuredResult) ExportFile(format string) (FileAttachment, error) {
+	var content []byte
+	var mimeType string
+	var err error
+
+	switch format {
+	case ExportFormatCSV:
+		content, err = sr.ToCSV()
+		mimeType = MIMETypeCSV
+	case ExportFormatXLSX:
+		content, err = sr.ToXLSX()
+		mimeType = MIMETypeXLSX
+	default:
+		return FileAttachment{}, fmt.Errorf("unsupported export format: %s (supported: csv, xlsx)", format)
+	}
+	if err != nil {
+		return FileAttachment{}, err
+	}
+
+	name := exportFileName(sr.Title)
+	return FileAttachment{
+		Name:    name + "." + format,
+		Type:    mimeType,
+		Size:    int64(len(content)),
+		Content: content,
+	}, nil
+}
+
+// AddExportActions attaches "Download CSV"/"Download XLSX" buttons to a table result.
+// The buttons call operation with args plus ExportFormatArg. If no formats are given,
+// both CSV and XLSX are offered. Returns the result for chaining.
+//
+// Example:
+//
+//	result.AddExportActions("list_projects", map[string]interface{}{"status": params.Status})
+func (sr *StructuredResult) AddExportActions(operation string, args map[string]interface{}, formats ...string) *StructuredResult {
+	if len(formats) == 0 {
+		formats = []string{ExportFormatCSV, ExportFormatXLSX}
+	}
+	for _, format := range formats {
+		actionArgs := make(map[string]interface{}, len(args)+1)
+		for k, v := range args {
+			actionArgs[k] = v
+		}
+		actionArgs[ExportFormatArg] = format
+		sr.AddAction("Download "+strings.ToUpper(format), operation, actionArgs)
+	}
+	return sr
+}
+
+// tableCells extracts the column names and row values of a table result.
+// Rows may be maps keyed by column name or positional slices.
+func (sr *StructuredResult) tableCells() ([]string, [][]interface{}, error) {
+	if sr.DisplayType != DisplayTypeTable {
+		return nil, nil, fmt.Errorf("result is not a table (displayType: %s)", sr.DisplayType)
+	}
+
+	// Results decoded from JSON/YAML hold generic values; normalize via JSON
+	raw, err := json.Marshal(sr.Data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid table data: %w", err)
+	}
+	var rows []interface{}
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil, nil, fmt.Errorf("table data must be a list of rows: %w", err)
+	}
+
+	var columns []string
+	switch v := sr.Metadata["columns"].(type) {
+	case []string:
+		columns = v
+	case []interface{}:
+		for _, col := range v {
+			columns = append(columns, fmt.Sprint(col))
+		}
+	}
+	if len(columns) == 0 && len(rows) > 0 {
+		// No column metadata: use the first row's keys in sorted order
+		if first, ok := rows[0].(map[string]interface{}); ok {
+			for k := range first {
+				columns = append(columns, k)
+			}
+			sort.Strings(columns)
+		}
+	}
+
+	cells := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		cells[i] = make([]interface{}, len(columns))
+		switch r := row.(type) {
+		case map[string]interface{}:
+			for j, col := range columns {
+				cells[i][j] = r[col]
+			}
+		case []interface{}:
+			for j := range columns {
+				if j < len(r) {
+					cells[i][j] = r[j]
+				}
+			}
+		default:
+			return nil, nil, fmt.Errorf("row %d: unsupported row type %T", i, row)
+		}
+	}
+	return columns, cells, nil
+}
+
+// tableCellString formats a decoded JSON value for export.
+func tableCellString(cell interface{}) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// csvSafeText neutralizes text a spreadsheet would treat as a formula.
+// Plain numbers such as "-5" are left as they are.
+func csvSafeText(s string) string {
+	if _, ok := plainNumber(s); ok {
+		return s
+	}
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// tableCellNumber reports whether a decoded JSON value is a number, or text
+// holding a plain number (NewTableResultFromStructs formats numbers as text).
+func tableCellNumber(cell interface{}) (float64, bool) {
+	switch v := cell.(type) {
+	case float64:
+		return v, true
+	case string:
+		return plainNumber(v)
+	}
+	return 0, false
+}
+
+// plainNumberPattern matches numbers written as JSON writes them, so text such
+// as zip codes with leading zeros or "+1" stays text.
+var plainNumberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// plainNumber parses s if it is a plain number (see plainNumberPattern).
+func plainNumber(s string) (float64, bool) {
+	if !plainNumberPattern.MatchString(s) {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	return n, err == nil
+}
+
+// xlsxColumnName converts a zero-based column index to a spreadsheet column (A, B, ..., AA).
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xlsxSheetName sanitizes a title for use as a worksheet name.
+func xlsxSheetName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return ' '
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if len([]rune(name)) > 31 {
+		name = string([]rune(name)[:31])
+	}
+	if name == "" {
+		return "Sheet1"
+	}
+	return name
+}
+
+// exportFileName derives a safe file name (without extension) from a result title.
+func exportFileName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r == ' ' || r == '.':
+			return '_'
+		default:
+			return -1
+		}
+	}, strings.TrimSpace(title))
+	if name == "" {
+		return "export"
+	}
+	return name
+}
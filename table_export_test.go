@@ -0,0 +1,172 @@
+package pluginapi
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTableResult_ToCSV(t *testing.T) {
+	result := NewTableResult("Items", []string{"Name", "Note"}, []map[string]string{
+		{"Name": "item-1", "Note": "has, comma"},
+		{"Name": "item-2", "Note": `has "quotes"`},
+	})
+
+	data, err := result.ToCSV()
+	if err != nil {
+		t.Fatalf("ToCSV failed: %v", err)
+	}
+
+	expected := "Name,Note\nitem-1,\"has, comma\"\nitem-2,\"has \"\"quotes\"\"\"\n"
+	if string(data) != expected {
+		t.Errorf("unexpected CSV:\n%s\nexpected:\n%s", data, expected)
+	}
+
+	// Works on results parsed back from JSON
+	jsonStr, _ := result.ToJSON()
+	parsed, _ := ParseStructuredResult(jsonStr)
+	parsedData, err := parsed.ToCSV()
+	if err != nil || string(parsedData) != expected {
+		t.Errorf("unexpected CSV after parse: %s (err: %v)", parsedData, err)
+	}
+
+	if _, err := NewTextResult("hello").ToCSV(); err == nil {
+		t.Error("expected error exporting a non-table result")
+	}
+}
+
+func TestTableResult_ToXLSX(t *testing.T) {
+	result := NewTableResult("Sizes <2024>", []string{"Name", "Bytes"}, []map[string]interface{}{
+		{"Name": "a & b", "Bytes": 1024},
+	})
+
+	data, err := result.ToXLSX()
+	if err != nil {
+		t.Fatalf("ToXLSX failed: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("XLSX is not a valid zip: %v", err)
+	}
+
+	var sheet, workbook string
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		content, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		switch f.Name {
+		case "xl/worksheets/sheet1.xml":
+			sheet = string(content)
+		case "xl/workbook.xml":
+			workbook = string(content)
+		}
+	}
+
+	if !strings.Contains(sheet, "a &amp; b") {
+		t.Errorf("expected escaped text cell in sheet: %s", sheet)
+	}
+	if !strings.Contains(sheet, `<c r="B2"><v>1024</v></c>`) {
+		t.Errorf("expected numeric cell in sheet: %s", sheet)
+	}
+	if !strings.Contains(workbook, `name="Sizes &lt;2024&gt;"`) {
+		t.Errorf("expected escaped sheet name in workbook: %s", workbook)
+	}
+}
+
+func TestTableResult_ExportFile(t *testing.T) {
+	result := NewTableResult("My Items", []string{"Name"}, []map[string]string{{"Name": "x"}})
+
+	file, err := result.ExportFile(ExportFormatCSV)
+	if err != nil {
+		t.Fatalf("ExportFile failed: %v", err)
+	}
+	if file.Name != "My_Items.csv" || file.Type != MIMETypeCSV || file.Size != int64(len(file.Content)) {
+		t.Errorf("unexpected export file: %+v", file)
+	}
+
+	if _, err := result.ExportFile("pdf"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+
+	result.AddExportActions("list_items", map[string]interface{}{"status": "active"})
+	actions := result.Actions()
+	if len(actions) != 2 || actions[1].Args[ExportFormatArg] != ExportFormatXLSX || actions[1].Args["status"] != "active" {
+		t.Errorf("unexpected export actions: %+v", actions)
+	}
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for index, want := range tests {
+		if got := xlsxColumnName(index); got != want {
+			t.Errorf("xlsxColumnName(%d) = %q, want %q", index, got, want)
+		}
+	}
+}
+
+func TestTableResult_ToCSVEscapesFormulas(t *testing.T) {
+	result := NewTableResult("Items", []string{"=Name", "Note"}, []map[string]interface{}{
+		{"=Name": "=HYPERLINK(\"http://evil\")", "Note": "+1"},
+		{"=Name": "@SUM(A1)", "Note": "-2+A1"},
+		{"=Name": "-5", "Note": "007"}, // Plain numbers written as text stay as they are
+		{"=Name": "plain", "Note": "a=b"},
+		{"=Name": -3.5}, // Numbers are not text and stay as they are
+	})
+
+	data, err := result.ToCSV()
+	if err != nil {
+		t.Fatalf("ToCSV failed: %v", err)
+	}
+	expected := "'=Name,Note\n\"'=HYPERLINK(\"\"http://evil\"\")\",'+1\n'@SUM(A1),'-2+A1\n-5,007\nplain,a=b\n-3.5,\n"
+	if string(data) != expected {
+		t.Errorf("unexpected CSV:\n%s\nexpected:\n%s", data, expected)
+	}
+}
+
+func TestTableResult_ExportStructTable(t *testing.T) {
+	type change struct {
+		Name  string
+		Delta int
+		Ratio float64
+		Size  int64 `table:"Size,format=bytes"`
+	}
+	result, err := NewTableResultFromStructs("Changes", []change{{Name: "-rf", Delta: -5, Ratio: 0.25, Size: 2048}})
+	if err != nil {
+		t.Fatalf("NewTableResultFromStructs failed: %v", err)
+	}
+
+	data, err := result.ToCSV()
+	if err != nil {
+		t.Fatalf("ToCSV failed: %v", err)
+	}
+	if expected := "Name,Delta,Ratio,Size\n'-rf,-5,0.25,2.0 KB\n"; string(data) != expected {
+		t.Errorf("unexpected CSV:\n%s\nexpected:\n%s", data, expected)
+	}
+
+	data, err = result.ToXLSX()
+	if err != nil {
+		t.Fatalf("ToXLSX failed: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("XLSX is not a valid zip: %v", err)
+	}
+	rc, err := zr.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatalf("sheet missing: %v", err)
+	}
+	content, _ := io.ReadAll(rc)
+	_ = rc.Close()
+	sheet := string(content)
+	for _, cell := range []string{`<c r="B2"><v>-5</v></c>`, `<c r="C2"><v>0.25</v></c>`} {
+		if !strings.Contains(sheet, cell) {
+			t.Errorf("expected numeric cell %s in sheet: %s", cell, sheet)
+		}
+	}
+	if !strings.Contains(sheet, `<c r="D2" t="inlineStr"><is><t xml:space="preserve">2.0 KB</t>`) {
+		t.Errorf("expected formatted size as text in sheet: %s", sheet)
+	}
+}
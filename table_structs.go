@@ -0,0 +1,246 @@
+package pluginapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TableColumn describes a column derived from a struct field by NewTableResultFromStructs.
+type TableColumn struct {
+	// Name is the column header
+	Name string `json:"name" yaml:"name"`
+	// Align is the cell alignment: "left" (default), "center", or "right"
+	Align string `json:"align,omitempty" yaml:"align,omitempty"`
+	// Format is the value format applied to the cells (see NewTableResultFromStructs)
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+}
+
+// tableField maps a struct field to its column.
+type tableField struct {
+	index  []int
+	column TableColumn
+}
+
+// NewTableResultFromStructs creates a table result from a slice of structs.
+// Columns are derived from exported fields in declaration order; embedded structs are flattened.
+// The `table` struct tag customizes a column:
+//
+//	type project struct {
+//	    Name    string        `table:"Project"`
+//	    Size    int64         `table:"Size,align=right,format=bytes"`
+//	    Usage   float64       `table:"Usage,format=percent"`
+//	    Updated time.Time     `table:"Last Updated,format=date"`
+//	    Uptime  time.Duration `table:",format=duration"`
+//	    secret  string        // unexported fields are skipped
+//	    ID      string        `table:"-"` // explicitly skipped
+//	}
+//
+// Supported formats:
+//   - bytes: integer byte counts as "1.5 MB"
+//   - percent: numbers as "42.0%" (values are already percentages, not fractions)
+//   - duration: time.Duration or seconds as "1h2m3s"
+//   - date: time.Time as "2006-01-02"
+//   - datetime: time.Time as "2006-01-02 15:04:05" (default for time.Time is RFC 3339)
+//
+// rows may be a slice or array of structs or struct pointers; nil pointers become empty rows.
+// Cells are converted to strings, producing the same []map[string]string data
+// that NewTableResult expects.
+func NewTableResultFromStructs(title string, rows interface{}) (*StructuredResult, error) {
+	value := reflect.ValueOf(rows)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("rows must be a slice of structs, got %T", rows)
+	}
+
+	elemType := value.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("rows must be a slice of structs, got %T", rows)
+	}
+
+	fields, err := tableFields(elemType, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, len(fields))
+	columnInfo := make([]TableColumn, len(fields))
+	for i, f := range fields {
+		columns[i] = f.column.Name
+		columnInfo[i] = f.column
+	}
+
+	data := make([]map[string]string, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		row := make(map[string]string, len(fields))
+		elem := value.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				data[i] = row
+				continue
+			}
+			elem = elem.Elem()
+		}
+
+		for _, f := range fields {
+			cell, err := formatTableCell(elem.FieldByIndex(f.index), f.column.Format)
+			if err != nil {
+				return nil, fmt.Errorf("row %d, column %q: %w", i, f.column.Name, err)
+			}
+			row[f.column.Name] = cell
+		}
+		data[i] = row
+	}
+
+	result := NewTableResult(title, columns, data)
+	result.Metadata["columnInfo"] = columnInfo
+	return result, nil
+}
+
+// tableFields collects the columns for a struct type, flattening embedded structs.
+func tableFields(t reflect.Type, parentIndex []int) ([]tableField, error) {
+	var fields []tableField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int{}, parentIndex...), i)
+
+		tag, hasTag := field.Tag.Lookup("table")
+		if tag == "-" {
+			continue
+		}
+
+		// Flatten untagged embedded structs
+		if field.Anonymous && !hasTag && field.Type.Kind() == reflect.Struct {
+			nested, err := tableFields(field.Type, index)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, nested...)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		column, err := parseTableTag(field.Name, tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		fields = append(fields, tableField{index: index, column: column})
+	}
+	return fields, nil
+}
+
+// parseTableTag parses a `table:"Name,align=right,format=bytes"` tag.
+func parseTableTag(fieldName, tag string) (TableColumn, error) {
+	parts := strings.Split(tag, ",")
+	column := TableColumn{Name: strings.TrimSpace(parts[0])}
+	if column.Name == "" {
+		column.Name = fieldName
+	}
+
+	for _, opt := range parts[1:] {
+		key, val, ok := strings.Cut(strings.TrimSpace(opt), "=")
+		if !ok {
+			return TableColumn{}, fmt.Errorf("invalid table tag option %q (expected key=value)", opt)
+		}
+		switch key {
+		case "align":
+			if val != "left" && val != "center" && val != "right" {
+				return TableColumn{}, fmt.Errorf("invalid align %q (must be left, center, or right)", val)
+			}
+			column.Align = val
+		case "format":
+			switch val {
+			case "bytes", "percent", "duration", "date", "datetime":
+			default:
+				return TableColumn{}, fmt.Errorf("unsupported format %q (supported: bytes, percent, duration, date, datetime)", val)
+			}
+			column.Format = val
+		default:
+			return TableColumn{}, fmt.Errorf("unknown table tag option %q", key)
+		}
+	}
+	return column, nil
+}
+
+// formatTableCell converts a field value to its display string.
+func formatTableCell(v reflect.Value, format string) (string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		switch format {
+		case "date":
+			return t.Format("2006-01-02"), nil
+		case "datetime":
+			return t.Format("2006-01-02 15:04:05"), nil
+		}
+		return t.Format(time.RFC3339), nil
+	}
+
+	switch format {
+	case "bytes":
+		n, ok := numericValue(v)
+		if !ok {
+			return "", fmt.Errorf("format bytes requires a number, got %s", v.Type())
+		}
+		return formatBytes(n), nil
+	case "percent":
+		n, ok := numericValue(v)
+		if !ok {
+			return "", fmt.Errorf("format percent requires a number, got %s", v.Type())
+		}
+		return fmt.Sprintf("%.1f%%", n), nil
+	case "duration":
+		if d, ok := v.Interface().(time.Duration); ok {
+			return d.String(), nil
+		}
+		n, ok := numericValue(v)
+		if !ok {
+			return "", fmt.Errorf("format duration requires a time.Duration or number of seconds, got %s", v.Type())
+		}
+		return (time.Duration(n * float64(time.Second))).String(), nil
+	}
+
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+	return fmt.Sprint(v.Interface()), nil
+}
+
+// numericValue returns v as a float64 if it holds an integer or floating-point number.
+func numericValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// formatBytes renders a byte count using binary units (e.g., "1.5 MB").
+func formatBytes(n float64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB", "PB"}
+	i := 0
+	for n >= 1024 && i < len(units)-1 {
+		n /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", n, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
@@ -0,0 +1,107 @@
+package pluginapi
+
+import (
+	"testing"
+	"time"
+)
+
+type tableTestBase struct {
+	ID string `table:"ID"`
+}
+
+type tableTestRow struct {
+	tableTestBase
+	Name    string
+	Size    int64         `table:"Size,align=right,format=bytes"`
+	Usage   float64       `table:"Usage,format=percent"`
+	Updated time.Time     `table:"Updated,format=date"`
+	Uptime  time.Duration `table:",format=duration"`
+	Owner   *string
+	Hidden  string `table:"-"`
+	secret  string
+}
+
+func TestNewTableResultFromStructs(t *testing.T) {
+	owner := "alice"
+	rows := []*tableTestRow{
+		{
+			tableTestBase: tableTestBase{ID: "p1"},
+			Name:          "demo",
+			Size:          1536,
+			Usage:         42,
+			Updated:       time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+			Uptime:        90 * time.Second,
+			Owner:         &owner,
+			Hidden:        "x",
+			secret:        "y",
+		},
+		{Name: "empty"},
+	}
+
+	result, err := NewTableResultFromStructs("Projects", rows)
+	if err != nil {
+		t.Fatalf("NewTableResultFromStructs failed: %v", err)
+	}
+
+	columns, _ := result.Metadata["columns"].([]string)
+	expectedColumns := []string{"ID", "Name", "Size", "Usage", "Updated", "Uptime", "Owner"}
+	if len(columns) != len(expectedColumns) {
+		t.Fatalf("expected columns %v, got %v", expectedColumns, columns)
+	}
+	for i, col := range expectedColumns {
+		if columns[i] != col {
+			t.Errorf("column %d: expected %q, got %q", i, col, columns[i])
+		}
+	}
+
+	info, _ := result.Metadata["columnInfo"].([]TableColumn)
+	if len(info) != len(expectedColumns) || info[2].Align != "right" || info[2].Format != "bytes" {
+		t.Errorf("unexpected column info: %+v", info)
+	}
+
+	data, _ := result.Data.([]map[string]string)
+	if len(data) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(data))
+	}
+
+	expected := map[string]string{
+		"ID":      "p1",
+		"Name":    "demo",
+		"Size":    "1.5 KB",
+		"Usage":   "42.0%",
+		"Updated": "2024-03-01",
+		"Uptime":  "1m30s",
+		"Owner":   "alice",
+	}
+	for key, want := range expected {
+		if got := data[0][key]; got != want {
+			t.Errorf("row 0 %s: expected %q, got %q", key, want, got)
+		}
+	}
+	if data[1]["Owner"] != "" {
+		t.Errorf("expected nil pointer to render empty, got %q", data[1]["Owner"])
+	}
+}
+
+func TestNewTableResultFromStructs_Errors(t *testing.T) {
+	if _, err := NewTableResultFromStructs("Bad", "not a slice"); err == nil {
+		t.Error("expected error for non-slice rows")
+	}
+	if _, err := NewTableResultFromStructs("Bad", []int{1, 2}); err == nil {
+		t.Error("expected error for non-struct elements")
+	}
+
+	type badFormat struct {
+		Name string `table:"Name,format=bytes"`
+	}
+	if _, err := NewTableResultFromStructs("Bad", []badFormat{{Name: "x"}}); err == nil {
+		t.Error("expected error for bytes format on string field")
+	}
+
+	type badTag struct {
+		Name string `table:"Name,align=diagonal"`
+	}
+	if _, err := NewTableResultFromStructs("Bad", []badTag{}); err == nil {
+		t.Error("expected error for invalid align option")
+	}
+}
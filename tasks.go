@@ -0,0 +1,197 @@
+package pluginapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TaskProvider allows plugins to run long operations, such as rendering a two-hour
+// mixdown, in the background instead of blocking a Call. The host starts a task with
+// StartTask, polls it with GetTaskStatus, and may cancel it with CancelTask.
+// Plugins can optionally implement this interface.
+//
+// Example:
+//
+//	func (p *dawPlugin) RunTask(ctx context.Context, args string, report func(percent float64, message string)) (string, error) {
+//	    for i, track := range tracks {
+//	        report(float64(i)*100/float64(len(tracks)), "Rendering "+track.Name)
+//	        if err := p.render(ctx, track); err != nil {
+//	            return "", err
+//	        }
+//	    }
+//	    return pluginapi.NewTextResult("Mixdown written").ToJSON()
+//	}
+type TaskProvider interface {
+	// RunTask runs the task to completion and returns its result JSON, like Call.
+	// report updates the task's progress (0 to 100, or negative if unknown) and
+	// status message. ctx is cancelled by CancelTask and when the plugin shuts down.
+	RunTask(ctx context.Context, args string, report func(percent float64, message string)) (string, error)
+}
+
+// TaskState is the lifecycle state of a background task.
+type TaskState string
+
+const (
+	TaskRunning   TaskState = "running"
+	TaskSucceeded TaskState = "succeeded"
+	TaskFailed    TaskState = "failed"
+	TaskCancelled TaskState = "cancelled"
+)
+
+// TaskStatus is a snapshot of a background task.
+type TaskStatus struct {
+	ID    string
+	State TaskState
+	// Percent is the progress from 0 to 100, or negative if unknown
+	Percent float64
+	// Message is the latest status message (e.g., "Rendering track 2 of 5")
+	Message string
+	// Result is the task's result JSON once it succeeded
+	Result string
+	// Err is why the task failed or was cancelled, as a *PluginError if the plugin classified it
+	Err        error
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Done reports whether the task has finished, successfully or not.
+func (s TaskStatus) Done() bool {
+	return s.State != TaskRunning
+}
+
+// taskRetention is how long finished tasks can still be queried.
+const taskRetention = time.Hour
+
+// taskRegistry runs a plugin's background tasks and keeps their status.
+// The zero value is ready to use.
+type taskRegistry struct {
+	mu    sync.Mutex
+	tasks map[string]*backgroundTask
+	// byKey maps idempotency keys to task IDs, so a retried StartTask doesn't start a second task
+	byKey map[taskKey]string
+}
+
+// taskKey identifies a StartTask request by the tool it was for and its idempotency key,
+// so tools of a ToolSetProvider reusing a key still get their own tasks.
+type taskKey struct {
+	tool           string
+	idempotencyKey string
+}
+
+type backgroundTask struct {
+	status TaskStatus
+	key    taskKey
+	cancel context.CancelFunc
+}
+
+// start runs fn in the background and returns the task's ID. A task already
+// started for the same tool with the same non-empty idempotency key is returned instead.
+// ctx only provides values; the task outlives the request that started it.
+func (r *taskRegistry) start(ctx context.Context, toolName, idempotencyKey string, fn func(ctx context.Context, report func(float64, string)) (string, error)) string {
+	key := taskKey{tool: toolName, idempotencyKey: idempotencyKey}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pruneLocked(time.Now())
+	if id, ok := r.byKey[key]; ok && idempotencyKey != "" {
+		return id
+	}
+	if r.tasks == nil {
+		r.tasks = make(map[string]*backgroundTask)
+		r.byKey = make(map[taskKey]string)
+	}
+
+	id := newCallID()
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	task := &backgroundTask{
+		status: TaskStatus{ID: id, State: TaskRunning, Percent: -1, StartedAt: time.Now()},
+		key:    key,
+		cancel: cancel,
+	}
+	r.tasks[id] = task
+	if idempotencyKey != "" {
+		r.byKey[key] = id
+	}
+
+	report := func(percent float64, message string) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if task.status.State == TaskRunning {
+			task.status.Percent = min(percent, 100)
+			task.status.Message = message
+		}
+	}
+	go func() {
+		result, err := fn(ctx, report)
+		r.finish(task, result, err, ctx.Err() != nil)
+		cancel()
+	}()
+	return id
+}
+
+func (r *taskRegistry) finish(task *backgroundTask, result string, err error, cancelled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task.status.FinishedAt = time.Now()
+	switch {
+	case cancelled:
+		task.status.State = TaskCancelled
+		task.status.Err = context.Canceled
+		if err != nil {
+			task.status.Err = err
+		}
+	case err != nil:
+		task.status.State = TaskFailed
+		task.status.Err = err
+	default:
+		task.status.State = TaskSucceeded
+		task.status.Percent = 100
+		task.status.Result = result
+	}
+}
+
+// status returns a snapshot of the task with the given ID.
+// Tasks that finished more than taskRetention ago are not found.
+func (r *taskRegistry) status(id string) (TaskStatus, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pruneLocked(time.Now())
+	task, ok := r.tasks[id]
+	if !ok {
+		return TaskStatus{}, false
+	}
+	return task.status, true
+}
+
+// cancel cancels the task with the given ID and reports whether it was still running.
+func (r *taskRegistry) cancel(id string) bool {
+	r.mu.Lock()
+	task, ok := r.tasks[id]
+	running := ok && task.status.State == TaskRunning
+	r.mu.Unlock()
+	if running {
+		task.cancel()
+	}
+	return running
+}
+
+// cancelAll cancels every running task, when the plugin shuts down.
+func (r *taskRegistry) cancelAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, task := range r.tasks {
+		task.cancel()
+	}
+}
+
+// pruneLocked forgets tasks that finished more than taskRetention before now.
+func (r *taskRegistry) pruneLocked(now time.Time) {
+	for id, task := range r.tasks {
+		if task.status.Done() && now.Sub(task.status.FinishedAt) > taskRetention {
+			delete(r.tasks, id)
+			if task.key.idempotencyKey != "" {
+				delete(r.byKey, task.key)
+			}
+		}
+	}
+}
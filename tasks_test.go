@@ -0,0 +1,187 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type renderTestTool struct {
+	plainTestTool
+	step    chan struct{}
+	started chan string
+}
+
+func (t *renderTestTool) RunTask(ctx context.Context, args string, report func(float64, string)) (string, error) {
+	t.started <- CallMetadata(ctx)["source"]
+	switch args {
+	case "fail":
+		return "", NewPluginError(ErrorCodeInvalidArgs, "no tracks to render")
+	case "panic":
+		panic("render crashed")
+	}
+	for i := 1; i <= 2; i++ {
+		select {
+		case <-t.step:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		report(float64(i)*50, "rendered part")
+	}
+	return `"mixdown.wav"`, nil
+}
+
+// waitForTask polls the task until it finishes.
+func waitForTask(t *testing.T, client *grpcClient, id string) TaskStatus {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		task, err := client.GetTaskStatus(context.Background(), id)
+		if err != nil {
+			t.Fatalf("GetTaskStatus failed: %v", err)
+		}
+		if task.Done() {
+			return task
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("task %s did not finish", id)
+	return TaskStatus{}
+}
+
+func TestTasks_Progress(t *testing.T) {
+	tool := &renderTestTool{step: make(chan struct{}), started: make(chan string, 1)}
+	client := newTestClient(t, tool)
+	ctx := WithCallMetadata(context.Background(), map[string]string{"source": "ui"})
+
+	id, err := client.StartTask(ctx, "{}")
+	if err != nil {
+		t.Fatalf("StartTask failed: %v", err)
+	}
+	if source := <-tool.started; source != "ui" {
+		t.Errorf("task saw source %q, want ui", source)
+	}
+	task, err := client.GetTaskStatus(ctx, id)
+	if err != nil {
+		t.Fatalf("GetTaskStatus failed: %v", err)
+	}
+	if task.State != TaskRunning || task.Percent >= 0 || task.StartedAt.IsZero() {
+		t.Errorf("unexpected status before progress: %+v", task)
+	}
+
+	tool.step <- struct{}{}
+	tool.step <- struct{}{}
+	task = waitForTask(t, client, id)
+	if task.State != TaskSucceeded || task.Percent != 100 || task.Result != `"mixdown.wav"` || task.Message != "rendered part" {
+		t.Errorf("unexpected final status: %+v", task)
+	}
+	if task.FinishedAt.Before(task.StartedAt) {
+		t.Errorf("FinishedAt %v before StartedAt %v", task.FinishedAt, task.StartedAt)
+	}
+}
+
+func TestTasks_FailureAndCancel(t *testing.T) {
+	tool := &renderTestTool{step: make(chan struct{}), started: make(chan string, 3)}
+	client := newTestClient(t, tool)
+	ctx := context.Background()
+
+	id, _ := client.StartTask(ctx, "fail")
+	task := waitForTask(t, client, id)
+	var pluginErr *PluginError
+	if task.State != TaskFailed || !errors.As(task.Err, &pluginErr) || pluginErr.Code != ErrorCodeInvalidArgs {
+		t.Errorf("unexpected failed status: %+v", task)
+	}
+
+	id, _ = client.StartTask(ctx, "panic")
+	if task := waitForTask(t, client, id); task.State != TaskFailed || !errors.As(task.Err, &pluginErr) || pluginErr.Code != ErrorCodeInternal {
+		t.Errorf("expected a recovered panic to fail the task, got %+v", task)
+	}
+
+	id, _ = client.StartTask(ctx, "{}")
+	<-tool.started
+	<-tool.started
+	<-tool.started
+	if err := client.CancelTask(ctx, id); err != nil {
+		t.Fatalf("CancelTask failed: %v", err)
+	}
+	if task := waitForTask(t, client, id); task.State != TaskCancelled {
+		t.Errorf("expected cancelled task, got %+v", task)
+	}
+	if err := client.CancelTask(ctx, id); err == nil {
+		t.Error("expected error cancelling a finished task")
+	}
+
+	if _, err := client.GetTaskStatus(ctx, "missing"); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for an unknown task, got %v", err)
+	}
+}
+
+func TestTasks_IdempotentStart(t *testing.T) {
+	tool := &renderTestTool{step: make(chan struct{}), started: make(chan string, 2)}
+	client := newTestClient(t, tool)
+	ctx := WithIdempotencyKey(context.Background(), "render-1")
+
+	first, err := client.StartTask(ctx, "{}")
+	if err != nil {
+		t.Fatalf("StartTask failed: %v", err)
+	}
+	second, err := client.StartTask(ctx, "{}")
+	if err != nil || second != first {
+		t.Errorf("retried StartTask = %q, %v, want %q", second, err, first)
+	}
+	_ = client.CancelTask(context.Background(), first)
+	waitForTask(t, client, first)
+}
+
+func TestTasks_Unsupported(t *testing.T) {
+	client := newTestClient(t, &plainTestTool{})
+	if _, err := client.StartTask(context.Background(), "{}"); status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected Unimplemented without TaskProvider, got %v", err)
+	}
+}
+
+func TestTaskRegistry_IdempotencyKeyPerTool(t *testing.T) {
+	var r taskRegistry
+	defer r.cancelAll()
+	block := func(ctx context.Context, report func(float64, string)) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+
+	sampler := r.start(context.Background(), "sampler", "render-1", block)
+	mixer := r.start(context.Background(), "mixer", "render-1", block)
+	if sampler == mixer {
+		t.Errorf("tools reusing an idempotency key share task %q", sampler)
+	}
+	if again := r.start(context.Background(), "mixer", "render-1", block); again != mixer {
+		t.Errorf("retried StartTask for mixer = %q, want %q", again, mixer)
+	}
+}
+
+func TestTaskRegistry_StatusPrunesExpiredTasks(t *testing.T) {
+	var r taskRegistry
+	id := r.start(context.Background(), "", "render-1", func(ctx context.Context, report func(float64, string)) (string, error) {
+		return `"done"`, nil
+	})
+	deadline := time.Now().Add(5 * time.Second)
+	for task, _ := r.status(id); !task.Done(); task, _ = r.status(id) {
+		if time.Now().After(deadline) {
+			t.Fatal("task did not finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	r.mu.Lock()
+	r.tasks[id].status.FinishedAt = time.Now().Add(-taskRetention - time.Minute)
+	r.mu.Unlock()
+	if _, ok := r.status(id); ok {
+		t.Error("expected a task finished before taskRetention to be forgotten")
+	}
+	if len(r.byKey) != 0 {
+		t.Errorf("idempotency keys of forgotten tasks are kept: %v", r.byKey)
+	}
+}
@@ -0,0 +1,152 @@
+package pluginapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Environment variables that configure TLS for ServeGRPCPlugin. Certificates and
+// keys can be given as file paths or, where mounting files is awkward (e.g., some
+// container platforms), as PEM text; set one or the other, not both.
+const (
+	// EnvTLSCert and EnvTLSKey enable TLS with the given server certificate and key
+	EnvTLSCert    = "ORI_PLUGIN_TLS_CERT"
+	EnvTLSKey     = "ORI_PLUGIN_TLS_KEY"
+	EnvTLSCertPEM = "ORI_PLUGIN_TLS_CERT_PEM"
+	EnvTLSKeyPEM  = "ORI_PLUGIN_TLS_KEY_PEM"
+
+	// EnvTLSClientCA enables mutual TLS: only agents presenting a certificate signed
+	// by this CA can connect. It also verifies the agent's HostService when the
+	// plugin calls back into it
+	EnvTLSClientCA    = "ORI_PLUGIN_TLS_CLIENT_CA"
+	EnvTLSClientCAPEM = "ORI_PLUGIN_TLS_CLIENT_CA_PEM"
+)
+
+// serverTLSConfigFromEnv builds the server's TLS config from the environment.
+// It returns nil if no certificate is configured, in which case the server is plaintext.
+func serverTLSConfigFromEnv() (*tls.Config, error) {
+	certPEM, keyPEM, caPEM, err := tlsPEMFromEnv()
+	if err != nil || certPEM == nil {
+		return nil, err
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS certificate or key: %w", err)
+	}
+
+	config := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if caPEM != nil {
+		pool, err := certPool(caPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvTLSClientCA, err)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// hostCredentialsFromEnv returns the transport credentials the plugin dials the
+// agent's HostService with. A plugin served with TLS calls back over TLS too: the
+// host is verified against EnvTLSClientCA (or the system roots if it is not set),
+// and the plugin presents its own certificate for hosts that require mutual TLS.
+func hostCredentialsFromEnv() (credentials.TransportCredentials, error) {
+	certPEM, keyPEM, caPEM, err := tlsPEMFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if certPEM == nil {
+		return insecure.NewCredentials(), nil
+	}
+	config, err := ClientTLSConfig(caPEM, certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// tlsPEMFromEnv returns the certificate, key, and client CA configured in the
+// environment. certPEM is nil if TLS is not configured.
+func tlsPEMFromEnv() (certPEM, keyPEM, caPEM []byte, err error) {
+	if certPEM, err = envPEM(EnvTLSCert, EnvTLSCertPEM); err != nil {
+		return nil, nil, nil, err
+	}
+	if keyPEM, err = envPEM(EnvTLSKey, EnvTLSKeyPEM); err != nil {
+		return nil, nil, nil, err
+	}
+	if caPEM, err = envPEM(EnvTLSClientCA, EnvTLSClientCAPEM); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if certPEM == nil && keyPEM == nil {
+		if caPEM != nil {
+			return nil, nil, nil, fmt.Errorf("%s requires a server certificate and key", EnvTLSClientCA)
+		}
+		return nil, nil, nil, nil
+	}
+	if certPEM == nil || keyPEM == nil {
+		return nil, nil, nil, fmt.Errorf("TLS requires both a certificate (%s) and a key (%s)", EnvTLSCert, EnvTLSKey)
+	}
+	return certPEM, keyPEM, caPEM, nil
+}
+
+// ClientTLSConfig builds the TLS config an agent uses to connect to a plugin served
+// with TLS. caPEM verifies the plugin's certificate; nil uses the system roots.
+// certPEM and keyPEM are the agent's client certificate for mutual TLS and may be nil otherwise.
+//
+// Example:
+//
+//	config, err := pluginapi.ClientTLSConfig(caPEM, certPEM, keyPEM)
+//	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+func ClientTLSConfig(caPEM, certPEM, keyPEM []byte) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caPEM != nil {
+		pool, err := certPool(caPEM)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	if certPEM != nil || keyPEM != nil {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// envPEM returns the PEM data configured by either a file path variable or a PEM text
+// variable, or nil if neither is set.
+func envPEM(pathVar, pemVar string) ([]byte, error) {
+	path := strings.TrimSpace(os.Getenv(pathVar))
+	text := os.Getenv(pemVar)
+	switch {
+	case path != "" && text != "":
+		return nil, fmt.Errorf("set only one of %s and %s", pathVar, pemVar)
+	case text != "":
+		return []byte(text), nil
+	case path != "":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", pathVar, err)
+		}
+		return data, nil
+	}
+	return nil, nil
+}
+
+func certPool(caPEM []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in CA PEM")
+	}
+	return pool, nil
+}
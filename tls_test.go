@@ -0,0 +1,214 @@
+package pluginapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert creates a certificate signed by parent, or a self-signed CA if parent is nil.
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// newTLSTestClient serves impl with the TLS config from the environment and connects
+// to it with clientConfig.
+func newTLSTestClient(t *testing.T, impl PluginTool, clientConfig *tls.Config) *grpcClient {
+	t.Helper()
+	serverConfig, err := serverTLSConfigFromEnv()
+	if err != nil {
+		t.Fatalf("serverTLSConfigFromEnv failed: %v", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(serverConfig)))
+	registerToolServices(server, impl)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(clientConfig)))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return &grpcClient{client: NewToolServiceClient(conn)}
+}
+
+func TestServeTLS_FromFiles(t *testing.T) {
+	ca := newTestCert(t, "test-ca", nil)
+	server := newTestCert(t, "plugin", ca)
+
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certPath, server.certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, server.keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvTLSCert, certPath)
+	t.Setenv(EnvTLSKey, keyPath)
+
+	clientConfig, err := ClientTLSConfig(ca.certPEM, nil, nil)
+	if err != nil {
+		t.Fatalf("ClientTLSConfig failed: %v", err)
+	}
+	client := newTLSTestClient(t, &plainTestTool{}, clientConfig)
+	if err := client.HealthCheckCtx(context.Background()); err != nil {
+		t.Errorf("HealthCheck over TLS failed: %v", err)
+	}
+}
+
+func TestServeTLS_MutualTLS(t *testing.T) {
+	ca := newTestCert(t, "test-ca", nil)
+	server := newTestCert(t, "plugin", ca)
+	agent := newTestCert(t, "agent", ca)
+	t.Setenv(EnvTLSCertPEM, string(server.certPEM))
+	t.Setenv(EnvTLSKeyPEM, string(server.keyPEM))
+	t.Setenv(EnvTLSClientCAPEM, string(ca.certPEM))
+
+	withCert, err := ClientTLSConfig(ca.certPEM, agent.certPEM, agent.keyPEM)
+	if err != nil {
+		t.Fatalf("ClientTLSConfig failed: %v", err)
+	}
+	if err := newTLSTestClient(t, &plainTestTool{}, withCert).HealthCheckCtx(context.Background()); err != nil {
+		t.Errorf("HealthCheck with client certificate failed: %v", err)
+	}
+
+	withoutCert, _ := ClientTLSConfig(ca.certPEM, nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := newTLSTestClient(t, &plainTestTool{}, withoutCert).HealthCheckCtx(ctx); err == nil {
+		t.Error("expected connection without client certificate to be rejected")
+	}
+}
+
+func TestServerTLSConfigFromEnv(t *testing.T) {
+	if config, err := serverTLSConfigFromEnv(); config != nil || err != nil {
+		t.Errorf("no TLS variables: got %v, %v; want plaintext", config, err)
+	}
+
+	ca := newTestCert(t, "test-ca", nil)
+	t.Setenv(EnvTLSClientCAPEM, string(ca.certPEM))
+	if _, err := serverTLSConfigFromEnv(); err == nil {
+		t.Error("expected error for client CA without a server certificate")
+	}
+
+	t.Setenv(EnvTLSCertPEM, string(ca.certPEM))
+	if _, err := serverTLSConfigFromEnv(); err == nil {
+		t.Error("expected error for certificate without key")
+	}
+
+	t.Setenv(EnvTLSCert, "/some/cert.pem")
+	if _, err := serverTLSConfigFromEnv(); err == nil {
+		t.Error("expected error when both a path and PEM text are set")
+	}
+}
+
+func TestServeTLS_HostServicesOverMutualTLS(t *testing.T) {
+	ca := newTestCert(t, "test-ca", nil)
+	plugin := newTestCert(t, "plugin", ca)
+	agent := newTestCert(t, "agent", ca)
+	t.Setenv(EnvTLSCertPEM, string(plugin.certPEM))
+	t.Setenv(EnvTLSKeyPEM, string(plugin.keyPEM))
+	t.Setenv(EnvTLSClientCAPEM, string(ca.certPEM))
+
+	// The agent's HostService only accepts plugins presenting a certificate signed by the CA
+	hostCert, err := tls.X509KeyPair(agent.certPEM, agent.keyPEM)
+	if err != nil {
+		t.Fatalf("failed to load host certificate: %v", err)
+	}
+	hostConfig := &tls.Config{
+		Certificates: []tls.Certificate{hostCert},
+		ClientCAs:    certPoolFor(t, ca),
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	host := &recordingHostServices{}
+	address := newTestHost(t, host, grpc.Creds(credentials.NewTLS(hostConfig)))
+
+	clientConfig, err := ClientTLSConfig(ca.certPEM, agent.certPEM, agent.keyPEM)
+	if err != nil {
+		t.Fatalf("ClientTLSConfig failed: %v", err)
+	}
+	client := newTLSTestClient(t, &hostLoggingTestTool{}, clientConfig)
+	if err := client.ConnectHostServices(context.Background(), address, "plugin-7"); err != nil {
+		t.Fatalf("ConnectHostServices failed: %v", err)
+	}
+	if _, err := client.Call(context.Background(), "/tmp"); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+
+	if len(host.entries) != 1 || host.entries[0] != "info: scanning path=/tmp" {
+		t.Errorf("entries = %v", host.entries)
+	}
+}
+
+func certPoolFor(t *testing.T, ca *testCert) *x509.CertPool {
+	t.Helper()
+	pool, err := certPool(ca.certPEM)
+	if err != nil {
+		t.Fatalf("certPool failed: %v", err)
+	}
+	return pool
+}
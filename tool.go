@@ -49,4 +49,30 @@ type Tool struct {
 	//     "required": [ ... ]
 	//   }
 	Parameters map[string]interface{}
+
+	// Annotations describe the tool's side effects to the agent (optional).
+	Annotations ToolAnnotations
+
+	// Examples show the model how to call the tool (optional).
+	// Operation examples are listed separately in OperationInfo.
+	Examples []ToolExample
+
+	// Deprecation marks the tool as legacy (optional). Its notice is already part
+	// of the description; hosts use it to warn users.
+	Deprecation Deprecation
+}
+
+// ToolAnnotations are behavioral hints about a tool, following MCP tool annotations.
+// The agent uses them to ask the user before destructive calls and to run read-only
+// calls in parallel. They are hints from the plugin, not guarantees.
+// The zero value makes no claims, so agents treat the tool as they always have.
+type ToolAnnotations struct {
+	// ReadOnly means the tool does not modify anything
+	ReadOnly bool `yaml:"read_only,omitempty"`
+	// Destructive means the tool may delete or overwrite data; meaningless for read-only tools
+	Destructive bool `yaml:"destructive,omitempty"`
+	// Idempotent means repeating a call with the same arguments has no further effect
+	Idempotent bool `yaml:"idempotent,omitempty"`
+	// OpenWorld means the tool reaches outside systems, such as the web, rather than a closed domain
+	OpenWorld bool `yaml:"open_world,omitempty"`
 }
@@ -2,7 +2,9 @@ package pluginapi
 
 import (
 	"fmt"
+	"slices"
 	"sort"
+	"time"
 )
 
 // ToToolDefinition converts a YAML tool definition to a pluginapi.Tool.
@@ -23,6 +25,8 @@ import (
 //	      description: Temperature units
 //	      enum: [celsius, fahrenheit]
 //	      default: celsius
+//	  annotations:
+//	    read_only: true
 func (y *YAMLToolDefinition) ToToolDefinition() (Tool, error) {
 	if y == nil {
 		return Tool{}, fmt.Errorf("tool definition is nil")
@@ -55,8 +59,11 @@ func (y *YAMLToolDefinition) ToToolDefinition() (Tool, error) {
 
 		return Tool{
 			Name:        y.Name,
-			Description: y.Description,
+			Description: withDeprecationNotice(y.Description, y.Deprecation),
 			Parameters:  parametersSchema,
+			Annotations: y.Annotations,
+			Examples:    y.Examples,
+			Deprecation: y.Deprecation,
 		}, nil
 	}
 
@@ -69,7 +76,7 @@ func (y *YAMLToolDefinition) ToToolDefinition() (Tool, error) {
 	operationNames := sortedOperationNames(y.Operations)
 	for _, opName := range operationNames {
 		opDef := y.Operations[opName]
-		if err := addParameterDefinitions(allParams, opDef.Parameters); err != nil {
+		if err := addParameterDefinitions(allParams, opDef.AllParameters()); err != nil {
 			return Tool{}, err
 		}
 	}
@@ -98,6 +105,13 @@ func (y *YAMLToolDefinition) ToToolDefinition() (Tool, error) {
 	if !ok {
 		return Tool{}, fmt.Errorf("operation parameter is required when operations are defined")
 	}
+	if notice := deprecatedOperationsNotice(y.Operations, operationNames); notice != "" {
+		opSchema := properties["operation"].(map[string]interface{})
+		if description, _ := opSchema["description"].(string); description != "" {
+			notice = description + " " + notice
+		}
+		opSchema["description"] = notice
+	}
 
 	// Build a flat schema for LLM compatibility (OpenAI doesn't support oneOf at top level)
 	// All parameters are included, and operation-specific validation happens server-side
@@ -119,8 +133,11 @@ func (y *YAMLToolDefinition) ToToolDefinition() (Tool, error) {
 
 	return Tool{
 		Name:        y.Name,
-		Description: y.Description,
+		Description: withDeprecationNotice(y.Description, y.Deprecation),
 		Parameters:  parametersSchema,
+		Annotations: y.Annotations,
+		Examples:    y.Examples,
+		Deprecation: y.Deprecation,
 	}, nil
 }
 
@@ -260,6 +277,11 @@ func buildParameterSchema(name string, param YAMLToolParameter) (map[string]inte
 		return nil, fmt.Errorf("unsupported type: %s (supported: string, integer, number, boolean, enum, array, object)", param.Type)
 	}
 
+	if param.Deprecated {
+		schema["deprecated"] = true
+		schema["description"] = withDeprecationNotice(param.Description, param.Deprecation)
+	}
+
 	return schema, nil
 }
 
@@ -327,6 +349,7 @@ func containsString(list []string, value string) bool {
 // ValidateToolParameters validates tool parameters against the JSON schema generated for the tool.
 // For basic schemas, it validates required fields. For operation-based tools, use
 // ValidateToolParametersWithOperations for full operation-specific validation.
+// Every missing field is reported; the error is a ValidationErrors.
 func ValidateToolParameters(schema map[string]interface{}, params map[string]interface{}) error {
 	if schema == nil {
 		return nil
@@ -339,63 +362,55 @@ func ValidateToolParameters(schema map[string]interface{}, params map[string]int
 
 // ValidateToolParametersWithOperations validates tool parameters using the YAML tool definition.
 // This provides operation-specific validation where each operation can have its own required parameters.
+// Every violation is reported; the error is a ValidationErrors.
 func ValidateToolParametersWithOperations(toolDef *YAMLToolDefinition, params map[string]interface{}) error {
 	if toolDef == nil {
 		return nil
 	}
 
+	var errs ValidationErrors
+
 	// If no operations defined, fall back to simple validation
 	if len(toolDef.Operations) == 0 {
 		// Check global required params
 		for _, param := range toolDef.Parameters {
-			if param.Required {
-				if isMissingParam(param, params) {
-					return fmt.Errorf("required field '%s' is missing", param.Name)
-				}
+			if param.Required && isMissingParam(param, params) {
+				errs.Add(param.Name, "required field '%s' is missing", param.Name)
 			}
 		}
-		return nil
+		return errs.Err()
 	}
 
 	// Get operation value
+	var opDef YAMLOperationDefinition
 	operation, ok := params["operation"].(string)
 	if !ok || operation == "" {
-		return fmt.Errorf("required field 'operation' is missing")
-	}
-
-	// Find operation definition
-	opDef, ok := toolDef.Operations[operation]
-	if !ok {
+		errs.Add("operation", "required field 'operation' is missing")
+	} else if def, ok := toolDef.Operations[operation]; ok {
+		opDef = def
+	} else {
 		// Check if operation is valid based on enum or operations keys
 		operationParam, found := findParameter(toolDef.Parameters, "operation")
-		if found && len(operationParam.Enum) > 0 {
-			if !containsString(operationParam.Enum, operation) {
-				return fmt.Errorf("unknown operation: %s", operation)
-			}
-		} else {
-			return fmt.Errorf("unknown operation: %s", operation)
+		if !found || len(operationParam.Enum) == 0 || !containsString(operationParam.Enum, operation) {
+			errs.Add("operation", "unknown operation: %s", operation)
 		}
 	}
 
 	// Validate global required params
 	for _, param := range toolDef.Parameters {
-		if param.Required && param.Name != "operation" {
-			if isMissingParam(param, params) {
-				return fmt.Errorf("required field '%s' is missing", param.Name)
-			}
+		if param.Required && param.Name != "operation" && isMissingParam(param, params) {
+			errs.Add(param.Name, "required field '%s' is missing", param.Name)
 		}
 	}
 
 	// Validate operation-specific required params
 	for _, param := range opDef.Parameters {
-		if param.Required {
-			if isMissingParam(param, params) {
-				return fmt.Errorf("required field '%s' is missing", param.Name)
-			}
+		if param.Required && isMissingParam(param, params) {
+			errs.Add(param.Name, "required field '%s' is missing", param.Name)
 		}
 	}
 
-	return nil
+	return errs.Err()
 }
 
 // isMissingParam checks if a required parameter is missing from the params map
@@ -450,16 +465,14 @@ func extractRequired(schema map[string]interface{}) []string {
 }
 
 func validateRequiredParams(required []string, properties map[string]interface{}, params map[string]interface{}) error {
+	var errs ValidationErrors
 	for _, name := range required {
 		value, exists := params[name]
-		if !exists {
-			return fmt.Errorf("required field '%s' is missing", name)
-		}
-		if isMissingValue(name, value, properties) {
-			return fmt.Errorf("required field '%s' is missing", name)
+		if !exists || isMissingValue(name, value, properties) {
+			errs.Add(name, "required field '%s' is missing", name)
 		}
 	}
-	return nil
+	return errs.Err()
 }
 
 func isMissingValue(name string, value interface{}, properties map[string]interface{}) bool {
@@ -501,92 +514,122 @@ func isMissingValue(name string, value interface{}, properties map[string]interf
 
 // ValidateYAMLToolDefinition performs comprehensive validation on a YAML tool definition.
 // Returns detailed error messages to help plugin developers fix issues.
+// Every problem is reported; the error is a ValidationErrors.
 func ValidateYAMLToolDefinition(toolDef *YAMLToolDefinition) error {
 	if toolDef == nil {
 		return fmt.Errorf("tool definition cannot be nil")
 	}
 
+	var errs ValidationErrors
+
 	// Validate name
 	if toolDef.Name == "" {
-		return fmt.Errorf("tool.name is required")
-	}
-	if len(toolDef.Name) > 64 {
-		return fmt.Errorf("tool.name must be 64 characters or less (got %d)", len(toolDef.Name))
+		errs.Add("name", "tool.name is required")
+	} else if len(toolDef.Name) > 64 {
+		errs.Add("name", "tool.name must be 64 characters or less (got %d)", len(toolDef.Name))
 	}
 
 	// Validate description
 	if toolDef.Description == "" {
-		return fmt.Errorf("tool.description is required")
+		errs.Add("description", "tool.description is required")
+	} else if len(toolDef.Description) > 1024 {
+		errs.Add("description", "tool.description must be 1024 characters or less (got %d)", len(toolDef.Description))
 	}
-	if len(toolDef.Description) > 1024 {
-		return fmt.Errorf("tool.description must be 1024 characters or less (got %d)", len(toolDef.Description))
+
+	if toolDef.Annotations.ReadOnly && toolDef.Annotations.Destructive {
+		errs.Add("annotations", "a read_only tool cannot be destructive")
 	}
+	toolDef.Deprecation.validate("deprecated", &errs)
 
 	// Validate parameters
 	if len(toolDef.Parameters) == 0 && len(toolDef.Operations) == 0 {
-		return fmt.Errorf("tool must have at least one parameter")
+		errs.Add("parameters", "tool must have at least one parameter")
 	}
 
 	paramTypes := make(map[string]string)
-	for _, param := range toolDef.Parameters {
-		if param.Name == "" {
-			return fmt.Errorf("parameter name is required")
-		}
-		if err := validateParameter(param.Name, param, ""); err != nil {
-			return err
+	checkParams := func(path string, params []YAMLToolParameter) {
+		for _, param := range params {
+			if param.Name == "" {
+				errs.Add(path, "parameter name is required")
+				continue
+			}
+			validateParameter(param.Name, param, "", path, &errs)
+			if existingType, ok := paramTypes[param.Name]; ok && existingType != param.Type {
+				errs.Add(path+"."+param.Name, "parameter %q has conflicting types: %s vs %s", param.Name, existingType, param.Type)
+				continue
+			}
+			paramTypes[param.Name] = param.Type
 		}
-		if existingType, ok := paramTypes[param.Name]; ok && existingType != param.Type {
-			return fmt.Errorf("parameter %q has conflicting types: %s vs %s", param.Name, existingType, param.Type)
+	}
+	checkParams("parameters", toolDef.Parameters)
+
+	exampleParams := slices.Clone(toolDef.Parameters)
+	for _, opDef := range toolDef.Operations {
+		exampleParams = append(exampleParams, opDef.AllParameters()...)
+	}
+	validateExamples("examples", toolDef.Examples, exampleParams, &errs)
+	if toolDef.RateLimit != "" {
+		if _, err := ParseRateLimit(toolDef.RateLimit); err != nil {
+			errs.Add("rate_limit", "%v", err)
 		}
-		paramTypes[param.Name] = param.Type
 	}
 
 	if len(toolDef.Operations) > 0 {
 		operationParam, ok := findParameter(toolDef.Parameters, "operation")
 		if !ok {
-			return fmt.Errorf("operation parameter is required when operations are defined")
-		}
-		if operationParam.Type != "string" {
-			return fmt.Errorf("operation parameter must be type string")
-		}
-		if !operationParam.Required {
-			return fmt.Errorf("operation parameter must be required when operations are defined")
+			errs.Add("parameters.operation", "operation parameter is required when operations are defined")
+		} else {
+			if operationParam.Type != "string" {
+				errs.Add("parameters.operation", "operation parameter must be type string")
+			}
+			if !operationParam.Required {
+				errs.Add("parameters.operation", "operation parameter must be required when operations are defined")
+			}
 		}
 
-		// Validate operation names
-		for opName := range toolDef.Operations {
+		for _, opName := range sortedOperationNames(toolDef.Operations) {
+			opDef := toolDef.Operations[opName]
+			path := "operations." + opName
+
+			// Validate operation names
 			if opName == "" {
-				return fmt.Errorf("operation name cannot be empty")
+				errs.Add("operations", "operation name cannot be empty")
 			}
-		}
 
-		// If enum is explicitly provided, validate it matches operations
-		if len(operationParam.Enum) > 0 {
-			for opName := range toolDef.Operations {
-				if !containsString(operationParam.Enum, opName) {
-					return fmt.Errorf("operation parameter enum missing value %q", opName)
-				}
+			// If enum is explicitly provided, validate it matches operations.
+			// If enum is empty, it will be auto-derived from operations keys in ToToolDefinition
+			if len(operationParam.Enum) > 0 && !containsString(operationParam.Enum, opName) {
+				errs.Add("parameters.operation.enum", "operation parameter enum missing value %q", opName)
 			}
-		}
-		// If enum is empty, it will be auto-derived from operations keys in ToToolDefinition
 
-		for _, opDef := range toolDef.Operations {
-			for _, param := range opDef.Parameters {
-				if param.Name == "" {
-					return fmt.Errorf("parameter name is required")
+			if opDef.Timeout != "" {
+				if timeout, err := time.ParseDuration(opDef.Timeout); err != nil || timeout <= 0 {
+					errs.Add(path+".timeout", "operation %q has invalid timeout %q (use a positive duration like \"30s\")", opName, opDef.Timeout)
 				}
-				if err := validateParameter(param.Name, param, ""); err != nil {
-					return err
+			}
+			if opDef.RateLimit != "" {
+				if _, err := ParseRateLimit(opDef.RateLimit); err != nil {
+					errs.Add(path+".rate_limit", "operation %q: %v", opName, err)
 				}
-				if existingType, ok := paramTypes[param.Name]; ok && existingType != param.Type {
-					return fmt.Errorf("parameter %q has conflicting types: %s vs %s", param.Name, existingType, param.Type)
+			}
+			for _, perm := range opDef.Permissions {
+				if !perm.IsValid() {
+					errs.Add(path+".permissions", "operation %q has unknown permission %q", opName, perm)
+				}
+			}
+			checkParams(path+".parameters", opDef.AllParameters())
+			opDef.Deprecation.validate(path+".deprecated", &errs)
+			opDef.CostHint.validate(path, &errs)
+			if replacement := opDef.ReplacedBy; replacement != "" {
+				if _, ok := toolDef.Operations[replacement]; !ok || replacement == opName {
+					errs.Add(path+".replaced_by", "operation %q is replaced by unknown operation %q", opName, replacement)
 				}
-				paramTypes[param.Name] = param.Type
 			}
+			validateExamples(path+".examples", opDef.Examples, slices.Concat(toolDef.Parameters, opDef.AllParameters()), &errs)
 		}
 	}
 
-	return nil
+	return errs.Err()
 }
 
 func findParameter(params []YAMLToolParameter, name string) (YAMLToolParameter, bool) {
@@ -598,12 +641,14 @@ func findParameter(params []YAMLToolParameter, name string) (YAMLToolParameter,
 	return YAMLToolParameter{}, false
 }
 
-// validateParameter validates a single parameter and its nested properties.
-func validateParameter(name string, param YAMLToolParameter, prefix string) error {
+// validateParameter validates a single parameter and its nested properties,
+// recording failures in errs under path (e.g., "parameters" or "operations.echo.parameters").
+func validateParameter(name string, param YAMLToolParameter, prefix, path string, errs *ValidationErrors) {
 	fullName := name
 	if prefix != "" {
 		fullName = prefix + "." + name
 	}
+	field := path + "." + name
 
 	// Validate type
 	validTypes := map[string]bool{
@@ -611,70 +656,57 @@ func validateParameter(name string, param YAMLToolParameter, prefix string) erro
 		"boolean": true, "enum": true, "array": true, "object": true,
 	}
 	if !validTypes[param.Type] {
-		return fmt.Errorf("parameter %q: invalid type %q (must be one of: string, integer, number, boolean, enum, array, object)", fullName, param.Type)
+		errs.Add(field, "parameter %q: invalid type %q (must be one of: string, integer, number, boolean, enum, array, object)", fullName, param.Type)
 	}
 
 	// Validate description
 	if param.Description == "" {
-		return fmt.Errorf("parameter %q: description is required", fullName)
+		errs.Add(field, "parameter %q: description is required", fullName)
 	}
 
 	// Type-specific validation
 	switch param.Type {
 	case "enum":
 		if len(param.Enum) == 0 {
-			return fmt.Errorf("parameter %q: enum type requires 'enum' field with values", fullName)
+			errs.Add(field, "parameter %q: enum type requires 'enum' field with values", fullName)
 		}
 		// Validate default is in enum values
 		if param.Default != nil {
 			defaultStr, ok := param.Default.(string)
 			if !ok {
-				return fmt.Errorf("parameter %q: enum default must be a string", fullName)
-			}
-			found := false
-			for _, v := range param.Enum {
-				if v == defaultStr {
-					found = true
-					break
-				}
-			}
-			if !found {
-				return fmt.Errorf("parameter %q: default value %q is not in enum values", fullName, defaultStr)
+				errs.Add(field, "parameter %q: enum default must be a string", fullName)
+			} else if !containsString(param.Enum, defaultStr) {
+				errs.Add(field, "parameter %q: default value %q is not in enum values", fullName, defaultStr)
 			}
 		}
 
 	case "array":
 		if param.Items == nil || param.Items.Type == "" {
-			return fmt.Errorf("parameter %q: array type requires 'items' field with type", fullName)
+			errs.Add(field, "parameter %q: array type requires 'items' field with type", fullName)
 		}
 
 	case "object":
-		if len(param.Properties) > 0 {
-			for propName, propParam := range param.Properties {
-				if err := validateParameter(propName, propParam, fullName); err != nil {
-					return err
-				}
-			}
+		propNames := make([]string, 0, len(param.Properties))
+		for propName := range param.Properties {
+			propNames = append(propNames, propName)
+		}
+		sort.Strings(propNames)
+		for _, propName := range propNames {
+			validateParameter(propName, param.Properties[propName], fullName, field, errs)
 		}
 
 	case "integer", "number":
 		// Validate min/max
-		if param.Min != nil && param.Max != nil {
-			if *param.Min > *param.Max {
-				return fmt.Errorf("parameter %q: min (%v) cannot be greater than max (%v)", fullName, *param.Min, *param.Max)
-			}
+		if param.Min != nil && param.Max != nil && *param.Min > *param.Max {
+			errs.Add(field, "parameter %q: min (%v) cannot be greater than max (%v)", fullName, *param.Min, *param.Max)
 		}
 
 	case "string":
 		// Validate min_length/max_length
-		if param.MinLength != nil && param.MaxLength != nil {
-			if *param.MinLength > *param.MaxLength {
-				return fmt.Errorf("parameter %q: min_length (%d) cannot be greater than max_length (%d)", fullName, *param.MinLength, *param.MaxLength)
-			}
+		if param.MinLength != nil && param.MaxLength != nil && *param.MinLength > *param.MaxLength {
+			errs.Add(field, "parameter %q: min_length (%d) cannot be greater than max_length (%d)", fullName, *param.MinLength, *param.MaxLength)
 		}
 	}
-
-	return nil
 }
 
 // GetOperationsFromYAML extracts operation information from a YAMLToolDefinition.
@@ -693,7 +725,7 @@ func GetOperationsFromYAML(toolDef *YAMLToolDefinition) []OperationInfo {
 		var params []string
 		var requiredParams []string
 
-		for _, param := range opDef.Parameters {
+		for _, param := range opDef.AllParameters() {
 			params = append(params, param.Name)
 			if param.Required {
 				requiredParams = append(requiredParams, param.Name)
@@ -703,10 +735,19 @@ func GetOperationsFromYAML(toolDef *YAMLToolDefinition) []OperationInfo {
 		sort.Strings(params)
 		sort.Strings(requiredParams)
 
+		// Invalid timeouts are reported by ValidateYAMLToolDefinition
+		timeout, _ := time.ParseDuration(opDef.Timeout)
+
 		operations = append(operations, OperationInfo{
-			Name:               opName,
-			Parameters:         params,
-			RequiredParameters: requiredParams,
+			Name:                opName,
+			Parameters:          params,
+			RequiredParameters:  requiredParams,
+			RequiredPermissions: opDef.Permissions,
+			SupportsDryRun:      opDef.DryRun,
+			Timeout:             timeout,
+			Examples:            operationExamples(opName, opDef.Examples),
+			Deprecation:         opDef.Deprecation,
+			CostHint:            opDef.CostHint,
 		})
 	}
 
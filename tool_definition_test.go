@@ -1,6 +1,11 @@
 package pluginapi
 
-import "testing"
+import (
+	"context"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
 
 func TestConditionalToolSchemaValidation(t *testing.T) {
 	toolDef := &YAMLToolDefinition{
@@ -194,3 +199,53 @@ func TestAutoDerivesEnumFromOperations(t *testing.T) {
 		t.Fatalf("expected error for unknown operation")
 	}
 }
+
+type annotatedTestTool struct {
+	plainTestTool
+	def Tool
+}
+
+func (t *annotatedTestTool) Definition() Tool {
+	return t.def
+}
+
+func TestToolAnnotations(t *testing.T) {
+	var toolDef YAMLToolDefinition
+	err := yaml.Unmarshal([]byte(`
+name: delete_project
+description: Deletes a project
+parameters:
+  - name: id
+    type: string
+    description: Project ID
+    required: true
+annotations:
+  destructive: true
+  idempotent: true
+`), &toolDef)
+	if err != nil {
+		t.Fatalf("failed to parse YAML: %v", err)
+	}
+	if err := ValidateYAMLToolDefinition(&toolDef); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	tool, err := toolDef.ToToolDefinition()
+	if err != nil {
+		t.Fatalf("ToToolDefinition failed: %v", err)
+	}
+	want := ToolAnnotations{Destructive: true, Idempotent: true}
+	if tool.Annotations != want {
+		t.Fatalf("Annotations = %+v, want %+v", tool.Annotations, want)
+	}
+
+	// Hosts receive the annotations with the definition
+	client := newTestClient(t, &annotatedTestTool{def: tool})
+	if got := client.DefinitionCtx(context.Background()).Annotations; got != want {
+		t.Errorf("client Annotations = %+v, want %+v", got, want)
+	}
+
+	toolDef.Annotations.ReadOnly = true
+	if err := ValidateYAMLToolDefinition(&toolDef); err == nil {
+		t.Error("expected validation error for a read-only destructive tool")
+	}
+}
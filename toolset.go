@@ -0,0 +1,113 @@
+package pluginapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ToolSetProvider allows one plugin process to serve several distinct tools, each with
+// its own definition and Call, instead of multiplexing them behind an "operation" argument.
+// Plugins can optionally implement this interface.
+// Hosts list the tools with GetTools and address calls to one of them with WithToolName.
+// Calls that name no tool go to the plugin itself.
+//
+// Example:
+//
+//	func (p *studioPlugin) Tools() []pluginapi.PluginTool {
+//	    return []pluginapi.PluginTool{p.mixer, p.sampler}
+//	}
+type ToolSetProvider interface {
+	// Tools returns the tools the plugin serves, dispatched by Definition().Name.
+	// Each tool may implement the call-related optional interfaces itself, such as
+	// StreamingTool, FileAttachmentHandler, or OperationsProvider.
+	Tools() []PluginTool
+}
+
+type toolNameContextKey struct{}
+
+// WithToolName returns a context that sends the next call to the named tool of a
+// plugin implementing ToolSetProvider.
+func WithToolName(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, toolNameContextKey{}, name)
+}
+
+// ToolName returns the tool the current call is addressed to, or an empty string
+// if the call went to the plugin's own tool.
+func ToolName(ctx context.Context) string {
+	name, _ := ctx.Value(toolNameContextKey{}).(string)
+	return name
+}
+
+// resolveTool returns the tool of the plugin a call named name is addressed to,
+// and the middleware WrapTool added to it.
+func (s *grpcServer) resolveTool(name string) (PluginTool, Middleware, error) {
+	if name == "" {
+		return s.Impl, s.middleware, nil
+	}
+	if provider, ok := s.Impl.(ToolSetProvider); ok {
+		for _, tool := range provider.Tools() {
+			if tool.Definition().Name == name {
+				tool, middleware := unwrapTool(tool)
+				return tool, middleware, nil
+			}
+		}
+	}
+	if s.Impl.Definition().Name == name {
+		return s.Impl, s.middleware, nil
+	}
+	return nil, nil, NewPluginError(ErrorCodeInvalidArgs, fmt.Sprintf("plugin has no tool named %q", name))
+}
+
+// toolDefinitionToProto converts a tool's definition for GetDefinition and GetTools.
+func toolDefinitionToProto(tool PluginTool) (*ToolDefinition, error) {
+	def := tool.Definition()
+	paramsJSON, err := json.Marshal(def.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	examples, err := toolExamplesToProto(def.Examples)
+	if err != nil {
+		return nil, err
+	}
+	return &ToolDefinition{
+		Name:           def.Name,
+		Description:    def.Description,
+		ParametersJson: string(paramsJSON),
+		Version:        definitionVersion(tool),
+		Annotations: &ProtoToolAnnotations{
+			ReadOnly:    def.Annotations.ReadOnly,
+			Destructive: def.Annotations.Destructive,
+			Idempotent:  def.Annotations.Idempotent,
+			OpenWorld:   def.Annotations.OpenWorld,
+		},
+		Examples:    examples,
+		Deprecation: deprecationToProto(def.Deprecation),
+	}, nil
+}
+
+// toolFromProto converts a tool definition received from a plugin.
+// Malformed parameter schemas are replaced by an empty one.
+func toolFromProto(def *ToolDefinition) Tool {
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(def.ParametersJson), &params); err != nil {
+		params = map[string]interface{}{}
+	}
+	annotations := def.GetAnnotations()
+	return Tool{
+		Name:        def.Name,
+		Description: def.Description,
+		Parameters:  params,
+		Annotations: ToolAnnotations{
+			ReadOnly:    annotations.GetReadOnly(),
+			Destructive: annotations.GetDestructive(),
+			Idempotent:  annotations.GetIdempotent(),
+			OpenWorld:   annotations.GetOpenWorld(),
+		},
+		Examples:    toolExamplesFromProto(def.Examples),
+		Deprecation: deprecationFromProto(def.Deprecation),
+	}
+}
@@ -0,0 +1,83 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type namedTestTool struct {
+	name string
+}
+
+func (t *namedTestTool) Definition() Tool {
+	return Tool{Name: t.name, Description: "The " + t.name, Parameters: map[string]interface{}{"type": "object"}}
+}
+
+func (t *namedTestTool) Call(ctx context.Context, args string) (string, error) {
+	return t.name + ":" + ToolName(ctx) + ":" + args, nil
+}
+
+type toolSetTestTool struct {
+	namedTestTool
+}
+
+func (t *toolSetTestTool) Tools() []PluginTool {
+	return []PluginTool{&namedTestTool{name: "mixer"}, &namedTestTool{name: "sampler"}}
+}
+
+func TestToolSet_DispatchesByName(t *testing.T) {
+	client := newTestClient(t, &toolSetTestTool{namedTestTool{name: "studio"}})
+
+	tools, err := client.GetTools(context.Background())
+	if err != nil {
+		t.Fatalf("GetTools failed: %v", err)
+	}
+	if len(tools) != 2 || tools[0].Name != "mixer" || tools[1].Name != "sampler" {
+		t.Fatalf("unexpected tools: %+v", tools)
+	}
+	if tools[1].Description != "The sampler" || tools[1].Parameters["type"] != "object" {
+		t.Errorf("expected the sampler's own definition, got %+v", tools[1])
+	}
+
+	result, err := client.Call(WithToolName(context.Background(), "sampler"), "{}")
+	if err != nil || result != "sampler:sampler:{}" {
+		t.Errorf("Call(sampler) = %q, %v", result, err)
+	}
+	result, err = client.CallStream(WithToolName(context.Background(), "mixer"), "{}", func(CallChunk) error { return nil })
+	if err != nil || result != "mixer:mixer:{}" {
+		t.Errorf("CallStream(mixer) = %q, %v", result, err)
+	}
+	result, err = client.CallWithFiles(WithToolName(context.Background(), "mixer"), "{}", nil)
+	if err != nil || result != "mixer:mixer:{}" {
+		t.Errorf("CallWithFiles(mixer) = %q, %v", result, err)
+	}
+
+	// Calls naming no tool go to the plugin itself
+	result, err = client.Call(context.Background(), "{}")
+	if err != nil || result != "studio::{}" {
+		t.Errorf("Call() = %q, %v", result, err)
+	}
+
+	_, err = client.Call(WithToolName(context.Background(), "drums"), "{}")
+	var pluginErr *PluginError
+	if !errors.As(err, &pluginErr) || pluginErr.Code != ErrorCodeInvalidArgs {
+		t.Errorf("expected an invalid args error for an unknown tool, got %v", err)
+	}
+}
+
+func TestToolSet_SingleToolPlugin(t *testing.T) {
+	client := newTestClient(t, &namedTestTool{name: "weather"})
+
+	tools, err := client.GetTools(context.Background())
+	if err != nil {
+		t.Fatalf("GetTools failed: %v", err)
+	}
+	if len(tools) != 1 || tools[0].Name != "weather" {
+		t.Fatalf("expected only the plugin's own tool, got %+v", tools)
+	}
+	result, err := client.Call(WithToolName(context.Background(), "weather"), "{}")
+	if err != nil || result != "weather:weather:{}" {
+		t.Errorf("Call(weather) = %q, %v", result, err)
+	}
+}
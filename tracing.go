@@ -0,0 +1,219 @@
+package pluginapi
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys of the W3C Trace Context format, which OpenTelemetry's gRPC
+// instrumentation uses as well, so hosts instrumented with it propagate to plugins.
+const (
+	traceparentMetadataKey = "traceparent"
+	tracestateMetadataKey  = "tracestate"
+)
+
+// TraceContext identifies a span in a distributed trace, in W3C Trace Context terms.
+type TraceContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	// Flags holds the trace flags; bit 0 means the trace is sampled
+	Flags byte
+	// State is the vendor-specific tracestate header, passed through unchanged
+	State string
+}
+
+// IsValid reports whether tc has a trace and span ID.
+func (tc TraceContext) IsValid() bool {
+	return tc.TraceID != [16]byte{} && tc.SpanID != [8]byte{}
+}
+
+// Sampled reports whether the trace is being recorded.
+func (tc TraceContext) Sampled() bool {
+	return tc.Flags&1 == 1
+}
+
+// Traceparent formats tc as a traceparent header (e.g., "00-4bf9...-00f0...-01").
+func (tc TraceContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", hex.EncodeToString(tc.TraceID[:]), hex.EncodeToString(tc.SpanID[:]), tc.Flags)
+}
+
+// ParseTraceparent parses a traceparent header. It returns false if the header is malformed.
+func ParseTraceparent(header string) (TraceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return TraceContext{}, false
+	}
+	var tc TraceContext
+	var flags [1]byte
+	if !decodeHex(tc.TraceID[:], parts[1]) || !decodeHex(tc.SpanID[:], parts[2]) || !decodeHex(flags[:], parts[3]) {
+		return TraceContext{}, false
+	}
+	tc.Flags = flags[0]
+	return tc, tc.IsValid()
+}
+
+func decodeHex(dst []byte, s string) bool {
+	if len(s) != 2*len(dst) || strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
+
+type traceContextKey struct{}
+
+// ContextWithTraceContext returns a context carrying tc, which is sent to the host
+// with HostServices calls made with it.
+func ContextWithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceContextFromContext returns the trace the current call belongs to: the
+// plugin's own span if its Tracer started one, otherwise the host's.
+func TraceContextFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// Tracer starts spans for the RPCs a plugin serves. Adapt an OpenTelemetry tracer
+// by starting a span whose remote parent is TraceContextFromContext(ctx):
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, pluginapi.Span) {
+//	    if parent, ok := pluginapi.TraceContextFromContext(ctx); ok {
+//	        ctx = trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+//	            TraceID: parent.TraceID, SpanID: parent.SpanID, TraceFlags: trace.TraceFlags(parent.Flags), Remote: true,
+//	        }))
+//	    }
+//	    ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer))
+//	    return ctx, otelSpan{span}
+//	}
+type Tracer interface {
+	// Start begins a span named after the RPC (e.g., "pluginapi.ToolService/Call")
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// TraceContext identifies the span, so calls back to the host continue the trace
+	TraceContext() TraceContext
+	// End finishes the span, recording err if the RPC failed
+	End(err error)
+}
+
+// TracingProvider allows plugins to trace the RPCs they serve.
+// Plugins can optionally implement this interface. Without it the host's trace
+// context is still available from TraceContextFromContext and is passed on to
+// HostServices calls, but no spans are recorded.
+type TracingProvider interface {
+	Tracer() Tracer
+}
+
+// traceUnary is the server interceptor that continues the host's trace.
+func traceUnary(tracer Tracer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := startSpan(ctx, tracer, info.FullMethod)
+		resp, err := handler(ctx, req)
+		if span != nil {
+			span.End(spanError(resp, err))
+		}
+		return resp, err
+	}
+}
+
+// traceStream is traceUnary for streaming RPCs.
+func traceStream(tracer Tracer) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startSpan(stream.Context(), tracer, info.FullMethod)
+		err := handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+		if span != nil {
+			span.End(err)
+		}
+		return err
+	}
+}
+
+// startSpan reads the host's trace context from the incoming metadata and, with a
+// tracer, starts a child span. span is nil without a tracer.
+func startSpan(ctx context.Context, tracer Tracer, fullMethod string) (context.Context, Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(traceparentMetadataKey); len(values) > 0 {
+		if tc, ok := ParseTraceparent(values[0]); ok {
+			tc.State = strings.Join(md.Get(tracestateMetadataKey), ",")
+			ctx = ContextWithTraceContext(ctx, tc)
+		}
+	}
+	if tracer == nil {
+		return ctx, nil
+	}
+	ctx, span := tracer.Start(ctx, strings.TrimPrefix(fullMethod, "/"))
+	if tc := span.TraceContext(); tc.IsValid() {
+		ctx = ContextWithTraceContext(ctx, tc)
+	}
+	return ctx, span
+}
+
+// spanError returns the error to record for a unary RPC, including failures
+// reported in a CallResponse rather than as a gRPC error.
+func spanError(resp any, err error) error {
+	if err != nil {
+		return err
+	}
+	if callResp, ok := resp.(*CallResponse); ok && callResp.Error != "" {
+		return callError(callResp.Error, callResp.StructuredError)
+	}
+	return nil
+}
+
+// contextServerStream replaces the context of a grpc.ServerStream.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// withTraceMetadata adds ctx's trace context, if any, to the outgoing metadata.
+func withTraceMetadata(ctx context.Context) context.Context {
+	tc, ok := TraceContextFromContext(ctx)
+	if !ok || !tc.IsValid() {
+		return ctx
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, traceparentMetadataKey, tc.Traceparent())
+	if tc.State != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, tracestateMetadataKey, tc.State)
+	}
+	return ctx
+}
+
+// TracePropagation returns client interceptors that send the trace context set
+// with ContextWithTraceContext to the plugin. Hosts instrumented with
+// OpenTelemetry's gRPC client handler don't need them.
+func TracePropagation() ClientInterceptors {
+	return ClientInterceptors{
+		Unary: []grpc.UnaryClientInterceptor{
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				return invoker(withTraceMetadata(ctx), method, req, reply, cc, opts...)
+			},
+		},
+		Stream: []grpc.StreamClientInterceptor{
+			func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return streamer(withTraceMetadata(ctx), desc, cc, method, opts...)
+			},
+		},
+	}
+}
+
+// tracerOf returns the plugin's Tracer, or nil if it doesn't trace.
+func tracerOf(tool PluginTool) Tracer {
+	if provider, ok := tool.(TracingProvider); ok {
+		return provider.Tracer()
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	header := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	tc, ok := ParseTraceparent(header)
+	if !ok || !tc.Sampled() || tc.TraceID[0] != 0x4b || tc.SpanID[7] != 0xb7 {
+		t.Fatalf("unexpected parse of %q: %+v, %v", header, tc, ok)
+	}
+	if got := tc.Traceparent(); got != header {
+		t.Errorf("Traceparent() = %q, want %q", got, header)
+	}
+
+	for _, bad := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+	} {
+		if _, ok := ParseTraceparent(bad); ok {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+type recordedSpan struct {
+	name   string
+	parent TraceContext
+	tc     TraceContext
+	err    error
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := TraceContextFromContext(ctx)
+	span := &recordedSpan{name: name, parent: parent, tc: parent}
+	span.tc.SpanID = [8]byte{0, 0, 0, 0, 0, 0, 0, byte(len(r.spans) + 1)}
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+	return ctx, &recordingSpan{span: span, tracer: r}
+}
+
+type recordingSpan struct {
+	span   *recordedSpan
+	tracer *recordingTracer
+}
+
+func (s *recordingSpan) TraceContext() TraceContext { return s.span.tc }
+
+func (s *recordingSpan) End(err error) {
+	s.tracer.mu.Lock()
+	s.span.err = err
+	s.tracer.mu.Unlock()
+}
+
+type tracedTestTool struct {
+	BasePlugin
+	tracer *recordingTracer
+	seen   chan TraceContext
+}
+
+func (t *tracedTestTool) Call(ctx context.Context, args string) (string, error) {
+	tc, _ := TraceContextFromContext(ctx)
+	t.seen <- tc
+	if args == "fail" {
+		return "", errors.New("export failed")
+	}
+	return "ok", nil
+}
+
+func (t *tracedTestTool) Tracer() Tracer {
+	if t.tracer == nil {
+		return nil
+	}
+	return t.tracer
+}
+
+func newTracedTestClient(t *testing.T, tool PluginTool) *grpcClient {
+	t.Helper()
+	conn := TracePropagation().Wrap(newTestConn(t, tool, serverOptions(tool)...))
+	return &grpcClient{client: NewToolServiceClient(conn)}
+}
+
+func TestTracing_SpansContinueHostTrace(t *testing.T) {
+	tool := &tracedTestTool{tracer: &recordingTracer{}, seen: make(chan TraceContext, 2)}
+	client := newTracedTestClient(t, tool)
+	host, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := ContextWithTraceContext(context.Background(), host)
+
+	if _, err := client.Call(ctx, "{}"); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if _, err := client.Call(ctx, "fail"); err == nil {
+		t.Fatal("expected the failing call to fail")
+	}
+
+	tool.tracer.mu.Lock()
+	defer tool.tracer.mu.Unlock()
+	if len(tool.tracer.spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(tool.tracer.spans))
+	}
+	ok, failed := tool.tracer.spans[0], tool.tracer.spans[1]
+	if ok.name != "pluginapi.ToolService/Call" || ok.parent != host || ok.err != nil {
+		t.Errorf("unexpected span: %+v", ok)
+	}
+	if failed.err == nil || failed.err.Error() != "export failed" {
+		t.Errorf("expected the failed call's error on its span, got %v", failed.err)
+	}
+	// The plugin sees its own span, so host calls continue from it
+	if seen := <-tool.seen; seen != ok.tc {
+		t.Errorf("plugin saw %+v, want its span %+v", seen, ok.tc)
+	}
+}
+
+func TestTracing_NoTracerStillPropagates(t *testing.T) {
+	tool := &tracedTestTool{seen: make(chan TraceContext, 1)}
+	client := newTracedTestClient(t, tool)
+	host, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	if _, err := client.Call(ContextWithTraceContext(context.Background(), host), "{}"); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if seen := <-tool.seen; seen != host {
+		t.Errorf("plugin saw %+v, want the host's trace %+v", seen, host)
+	}
+
+	if _, err := client.Call(context.Background(), "{}"); err != nil {
+		t.Fatalf("untraced Call failed: %v", err)
+	}
+	if seen := <-tool.seen; seen.IsValid() {
+		t.Errorf("expected no trace for an untraced call, got %+v", seen)
+	}
+}
@@ -0,0 +1,123 @@
+package pluginapi
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Environment variables that tell ServeGRPCPlugin where to listen.
+const (
+	// EnvGRPCPort is the TCP port to serve on
+	EnvGRPCPort = "ORI_PLUGIN_GRPC_PORT"
+	// EnvGRPCHost is the address to bind; defaults to 127.0.0.1. Set it (e.g., to
+	// 0.0.0.0) when the agent runs on another host, and configure TLS as well.
+	EnvGRPCHost = "ORI_PLUGIN_GRPC_HOST"
+	// EnvSocket is the path of a unix socket to serve on instead of a TCP port.
+	// It avoids port exhaustion and local firewall rules when agent and plugin share a host.
+	EnvSocket = "ORI_PLUGIN_SOCKET"
+	// EnvTransport selects TransportGRPC or TransportStdio. When it is unset, plugins
+	// use stdio if neither EnvGRPCPort nor EnvSocket is set.
+	EnvTransport = "ORI_PLUGIN_TRANSPORT"
+	// EnvDev, set to a true value (e.g., "1"), runs the plugin standalone for manual
+	// testing: without EnvGRPCPort or EnvSocket it listens on a free port and prints
+	// it, instead of using stdio. It also enables reflection (see EnvGRPCReflection).
+	EnvDev = "ORI_PLUGIN_DEV"
+)
+
+// devMode reports whether EnvDev is set.
+func devMode() bool {
+	dev, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv(EnvDev)))
+	return dev
+}
+
+// Transports ServeGRPCPlugin can serve on.
+const (
+	// TransportGRPC listens on a TCP port or unix socket
+	TransportGRPC = "grpc"
+	// TransportStdio speaks over stdin and stdout, for sandboxes that forbid
+	// listeners (see StdioClientConn)
+	TransportStdio = "stdio"
+)
+
+// transportFromEnv returns the transport ServeGRPCPlugin should use.
+func transportFromEnv() (string, error) {
+	switch transport := strings.TrimSpace(os.Getenv(EnvTransport)); transport {
+	case TransportGRPC, TransportStdio:
+		return transport, nil
+	case "":
+		if strings.TrimSpace(os.Getenv(EnvGRPCPort)) == "" && strings.TrimSpace(os.Getenv(EnvSocket)) == "" && !devMode() {
+			return TransportStdio, nil
+		}
+		return TransportGRPC, nil
+	default:
+		return "", fmt.Errorf("unknown %s %q (want %q or %q)", EnvTransport, transport, TransportGRPC, TransportStdio)
+	}
+}
+
+// listenFromEnv opens the listener ServeGRPCPlugin serves on.
+func listenFromEnv() (net.Listener, error) {
+	if path := strings.TrimSpace(os.Getenv(EnvSocket)); path != "" {
+		return listenUnix(path)
+	}
+
+	portStr := strings.TrimSpace(os.Getenv(EnvGRPCPort))
+	if portStr == "" {
+		if !devMode() {
+			return nil, fmt.Errorf("requires %s or %s to be set", EnvGRPCPort, EnvSocket)
+		}
+		portStr = "0" // Any free port
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 0 || port > 65535 {
+		return nil, fmt.Errorf("invalid %s: %q", EnvGRPCPort, portStr)
+	}
+	addr := listenAddressFromEnv(port)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return lis, nil
+}
+
+// listenAddressFromEnv returns the host:port to listen on for port.
+func listenAddressFromEnv(port int) string {
+	host := strings.TrimSpace(os.Getenv(EnvGRPCHost))
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return fmt.Sprintf("%s:%d", host, port)
+}
+
+// listenUnix listens on a unix socket at path that only the current user can connect to.
+// A socket left behind by a previous run is removed; any other file at path is an error.
+func listenUnix(path string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("cannot listen on %s: file exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+		}
+	}
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = lis.Close()
+		return nil, fmt.Errorf("failed to restrict permissions on %s: %w", path, err)
+	}
+	return lis, nil
+}
+
+// dialTarget turns an address into a gRPC dial target. gRPC understands host:port
+// and unix:///path already; a bare absolute path is taken to be a unix socket.
+func dialTarget(address string) string {
+	if strings.HasPrefix(address, "/") {
+		return "unix://" + address
+	}
+	return address
+}
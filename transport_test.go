@@ -0,0 +1,164 @@
+package pluginapi
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestListenAddressFromEnv(t *testing.T) {
+	if got := listenAddressFromEnv(5000); got != "127.0.0.1:5000" {
+		t.Errorf("default address = %q", got)
+	}
+	t.Setenv(EnvGRPCHost, "0.0.0.0")
+	if got := listenAddressFromEnv(5000); got != "0.0.0.0:5000" {
+		t.Errorf("address = %q, want 0.0.0.0:5000", got)
+	}
+}
+
+func TestListenFromEnv_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plugin.sock")
+	t.Setenv(EnvSocket, path)
+	t.Setenv(EnvGRPCPort, "")
+
+	// A socket left behind by a crashed plugin must not block the next start
+	stale, err := listenUnix(path)
+	if err != nil {
+		t.Fatalf("listenUnix failed: %v", err)
+	}
+	defer stale.Close()
+
+	lis, err := listenFromEnv()
+	if err != nil {
+		t.Fatalf("listenFromEnv failed: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("socket permissions = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+
+	server := grpc.NewServer()
+	registerToolServices(server, &plainTestTool{})
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("unix://"+path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	client := &grpcClient{client: NewToolServiceClient(conn)}
+	if err := client.HealthCheckCtx(context.Background()); err != nil {
+		t.Errorf("HealthCheck over unix socket failed: %v", err)
+	}
+}
+
+func TestListenFromEnv_Errors(t *testing.T) {
+	t.Setenv(EnvDev, "")
+	t.Setenv(EnvSocket, "")
+	t.Setenv(EnvGRPCPort, "")
+	if _, err := listenFromEnv(); err == nil {
+		t.Error("expected error with neither port nor socket set")
+	}
+
+	t.Setenv(EnvGRPCPort, "70000")
+	if _, err := listenFromEnv(); err == nil {
+		t.Error("expected error for out of range port")
+	}
+
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("keep me"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvSocket, path)
+	if _, err := listenFromEnv(); err == nil {
+		t.Error("expected error for a regular file at the socket path")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("regular file should not be removed: %v", err)
+	}
+}
+
+func TestHostServices_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "host.sock")
+	lis, err := listenUnix(path)
+	if err != nil {
+		t.Fatalf("listenUnix failed: %v", err)
+	}
+	host := &recordingHostServices{}
+	server := grpc.NewServer()
+	RegisterHostServices(server, host)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	for _, address := range []string{"unix://" + path, path} {
+		var conn hostConnection
+		services, _, err := conn.connect(address, "plugin")
+		if err != nil {
+			t.Fatalf("connect to %q failed: %v", address, err)
+		}
+		if err := services.Log(context.Background(), LogInfo, "hello", nil); err != nil {
+			t.Errorf("Log via %q failed: %v", address, err)
+		}
+	}
+}
+
+func TestTransportFromEnv(t *testing.T) {
+	t.Setenv(EnvDev, "")
+	t.Setenv(EnvTransport, "")
+	t.Setenv(EnvSocket, "")
+	t.Setenv(EnvGRPCPort, "")
+	if got, _ := transportFromEnv(); got != TransportStdio {
+		t.Errorf("without port or socket: transport = %q, want stdio", got)
+	}
+
+	t.Setenv(EnvGRPCPort, "5000")
+	if got, _ := transportFromEnv(); got != TransportGRPC {
+		t.Errorf("with port: transport = %q, want grpc", got)
+	}
+
+	t.Setenv(EnvGRPCPort, "")
+	t.Setenv(EnvDev, "1")
+	if got, _ := transportFromEnv(); got != TransportGRPC {
+		t.Errorf("in dev mode: transport = %q, want grpc", got)
+	}
+	t.Setenv(EnvDev, "")
+
+	t.Setenv(EnvTransport, TransportStdio)
+	if got, _ := transportFromEnv(); got != TransportStdio {
+		t.Errorf("explicit stdio: transport = %q", got)
+	}
+
+	t.Setenv(EnvTransport, "carrier-pigeon")
+	if _, err := transportFromEnv(); err == nil {
+		t.Error("expected error for unknown transport")
+	}
+}
+
+func TestListenFromEnv_DevModePicksPort(t *testing.T) {
+	t.Setenv(EnvDev, "true")
+	t.Setenv(EnvSocket, "")
+	t.Setenv(EnvGRPCPort, "")
+	t.Setenv(EnvGRPCHost, "")
+
+	lis, err := listenFromEnv()
+	if err != nil {
+		t.Fatalf("listenFromEnv failed: %v", err)
+	}
+	defer lis.Close()
+	addr := lis.Addr().(*net.TCPAddr)
+	if addr.Port == 0 || !addr.IP.IsLoopback() {
+		t.Errorf("expected a free loopback port, got %v", addr)
+	}
+	if !reflectionEnabled() {
+		t.Error("dev mode should enable reflection")
+	}
+	t.Setenv(EnvGRPCReflection, "false")
+	if reflectionEnabled() {
+		t.Error("explicitly disabled reflection should stay off in dev mode")
+	}
+}
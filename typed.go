@@ -0,0 +1,71 @@
+package pluginapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// HandleTyped turns fn into a Call implementation that works with Go types instead
+// of JSON strings. The returned function decodes args into a new P (empty args
+// leave it zero), calls its Validate method if *P has one, runs fn, and encodes the
+// result as JSON. A string result is returned as-is, so fn can return plain text.
+//
+// Arguments that fail to decode or validate are reported as invalid_args
+// PluginErrors, so the LLM is told to fix them; errors from fn are returned unchanged.
+//
+// Example:
+//
+//	type WeatherParams struct {
+//	    Location string `json:"location"`
+//	    Units    string `json:"units,omitempty"`
+//	}
+//
+//	func (p *WeatherParams) Validate() error {
+//	    if p.Location == "" {
+//	        return &pluginapi.FieldError{Field: "location", Message: "location is required"}
+//	    }
+//	    return nil
+//	}
+//
+//	var handleWeather = pluginapi.HandleTyped(func(ctx context.Context, p *WeatherParams) (*Forecast, error) {
+//	    return fetchForecast(ctx, p.Location, p.Units)
+//	})
+//
+//	func (t *WeatherTool) Call(ctx context.Context, args string) (string, error) {
+//	    return handleWeather(ctx, args)
+//	}
+func HandleTyped[P any, R any](fn func(ctx context.Context, params *P) (R, error)) func(ctx context.Context, args string) (string, error) {
+	return func(ctx context.Context, args string) (string, error) {
+		params := new(P)
+		if strings.TrimSpace(args) != "" {
+			if err := json.Unmarshal([]byte(args), params); err != nil {
+				return "", WrapPluginError(ErrorCodeInvalidArgs, fmt.Errorf("invalid arguments: %w", err))
+			}
+		}
+		if v, ok := any(params).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				var pluginErr *PluginError
+				if errors.As(err, &pluginErr) {
+					return "", err
+				}
+				return "", WrapPluginError(ErrorCodeInvalidArgs, err)
+			}
+		}
+
+		result, err := fn(ctx, params)
+		if err != nil {
+			return "", err
+		}
+		if s, ok := any(result).(string); ok {
+			return s, nil
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode result: %w", err)
+		}
+		return string(data), nil
+	}
+}
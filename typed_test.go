@@ -0,0 +1,52 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type typedTestParams struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+func (p *typedTestParams) Validate() error {
+	if p.B == 0 {
+		return &FieldError{Field: "b", Message: "b must not be zero"}
+	}
+	return nil
+}
+
+func TestHandleTyped(t *testing.T) {
+	divide := HandleTyped(func(ctx context.Context, p *typedTestParams) (map[string]int, error) {
+		return map[string]int{"quotient": p.A / p.B}, nil
+	})
+	ctx := context.Background()
+
+	got, err := divide(ctx, `{"a": 7, "b": 2}`)
+	if err != nil || got != `{"quotient":3}` {
+		t.Errorf("divide = %q, %v", got, err)
+	}
+
+	for _, args := range []string{`{"a": 1, "b": 0}`, `{"a": "x"}`, ``} {
+		_, err := divide(ctx, args)
+		var pluginErr *PluginError
+		if !errors.As(err, &pluginErr) || pluginErr.Code != ErrorCodeInvalidArgs {
+			t.Errorf("divide(%q) error = %v, want invalid_args", args, err)
+		}
+	}
+
+	echo := HandleTyped(func(ctx context.Context, p *struct{ Text string }) (string, error) {
+		if p.Text == "" {
+			return "", errors.New("nothing to echo")
+		}
+		return p.Text, nil
+	})
+	if got, err := echo(ctx, `{"Text": "hi"}`); err != nil || got != "hi" {
+		t.Errorf("echo = %q, %v", got, err)
+	}
+	if _, err := echo(ctx, ""); err == nil || err.Error() != "nothing to echo" {
+		t.Errorf("echo error = %v, want the handler's error", err)
+	}
+}
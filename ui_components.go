@@ -0,0 +1,138 @@
+package pluginapi
+
+import "fmt"
+
+// UIComponentType identifies the kind of a UI component in a DisplayTypeUI result.
+type UIComponentType string
+
+const (
+	UIComponentRow    UIComponentType = "row"    // Lays out children horizontally
+	UIComponentColumn UIComponentType = "column" // Lays out children vertically
+	UIComponentText   UIComponentType = "text"   // Static text
+	UIComponentBadge  UIComponentType = "badge"  // Small status label
+	UIComponentButton UIComponentType = "button" // Button that triggers a plugin operation
+	UIComponentInput  UIComponentType = "input"  // Text input whose value is sent with button actions
+)
+
+// UIAction describes a plugin call triggered from the UI.
+// When the user activates the component, the host calls the same plugin with
+// Args merged with the current values of all inputs in the result (keyed by input name).
+type UIAction struct {
+	// Operation is the value passed as the "operation" argument
+	Operation string `json:"operation" yaml:"operation"`
+	// Args are additional prefilled arguments for the call
+	Args map[string]interface{} `json:"args,omitempty" yaml:"args,omitempty"`
+}
+
+// UIComponent is a node in a declarative UI tree.
+// Only the fields relevant to Type are used; build components with the UI* helpers
+// rather than filling this struct directly.
+type UIComponent struct {
+	Type UIComponentType `json:"type" yaml:"type"`
+
+	// Children holds nested components for rows and columns
+	Children []UIComponent `json:"children,omitempty" yaml:"children,omitempty"`
+
+	// Text is the content of text and badge components, and the label of buttons
+	Text string `json:"text,omitempty" yaml:"text,omitempty"`
+
+	// Variant selects a visual style (e.g., "success", "warning", "danger" for badges;
+	// "primary", "secondary", "danger" for buttons; "heading", "muted" for text)
+	Variant string `json:"variant,omitempty" yaml:"variant,omitempty"`
+
+	// Action is triggered when a button is clicked
+	Action *UIAction `json:"action,omitempty" yaml:"action,omitempty"`
+
+	// Name is the argument name an input's value is submitted under
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Label is shown next to an input
+	Label string `json:"label,omitempty" yaml:"label,omitempty"`
+	// Placeholder is shown in an empty input
+	Placeholder string `json:"placeholder,omitempty" yaml:"placeholder,omitempty"`
+	// Value is an input's initial value
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// Validate checks the component tree: every component has a known type, and
+// buttons, like any component with an action, name the operation they trigger.
+func (c *UIComponent) Validate() error {
+	switch c.Type {
+	case UIComponentRow, UIComponentColumn, UIComponentText, UIComponentBadge, UIComponentButton, UIComponentInput:
+	case "":
+		return fmt.Errorf("component type is required")
+	default:
+		return fmt.Errorf("unsupported component type: %s", c.Type)
+	}
+
+	if c.Type == UIComponentButton && c.Action == nil {
+		return fmt.Errorf("button %q: action is required", c.Text)
+	}
+	if c.Action != nil && c.Action.Operation == "" {
+		return fmt.Errorf("%s %q: action operation is required", c.Type, c.Text)
+	}
+
+	for i := range c.Children {
+		if err := c.Children[i].Validate(); err != nil {
+			return fmt.Errorf("children[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// UIRow creates a component that lays out its children horizontally.
+func UIRow(children ...UIComponent) UIComponent {
+	return UIComponent{Type: UIComponentRow, Children: children}
+}
+
+// UIColumn creates a component that lays out its children vertically.
+func UIColumn(children ...UIComponent) UIComponent {
+	return UIComponent{Type: UIComponentColumn, Children: children}
+}
+
+// UIText creates a static text component.
+func UIText(text string) UIComponent {
+	return UIComponent{Type: UIComponentText, Text: text}
+}
+
+// UIBadge creates a small status label.
+//
+// Example:
+//
+//	pluginapi.UIBadge("running", "success")
+func UIBadge(text, variant string) UIComponent {
+	return UIComponent{Type: UIComponentBadge, Text: text, Variant: variant}
+}
+
+// UIButton creates a button that calls the plugin with the given operation and arguments.
+//
+// Example:
+//
+//	pluginapi.UIButton("Delete", "delete_project", map[string]interface{}{"id": p.ID})
+func UIButton(label, operation string, args map[string]interface{}) UIComponent {
+	return UIComponent{
+		Type:   UIComponentButton,
+		Text:   label,
+		Action: &UIAction{Operation: operation, Args: args},
+	}
+}
+
+// UIInput creates a text input. Its value is submitted under name when a button is clicked.
+func UIInput(name, label, placeholder string) UIComponent {
+	return UIComponent{Type: UIComponentInput, Name: name, Label: label, Placeholder: placeholder}
+}
+
+// WithVariant returns a copy of the component with the given visual variant.
+//
+// Example:
+//
+//	pluginapi.UIButton("Delete", "delete", args).WithVariant("danger")
+func (c UIComponent) WithVariant(variant string) UIComponent {
+	c.Variant = variant
+	return c
+}
+
+// WithValue returns a copy of the component with the given initial input value.
+func (c UIComponent) WithValue(value string) UIComponent {
+	c.Value = value
+	return c
+}
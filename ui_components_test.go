@@ -0,0 +1,105 @@
+package pluginapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewUIResult_JSON(t *testing.T) {
+	result := NewUIResult("Project", UIColumn(
+		UIRow(UIText("demo"), UIBadge("running", "success")),
+		UIInput("new_name", "Rename to", "demo").WithValue("demo"),
+		UIButton("Delete", "delete_project", map[string]interface{}{"id": "p1"}).WithVariant("danger"),
+	))
+
+	jsonStr, err := result.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+
+	var decoded struct {
+		DisplayType DisplayType `json:"displayType"`
+		Data        UIComponent `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &decoded); err != nil {
+		t.Fatalf("failed to decode UI result: %v", err)
+	}
+
+	if decoded.DisplayType != DisplayTypeUI {
+		t.Errorf("expected display type %q, got %q", DisplayTypeUI, decoded.DisplayType)
+	}
+
+	root := decoded.Data
+	if root.Type != UIComponentColumn || len(root.Children) != 3 {
+		t.Fatalf("unexpected root component: %+v", root)
+	}
+
+	row := root.Children[0]
+	if row.Type != UIComponentRow || len(row.Children) != 2 || row.Children[1].Variant != "success" {
+		t.Errorf("unexpected row component: %+v", row)
+	}
+
+	input := root.Children[1]
+	if input.Type != UIComponentInput || input.Name != "new_name" || input.Value != "demo" {
+		t.Errorf("unexpected input component: %+v", input)
+	}
+
+	button := root.Children[2]
+	if button.Type != UIComponentButton || button.Action == nil || button.Action.Operation != "delete_project" {
+		t.Fatalf("unexpected button component: %+v", button)
+	}
+	if button.Action.Args["id"] != "p1" || button.Variant != "danger" {
+		t.Errorf("unexpected button action: %+v", button.Action)
+	}
+}
+
+func TestNewUIResult_Validate(t *testing.T) {
+	result := NewUIResult("Project", UIColumn(
+		UIRow(UIText("demo"), UIBadge("running", "success")),
+		UIInput("new_name", "Rename to", "demo"),
+		UIButton("Delete", "delete_project", map[string]interface{}{"id": "p1"}),
+	))
+	if err := result.Validate(); err != nil {
+		t.Fatalf("expected valid UI result, got %v", err)
+	}
+
+	parsed, err := FromJSON(`{"displayType":"ui","data":{"type":"column","children":[{"type":"text","text":"hi"}]}}`)
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Errorf("expected parsed UI result to be valid, got %v", err)
+	}
+}
+
+func TestNewUIResult_ValidateRejectsUnknownComponent(t *testing.T) {
+	parsed, err := FromJSON(`{"displayType":"ui","data":{"type":"column","children":[{"type":"slider"}]}}`)
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	err = parsed.Validate()
+	if err == nil || !strings.Contains(err.Error(), "unsupported component type: slider") {
+		t.Errorf("expected unsupported component error, got %v", err)
+	}
+
+	if err := NewUIResult("Empty", UIComponent{}).Validate(); err == nil {
+		t.Error("expected error for component without a type")
+	}
+}
+
+func TestNewUIResult_ValidateRejectsActionWithoutOperation(t *testing.T) {
+	tests := map[string]UIComponent{
+		"button without operation": UIButton("Delete", "", nil),
+		"button without action":    {Type: UIComponentButton, Text: "Delete"},
+		"nested action":            UIRow(UIText("x"), UIComponent{Type: UIComponentText, Text: "go", Action: &UIAction{}}),
+	}
+	for name, root := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := NewUIResult("Project", root).Validate()
+			if err == nil || !strings.Contains(err.Error(), "action") {
+				t.Errorf("expected action error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrUsageBudgetExceeded is returned by HostServices.ReportUsage when the plugin has
+// used up a budget the host set for an upstream service. Plugins should fail further
+// calls to that service (e.g., with ErrorCodeRateLimited) instead of retrying.
+var ErrUsageBudgetExceeded = errors.New("usage budget exceeded")
+
+// Usage is upstream API consumption, reported by a plugin for the call it was made in.
+// Counts are deltas, not running totals.
+type Usage struct {
+	// Service names the upstream API (e.g., "openweathermap"); empty means the plugin's main API
+	Service       string `json:"service,omitempty"`
+	Requests      int64  `json:"requests,omitempty"`
+	InputTokens   int64  `json:"input_tokens,omitempty"`
+	OutputTokens  int64  `json:"output_tokens,omitempty"`
+	BytesSent     int64  `json:"bytes_sent,omitempty"`
+	BytesReceived int64  `json:"bytes_received,omitempty"`
+}
+
+// Add returns the sum of u and other, keeping u's Service.
+func (u Usage) Add(other Usage) Usage {
+	u.Requests += other.Requests
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.BytesSent += other.BytesSent
+	u.BytesReceived += other.BytesReceived
+	return u
+}
+
+// Validate checks that no count is negative.
+func (u Usage) Validate() error {
+	if u.Requests < 0 || u.InputTokens < 0 || u.OutputTokens < 0 || u.BytesSent < 0 || u.BytesReceived < 0 {
+		return fmt.Errorf("usage counts cannot be negative")
+	}
+	return nil
+}
+
+// UsageReporter receives usage reports. HostServices implements it, so plugins
+// report through BasePlugin.Host(); pass the call's ctx so the host can attribute
+// the usage to the invocation (see InvocationID).
+//
+// Example:
+//
+//	resp, err := t.client.Do(req)
+//	if err == nil {
+//	    _ = t.Host().ReportUsage(ctx, pluginapi.Usage{Requests: 1, BytesReceived: resp.ContentLength})
+//	}
+type UsageReporter interface {
+	ReportUsage(ctx context.Context, usage Usage) error
+}
+
+func (UnimplementedHostServices) ReportUsage(ctx context.Context, usage Usage) error {
+	return ErrHostServiceUnavailable
+}
+
+func (s *hostServer) ReportUsage(ctx context.Context, req *HostUsageRequest) (*ConfigResponse, error) {
+	usage := usageFromProto(req)
+	if err := usage.Validate(); err != nil {
+		return &ConfigResponse{Success: false, Error: err.Error()}, nil
+	}
+	return hostResponse(s.Impl.ReportUsage(incomingInvocationID(ctx), usage))
+}
+
+func (h *hostClient) ReportUsage(ctx context.Context, usage Usage) error {
+	return hostError(h.client.ReportUsage(h.outgoing(ctx), &HostUsageRequest{
+		Service:       usage.Service,
+		Requests:      usage.Requests,
+		InputTokens:   usage.InputTokens,
+		OutputTokens:  usage.OutputTokens,
+		BytesSent:     usage.BytesSent,
+		BytesReceived: usage.BytesReceived,
+	}))
+}
+
+func usageFromProto(req *HostUsageRequest) Usage {
+	return Usage{
+		Service:       req.Service,
+		Requests:      req.Requests,
+		InputTokens:   req.InputTokens,
+		OutputTokens:  req.OutputTokens,
+		BytesSent:     req.BytesSent,
+		BytesReceived: req.BytesReceived,
+	}
+}
+
+// UsageMeter is a host-side helper for ReportUsage: it keeps running totals per
+// plugin and service for dashboards and enforces budgets. Plugins are identified by
+// any host-chosen key, typically HostCallerToken(ctx). It is safe for concurrent use.
+type UsageMeter struct {
+	mu      sync.Mutex
+	totals  map[usageKey]Usage
+	budgets map[usageKey]Usage
+}
+
+type usageKey struct {
+	plugin, service string
+}
+
+// NewUsageMeter creates an empty meter with no budgets.
+func NewUsageMeter() *UsageMeter {
+	return &UsageMeter{totals: make(map[usageKey]Usage), budgets: make(map[usageKey]Usage)}
+}
+
+// SetBudget limits plugin's total usage of budget.Service. Each nonzero count in
+// budget is a limit; zero counts are unlimited. A zero budget removes the limits.
+func (m *UsageMeter) SetBudget(plugin string, budget Usage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := usageKey{plugin, budget.Service}
+	if budget == (Usage{Service: budget.Service}) {
+		delete(m.budgets, key)
+		return
+	}
+	m.budgets[key] = budget
+}
+
+// Record adds usage to plugin's totals. The usage is always recorded, since it has
+// already happened; if the new total exceeds a budget, Record returns an error
+// wrapping ErrUsageBudgetExceeded, which hosts return from ReportUsage.
+func (m *UsageMeter) Record(plugin string, usage Usage) error {
+	if err := usage.Validate(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := usageKey{plugin, usage.Service}
+	total := m.totals[key]
+	total.Service = usage.Service
+	total = total.Add(usage)
+	m.totals[key] = total
+
+	budget, ok := m.budgets[key]
+	if !ok {
+		return nil
+	}
+	var exceeded []string
+	for _, c := range []struct {
+		name         string
+		total, limit int64
+	}{
+		{"requests", total.Requests, budget.Requests},
+		{"input tokens", total.InputTokens, budget.InputTokens},
+		{"output tokens", total.OutputTokens, budget.OutputTokens},
+		{"bytes sent", total.BytesSent, budget.BytesSent},
+		{"bytes received", total.BytesReceived, budget.BytesReceived},
+	} {
+		if c.limit > 0 && c.total > c.limit {
+			exceeded = append(exceeded, fmt.Sprintf("%s %d/%d", c.name, c.total, c.limit))
+		}
+	}
+	if len(exceeded) > 0 {
+		return fmt.Errorf("%w: %s", ErrUsageBudgetExceeded, strings.Join(exceeded, ", "))
+	}
+	return nil
+}
+
+// Totals returns plugin's usage of service so far.
+func (m *UsageMeter) Totals(plugin, service string) Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	total := m.totals[usageKey{plugin, service}]
+	total.Service = service
+	return total
+}
+
+// Reset clears plugin's totals for every service, e.g. at the start of a billing period.
+// Budgets are kept.
+func (m *UsageMeter) Reset(plugin string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.totals {
+		if key.plugin == plugin {
+			delete(m.totals, key)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package pluginapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// meteredHost records usage in a UsageMeter, like an agent showing a usage dashboard.
+type meteredHost struct {
+	UnimplementedHostServices
+	meter       *UsageMeter
+	invocations []string
+}
+
+func (h *meteredHost) ReportUsage(ctx context.Context, usage Usage) error {
+	h.invocations = append(h.invocations, InvocationID(ctx))
+	return h.meter.Record(HostCallerToken(ctx), usage)
+}
+
+func TestHostServices_ReportUsage(t *testing.T) {
+	impl := &meteredHost{meter: NewUsageMeter()}
+	impl.meter.SetBudget("weather", Usage{Requests: 2})
+	weather := connectTestHost(t, impl, "weather")
+	ctx := WithInvocationID(context.Background(), "inv-1")
+
+	if err := weather.ReportUsage(ctx, Usage{Requests: 1, BytesReceived: 512}); err != nil {
+		t.Fatalf("ReportUsage failed: %v", err)
+	}
+	if err := weather.ReportUsage(ctx, Usage{Service: "geocoder", Requests: 5}); err != nil {
+		t.Errorf("budgets apply per service, got %v", err)
+	}
+	if err := weather.ReportUsage(ctx, Usage{Requests: 2}); !errors.Is(err, ErrUsageBudgetExceeded) {
+		t.Errorf("expected ErrUsageBudgetExceeded, got %v", err)
+	}
+	if err := weather.ReportUsage(ctx, Usage{Requests: -1}); err == nil {
+		t.Error("expected negative counts to be rejected")
+	}
+
+	if total := impl.meter.Totals("weather", ""); total != (Usage{Requests: 3, BytesReceived: 512}) {
+		t.Errorf("unexpected totals: %+v", total)
+	}
+	if len(impl.invocations) == 0 || impl.invocations[0] != "inv-1" {
+		t.Errorf("expected usage to be attributed to the invocation, got %v", impl.invocations)
+	}
+
+	impl.meter.Reset("weather")
+	if err := weather.ReportUsage(ctx, Usage{Requests: 1}); err != nil {
+		t.Errorf("expected the budget to apply to the new period, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package pluginapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is a single validation failure.
+type FieldError struct {
+	// Field is the path of the offending field (e.g., "message", "operations.echo.parameters.text").
+	// It is empty for failures that don't belong to one field.
+	Field string `json:"field,omitempty"`
+	// Message describes the failure and is what Error returns
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors collects every failure found by a validation pass, so callers
+// (and LLMs) can fix all problems in one round trip instead of one at a time.
+// Use errors.As to get at the individual FieldErrors.
+type ValidationErrors []*FieldError
+
+// Add records a failure for field.
+func (v *ValidationErrors) Add(field, format string, args ...interface{}) {
+	*v = append(*v, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// Err returns v as an error, or nil if no failures were recorded.
+func (v ValidationErrors) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+// Error returns the single message, or all messages prefixed with their count.
+func (v ValidationErrors) Error() string {
+	if len(v) == 1 {
+		return v[0].Message
+	}
+	messages := make([]string, len(v))
+	for i, e := range v {
+		messages[i] = e.Message
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(v), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the individual failures for errors.Is and errors.As.
+func (v ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(v))
+	for i, e := range v {
+		errs[i] = e
+	}
+	return errs
+}
+
+// Fields returns the paths of the failed fields, in the order they were found.
+func (v ValidationErrors) Fields() []string {
+	fields := make([]string, 0, len(v))
+	for _, e := range v {
+		if e.Field != "" {
+			fields = append(fields, e.Field)
+		}
+	}
+	return fields
+}
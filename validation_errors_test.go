@@ -0,0 +1,126 @@
+package pluginapi
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidationErrors(t *testing.T) {
+	var errs ValidationErrors
+	if errs.Err() != nil {
+		t.Fatal("empty ValidationErrors should produce a nil error")
+	}
+
+	errs.Add("name", "name is required")
+	if got := errs.Err().Error(); got != "name is required" {
+		t.Errorf("single error message = %q", got)
+	}
+
+	errs.Add("", "something else")
+	err := errs.Err()
+	if got := err.Error(); got != "2 validation errors: name is required; something else" {
+		t.Errorf("multi error message = %q", got)
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "name" {
+		t.Errorf("errors.As should find the first FieldError, got %+v", fieldErr)
+	}
+	var all ValidationErrors
+	if !errors.As(err, &all) || !reflect.DeepEqual(all.Fields(), []string{"name"}) {
+		t.Errorf("Fields() = %v", all.Fields())
+	}
+}
+
+func TestValidateToolParameters_ReportsAllMissing(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"a": map[string]interface{}{"type": "string"},
+			"b": map[string]interface{}{"type": "integer"},
+			"c": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"a", "b", "c"},
+	}
+
+	err := ValidateToolParameters(schema, map[string]interface{}{"c": "set"})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	if !reflect.DeepEqual(errs.Fields(), []string{"a", "b"}) {
+		t.Errorf("Fields() = %v, want [a b]", errs.Fields())
+	}
+}
+
+func TestValidateToolParametersWithOperations_ReportsAll(t *testing.T) {
+	toolDef := &YAMLToolDefinition{
+		Name:        "multi-op",
+		Description: "test",
+		Parameters: []YAMLToolParameter{
+			{Name: "operation", Type: "string", Description: "operation", Required: true},
+			{Name: "project", Type: "string", Description: "project", Required: true},
+		},
+		Operations: map[string]YAMLOperationDefinition{
+			"rename": {Parameters: []YAMLToolParameter{
+				{Name: "from", Type: "string", Description: "from", Required: true},
+				{Name: "to", Type: "string", Description: "to", Required: true},
+			}},
+		},
+	}
+
+	err := ValidateToolParametersWithOperations(toolDef, map[string]interface{}{"operation": "rename", "to": ""})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	if !reflect.DeepEqual(errs.Fields(), []string{"project", "from", "to"}) {
+		t.Errorf("Fields() = %v", errs.Fields())
+	}
+
+	err = ValidateToolParametersWithOperations(toolDef, map[string]interface{}{"operation": "bogus"})
+	if !errors.As(err, &errs) || !reflect.DeepEqual(errs.Fields(), []string{"operation", "project"}) {
+		t.Errorf("unknown operation errors = %v", err)
+	}
+}
+
+func TestValidateYAMLToolDefinition_ReportsAll(t *testing.T) {
+	lo, hi := 10.0, 1.0
+	toolDef := &YAMLToolDefinition{
+		Name: "broken",
+		Parameters: []YAMLToolParameter{
+			{Name: "operation", Type: "string", Description: "operation", Required: true, Enum: []string{"echo"}},
+			{Name: "count", Type: "integer", Description: "count", Min: &lo, Max: &hi},
+			{Name: "mode", Type: "color"},
+		},
+		Operations: map[string]YAMLOperationDefinition{
+			"echo": {},
+			"status": {Parameters: []YAMLToolParameter{
+				{Name: "items", Type: "array", Description: "items"},
+			}},
+		},
+	}
+
+	err := ValidateYAMLToolDefinition(toolDef)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+
+	wantFields := []string{
+		"description",
+		"parameters.count",
+		"parameters.mode",
+		"parameters.mode",
+		"parameters.operation.enum",
+		"operations.status.parameters.items",
+	}
+	if !reflect.DeepEqual(errs.Fields(), wantFields) {
+		t.Errorf("Fields() = %v, want %v", errs.Fields(), wantFields)
+	}
+	if !strings.HasPrefix(err.Error(), "6 validation errors: ") {
+		t.Errorf("unexpected message: %v", err)
+	}
+}
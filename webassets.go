@@ -0,0 +1,77 @@
+package pluginapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// WebPageContent is a web page response with caching information, as received by hosts.
+type WebPageContent struct {
+	// Content is the page or asset bytes; empty when NotModified is set
+	Content []byte
+	// ContentType is the MIME type (e.g., "text/css")
+	ContentType string
+	// ETag identifies the content; send it back as ifNoneMatch to skip unchanged responses
+	ETag string
+	// NotModified is true when the content matched the ETag the host already has
+	NotModified bool
+}
+
+// ServeAssetsFrom returns a handler that serves static files from fsys, typically an
+// embed.FS. prefix is stripped from the request path before the file is looked up,
+// so with prefix "static" a request for "static/app.css" reads "app.css" from fsys.
+// The content type is derived from the file extension, falling back to content sniffing.
+//
+// Hosts receive an ETag for every web page response, so unchanged assets are not resent.
+//
+// Example:
+//
+//	//go:embed assets
+//	var assetsFS embed.FS
+//
+//	router := pluginapi.NewWebRouter().
+//	    Handle("dashboard", t.serveDashboard).
+//	    Handle("assets/*", pluginapi.ServeAssetsFrom(assetsFS, ""))
+//
+// The dashboard HTML can then reference "assets/app.css".
+func ServeAssetsFrom(fsys fs.FS, prefix string) WebHandlerFunc {
+	prefix = trimPath(prefix)
+	return func(req WebRequest) (string, string, error) {
+		name := trimPath(req.Path)
+		if prefix != "" {
+			if name != prefix && !strings.HasPrefix(name, prefix+"/") {
+				return "", "", fmt.Errorf("asset not found: %s", req.Path)
+			}
+			name = trimPath(strings.TrimPrefix(name, prefix))
+		}
+		if name == "" || !fs.ValidPath(name) {
+			return "", "", fmt.Errorf("asset not found: %s", req.Path)
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return "", "", fmt.Errorf("asset not found: %s", req.Path)
+		}
+		return string(data), assetContentType(name, data), nil
+	}
+}
+
+// assetContentType returns the MIME type for a file, preferring its extension.
+func assetContentType(name string, data []byte) string {
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(data)
+}
+
+// webContentETag returns a strong ETag for web page content.
+func webContentETag(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
@@ -0,0 +1,154 @@
+package pluginapi
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var testAssets = fstest.MapFS{
+	"static/app.css":  {Data: []byte("body { color: red; }")},
+	"static/app.js":   {Data: []byte("console.log('hi');")},
+	"static/logo.png": {Data: []byte("\x89PNG\r\n\x1a\n\x00\x00\xff\xfe")},
+	"static/notes":    {Data: []byte("plain notes")},
+}
+
+func TestServeAssetsFrom(t *testing.T) {
+	handler := ServeAssetsFrom(testAssets, "")
+
+	tests := []struct {
+		path        string
+		wantType    string
+		wantContent string
+		wantErr     bool
+	}{
+		{path: "static/app.css", wantType: "text/css; charset=utf-8", wantContent: "body { color: red; }"},
+		{path: "/static/app.js/", wantType: "text/javascript; charset=utf-8", wantContent: "console.log('hi');"},
+		{path: "static/notes", wantType: "text/plain; charset=utf-8", wantContent: "plain notes"},
+		{path: "static/missing.css", wantErr: true},
+		{path: "static/../static/app.css", wantErr: true},
+		{path: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			content, contentType, err := handler(WebRequest{Path: tt.path})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q", tt.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if content != tt.wantContent {
+				t.Errorf("content = %q, want %q", content, tt.wantContent)
+			}
+			if contentType != tt.wantType {
+				t.Errorf("content type = %q, want %q", contentType, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestServeAssetsFrom_Prefix(t *testing.T) {
+	sub := fstest.MapFS{"app.css": {Data: []byte("css")}}
+	handler := ServeAssetsFrom(sub, "/assets/")
+
+	if content, _, err := handler(WebRequest{Path: "assets/app.css"}); err != nil || content != "css" {
+		t.Errorf("got %q, %v", content, err)
+	}
+	if _, _, err := handler(WebRequest{Path: "other/app.css"}); err == nil {
+		t.Error("expected error for path outside prefix")
+	}
+	if _, _, err := handler(WebRequest{Path: "assetsx/app.css"}); err == nil {
+		t.Error("expected error for path sharing only a string prefix")
+	}
+}
+
+type assetsTestTool struct {
+	BasePlugin
+	router *WebRouter
+}
+
+func (t *assetsTestTool) Call(ctx context.Context, args string) (string, error) {
+	return "", nil
+}
+
+func (t *assetsTestTool) GetWebPages() []string {
+	return []string{"dashboard"}
+}
+
+func (t *assetsTestTool) ServeWebPage(path string, query map[string]string) (string, string, error) {
+	return t.router.ServeWebPage(path, query)
+}
+
+func newAssetsTestTool() *assetsTestTool {
+	return &assetsTestTool{
+		router: NewWebRouter().
+			Handle("dashboard", func(req WebRequest) (string, string, error) {
+				return `<link rel="stylesheet" href="static/app.css">`, "text/html", nil
+			}).
+			Handle("*", ServeAssetsFrom(testAssets, "")),
+	}
+}
+
+func TestGRPCClient_ServeWebPage_BinaryAsset(t *testing.T) {
+	client := newTestClient(t, newAssetsTestTool())
+
+	content, contentType, err := client.ServeWebPage("static/logo.png", nil)
+	if err != nil {
+		t.Fatalf("ServeWebPage failed: %v", err)
+	}
+	if contentType != "image/png" {
+		t.Errorf("content type = %q, want image/png", contentType)
+	}
+	if content != string(testAssets["static/logo.png"].Data) {
+		t.Errorf("binary content was not preserved: %q", content)
+	}
+
+	// Pages win over the asset fallback
+	content, _, err = client.ServeWebPage("dashboard", nil)
+	if err != nil || !strings.Contains(content, "static/app.css") {
+		t.Errorf("dashboard = %q, %v", content, err)
+	}
+}
+
+func TestGRPCClient_FetchWebPage_ETag(t *testing.T) {
+	client := newTestClient(t, newAssetsTestTool())
+	ctx := context.Background()
+
+	first, err := client.FetchWebPage(ctx, "static/app.css", nil, "")
+	if err != nil {
+		t.Fatalf("FetchWebPage failed: %v", err)
+	}
+	if first.ETag == "" || first.NotModified {
+		t.Fatalf("unexpected first response: %+v", first)
+	}
+	if string(first.Content) != "body { color: red; }" {
+		t.Errorf("content = %q", first.Content)
+	}
+
+	second, err := client.FetchWebPage(ctx, "static/app.css", nil, first.ETag)
+	if err != nil {
+		t.Fatalf("FetchWebPage failed: %v", err)
+	}
+	if !second.NotModified || len(second.Content) != 0 || second.ETag != first.ETag {
+		t.Errorf("expected not modified response, got %+v", second)
+	}
+
+	logo, err := client.FetchWebPage(ctx, "static/logo.png", nil, first.ETag)
+	if err != nil {
+		t.Fatalf("FetchWebPage failed: %v", err)
+	}
+	if logo.NotModified || !bytes.Equal(logo.Content, testAssets["static/logo.png"].Data) {
+		t.Errorf("unexpected logo response: %+v", logo)
+	}
+
+	if _, err := client.FetchWebPage(ctx, "static/missing.css", nil, ""); err == nil {
+		t.Error("expected error for missing asset")
+	}
+}
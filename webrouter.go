@@ -0,0 +1,223 @@
+package pluginapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WebRequest is a web page request matched by a WebRouter.
+type WebRequest struct {
+	// Path is the requested path without leading or trailing slashes
+	Path string
+	// Params holds values captured by "{name}" and "{name...}" pattern segments
+	Params map[string]string
+	// Query holds the URL query parameters
+	Query map[string]string
+}
+
+// Param returns a captured path parameter, or an empty string if it is absent.
+func (r WebRequest) Param(name string) string {
+	return r.Params[name]
+}
+
+// WebHandlerFunc serves a routed web page. It returns the same values as WebPageProvider.ServeWebPage.
+type WebHandlerFunc func(req WebRequest) (content string, contentType string, err error)
+
+// WebRouter dispatches web page paths to handlers by pattern.
+//
+// Patterns are slash-separated segments:
+//   - "stats" matches exactly
+//   - "{id}" matches any single segment and captures it as id
+//   - "{rest...}" as the last segment matches the remainder of the path (possibly empty)
+//   - "*" as the last segment is shorthand for "{*...}"
+//
+// When several patterns match, the most specific wins: literal segments beat
+// parameters, which beat wildcards.
+//
+// Example:
+//
+//	router := pluginapi.NewWebRouter().
+//	    Handle("projects", t.listProjects).
+//	    Handle("projects/{id}", t.showProject).
+//	    Handle("files/{path...}", t.showFile)
+//
+//	func (t *myTool) ServeWebPage(path string, query map[string]string) (string, string, error) {
+//	    return t.router.ServeWebPage(path, query)
+//	}
+type WebRouter struct {
+	routes []webRoute
+}
+
+type webRoute struct {
+	pattern  string
+	segments []routeSegment
+	handler  WebHandlerFunc
+}
+
+type routeSegmentKind int
+
+const (
+	segmentWildcard routeSegmentKind = iota
+	segmentParam
+	segmentLiteral
+)
+
+type routeSegment struct {
+	kind  routeSegmentKind
+	value string // literal text or parameter name
+}
+
+// NewWebRouter creates an empty router.
+func NewWebRouter() *WebRouter {
+	return &WebRouter{}
+}
+
+// Handle registers handler for pattern. It panics if the pattern is malformed or
+// already registered, since both are programming errors. Returns the router for chaining.
+func (r *WebRouter) Handle(pattern string, handler WebHandlerFunc) *WebRouter {
+	segments, err := parseRoutePattern(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("pluginapi: %v", err))
+	}
+	normalized := trimPath(pattern)
+	for _, route := range r.routes {
+		if route.pattern == normalized {
+			panic(fmt.Sprintf("pluginapi: web route %q registered twice", pattern))
+		}
+	}
+	r.routes = append(r.routes, webRoute{pattern: normalized, segments: segments, handler: handler})
+	return r
+}
+
+// Patterns returns the registered patterns in registration order.
+func (r *WebRouter) Patterns() []string {
+	patterns := make([]string, len(r.routes))
+	for i, route := range r.routes {
+		patterns[i] = route.pattern
+	}
+	return patterns
+}
+
+// ServeWebPage dispatches path to the most specific matching route.
+// It has the same signature as WebPageProvider.ServeWebPage so plugins can delegate to it.
+func (r *WebRouter) ServeWebPage(path string, query map[string]string) (string, string, error) {
+	path = trimPath(path)
+
+	var best *webRoute
+	var bestParams map[string]string
+	for i := range r.routes {
+		route := &r.routes[i]
+		params, ok := route.match(path)
+		if !ok {
+			continue
+		}
+		if best == nil || moreSpecific(route.segments, best.segments) {
+			best, bestParams = route, params
+		}
+	}
+	if best == nil {
+		return "", "", fmt.Errorf("page not found: %s", path)
+	}
+
+	return best.handler(WebRequest{Path: path, Params: bestParams, Query: query})
+}
+
+// match reports whether path matches the route and returns the captured parameters.
+func (route *webRoute) match(path string) (map[string]string, bool) {
+	var parts []string
+	if path != "" {
+		parts = strings.Split(path, "/")
+	}
+
+	params := make(map[string]string)
+	for i, seg := range route.segments {
+		if seg.kind == segmentWildcard {
+			params[seg.value] = strings.Join(parts[min(i, len(parts)):], "/")
+			return params, true
+		}
+		if i >= len(parts) {
+			return nil, false
+		}
+		switch seg.kind {
+		case segmentLiteral:
+			if parts[i] != seg.value {
+				return nil, false
+			}
+		case segmentParam:
+			if parts[i] == "" {
+				return nil, false
+			}
+			params[seg.value] = parts[i]
+		}
+	}
+	if len(parts) != len(route.segments) {
+		return nil, false
+	}
+	return params, true
+}
+
+// moreSpecific reports whether a should win over b when both match the same path.
+func moreSpecific(a, b []routeSegment) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i].kind != b[i].kind {
+			return a[i].kind > b[i].kind
+		}
+	}
+	return len(a) > len(b)
+}
+
+func parseRoutePattern(pattern string) ([]routeSegment, error) {
+	trimmed := trimPath(pattern)
+	if trimmed == "" {
+		return nil, fmt.Errorf("web route pattern %q is empty", pattern)
+	}
+
+	parts := strings.Split(trimmed, "/")
+	segments := make([]routeSegment, len(parts))
+	seen := make(map[string]bool)
+	for i, part := range parts {
+		last := i == len(parts)-1
+		switch {
+		case part == "":
+			return nil, fmt.Errorf("web route pattern %q has an empty segment", pattern)
+		case part == "*":
+			if !last {
+				return nil, fmt.Errorf("web route pattern %q: '*' must be the last segment", pattern)
+			}
+			segments[i] = routeSegment{kind: segmentWildcard, value: "*"}
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			name := part[1 : len(part)-1]
+			kind := segmentParam
+			if strings.HasSuffix(name, "...") {
+				if !last {
+					return nil, fmt.Errorf("web route pattern %q: %s must be the last segment", pattern, part)
+				}
+				name = strings.TrimSuffix(name, "...")
+				kind = segmentWildcard
+			}
+			if name == "" || strings.ContainsAny(name, "{}") {
+				return nil, fmt.Errorf("web route pattern %q has an invalid parameter %s", pattern, part)
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("web route pattern %q repeats parameter %q", pattern, name)
+			}
+			seen[name] = true
+			segments[i] = routeSegment{kind: kind, value: name}
+		case strings.ContainsAny(part, "{}*"):
+			return nil, fmt.Errorf("web route pattern %q has an invalid segment %q", pattern, part)
+		default:
+			segments[i] = routeSegment{kind: segmentLiteral, value: part}
+		}
+	}
+	return segments, nil
+}
+
+// IsWebRoutePattern reports whether page contains parameters or wildcards
+// rather than being a plain page path.
+func IsWebRoutePattern(page string) bool {
+	return strings.ContainsAny(page, "{}*")
+}
+
+func trimPath(path string) string {
+	return strings.Trim(path, "/")
+}
@@ -0,0 +1,84 @@
+package pluginapi
+
+import (
+	"testing"
+)
+
+func TestWebRouter_ServeWebPage(t *testing.T) {
+	handler := func(name string) WebHandlerFunc {
+		return func(req WebRequest) (string, string, error) {
+			return name + ":" + req.Param("id") + req.Param("path") + req.Param("*"), "text/html", nil
+		}
+	}
+	router := NewWebRouter().
+		Handle("projects", handler("list")).
+		Handle("projects/{id}", handler("show")).
+		Handle("projects/new", handler("new")).
+		Handle("projects/{id}/files/{path...}", handler("file")).
+		Handle("static/*", handler("static"))
+
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "projects", want: "list:"},
+		{path: "/projects/", want: "list:"},
+		{path: "projects/42", want: "show:42"},
+		{path: "projects/new", want: "new:"},
+		{path: "projects/42/files/mix/final.wav", want: "file:42mix/final.wav"},
+		{path: "projects/42/files", want: "file:42"},
+		{path: "static/css/app.css", want: "static:css/app.css"},
+		{path: "projects/42/extra", wantErr: true},
+		{path: "unknown", wantErr: true},
+		{path: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, _, err := router.ServeWebPage(tt.path, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebRouter_PassesQuery(t *testing.T) {
+	router := NewWebRouter().Handle("search", func(req WebRequest) (string, string, error) {
+		return req.Query["q"], "text/plain", nil
+	})
+	got, contentType, err := router.ServeWebPage("search", map[string]string{"q": "drums"})
+	if err != nil || got != "drums" || contentType != "text/plain" {
+		t.Errorf("got %q %q (err: %v)", got, contentType, err)
+	}
+}
+
+func TestWebRouter_InvalidPatterns(t *testing.T) {
+	for _, pattern := range []string{"", "a//b", "{id}/{id}", "{rest...}/x", "*/x", "a{b}", "{}"} {
+		t.Run(pattern, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for pattern %q", pattern)
+				}
+			}()
+			NewWebRouter().Handle(pattern, nil)
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for duplicate pattern")
+		}
+	}()
+	NewWebRouter().Handle("a/{id}", nil).Handle("/a/{id}/", nil)
+}